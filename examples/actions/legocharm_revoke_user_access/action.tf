@@ -0,0 +1,6 @@
+# Revoke with: terraform apply -invoke=action.legocharm_revoke_user_access.ci
+action "legocharm_revoke_user_access" "ci" {
+  config {
+    username = "ci-bot"
+  }
+}
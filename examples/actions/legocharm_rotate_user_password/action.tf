@@ -0,0 +1,6 @@
+# Rotate with: terraform apply -invoke=action.legocharm_rotate_user_password.ci
+action "legocharm_rotate_user_password" "ci" {
+  config {
+    username = "ci-bot"
+  }
+}
@@ -0,0 +1,12 @@
+variable "ci_active" {
+  type    = bool
+  default = true
+}
+
+# Suspend with: terraform apply -invoke=action.legocharm_set_user_active.ci -var ci_active=false
+action "legocharm_set_user_active" "ci" {
+  config {
+    username = "ci-bot"
+    active   = var.ci_active
+  }
+}
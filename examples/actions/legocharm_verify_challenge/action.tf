@@ -0,0 +1,14 @@
+variable "ci_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+# Verify with: terraform apply -invoke=action.legocharm_verify_challenge.ci
+action "legocharm_verify_challenge" "ci" {
+  config {
+    domain      = "staging.example.com"
+    username    = "ci-bot"
+    password_wo = var.ci_password
+  }
+}
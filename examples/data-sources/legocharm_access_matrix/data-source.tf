@@ -0,0 +1,10 @@
+data "legocharm_access_matrix" "all" {}
+
+# Export the permissions as CSV for a compliance review.
+resource "local_file" "access_matrix" {
+  filename = "${path.module}/access-matrix.csv"
+  content = join("\n", concat(
+    ["username,domain,access_level"],
+    [for e in data.legocharm_access_matrix.all.entries : "${e.username},${e.domain},${e.access_level}"],
+  ))
+}
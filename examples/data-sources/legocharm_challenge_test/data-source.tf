@@ -0,0 +1,13 @@
+data "legocharm_challenge_test" "ci" {
+  domain              = "example.com"
+  username            = legocharm_service_account.ci.username
+  password            = legocharm_service_account.ci.password
+  propagation_timeout = "2m"
+}
+
+check "ci_challenge" {
+  assert {
+    condition     = data.legocharm_challenge_test.ci.success
+    error_message = "DNS-01 challenge for example.com failed: ${coalesce(data.legocharm_challenge_test.ci.error, "unknown error")}"
+  }
+}
@@ -0,0 +1,11 @@
+data "legocharm_credentials_check" "ci" {
+  username = legocharm_service_account.ci.username
+  password = legocharm_service_account.ci.password
+}
+
+check "ci_credentials" {
+  assert {
+    condition     = data.legocharm_credentials_check.ci.valid
+    error_message = "The ACME credentials handed out to CI no longer authenticate."
+  }
+}
@@ -0,0 +1,12 @@
+data "legocharm_domain_user_accesses" "prod" {
+  domain = "example.com"
+}
+
+# Fail the plan if anyone other than the expected identities can issue
+# certificates for the zone.
+check "only_expected_issuers" {
+  assert {
+    condition     = toset(data.legocharm_domain_user_accesses.prod.usernames) == toset(["ci-bot", "svc-web"])
+    error_message = "Unexpected users can issue certificates for example.com."
+  }
+}
@@ -0,0 +1,13 @@
+data "legocharm_domains" "example" {
+  suffix = "example.com"
+}
+
+# Grant the CI bot access to every registered domain under example.com.
+resource "legocharm_user_domain_access" "ci" {
+  for_each = toset(data.legocharm_domains.example.fqdns)
+
+  username      = "ci-bot"
+  domain        = each.value
+  access_level  = "domain"
+  manage_domain = false
+}
@@ -0,0 +1,8 @@
+data "legocharm_export" "existing" {}
+
+# Write the import blocks of the existing users, domains and grants, then run
+# terraform plan -generate-config-out=generated.tf to adopt them.
+resource "local_file" "imports" {
+  filename = "${path.module}/imports.tf"
+  content  = data.legocharm_export.existing.import_blocks
+}
@@ -0,0 +1,11 @@
+data "legocharm_permission_check" "ci_prod" {
+  username = "ci-bot"
+  fqdn     = "db.prod.example.com"
+}
+
+check "ci_cannot_issue_for_prod" {
+  assert {
+    condition     = !data.legocharm_permission_check.ci_prod.allowed
+    error_message = "ci-bot can solve challenges for db.prod.example.com through the permission on ${try(data.legocharm_permission_check.ci_prod.grant.domain, "")}."
+  }
+}
@@ -0,0 +1,14 @@
+# Look up a permission by user and domain.
+data "legocharm_user_domain_access" "ci" {
+  username = "ci-bot"
+  domain   = "staging.example.com"
+}
+
+# Alternatively, look it up by its server-side ID.
+data "legocharm_user_domain_access" "by_id" {
+  database_id = 42
+}
+
+output "ci_access_level" {
+  value = data.legocharm_user_domain_access.ci.access_level
+}
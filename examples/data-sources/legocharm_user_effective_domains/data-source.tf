@@ -0,0 +1,10 @@
+data "legocharm_user_effective_domains" "ci" {
+  username = "ci-bot"
+}
+
+check "ci_scope" {
+  assert {
+    condition     = alltrue([for name in data.legocharm_user_effective_domains.ci.effective_domains : endswith(name, ".staging.example.com") || name == "staging.example.com"])
+    error_message = "ci-bot can solve challenges outside staging.example.com: ${join(", ", data.legocharm_user_effective_domains.ci.effective_domains)}"
+  }
+}
@@ -0,0 +1,17 @@
+ephemeral "legocharm_user_credentials" "monitor" {
+  username = "svc-monitor"
+}
+
+ephemeral "legocharm_basic_auth_header" "monitor" {
+  username = "svc-monitor"
+  password = ephemeral.legocharm_user_credentials.monitor.password
+}
+
+resource "vault_kv_secret_v2" "monitor" {
+  mount = "secret"
+  name  = "monitor/legocharm"
+  data_json_wo = jsonencode({
+    Authorization = ephemeral.legocharm_basic_auth_header.monitor.value
+  })
+  data_json_wo_version = 1
+}
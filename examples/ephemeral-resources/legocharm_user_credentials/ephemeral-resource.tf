@@ -0,0 +1,12 @@
+ephemeral "legocharm_user_credentials" "acme" {
+  username = "svc-acme"
+}
+
+# Ephemeral values can only be passed to provider configurations and
+# write-only arguments, such as this secret read by the ACME client.
+resource "vault_kv_secret_v2" "acme" {
+  mount                = "secret"
+  name                 = "acme/httpreq"
+  data_json_wo         = jsonencode(ephemeral.legocharm_user_credentials.acme.httpreq_config)
+  data_json_wo_version = 1
+}
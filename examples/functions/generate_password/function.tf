@@ -0,0 +1,11 @@
+variable "admin_password_version" {
+  type    = string
+  default = "1"
+}
+
+resource "legocharm_admin_password" "rotated" {
+  # Write-only arguments are not stored, so a new password on every run is
+  # only sent to the API when the version changes.
+  password_wo         = provider::legocharm::generate_password(32, "special")
+  password_wo_version = var.admin_password_version
+}
@@ -0,0 +1,8 @@
+variable "domain" {
+  type = string
+
+  validation {
+    condition     = provider::legocharm::is_valid_fqdn(var.domain)
+    error_message = "The domain must be a name such as \"example.com\" or \"*.example.com\"."
+  }
+}
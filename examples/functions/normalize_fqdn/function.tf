@@ -0,0 +1,12 @@
+variable "domains" {
+  type    = list(string)
+  default = ["Staging.Example.com.", "bücher.example.com"]
+}
+
+resource "legocharm_user_domain_access" "ci" {
+  for_each = toset([for d in var.domains : provider::legocharm::normalize_fqdn(d)])
+
+  username     = "ci-bot"
+  domain       = each.value
+  access_level = "domain"
+}
@@ -0,0 +1,18 @@
+# IDs exported by another configuration, such as through remote state.
+variable "access_ids" {
+  type    = list(string)
+  default = ["ci-bot:staging.example.com:subdomain", "1004:example.com:domain"]
+}
+
+locals {
+  accesses = [for id in var.access_ids : provider::legocharm::parse_access_id(id)]
+}
+
+resource "legocharm_user_domain_access" "migrated" {
+  count = length(local.accesses)
+
+  user_id      = local.accesses[count.index].user_id
+  username     = local.accesses[count.index].username
+  domain       = local.accesses[count.index].domain
+  access_level = local.accesses[count.index].access_level
+}
@@ -0,0 +1,9 @@
+# Run `terraform query -generate-config-out=domains.tf` to generate import
+# blocks and configuration for every domain under example.com.
+list "legocharm_domain" "example" {
+  provider = legocharm
+
+  config {
+    suffix = "example.com"
+  }
+}
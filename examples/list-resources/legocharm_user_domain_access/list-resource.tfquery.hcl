@@ -0,0 +1,9 @@
+# Run `terraform query -generate-config-out=access.tf` to generate import
+# blocks and configuration for every permission held by ci-bot.
+list "legocharm_user_domain_access" "ci" {
+  provider = legocharm
+
+  config {
+    username = "ci-bot"
+  }
+}
@@ -0,0 +1,13 @@
+variable "admin_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+# Rotate the bootstrap admin password. Bump password_wo_version whenever
+# admin_password changes, and configure the provider with the new password
+# for subsequent runs.
+resource "legocharm_admin_password" "this" {
+  password_wo         = var.admin_password
+  password_wo_version = "1"
+}
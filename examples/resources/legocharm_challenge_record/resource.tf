@@ -0,0 +1,10 @@
+# Publish a throwaway TXT record to check that the DNS pipeline behind the
+# charm works before pointing ACME clients at it.
+resource "legocharm_challenge_record" "smoke_test" {
+  domain = "staging.example.com"
+  value  = "legocharm-smoke-test"
+}
+
+output "challenge_record" {
+  value = legocharm_challenge_record.smoke_test.fqdn
+}
@@ -0,0 +1,6 @@
+import {
+  to = legocharm_domain.staging
+  identity = {
+    id = "2"
+  }
+}
@@ -0,0 +1,5 @@
+# A domain can be imported by its numeric ID.
+terraform import legocharm_domain.staging 2
+
+# Alternatively, import by FQDN.
+terraform import legocharm_domain.staging staging.example.com
@@ -0,0 +1,10 @@
+resource "legocharm_domain" "staging" {
+  fqdn = "staging.example.com"
+}
+
+resource "legocharm_user_domain_access" "staging" {
+  username      = "ci-bot"
+  domain        = legocharm_domain.staging.fqdn
+  access_level  = "domain"
+  manage_domain = false
+}
@@ -0,0 +1,2 @@
+# A domain set can be imported by a comma-separated list of existing FQDNs.
+terraform import legocharm_domain_set.zones example.com,*.example.com,example.org
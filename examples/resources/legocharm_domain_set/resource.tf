@@ -0,0 +1,7 @@
+resource "legocharm_domain_set" "zones" {
+  fqdns = [
+    "example.com",
+    "*.example.com",
+    "example.org",
+  ]
+}
@@ -0,0 +1,2 @@
+# Domain user permissions can be imported by specifying the domain FQDN.
+terraform import legocharm_domain_user_permissions.staging staging.example.com
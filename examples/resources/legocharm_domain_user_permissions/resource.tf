@@ -0,0 +1,14 @@
+resource "legocharm_domain_user_permissions" "staging" {
+  domain = "staging.example.com"
+
+  permissions = [
+    {
+      username     = "ci-bot"
+      access_level = "domain"
+    },
+    {
+      username     = "svc-web"
+      access_level = "subdomain"
+    },
+  ]
+}
@@ -0,0 +1,2 @@
+# Group membership can be imported by specifying the group.
+terraform import legocharm_group_membership.acme acme
@@ -0,0 +1,4 @@
+resource "legocharm_group_membership" "acme" {
+  group   = "acme"
+  members = ["ci-bot", "svc-web"]
+}
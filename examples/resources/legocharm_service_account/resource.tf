@@ -0,0 +1,20 @@
+resource "legocharm_service_account" "acme" {
+  username = "svc-acme"
+
+  grants = [
+    {
+      domain       = "web.example.com"
+      access_level = "domain"
+    },
+  ]
+}
+
+resource "acme_certificate" "web" {
+  account_key_pem = acme_registration.reg.account_key_pem
+  common_name     = "web.example.com"
+
+  dns_challenge {
+    provider = "httpreq"
+    config   = legocharm_service_account.acme.httpreq_config
+  }
+}
@@ -0,0 +1,19 @@
+resource "legocharm_service_user_with_access" "web" {
+  username = "svc-web"
+
+  grants = [
+    {
+      domain       = "web.example.com"
+      access_level = "domain"
+    },
+    {
+      domain       = "*.web.example.com"
+      access_level = "subdomain"
+    },
+  ]
+}
+
+output "web_password" {
+  value     = legocharm_service_user_with_access.web.password
+  sensitive = true
+}
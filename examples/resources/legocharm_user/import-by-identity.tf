@@ -0,0 +1,6 @@
+import {
+  to = legocharm_user.example_user
+  identity = {
+    id = "1004"
+  }
+}
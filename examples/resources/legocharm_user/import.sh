@@ -1,2 +1,11 @@
-# Order can be imported by specifying the numeric identifier.
-terraform import legocharm_user.example_user
+# A user can be imported by its numeric ID. A number no user has as ID is
+# taken as a username; prefix the ID with "id:" to never read it as one.
+terraform import legocharm_user.example_user 1004
+terraform import legocharm_user.example_user id:1004
+
+# Alternatively, import by username. The password is rotated on the next
+# apply, since it is not known to Terraform.
+terraform import legocharm_user.example_user example_user
+
+# If the current password is known, it can be supplied to avoid the rotation.
+terraform import legocharm_user.example_user example_user:test1234
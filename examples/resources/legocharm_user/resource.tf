@@ -3,3 +3,15 @@ resource "legocharm_user" "example_user" {
   email    = "test@example.com"
   password = "test1234"
 }
+
+# Omit password to have the provider generate one.
+resource "legocharm_user" "generated_password_user" {
+  username        = "generated_password_user"
+  password_length = 24
+}
+
+# Bump password_version to rotate the password in place.
+resource "legocharm_user" "rotated_password_user" {
+  username         = "rotated_password_user"
+  password_version = "2026-10"
+}
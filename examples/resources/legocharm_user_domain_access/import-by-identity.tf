@@ -0,0 +1,6 @@
+import {
+  to = legocharm_user_domain_access.example_access
+  identity = {
+    database_id = 42
+  }
+}
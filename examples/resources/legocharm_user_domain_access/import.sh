@@ -1,2 +1,9 @@
-# Order can be imported by specifying the numeric identifier.
-terraform import legocharm_user_domain_access.example_access
+# User domain access can be imported by specifying the user ID or username,
+# domain and access level separated by colons. A number no user has as ID is
+# taken as a username; prefix the ID with "id:" to never read it as one.
+terraform import legocharm_user_domain_access.example_access 1004:staging.example.com:subdomain
+terraform import legocharm_user_domain_access.example_access id:1004:staging.example.com:subdomain
+terraform import legocharm_user_domain_access.example_access ci-bot:staging.example.com:subdomain
+
+# Alternatively, specify the numeric database ID of the permission.
+terraform import legocharm_user_domain_access.example_access 42
@@ -3,3 +3,16 @@ resource "legocharm_user_domain_access" "example_access" {
   domain       = "staging.example.com"
   access_level = "subdomain"
 }
+
+resource "legocharm_user_domain_access" "by_username" {
+  username     = "ci-bot"
+  domain       = "ci.example.com"
+  access_level = "domain"
+}
+
+# Wildcard certificates for any name under apps.example.com.
+resource "legocharm_user_domain_access" "wildcard" {
+  username     = "ci-bot"
+  domain       = "*.apps.example.com"
+  access_level = "subdomain"
+}
@@ -0,0 +1,3 @@
+# User group membership can be imported by specifying the username and group
+# separated by a colon.
+terraform import legocharm_user_group_membership.ci_bot_acme ci-bot:acme
@@ -0,0 +1,4 @@
+resource "legocharm_user_group_membership" "ci_bot_acme" {
+  username = legocharm_user.ci_bot.username
+  group    = "acme"
+}
@@ -0,0 +1,573 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+// Package fakeserver implements an in-memory stand-in for the LegoCharm API
+// (httprequest-lego-provider), for tests that need a working server without a
+// charm deployment. It covers the user, domain and domain-user-permission
+// endpoints, the httpreq present and cleanup endpoints, basic authentication
+// and, optionally, Django REST framework page number pagination.
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// user is a stored user account.
+type user struct {
+	username    string
+	password    string
+	email       string
+	groups      []string
+	isStaff     bool
+	isSuperuser bool
+	isActive    bool
+	dateJoined  string
+}
+
+// Server is an in-memory LegoCharm API. The zero value is not usable; create
+// servers with New.
+type Server struct {
+	// PageSize enables DRF page number pagination of list endpoints with
+	// the given page size. Lists are returned as plain JSON arrays, like the
+	// charm does by default, when it is zero.
+	PageSize int
+
+	mu          sync.Mutex
+	users       map[int]*user
+	domains     map[int]string
+	permissions map[int]legocharmclient.DomainUserPermissionData
+	txtRecords  map[string]string
+	nextID      int
+}
+
+// New returns a server with a single superuser account, for the provider to
+// authenticate as.
+func New(adminUsername, adminPassword string) *Server {
+	s := &Server{
+		users:       map[int]*user{},
+		domains:     map[int]string{},
+		permissions: map[int]legocharmclient.DomainUserPermissionData{},
+		txtRecords:  map[string]string{},
+	}
+	s.AddUser(adminUsername, adminPassword, true)
+	return s
+}
+
+// Start serves s on a local HTTP test server. Close the returned server when
+// done.
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+// AddUser adds an active user and returns its ID. Superusers may use the
+// management endpoints; other users may only present and clean up records.
+func (s *Server) AddUser(username, password string, superuser bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addUser(&user{
+		username:    username,
+		password:    password,
+		isStaff:     superuser,
+		isSuperuser: superuser,
+		isActive:    true,
+	})
+}
+
+// AddDomain adds a domain and returns its ID.
+func (s *Server) AddDomain(fqdn string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	s.domains[s.nextID] = fqdn
+	return s.nextID
+}
+
+// AddPermission grants the user access to the domain and returns the ID of
+// the permission.
+func (s *Server) AddPermission(userID, domainID int, accessLevel string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	s.permissions[s.nextID] = legocharmclient.DomainUserPermissionData{ID: s.nextID, UserID: userID, Domain: domainID, AccessLevel: accessLevel}
+	return s.nextID
+}
+
+// TXTRecord returns the value of the presented TXT record with the given
+// name, such as "_acme-challenge.example.com.".
+func (s *Server) TXTRecord(fqdn string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.txtRecords[fqdn]
+	return value, ok
+}
+
+// UserPassword returns the password of the user with the given username.
+func (s *Server) UserPassword(username string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.userID(username)
+	if !ok {
+		return "", false
+	}
+	return s.users[id].password, true
+}
+
+func (s *Server) addUser(u *user) int {
+	s.nextID++
+	if u.groups == nil {
+		u.groups = []string{}
+	}
+	u.dateJoined = time.Now().UTC().Format(time.RFC3339)
+	s.users[s.nextID] = u
+	return s.nextID
+}
+
+func (s *Server) userID(username string) (int, bool) {
+	for id, u := range s.users {
+		if u.username == username {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Server) domainID(fqdn string) (int, bool) {
+	for id, d := range s.domains {
+		if d == fqdn {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// authenticate returns the ID of the active user whose credentials the
+// request carries.
+func (s *Server) authenticate(r *http.Request) (int, bool) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return 0, false
+	}
+	id, ok := s.userID(username)
+	if !ok || s.users[id].password != password || !s.users[id].isActive {
+		return 0, false
+	}
+	return id, true
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	callerID, ok := s.authenticate(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Basic realm="api"`)
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"detail": "Invalid username/password."})
+		return
+	}
+
+	if r.URL.Path == "/present" || r.URL.Path == "/cleanup" {
+		s.serveChallenge(w, r, callerID)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, "/api/v1/") {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+	if !s.users[callerID].isSuperuser {
+		writeJSON(w, http.StatusForbidden, map[string]string{"detail": "You do not have permission to perform this action."})
+		return
+	}
+
+	var id int
+	switch {
+	case r.URL.Path == "/api/v1/users/":
+		s.serveUsers(w, r)
+	case scanID(r.URL.Path, "/api/v1/users/%d/", &id):
+		s.serveUser(w, r, id)
+	case r.URL.Path == "/api/v1/domains/":
+		s.serveDomains(w, r)
+	case scanID(r.URL.Path, "/api/v1/domains/%d/", &id):
+		s.serveDomain(w, r, id)
+	case r.URL.Path == "/api/v1/domain-user-permissions/":
+		s.servePermissions(w, r)
+	case scanID(r.URL.Path, "/api/v1/domain-user-permissions/%d/", &id):
+		s.servePermission(w, r, id)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+	}
+}
+
+func (s *Server) serveUsers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var list []any
+		for _, id := range sortedKeys(s.users) {
+			if username := r.URL.Query().Get("username"); username != "" && s.users[id].username != username {
+				continue
+			}
+			list = append(list, s.userData(r, id))
+		}
+		s.writeList(w, r, list)
+	case http.MethodPost:
+		var create legocharmclient.UserCreateData
+		if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "JSON parse error - " + err.Error()})
+			return
+		}
+		if create.Username == "" {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"username": {"This field may not be blank."}})
+			return
+		}
+		if _, exists := s.userID(create.Username); exists {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"username": {"A user with that username already exists."}})
+			return
+		}
+		id := s.addUser(&user{
+			username:    create.Username,
+			password:    create.Password,
+			email:       create.Email,
+			groups:      create.Groups,
+			isStaff:     create.IsStaff,
+			isSuperuser: create.IsSuperuser,
+			isActive:    create.IsActive,
+		})
+		writeJSON(w, http.StatusCreated, s.userData(r, id))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) serveUser(w http.ResponseWriter, r *http.Request, id int) {
+	u, ok := s.users[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.userData(r, id))
+	case http.MethodPatch:
+		var update legocharmclient.UserUpdateData
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "JSON parse error - " + err.Error()})
+			return
+		}
+		if update.Password != "" {
+			u.password = update.Password
+		}
+		if update.Email != nil {
+			u.email = *update.Email
+		}
+		if update.IsStaff != nil {
+			u.isStaff = *update.IsStaff
+		}
+		if update.IsSuperuser != nil {
+			u.isSuperuser = *update.IsSuperuser
+		}
+		if update.IsActive != nil {
+			u.isActive = *update.IsActive
+		}
+		if update.Groups != nil {
+			u.groups = *update.Groups
+		}
+		writeJSON(w, http.StatusOK, s.userData(r, id))
+	case http.MethodDelete:
+		// Permissions cascade with the user, as in the charm's database.
+		delete(s.users, id)
+		for pid, p := range s.permissions {
+			if p.UserID == id {
+				delete(s.permissions, pid)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) serveDomains(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var list []any
+		for _, id := range sortedKeys(s.domains) {
+			if fqdn := r.URL.Query().Get("fqdn"); fqdn != "" && s.domains[id] != fqdn {
+				continue
+			}
+			list = append(list, legocharmclient.DomainData{ID: id, Fqdn: s.domains[id]})
+		}
+		s.writeList(w, r, list)
+	case http.MethodPost:
+		var domain legocharmclient.DomainData
+		if err := json.NewDecoder(r.Body).Decode(&domain); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "JSON parse error - " + err.Error()})
+			return
+		}
+		if _, exists := s.domainID(domain.Fqdn); exists {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"fqdn": {"domain with this fqdn already exists."}})
+			return
+		}
+		s.nextID++
+		domain.ID = s.nextID
+		s.domains[domain.ID] = domain.Fqdn
+		writeJSON(w, http.StatusCreated, domain)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) serveDomain(w http.ResponseWriter, r *http.Request, id int) {
+	fqdn, ok := s.domains[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, legocharmclient.DomainData{ID: id, Fqdn: fqdn})
+	case http.MethodPatch:
+		var update legocharmclient.DomainUpdateData
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "JSON parse error - " + err.Error()})
+			return
+		}
+		if other, exists := s.domainID(update.Fqdn); exists && other != id {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"fqdn": {"domain with this fqdn already exists."}})
+			return
+		}
+		s.domains[id] = update.Fqdn
+		writeJSON(w, http.StatusOK, legocharmclient.DomainData{ID: id, Fqdn: update.Fqdn})
+	case http.MethodDelete:
+		delete(s.domains, id)
+		for pid, p := range s.permissions {
+			if p.Domain == id {
+				delete(s.permissions, pid)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) servePermissions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		var list []any
+		for _, id := range sortedKeys(s.permissions) {
+			p := s.permissions[id]
+			if username := query.Get("username"); username != "" && (s.users[p.UserID] == nil || s.users[p.UserID].username != username) {
+				continue
+			}
+			if fqdn := query.Get("fqdn"); fqdn != "" && s.domains[p.Domain] != fqdn {
+				continue
+			}
+			list = append(list, p)
+		}
+		s.writeList(w, r, list)
+	case http.MethodPost:
+		var payload legocharmclient.DomainUserPermissionCreatePayloadData
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "JSON parse error - " + err.Error()})
+			return
+		}
+		userID, _ := strconv.Atoi(payload.UserID)
+		if _, ok := s.users[userID]; !ok {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"user": {fmt.Sprintf("Invalid pk \"%s\" - object does not exist.", payload.UserID)}})
+			return
+		}
+		if _, ok := s.domains[payload.Domain]; !ok {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"domain": {fmt.Sprintf("Invalid pk \"%d\" - object does not exist.", payload.Domain)}})
+			return
+		}
+		if !validAccessLevel(payload.AccessLevel) {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"access_level": {fmt.Sprintf("\"%s\" is not a valid choice.", payload.AccessLevel)}})
+			return
+		}
+		s.nextID++
+		p := legocharmclient.DomainUserPermissionData{ID: s.nextID, UserID: userID, Domain: payload.Domain, AccessLevel: payload.AccessLevel}
+		s.permissions[p.ID] = p
+		writeJSON(w, http.StatusCreated, p)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) servePermission(w http.ResponseWriter, r *http.Request, id int) {
+	p, ok := s.permissions[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, p)
+	case http.MethodPatch:
+		var update legocharmclient.DomainUserPermissionUpdateData
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "JSON parse error - " + err.Error()})
+			return
+		}
+		if !validAccessLevel(update.AccessLevel) {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"access_level": {fmt.Sprintf("\"%s\" is not a valid choice.", update.AccessLevel)}})
+			return
+		}
+		p.AccessLevel = update.AccessLevel
+		s.permissions[id] = p
+		writeJSON(w, http.StatusOK, p)
+	case http.MethodDelete:
+		delete(s.permissions, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// serveChallenge presents or cleans up a TXT record if the caller has access
+// to the domain the record is for.
+func (s *Server) serveChallenge(w http.ResponseWriter, r *http.Request, callerID int) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var record legocharmclient.TXTRecordData
+	if err := json.NewDecoder(r.Body).Decode(&record); err != nil || record.Fqdn == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "fqdn and value are required."})
+		return
+	}
+	if !s.allowed(callerID, record.Fqdn) {
+		writeJSON(w, http.StatusForbidden, map[string]string{"detail": "You do not have permission to perform this action."})
+		return
+	}
+
+	if r.URL.Path == "/present" {
+		s.txtRecords[record.Fqdn] = record.Value
+	} else {
+		delete(s.txtRecords, record.Fqdn)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowed reports whether the user may manage the challenge record with the
+// given name. A permission on a domain covers the domain itself; subdomain
+// permissions also cover every name under it, and wildcard domains cover the
+// names under the domain they stand for.
+func (s *Server) allowed(userID int, recordFqdn string) bool {
+	name := strings.TrimPrefix(legocharmclient.NormalizeFQDN(recordFqdn), "_acme-challenge.")
+	for _, p := range s.permissions {
+		if p.UserID != userID {
+			continue
+		}
+		domain := legocharmclient.NormalizeFQDN(s.domains[p.Domain])
+		if domain == name {
+			return true
+		}
+		base := strings.TrimPrefix(domain, "*.")
+		if (p.AccessLevel == "subdomain" || base != domain) && strings.HasSuffix(name, "."+base) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) userData(r *http.Request, id int) legocharmclient.UserData {
+	u := s.users[id]
+	return legocharmclient.UserData{
+		Username:    u.username,
+		Url:         fmt.Sprintf("http://%s/api/v1/users/%d/", r.Host, id),
+		Email:       u.email,
+		Groups:      u.groups,
+		IsStaff:     u.isStaff,
+		IsSuperuser: u.isSuperuser,
+		IsActive:    u.isActive,
+		DateJoined:  u.dateJoined,
+	}
+}
+
+// writeList writes list as a JSON array, or as a DRF page when pagination is
+// enabled.
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, list []any) {
+	if list == nil {
+		list = []any{}
+	}
+	if s.PageSize <= 0 {
+		writeJSON(w, http.StatusOK, list)
+		return
+	}
+
+	page := 1
+	if v := r.URL.Query().Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || (n-1)*s.PageSize >= max(len(list), 1) {
+			writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Invalid page."})
+			return
+		}
+		page = n
+	}
+
+	start := (page - 1) * s.PageSize
+	end := min(start+s.PageSize, len(list))
+	pageURL := func(n int) *string {
+		u := *r.URL
+		u.Scheme, u.Host = "http", r.Host
+		query := u.Query()
+		query.Set("page", strconv.Itoa(n))
+		u.RawQuery = query.Encode()
+		link := u.String()
+		return &link
+	}
+	var next, previous *string
+	if end < len(list) {
+		next = pageURL(page + 1)
+	}
+	if page > 1 {
+		previous = pageURL(page - 1)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"count":    len(list),
+		"next":     next,
+		"previous": previous,
+		"results":  list[start:end],
+	})
+}
+
+func validAccessLevel(level string) bool {
+	return level == "domain" || level == "subdomain"
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) // nolint:errcheck
+}
+
+// scanID parses the numeric ID out of path according to format.
+func scanID(path, format string, id *int) bool {
+	var rest string
+	n, _ := fmt.Sscanf(path, format+"%s", id, &rest)
+	return n == 1
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
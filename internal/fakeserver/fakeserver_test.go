@@ -0,0 +1,191 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package fakeserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func newTestClient(t *testing.T, s *Server, username, password string) *legocharmclient.Client {
+	t.Helper()
+	srv := s.Start()
+	t.Cleanup(srv.Close)
+	client, err := legocharmclient.NewClient(&srv.URL, &username, &password)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestServer_UserLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, New("admin", "secret"), "admin", "secret")
+
+	created, err := client.CreateUser(ctx, legocharmclient.UserCreateData{Username: "alice", Password: "pw", Email: "alice@example.com", IsActive: true})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	id := legocharmclient.LastPathSegment(created.Url)
+
+	if _, err := client.CreateUser(ctx, legocharmclient.UserCreateData{Username: "alice", Password: "pw"}); err == nil {
+		t.Fatal("expected an error creating a duplicate user")
+	}
+
+	user, err := client.GetUserByUsername(ctx, "alice")
+	if err != nil || user.Email != "alice@example.com" || !user.IsActive {
+		t.Fatalf("GetUserByUsername = %+v, %v", user, err)
+	}
+
+	email, groups := "new@example.com", []string{"ops"}
+	if _, err := client.UpdateUser(ctx, id, legocharmclient.UserUpdateData{Email: &email, Groups: &groups, Password: "new"}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	user, err = client.GetUserById(ctx, id)
+	if err != nil || user.Email != email || len(user.Groups) != 1 || user.Groups[0] != "ops" {
+		t.Fatalf("GetUserById = %+v, %v", user, err)
+	}
+	if ok, err := client.HasValidUserPassword(ctx, "alice", "new"); err != nil || !ok {
+		t.Fatalf("HasValidUserPassword(new) = %v, %v", ok, err)
+	}
+	if ok, err := client.HasValidUserPassword(ctx, "alice", "pw"); err != nil || ok {
+		t.Fatalf("HasValidUserPassword(pw) = %v, %v", ok, err)
+	}
+
+	if _, err := client.DeleteUserById(ctx, id); err != nil {
+		t.Fatalf("DeleteUserById: %v", err)
+	}
+	if _, err := client.GetUserById(ctx, id); !errors.Is(err, legocharmclient.ErrNotFound) {
+		t.Fatalf("GetUserById after delete: %v", err)
+	}
+}
+
+func TestServer_DomainAccess(t *testing.T) {
+	ctx := context.Background()
+	s := New("admin", "secret")
+	aliceID := s.AddUser("alice", "pw", false)
+	client := newTestClient(t, s, "admin", "secret")
+
+	access, err := client.CreateDomainAccess(ctx, legocharmclient.DomainUserPermissionCreateData{UserID: "2", Domain: "example.com", AccessLevel: "subdomain"})
+	if err != nil {
+		t.Fatalf("CreateDomainAccess: %v", err)
+	}
+	if access.UserID != aliceID || !access.DomainCreated {
+		t.Fatalf("CreateDomainAccess = %+v", access)
+	}
+
+	list, err := client.ListDomainAccessByUsername(ctx, "alice")
+	if err != nil || len(list) != 1 || list[0].ID != access.ID {
+		t.Fatalf("ListDomainAccessByUsername = %+v, %v", list, err)
+	}
+
+	if _, err := client.UpdateDomainAccess(ctx, access.ID, "domain"); err != nil {
+		t.Fatalf("UpdateDomainAccess: %v", err)
+	}
+	if _, err := client.UpdateDomainAccess(ctx, access.ID, "everything"); err == nil {
+		t.Fatal("expected an error for an invalid access level")
+	}
+
+	// Deleting the domain removes the permissions on it.
+	if err := client.DeleteDomain(ctx, access.Domain); err != nil {
+		t.Fatalf("DeleteDomain: %v", err)
+	}
+	if _, err := client.GetDomainAccessById(ctx, access.ID); !errors.Is(err, legocharmclient.ErrNotFound) {
+		t.Fatalf("GetDomainAccessById after domain delete: %v", err)
+	}
+}
+
+func TestServer_Auth(t *testing.T) {
+	ctx := context.Background()
+	s := New("admin", "secret")
+	s.AddUser("alice", "pw", false)
+
+	if _, err := newTestClient(t, s, "admin", "wrong").ListUsers(ctx); err == nil {
+		t.Fatal("expected an error with a wrong password")
+	}
+	if _, err := newTestClient(t, s, "alice", "pw").ListUsers(ctx); err == nil {
+		t.Fatal("expected an error listing users as a non-superuser")
+	}
+	ok, err := newTestClient(t, s, "admin", "secret").HasValidUserPassword(ctx, "alice", "pw")
+	if err != nil || !ok {
+		t.Fatalf("HasValidUserPassword = %v, %v", ok, err)
+	}
+}
+
+func TestServer_Challenge(t *testing.T) {
+	ctx := context.Background()
+	s := New("admin", "secret")
+	aliceID := s.AddUser("alice", "pw", false)
+	s.AddPermission(aliceID, s.AddDomain("example.com"), "subdomain")
+	s.AddPermission(aliceID, s.AddDomain("*.wild.example.org"), "domain")
+	client := newTestClient(t, s, "alice", "pw")
+
+	for _, fqdn := range []string{"_acme-challenge.example.com.", "_acme-challenge.www.example.com.", "_acme-challenge.a.wild.example.org."} {
+		if err := client.PresentTXTRecord(ctx, legocharmclient.TXTRecordData{Fqdn: fqdn, Value: "token"}); err != nil {
+			t.Fatalf("PresentTXTRecord(%s): %v", fqdn, err)
+		}
+		if value, ok := s.TXTRecord(fqdn); !ok || value != "token" {
+			t.Fatalf("TXTRecord(%s) = %q, %v", fqdn, value, ok)
+		}
+		if err := client.CleanupTXTRecord(ctx, legocharmclient.TXTRecordData{Fqdn: fqdn, Value: "token"}); err != nil {
+			t.Fatalf("CleanupTXTRecord(%s): %v", fqdn, err)
+		}
+		if _, ok := s.TXTRecord(fqdn); ok {
+			t.Fatalf("TXTRecord(%s) still present after cleanup", fqdn)
+		}
+	}
+
+	if err := client.PresentTXTRecord(ctx, legocharmclient.TXTRecordData{Fqdn: "_acme-challenge.example.net.", Value: "token"}); err == nil {
+		t.Fatal("expected an error presenting a record for a domain without access")
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	s := New("admin", "secret")
+	s.PageSize = 2
+	for _, fqdn := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+		s.AddDomain(fqdn)
+	}
+	srv := s.Start()
+	defer srv.Close()
+
+	type page struct {
+		Count    int                          `json:"count"`
+		Next     *string                      `json:"next"`
+		Previous *string                      `json:"previous"`
+		Results  []legocharmclient.DomainData `json:"results"`
+	}
+	get := func(url string) page {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.SetBasicAuth("admin", "secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s: %v", url, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s: status %d", url, resp.StatusCode)
+		}
+		var p page
+		if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return p
+	}
+
+	first := get(srv.URL + "/api/v1/domains/")
+	if first.Count != 3 || len(first.Results) != 2 || first.Next == nil || first.Previous != nil {
+		t.Fatalf("first page = %+v", first)
+	}
+	second := get(*first.Next)
+	if len(second.Results) != 1 || second.Results[0].Fqdn != "c.example.com" || second.Next != nil || second.Previous == nil {
+		t.Fatalf("second page = %+v", second)
+	}
+}
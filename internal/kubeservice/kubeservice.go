@@ -0,0 +1,433 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+// Package kubeservice resolves the address of a Kubernetes Service, such as
+// the one Juju creates for a charm deployed on Kubernetes, with the
+// credentials of a kubeconfig file or of the pod the provider runs in.
+//
+// It only reads Services, with a single GET request, so it implements the
+// little of the Kubernetes client configuration that needs rather than
+// depending on client-go: bearer tokens, client certificates and basic
+// authentication, but not exec or auth-provider plugins.
+package kubeservice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// serviceAccountDir holds the credentials of the pod the provider runs in,
+// replaced in tests.
+var serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// Config selects the Service to resolve and the cluster it is in.
+type Config struct {
+	// ConfigPath is the kubeconfig file. If empty, the first file of the
+	// KUBECONFIG environment variable is used, or else ~/.kube/config, or
+	// else, if there is none, the service account of the pod.
+	ConfigPath string
+	// Context is the kubeconfig context, its current context if empty.
+	Context string
+	// Namespace of the Service, that of the context or of the service
+	// account if empty, or else "default". With Juju, it is the model name.
+	Namespace string
+	// Service is the name of the Service. With Juju, it is the application
+	// name.
+	Service string
+	// Port is the name or number of the port of the Service, its first port
+	// if empty.
+	Port string
+	// Scheme is the scheme of the returned address, "http" if empty.
+	Scheme string
+}
+
+// Resolve returns the base URL of the Service selected by cfg: that of its
+// load balancer, if it has one, or else, in a pod of the cluster, its DNS
+// name in the cluster. Services only reachable from inside the cluster
+// cannot be resolved from outside it.
+func Resolve(ctx context.Context, cfg Config) (string, error) {
+	if cfg.Service == "" {
+		return "", errors.New("service name is required")
+	}
+	cluster, err := loadCluster(cfg)
+	if err != nil {
+		return "", err
+	}
+	svc, err := cluster.getService(ctx, cluster.namespace, cfg.Service)
+	if err != nil {
+		return "", err
+	}
+	port, err := svc.port(cfg.Port)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := cfg.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		host := ingress.IP
+		if ingress.Hostname != "" {
+			host = ingress.Hostname
+		}
+		if host != "" {
+			return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port.Port)), nil
+		}
+	}
+	if cluster.inCluster {
+		host := cfg.Service + "." + cluster.namespace + ".svc"
+		return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port.Port)), nil
+	}
+	return "", fmt.Errorf("service %s/%s has no load balancer address, and is only reachable from inside the cluster", cluster.namespace, cfg.Service)
+}
+
+// service is the part of a Kubernetes Service used to resolve it.
+type service struct {
+	Spec struct {
+		Ports []servicePort `json:"ports"`
+	} `json:"spec"`
+	Status struct {
+		LoadBalancer struct {
+			Ingress []struct {
+				IP       string `json:"ip"`
+				Hostname string `json:"hostname"`
+			} `json:"ingress"`
+		} `json:"loadBalancer"`
+	} `json:"status"`
+}
+
+type servicePort struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+}
+
+// port returns the port of s named or numbered name, its first if empty.
+func (s *service) port(name string) (servicePort, error) {
+	if len(s.Spec.Ports) == 0 {
+		return servicePort{}, errors.New("service has no ports")
+	}
+	if name == "" {
+		return s.Spec.Ports[0], nil
+	}
+	for _, p := range s.Spec.Ports {
+		if p.Name == name || strconv.Itoa(p.Port) == name {
+			return p, nil
+		}
+	}
+	return servicePort{}, fmt.Errorf("service has no port %q", name)
+}
+
+// cluster is a Kubernetes API server and the credentials to call it with.
+type cluster struct {
+	server    string
+	namespace string
+	inCluster bool
+	client    *http.Client
+	// authorize sets the credentials on a request.
+	authorize func(*http.Request)
+}
+
+// getService reads the Service name in namespace.
+func (c *cluster) getService(ctx context.Context, namespace, name string) (*service, error) {
+	u := strings.TrimRight(c.server, "/") + "/api/v1/namespaces/" + url.PathEscape(namespace) + "/services/" + url.PathEscape(name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	c.authorize(req)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service %s/%s: %w", namespace, name, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service %s/%s: %w", namespace, name, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var status struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &status) != nil || status.Message == "" {
+			status.Message = http.StatusText(resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to get service %s/%s: status %d: %s", namespace, name, resp.StatusCode, status.Message)
+	}
+	var svc service
+	if err := json.Unmarshal(body, &svc); err != nil {
+		return nil, fmt.Errorf("failed to parse service %s/%s: %w", namespace, name, err)
+	}
+	return &svc, nil
+}
+
+// kubeconfig is the part of a kubeconfig file used to call the API server.
+type kubeconfig struct {
+	CurrentContext string         `yaml:"current-context"`
+	Clusters       []namedCluster `yaml:"clusters"`
+	Contexts       []namedContext `yaml:"contexts"`
+	Users          []namedUser    `yaml:"users"`
+}
+
+type namedCluster struct {
+	Name    string `yaml:"name"`
+	Cluster struct {
+		Server                   string `yaml:"server"`
+		CertificateAuthority     string `yaml:"certificate-authority"`
+		CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		TLSServerName            string `yaml:"tls-server-name"`
+	} `yaml:"cluster"`
+}
+
+type namedContext struct {
+	Name    string `yaml:"name"`
+	Context struct {
+		Cluster   string `yaml:"cluster"`
+		User      string `yaml:"user"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"context"`
+}
+
+type namedUser struct {
+	Name string `yaml:"name"`
+	User struct {
+		Token                 string    `yaml:"token"`
+		TokenFile             string    `yaml:"tokenFile"`
+		ClientCertificate     string    `yaml:"client-certificate"`
+		ClientCertificateData string    `yaml:"client-certificate-data"`
+		ClientKey             string    `yaml:"client-key"`
+		ClientKeyData         string    `yaml:"client-key-data"`
+		Username              string    `yaml:"username"`
+		Password              string    `yaml:"password"`
+		Exec                  yaml.Node `yaml:"exec"`
+		AuthProvider          yaml.Node `yaml:"auth-provider"`
+	} `yaml:"user"`
+}
+
+// kubeconfigPath returns the kubeconfig file to use, or "" if there is none.
+func kubeconfigPath(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if paths := filepath.SplitList(os.Getenv("KUBECONFIG")); len(paths) > 0 && paths[0] != "" {
+		return paths[0]
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		path := filepath.Join(home, ".kube", "config")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// loadCluster returns the cluster of cfg, from its kubeconfig or from the
+// service account of the pod.
+func loadCluster(cfg Config) (*cluster, error) {
+	path := kubeconfigPath(cfg.ConfigPath)
+	if path == "" {
+		if host := os.Getenv("KUBERNETES_SERVICE_HOST"); host != "" {
+			return inClusterCluster(cfg, host, os.Getenv("KUBERNETES_SERVICE_PORT"))
+		}
+		return nil, errors.New("no kubeconfig file found, and not running in a Kubernetes pod")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+	var kc kubeconfig
+	if err := yaml.Unmarshal(data, &kc); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig %s: %w", path, err)
+	}
+	// Relative paths in a kubeconfig are relative to the file.
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(filepath.Dir(path), p)
+	}
+
+	contextName := cfg.Context
+	if contextName == "" {
+		contextName = kc.CurrentContext
+	}
+	i := slices.IndexFunc(kc.Contexts, func(c namedContext) bool { return c.Name == contextName })
+	if i < 0 {
+		return nil, fmt.Errorf("kubeconfig %s has no context %q", path, contextName)
+	}
+	kctx := kc.Contexts[i].Context
+	ci := slices.IndexFunc(kc.Clusters, func(c namedCluster) bool { return c.Name == kctx.Cluster })
+	if ci < 0 {
+		return nil, fmt.Errorf("kubeconfig %s has no cluster %q", path, kctx.Cluster)
+	}
+	kcluster := kc.Clusters[ci].Cluster
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: kcluster.InsecureSkipTLSVerify, // nolint:gosec // as configured in the kubeconfig
+		ServerName:         kcluster.TLSServerName,
+	}
+	if tlsConfig.RootCAs, err = certPool(kcluster.CertificateAuthorityData, resolve(kcluster.CertificateAuthority)); err != nil {
+		return nil, err
+	}
+
+	authorize := func(*http.Request) {}
+	if kctx.User != "" {
+		ui := slices.IndexFunc(kc.Users, func(u namedUser) bool { return u.Name == kctx.User })
+		if ui < 0 {
+			return nil, fmt.Errorf("kubeconfig %s has no user %q", path, kctx.User)
+		}
+		user := kc.Users[ui].User
+		switch {
+		case !user.Exec.IsZero():
+			return nil, fmt.Errorf("user %q of kubeconfig %s uses an exec credential plugin, which is not supported", kctx.User, path)
+		case !user.AuthProvider.IsZero():
+			return nil, fmt.Errorf("user %q of kubeconfig %s uses an auth provider plugin, which is not supported", kctx.User, path)
+		}
+		cert, err := clientCertificate(user.ClientCertificateData, resolve(user.ClientCertificate), user.ClientKeyData, resolve(user.ClientKey))
+		if err != nil {
+			return nil, err
+		}
+		if cert != nil {
+			tlsConfig.Certificates = []tls.Certificate{*cert}
+		}
+		token := user.Token
+		if token == "" && user.TokenFile != "" {
+			b, err := os.ReadFile(resolve(user.TokenFile))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read token file: %w", err)
+			}
+			token = strings.TrimSpace(string(b))
+		}
+		switch {
+		case token != "":
+			authorize = func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+		case user.Username != "":
+			authorize = func(req *http.Request) { req.SetBasicAuth(user.Username, user.Password) }
+		}
+	}
+
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = kctx.Namespace
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	return &cluster{
+		server:    kcluster.Server,
+		namespace: namespace,
+		client:    newHTTPClient(tlsConfig),
+		authorize: authorize,
+	}, nil
+}
+
+// inClusterCluster returns the cluster of the pod the provider runs in,
+// called with the token of its service account.
+func inClusterCluster(cfg Config, host, port string) (*cluster, error) {
+	token, err := os.ReadFile(filepath.Join(serviceAccountDir, "token"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
+	}
+	pool, err := certPool("", filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, err
+	}
+	namespace := cfg.Namespace
+	if namespace == "" {
+		if b, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace")); err == nil {
+			namespace = strings.TrimSpace(string(b))
+		}
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	if port == "" {
+		port = "443"
+	}
+	bearer := "Bearer " + strings.TrimSpace(string(token))
+	return &cluster{
+		server:    "https://" + net.JoinHostPort(host, port),
+		namespace: namespace,
+		inCluster: true,
+		client:    newHTTPClient(&tls.Config{RootCAs: pool}),
+		authorize: func(req *http.Request) { req.Header.Set("Authorization", bearer) },
+	}, nil
+}
+
+func newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}
+}
+
+// certPool returns the certificate authorities of the base64 data, or else
+// of the file, or nil for the system ones.
+func certPool(data, file string) (*x509.CertPool, error) {
+	pem, err := readData(data, file)
+	if err != nil || pem == nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no certificates found in the certificate authority of the cluster")
+	}
+	return pool, nil
+}
+
+// clientCertificate returns the client certificate of a kubeconfig user,
+// or nil if it has none.
+func clientCertificate(certData, certFile, keyData, keyFile string) (*tls.Certificate, error) {
+	certPEM, err := readData(certData, certFile)
+	if err != nil || certPEM == nil {
+		return nil, err
+	}
+	keyPEM, err := readData(keyData, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// readData returns the base64 data, or else the content of the file, or nil
+// if both are empty.
+func readData(data, file string) ([]byte, error) {
+	if data != "" {
+		b, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 data in kubeconfig: %w", err)
+		}
+		return b, nil
+	}
+	if file == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package kubeservice
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const token = "kube-token-1234"
+
+// apiServer serves the Services of namespace "lego", to requests with the
+// bearer token.
+func apiServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	services := map[string]string{
+		"/api/v1/namespaces/lego/services/lb": `{"spec":{"ports":[{"name":"metrics","port":9090},{"name":"http","port":8080}]},
+			"status":{"loadBalancer":{"ingress":[{"ip":"10.1.2.3"}]}}}`,
+		"/api/v1/namespaces/lego/services/internal": `{"spec":{"ports":[{"name":"http","port":8080}]},"status":{}}`,
+	}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"kind":"Status","message":"Unauthorized"}`)
+			return
+		}
+		body, ok := services[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"kind":"Status","message":"services \"%s\" not found"}`, filepath.Base(r.URL.Path))
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func caData(srv *httptest.Server) string {
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+}
+
+func writeKubeconfig(t *testing.T, srv *httptest.Server, user string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	config := fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: juju
+clusters:
+- name: k8s
+  cluster:
+    server: %s
+    certificate-authority-data: %s
+contexts:
+- name: juju
+  context:
+    cluster: k8s
+    user: admin
+    namespace: lego
+users:
+- name: admin
+  user:
+%s
+`, srv.URL, caData(srv), user)
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestResolve_Kubeconfig(t *testing.T) {
+	srv := apiServer(t)
+	path := writeKubeconfig(t, srv, "    token: "+token)
+	ctx := context.Background()
+
+	tests := []struct {
+		cfg     Config
+		want    string
+		wantErr string
+	}{
+		{cfg: Config{ConfigPath: path, Service: "lb"}, want: "http://10.1.2.3:9090"},
+		{cfg: Config{ConfigPath: path, Service: "lb", Port: "http"}, want: "http://10.1.2.3:8080"},
+		{cfg: Config{ConfigPath: path, Service: "lb", Port: "8080", Scheme: "https"}, want: "https://10.1.2.3:8080"},
+		{cfg: Config{ConfigPath: path, Service: "lb", Port: "grpc"}, wantErr: `service has no port "grpc"`},
+		{cfg: Config{ConfigPath: path, Service: "internal"}, wantErr: "only reachable from inside the cluster"},
+		{cfg: Config{ConfigPath: path, Service: "missing"}, wantErr: `status 404: services "missing" not found`},
+		{cfg: Config{ConfigPath: path, Service: "lb", Namespace: "other"}, wantErr: "status 404"},
+		{cfg: Config{ConfigPath: path, Service: "lb", Context: "other"}, wantErr: `no context "other"`},
+	}
+	for _, tt := range tests {
+		got, err := Resolve(ctx, tt.cfg)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Resolve(%+v) = %q, %v; want error containing %q", tt.cfg, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Resolve(%+v) = %q, %v; want %q", tt.cfg, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolve_KubeconfigUsers(t *testing.T) {
+	srv := apiServer(t)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte(token+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Resolve(context.Background(), Config{ConfigPath: writeKubeconfig(t, srv, "    tokenFile: "+tokenFile), Service: "lb"}); err != nil {
+		t.Errorf("Resolve with a token file: %v", err)
+	}
+	_, err := Resolve(context.Background(), Config{ConfigPath: writeKubeconfig(t, srv, "    token: wrong"), Service: "lb"})
+	if err == nil || !strings.Contains(err.Error(), "status 401: Unauthorized") {
+		t.Errorf("expected the API server to reject the token, got %v", err)
+	}
+	_, err = Resolve(context.Background(), Config{ConfigPath: writeKubeconfig(t, srv, "    exec:\n      command: kubelogin"), Service: "lb"})
+	if err == nil || !strings.Contains(err.Error(), "exec credential plugin") {
+		t.Errorf("expected exec plugins to be rejected, got %v", err)
+	}
+}
+
+func TestResolve_InCluster(t *testing.T) {
+	srv := apiServer(t)
+	dir := t.TempDir()
+	files := map[string]string{
+		"token":     token,
+		"namespace": "lego",
+		"ca.crt":    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := serviceAccountDir
+	serviceAccountDir = dir
+	t.Cleanup(func() { serviceAccountDir = old })
+
+	host, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	t.Setenv("KUBECONFIG", "")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBERNETES_SERVICE_HOST", host)
+	t.Setenv("KUBERNETES_SERVICE_PORT", port)
+
+	got, err := Resolve(context.Background(), Config{Service: "internal"})
+	if err != nil || got != "http://internal.lego.svc:8080" {
+		t.Fatalf("Resolve() = %q, %v; want the cluster DNS name", got, err)
+	}
+	got, err = Resolve(context.Background(), Config{Service: "lb", Port: "http"})
+	if err != nil || got != "http://10.1.2.3:8080" {
+		t.Fatalf("Resolve() = %q, %v; want the load balancer", got, err)
+	}
+}
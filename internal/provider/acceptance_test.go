@@ -0,0 +1,199 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// The acceptance tests below are resource.Test cases, which run only when
+// TF_ACC is set. They run Terraform CLI against the provider served in the
+// test process, so need terraform on the PATH or in TF_ACC_TERRAFORM_PATH,
+// and a deployed httprequest-lego-provider to talk to: the provider is
+// configured from LEGOCHARM_ADDRESS, LEGOCHARM_USERNAME and
+// LEGOCHARM_PASSWORD, which must name a superuser of it. See the README for
+// how to deploy one. Every object they create is named with
+// testAccNamePrefix.
+
+// testAccNamePrefix starts the names of all users, groups and domains the
+// acceptance tests create, so leftovers can be told apart from real objects.
+const testAccNamePrefix = "tfacc-"
+
+// testAccProtoV6ProviderFactories serves the provider to Terraform CLI from
+// the test process.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"legocharm": NewProtocol6Server("test"),
+}
+
+// accEnv is the API an acceptance test runs against.
+type accEnv struct {
+	Address  string
+	Username string
+	Password string
+	// API is a client for setting up fixtures and checking or changing
+	// objects behind the provider's back.
+	API *legocharmclient.Client
+}
+
+// testAccPreCheck skips the test unless TF_ACC is set, and returns the API
+// to run it against. It fails the test if no deployment is configured.
+func testAccPreCheck(t *testing.T) accEnv {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("acceptance tests skipped unless env 'TF_ACC' set")
+	}
+
+	env := accEnv{
+		Address:  os.Getenv("LEGOCHARM_ADDRESS"),
+		Username: os.Getenv("LEGOCHARM_USERNAME"),
+		Password: os.Getenv("LEGOCHARM_PASSWORD"),
+	}
+	if env.Address == "" || env.Username == "" || env.Password == "" {
+		t.Fatal("acceptance tests run against a deployed httprequest-lego-provider: LEGOCHARM_ADDRESS, LEGOCHARM_USERNAME and LEGOCHARM_PASSWORD must be set to a superuser of it")
+	}
+
+	var err error
+	env.API, err = legocharmclient.NewClient(&env.Address, &env.Username, &env.Password)
+	require.NoError(t, err)
+	return env
+}
+
+// testAccName returns a new random name starting with testAccNamePrefix.
+func testAccName(t *testing.T) string {
+	t.Helper()
+	b := make([]byte, 5)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return testAccNamePrefix + hex.EncodeToString(b)
+}
+
+// testAccUser creates a user for the test and deletes it afterwards.
+func testAccUser(t *testing.T, env accEnv) (id, username, password string) {
+	t.Helper()
+	username, password = testAccName(t), testAccName(t)
+	user, err := env.API.CreateUser(context.Background(), legocharmclient.UserCreateData{Username: username, Password: password, IsActive: true, Groups: []string{}})
+	require.NoError(t, err)
+	id = legocharmclient.LastPathSegment(user.Url)
+	t.Cleanup(func() {
+		env.API.DeleteUserById(context.Background(), id) // nolint:errcheck
+	})
+	return id, username, password
+}
+
+// testAccGroup returns the group for group membership tests. The API cannot
+// create groups, so the test is skipped unless LEGOCHARM_ACC_GROUP names an
+// existing one.
+func testAccGroup(t *testing.T) string {
+	t.Helper()
+	group := os.Getenv("LEGOCHARM_ACC_GROUP")
+	if group == "" {
+		t.Skip("group membership acceptance tests skipped unless env 'LEGOCHARM_ACC_GROUP' set")
+	}
+	return group
+}
+
+// testAccDomain creates a domain for the test and deletes it afterwards.
+func testAccDomain(t *testing.T, env accEnv) legocharmclient.DomainData {
+	t.Helper()
+	domain, err := env.API.CreateDomain(context.Background(), legocharmclient.DomainData{Fqdn: testAccName(t) + ".example.com"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		env.API.DeleteDomain(context.Background(), domain.ID) // nolint:errcheck
+	})
+	return *domain
+}
+
+// testAccGrant grants the user access to the domain for the test.
+func testAccGrant(t *testing.T, env accEnv, userID, fqdn, accessLevel string) legocharmclient.DomainUserPermissionData {
+	t.Helper()
+	access, err := env.API.CreateDomainAccess(context.Background(), legocharmclient.DomainUserPermissionCreateData{UserID: userID, Domain: fqdn, AccessLevel: accessLevel})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		env.API.DeleteDomainAccess(context.Background(), access.ID) // nolint:errcheck
+	})
+	return *access
+}
+
+// testAccStoreAttr stores the value of the attribute key of the resource
+// name in *value, for the PreConfig of later steps.
+func testAccStoreAttr(name, key string, value *string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrWith(name, key, func(v string) error {
+		*value = v
+		return nil
+	})
+}
+
+// testAccCheckDomainExists checks whether the domain fqdn exists.
+func testAccCheckDomainExists(env accEnv, fqdn string, exists bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		_, err := env.API.GetDomain(context.Background(), fqdn)
+		switch {
+		case errors.Is(err, legocharmclient.ErrNotFound):
+			if exists {
+				return fmt.Errorf("domain %s does not exist", fqdn)
+			}
+			return nil
+		case err != nil:
+			return err
+		case !exists:
+			return fmt.Errorf("domain %s still exists", fqdn)
+		}
+		return nil
+	}
+}
+
+// testAccCheckUserDestroyed checks that the user username no longer exists.
+func testAccCheckUserDestroyed(env accEnv, username string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		_, err := env.API.GetUserByUsername(context.Background(), username)
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("user %s still exists", username)
+		}
+		return err
+	}
+}
+
+// testAccCheckGroupMember checks whether the user username is a member of
+// group.
+func testAccCheckGroupMember(env accEnv, username, group string, member bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		user, err := env.API.GetUserByUsername(context.Background(), username)
+		if err != nil {
+			return err
+		}
+		if got := slices.Contains(user.Groups, group); got != member {
+			return fmt.Errorf("user %s is a member of group %s: %v, want %v", username, group, got, member)
+		}
+		return nil
+	}
+}
+
+// testAccCheckPassword checks that the password attribute of the resource
+// name is the password of the user username.
+func testAccCheckPassword(env accEnv, name, username string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrWith(name, "password", func(password string) error {
+		valid, err := env.API.HasValidUserPassword(context.Background(), username, password)
+		if err == nil && !valid {
+			err = fmt.Errorf("the password of %s is not that of user %s", name, username)
+		}
+		return err
+	})
+}
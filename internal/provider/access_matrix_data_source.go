@@ -0,0 +1,219 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ datasource.DataSource = &AccessMatrixDataSource{}
+
+// NewAccessMatrixDataSource creates a new access matrix data source.
+func NewAccessMatrixDataSource() datasource.DataSource { return &AccessMatrixDataSource{} }
+
+// AccessMatrixDataSource is the data source implementation for every domain
+// access permission across all users and domains.
+type AccessMatrixDataSource struct {
+	client legocharmclient.API
+}
+
+// AccessMatrixDataSourceModel maps Terraform schema to Go types for the
+// access matrix data source.
+type AccessMatrixDataSourceModel struct {
+	Users   types.List `tfsdk:"users"`
+	Domains types.List `tfsdk:"domains"`
+	Entries types.List `tfsdk:"entries"`
+}
+
+// accessMatrixEntryModel maps a single element of the entries attribute.
+type accessMatrixEntryModel struct {
+	Username    types.String `tfsdk:"username"`
+	UserId      types.String `tfsdk:"user_id"`
+	Domain      types.String `tfsdk:"domain"`
+	DomainId    types.Int64  `tfsdk:"domain_id"`
+	AccessLevel types.String `tfsdk:"access_level"`
+	DatabaseID  types.Int64  `tfsdk:"database_id"`
+}
+
+// accessMatrixEntryObjectType is the object type of an element of the
+// entries attribute.
+var accessMatrixEntryObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"username":     types.StringType,
+	"user_id":      types.StringType,
+	"domain":       types.StringType,
+	"domain_id":    types.Int64Type,
+	"access_level": types.StringType,
+	"database_id":  types.Int64Type,
+}}
+
+func (d *AccessMatrixDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_matrix"
+}
+
+func (d *AccessMatrixDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Every domain access permission across all users and domains, for compliance exports and drift dashboards. Requires a provider account allowed to list users.",
+		Attributes: map[string]schema.Attribute{
+			"users": schema.ListAttribute{
+				MarkdownDescription: "Usernames of all users, sorted, including those without permissions.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"domains": schema.ListAttribute{
+				MarkdownDescription: "FQDNs of all domains, sorted, including those without permissions.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "One element per permission, sorted by username and then domain. A user×domain pair without an element has no permission.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
+							MarkdownDescription: "Username of the user holding the permission.",
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the user holding the permission.",
+							Computed:            true,
+						},
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "FQDN of the domain the permission is on.",
+							Computed:            true,
+						},
+						"domain_id": schema.Int64Attribute{
+							MarkdownDescription: "The ID of the domain.",
+							Computed:            true,
+						},
+						"access_level": schema.StringAttribute{
+							MarkdownDescription: "Access level of the permission: 'domain' or 'subdomain'.",
+							Computed:            true,
+						},
+						"database_id": schema.Int64Attribute{
+							MarkdownDescription: "The server-side ID of the permission.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AccessMatrixDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AccessMatrixDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AccessMatrixDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this data source")
+		return
+	}
+
+	userList, err := d.client.ListUsers(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list users", err, nil)
+		return
+	}
+	domainList, err := d.client.ListDomains(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list domains", err, nil)
+		return
+	}
+	permissions, err := d.client.ListAllDomainAccess(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list domain access", err, nil)
+		return
+	}
+
+	usernames := map[int]string{}
+	users := []string{}
+	for _, user := range userList {
+		id, err := strconv.Atoi(legocharmclient.LastPathSegment(user.Url))
+		if err != nil {
+			continue
+		}
+		usernames[id] = user.Username
+		users = append(users, user.Username)
+	}
+	fqdns := map[int]string{}
+	domains := []string{}
+	for _, domain := range domainList {
+		fqdns[domain.ID] = domain.Fqdn
+		domains = append(domains, domain.Fqdn)
+	}
+	sort.Strings(users)
+	sort.Strings(domains)
+
+	entries := []accessMatrixEntryModel{}
+	for _, p := range permissions {
+		// skip permissions on users or domains deleted between the calls
+		username, ok := usernames[p.UserID]
+		if !ok {
+			continue
+		}
+		fqdn, ok := fqdns[p.Domain]
+		if !ok {
+			continue
+		}
+		entries = append(entries, accessMatrixEntryModel{
+			Username:    types.StringValue(username),
+			UserId:      types.StringValue(strconv.Itoa(p.UserID)),
+			Domain:      types.StringValue(fqdn),
+			DomainId:    types.Int64Value(int64(p.Domain)),
+			AccessLevel: types.StringValue(p.AccessLevel),
+			DatabaseID:  types.Int64Value(int64(p.ID)),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if a, b := entries[i].Username.ValueString(), entries[j].Username.ValueString(); a != b {
+			return a < b
+		}
+		return entries[i].Domain.ValueString() < entries[j].Domain.ValueString()
+	})
+
+	usersValue, diags := types.ListValueFrom(ctx, types.StringType, users)
+	resp.Diagnostics.Append(diags...)
+	domainsValue, diags := types.ListValueFrom(ctx, types.StringType, domains)
+	resp.Diagnostics.Append(diags...)
+	entriesValue, diags := types.ListValueFrom(ctx, accessMatrixEntryObjectType, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Users = usersValue
+	data.Domains = domainsValue
+	data.Entries = entriesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestAccessMatrixDataSource_Read(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "bob"
+	api.users[1006] = "carol"
+	api.domains[3] = "example.com"
+	api.permissions[7] = legocharmclient.DomainUserPermissionData{ID: 7, UserID: 1005, Domain: 2, AccessLevel: "subdomain"}
+	api.permissions[8] = legocharmclient.DomainUserPermissionData{ID: 8, UserID: 1004, Domain: 3, AccessLevel: "domain"}
+	api.permissions[9] = legocharmclient.DomainUserPermissionData{ID: 9, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	// A permission on a domain deleted concurrently is skipped.
+	api.permissions[10] = legocharmclient.DomainUserPermissionData{ID: 10, UserID: 1004, Domain: 99, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	d := &AccessMatrixDataSource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	data := AccessMatrixDataSourceModel{Users: types.ListNull(types.StringType), Domains: types.ListNull(types.StringType), Entries: types.ListNull(accessMatrixEntryObjectType)}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var result AccessMatrixDataSourceModel
+	require.False(t, resp.State.Get(ctx, &result).HasError())
+	var users, domains []string
+	require.False(t, result.Users.ElementsAs(ctx, &users, false).HasError())
+	require.Equal(t, []string{"alice", "bob", "carol"}, users)
+	require.False(t, result.Domains.ElementsAs(ctx, &domains, false).HasError())
+	require.Equal(t, []string{"example.com", "staging.example.com"}, domains)
+
+	var entries []accessMatrixEntryModel
+	require.False(t, result.Entries.ElementsAs(ctx, &entries, false).HasError())
+	entry := func(username, userID, domain string, domainID int64, level string, id int64) accessMatrixEntryModel {
+		return accessMatrixEntryModel{
+			Username:    types.StringValue(username),
+			UserId:      types.StringValue(userID),
+			Domain:      types.StringValue(domain),
+			DomainId:    types.Int64Value(domainID),
+			AccessLevel: types.StringValue(level),
+			DatabaseID:  types.Int64Value(id),
+		}
+	}
+	require.Equal(t, []accessMatrixEntryModel{
+		entry("alice", "1004", "example.com", 3, "domain", 8),
+		entry("alice", "1004", "staging.example.com", 2, "domain", 9),
+		entry("bob", "1005", "staging.example.com", 2, "subdomain", 7),
+	}, entries)
+}
+
+func TestAccAccessMatrixDataSource(t *testing.T) {
+	env := testAccPreCheck(t)
+	userID, username, _ := testAccUser(t, env)
+	domain := testAccDomain(t, env)
+	access := testAccGrant(t, env, userID, domain.Fqdn, "domain")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "legocharm_access_matrix" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.legocharm_access_matrix.test", "users.*", username),
+					resource.TestCheckTypeSetElemAttr("data.legocharm_access_matrix.test", "domains.*", domain.Fqdn),
+					resource.TestCheckTypeSetElemNestedAttrs("data.legocharm_access_matrix.test", "entries.*", map[string]string{
+						"username":     username,
+						"user_id":      userID,
+						"domain":       domain.Fqdn,
+						"domain_id":    strconv.Itoa(domain.ID),
+						"access_level": "domain",
+						"database_id":  strconv.Itoa(access.ID),
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ resource.Resource = &AdminPasswordResource{}
+
+// NewAdminPasswordResource creates a new admin password resource.
+func NewAdminPasswordResource() resource.Resource { return &AdminPasswordResource{} }
+
+// AdminPasswordResource is the resource implementation for the password of
+// the account the provider authenticates as.
+type AdminPasswordResource struct {
+	client legocharmclient.API
+}
+
+// AdminPasswordModel maps Terraform schema to Go types for admin password
+// resources.
+type AdminPasswordModel struct {
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.String `tfsdk:"password_wo_version"`
+	Username          types.String `tfsdk:"username"`
+	Id                types.String `tfsdk:"id"`
+}
+
+func (r *AdminPasswordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_password"
+}
+
+func (r *AdminPasswordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The password of the account the provider authenticates as. The password is changed on create and whenever `password_wo_version` changes, and the provider uses the new password for the rest of the apply. Update the provider configuration with the new password before the next run. Destroying the resource leaves the password unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only new password. The value is sent to the API but never stored in the Terraform plan or state. Requires Terraform 1.11 or later.",
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringLengthAtLeast(minPasswordLength),
+				},
+			},
+			"password_wo_version": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value whose change sends `password_wo` to the API again. Change it together with `password_wo`.",
+				Required:            true,
+			},
+			"username": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Username of the account, as configured on the provider.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the admin password resource, equal to `username`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AdminPasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AdminPasswordModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	r.changePassword(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Username = types.StringValue(r.client.AuthenticatedUsername())
+	data.Id = data.Username
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdminPasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The password cannot be read back; keep the state as is.
+	var data AdminPasswordModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdminPasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state AdminPasswordModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		r.changePassword(ctx, req.Config, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	plan.Username = types.StringValue(r.client.AuthenticatedUsername())
+	plan.Id = plan.Username
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AdminPasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The previous password is not known, so the current one is kept.
+	tflog.Debug(ctx, "removing admin password from state without changing it")
+}
+
+// changePassword changes the password of the provider's account to the
+// configured write-only password and switches the client over to it.
+func (r *AdminPasswordResource) changePassword(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	// Write-only values are only available from configuration
+	var passwordWO types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if diags.HasError() {
+		return
+	}
+	if passwordWO.IsNull() || passwordWO.IsUnknown() {
+		diags.AddAttributeError(path.Root("password_wo"), "Missing Password", "`password_wo` must be known when the password is changed.")
+		return
+	}
+
+	tflog.Debug(ctx, "changing admin password", map[string]interface{}{"username": r.client.AuthenticatedUsername()})
+	if err := r.client.ChangePassword(ctx, passwordWO.ValueString()); err != nil {
+		addClientError(diags, "Unable to change admin password", err, adminPasswordAPIFields)
+		return
+	}
+	diags.AddWarning(
+		"Admin Password Changed",
+		fmt.Sprintf("The password of %s was changed. Update the provider configuration with the new password before the next run.", r.client.AuthenticatedUsername()),
+	)
+}
+
+func (r *AdminPasswordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestAdminPasswordResource_Metadata(t *testing.T) {
+	r := &AdminPasswordResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+	require.Equal(t, "legocharm_admin_password", resp.TypeName)
+}
+
+// adminPasswordPlan returns a plan and configuration for the given write-only
+// password and version.
+func adminPasswordPlan(t *testing.T, schemaResp *resource.SchemaResponse, password, version string) (tfsdk.Plan, tfsdk.Config) {
+	ctx := context.Background()
+	data := AdminPasswordModel{
+		PasswordWO:        types.StringNull(),
+		PasswordWOVersion: types.StringValue(version),
+		Username:          types.StringUnknown(),
+		Id:                types.StringUnknown(),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	data.PasswordWO = types.StringValue(password)
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+	return plan, tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+}
+
+func TestAdminPasswordResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1] = "admin"
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "bootstrap"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &AdminPasswordResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	plan, config := adminPasswordPlan(t, schemaResp, "first-password", "1")
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan, Config: config}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	require.Equal(t, "first-password", api.passwords[1])
+	require.Equal(t, "first-password", client.Password)
+
+	var created AdminPasswordModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Equal(t, "admin", created.Username.ValueString())
+	require.True(t, created.PasswordWO.IsNull())
+
+	// Without a version change the password is left alone.
+	plan, config = adminPasswordPlan(t, schemaResp, "second-password", "1")
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, Config: config, State: createResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Equal(t, "first-password", client.Password)
+
+	plan, config = adminPasswordPlan(t, schemaResp, "second-password", "2")
+	updateResp = &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, Config: config, State: createResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Equal(t, "second-password", api.passwords[1])
+	require.Equal(t, "second-password", client.Password)
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Equal(t, "second-password", api.passwords[1])
+}
+
+func TestAccAdminPasswordResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	newPassword := testAccName(t)
+	ctx := context.Background()
+
+	authenticates := func(password string) resourcetest.TestCheckFunc {
+		return func(*terraform.State) error {
+			client, err := legocharmclient.NewClient(&env.Address, &env.Username, &password)
+			if err != nil {
+				return err
+			}
+			_, err = client.ListUsers(ctx)
+			return err
+		}
+	}
+	rejects := func(password string) resourcetest.TestCheckFunc {
+		return func(s *terraform.State) error {
+			if authenticates(password)(s) == nil {
+				return fmt.Errorf("the old password of %s still authenticates", env.Username)
+			}
+			return nil
+		}
+	}
+	config := func(password, version string) string {
+		return fmt.Sprintf(`
+resource "legocharm_admin_password" "test" {
+  password_wo         = %q
+  password_wo_version = %q
+}
+`, password, version)
+	}
+
+	// The last step restores the original password, so the deployment is
+	// left as it was.
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks:   []tfversion.TerraformVersionCheck{tfversion.SkipBelow(tfversion.Version1_11_0)},
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config(newPassword, "1"),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttr("legocharm_admin_password.test", "username", env.Username),
+					authenticates(newPassword),
+					rejects(env.Password),
+				),
+			},
+			{
+				Config: config(env.Password, "2"),
+				Check:  authenticates(env.Password),
+			},
+		},
+		CheckDestroy: authenticates(env.Password),
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// auditField is a value the provider can send with every API call, so that
+// the charm's access logs attribute changes to the run making them.
+type auditField struct {
+	// attribute is the name of the attribute of the audit object.
+	attribute string
+	// header is the request header carrying the value.
+	header string
+	// env is the environment variable overriding detection.
+	env string
+	// detect are the environment variables the value is detected from, in
+	// order, when it is not set otherwise.
+	detect []string
+	// description documents the attribute.
+	description string
+}
+
+// auditFields are the values of the audit object.
+var auditFields = []auditField{
+	{
+		attribute:   "workspace",
+		header:      "X-Terraform-Workspace",
+		env:         "LEGOCHARM_AUDIT_WORKSPACE",
+		detect:      []string{"TF_WORKSPACE", "TFC_WORKSPACE_NAME"},
+		description: "The Terraform workspace, such as terraform.workspace. Detected from TF_WORKSPACE and TFC_WORKSPACE_NAME.",
+	},
+	{
+		attribute:   "run_id",
+		header:      "X-Terraform-Run-ID",
+		env:         "LEGOCHARM_AUDIT_RUN_ID",
+		detect:      []string{"TFC_RUN_ID", "GITHUB_RUN_ID", "CI_PIPELINE_ID", "BUILD_ID"},
+		description: "The ID of the run or pipeline. Detected from TFC_RUN_ID, GITHUB_RUN_ID, CI_PIPELINE_ID and BUILD_ID.",
+	},
+	{
+		attribute:   "operator",
+		header:      "X-Terraform-Operator",
+		env:         "LEGOCHARM_AUDIT_OPERATOR",
+		detect:      []string{"GITHUB_ACTOR", "GITLAB_USER_LOGIN", "BUILD_USER_ID", "USER", "USERNAME"},
+		description: "Who runs Terraform. Detected from GITHUB_ACTOR, GITLAB_USER_LOGIN, BUILD_USER_ID, USER and USERNAME.",
+	},
+}
+
+// auditSchema returns the schema of the audit attribute of the provider.
+func auditSchema() schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(auditFields))
+	for _, f := range auditFields {
+		attributes[f.attribute] = schema.StringAttribute{
+			Optional:    true,
+			Description: f.description + " Can also be set via the " + f.env + " environment variable. Sent as the " + f.header + " header.",
+		}
+	}
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Description: "Identify the Terraform run in the headers of every API call, so that the charm's access logs attribute changes to specific pipelines. " +
+			"Values not set are detected from the environment of common CI systems, and any that cannot be found are not sent. " +
+			"Also enabled by setting any of the LEGOCHARM_AUDIT_* environment variables, in which case only those are sent.",
+		Attributes: attributes,
+	}
+}
+
+// auditAttributeTypes returns the attribute types of the audit object.
+func auditAttributeTypes() map[string]attr.Type {
+	attrTypes := make(map[string]attr.Type, len(auditFields))
+	for _, f := range auditFields {
+		attrTypes[f.attribute] = types.StringType
+	}
+	return attrTypes
+}
+
+// auditHeaders returns the headers to send for the audit object of the
+// provider configuration. A value comes from the attribute, or else from its
+// environment variable, or else, if the audit object is set, is detected from
+// the environment. Control characters are removed, as they are not allowed
+// in headers.
+func auditHeaders(audit types.Object) http.Header {
+	var attrs map[string]attr.Value
+	enabled := !audit.IsNull() && !audit.IsUnknown()
+	if enabled {
+		attrs = audit.Attributes()
+	}
+
+	headers := http.Header{}
+	for _, f := range auditFields {
+		value := os.Getenv(f.env)
+		if v, ok := attrs[f.attribute].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			value = v.ValueString()
+		}
+		if value == "" && enabled {
+			for _, name := range f.detect {
+				if value = os.Getenv(name); value != "" {
+					break
+				}
+			}
+		}
+		if value = headerValue(value); value != "" {
+			headers.Set(f.header, value)
+		}
+	}
+	return headers
+}
+
+// headerValue returns s without the control characters, which are not
+// allowed in headers, and surrounding spaces.
+func headerValue(s string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s))
+}
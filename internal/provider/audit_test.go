@@ -0,0 +1,57 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditHeaders(t *testing.T) {
+	// Clear what the environment running the tests may set.
+	for _, f := range auditFields {
+		t.Setenv(f.env, "")
+		for _, name := range f.detect {
+			t.Setenv(name, "")
+		}
+	}
+	audit := func(values map[string]string) types.Object {
+		attrs := map[string]attr.Value{}
+		for name := range auditAttributeTypes() {
+			attrs[name] = types.StringNull()
+			if v, ok := values[name]; ok {
+				attrs[name] = types.StringValue(v)
+			}
+		}
+		return types.ObjectValueMust(auditAttributeTypes(), attrs)
+	}
+	disabled := types.ObjectNull(auditAttributeTypes())
+
+	t.Setenv("GITHUB_RUN_ID", "4711")
+	t.Setenv("USER", "ci")
+	require.Empty(t, auditHeaders(disabled), "nothing is detected unless audit is set")
+
+	require.Equal(t, http.Header{
+		"X-Terraform-Run-Id":   {"4711"},
+		"X-Terraform-Operator": {"ci"},
+	}, auditHeaders(audit(nil)))
+
+	t.Setenv("LEGOCHARM_AUDIT_OPERATOR", "alice")
+	require.Equal(t, http.Header{"X-Terraform-Operator": {"alice"}}, auditHeaders(disabled), "the environment enables a value on its own")
+	require.Equal(t, http.Header{
+		"X-Terraform-Workspace": {"prod"},
+		"X-Terraform-Run-Id":    {"4711"},
+		"X-Terraform-Operator":  {"bob"},
+	}, auditHeaders(audit(map[string]string{"workspace": "prod", "operator": "bob"})), "attributes override the environment")
+
+	require.Equal(t, http.Header{
+		"X-Terraform-Workspace": {"prodX-Injected: 1"},
+		"X-Terraform-Run-Id":    {"4711"},
+		"X-Terraform-Operator":  {"alice"},
+	}, auditHeaders(audit(map[string]string{"workspace": " prod\r\nX-Injected: 1"})), "control characters are removed")
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &BasicAuthHeaderEphemeralResource{}
+
+// NewBasicAuthHeaderEphemeralResource creates a new basic auth header
+// ephemeral resource.
+func NewBasicAuthHeaderEphemeralResource() ephemeral.EphemeralResource {
+	return &BasicAuthHeaderEphemeralResource{}
+}
+
+// BasicAuthHeaderEphemeralResource is the ephemeral resource implementation
+// for deriving an HTTP basic authentication header from user credentials. It
+// does not call the API.
+type BasicAuthHeaderEphemeralResource struct{}
+
+// BasicAuthHeaderEphemeralModel maps Terraform schema to Go types for the
+// basic auth header ephemeral resource.
+type BasicAuthHeaderEphemeralModel struct {
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Credentials types.String `tfsdk:"credentials"`
+	Value       types.String `tfsdk:"value"`
+}
+
+func (r *BasicAuthHeaderEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_basic_auth_header"
+}
+
+func (r *BasicAuthHeaderEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Derives the HTTP basic authentication header for a username and password, as accepted by the API and lego's `httpreq` endpoint, without storing it in the plan or state. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username. It must not contain a colon.",
+				Required:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"credentials": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoding of `username:password`.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Value of the `Authorization` header: `Basic ` followed by `credentials`.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *BasicAuthHeaderEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data BasicAuthHeaderEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// RFC 7617 does not allow a colon in the user-id.
+	if strings.Contains(data.Username.ValueString(), ":") {
+		resp.Diagnostics.AddAttributeError(path.Root("username"), "Invalid Username", "Basic authentication does not support usernames containing a colon.")
+		return
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(data.Username.ValueString() + ":" + data.Password.ValueString()))
+	data.Credentials = types.StringValue(credentials)
+	data.Value = types.StringValue("Basic " + credentials)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
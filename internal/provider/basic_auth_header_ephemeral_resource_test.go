@@ -0,0 +1,55 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasicAuthHeaderEphemeralResource_Open(t *testing.T) {
+	r := &BasicAuthHeaderEphemeralResource{}
+	ctx := context.Background()
+	schemaResp := &ephemeral.SchemaResponse{}
+	r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+
+	open := func(username, password string) (*ephemeral.OpenResponse, BasicAuthHeaderEphemeralModel) {
+		data := BasicAuthHeaderEphemeralModel{
+			Username:    types.StringValue(username),
+			Password:    types.StringValue(password),
+			Credentials: types.StringNull(),
+			Value:       types.StringNull(),
+		}
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, config.Set(ctx, &data).HasError())
+
+		resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema}}
+		r.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		var result BasicAuthHeaderEphemeralModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.Result.Get(ctx, &result).HasError())
+		}
+		return resp, result
+	}
+
+	resp, result := open("alice", "p@ss:word")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, "YWxpY2U6cEBzczp3b3Jk", result.Credentials.ValueString())
+
+	// The header must be what net/http would send for the same credentials.
+	req, err := http.NewRequest("GET", "http://example.com", nil)
+	require.NoError(t, err)
+	req.SetBasicAuth("alice", "p@ss:word")
+	require.Equal(t, req.Header.Get("Authorization"), result.Value.ValueString())
+
+	resp, _ = open("ali:ce", "secret")
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid Username", resp.Diagnostics.Errors()[0].Summary())
+}
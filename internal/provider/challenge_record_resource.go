@@ -0,0 +1,177 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ resource.Resource = &ChallengeRecordResource{}
+
+// NewChallengeRecordResource creates a new challenge record resource.
+func NewChallengeRecordResource() resource.Resource { return &ChallengeRecordResource{} }
+
+// ChallengeRecordResource is the resource implementation for a DNS-01
+// challenge TXT record published through the charm.
+type ChallengeRecordResource struct {
+	client legocharmclient.API
+}
+
+// ChallengeRecordModel maps Terraform schema to Go types for challenge record
+// resources.
+type ChallengeRecordModel struct {
+	Domain types.String `tfsdk:"domain"`
+	Value  types.String `tfsdk:"value"`
+	Fqdn   types.String `tfsdk:"fqdn"`
+	Id     types.String `tfsdk:"id"`
+}
+
+func (r *ChallengeRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_challenge_record"
+}
+
+func (r *ChallengeRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A DNS-01 challenge TXT record, published through the charm's `present` endpoint on create and removed through its `cleanup` endpoint on destroy, exactly as an ACME client using lego's `httpreq` DNS provider would. Intended for smoke-testing the DNS pipeline behind the charm. The record cannot be read back, so changes made outside Terraform are not detected.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Domain the challenge is for, such as `example.com`. A wildcard such as `*.example.com` uses the record of the domain it covers. The provider account must be permitted on the domain.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Content of the TXT record.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringLengthBetween(1, 255),
+				},
+			},
+			"fqdn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the TXT record, such as `_acme-challenge.example.com.`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the challenge record, equal to `fqdn`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ChallengeRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ChallengeRecordModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	record := legocharmclient.TXTRecordData{
+		Fqdn:  challengeFQDN(data.Domain.ValueString()),
+		Value: data.Value.ValueString(),
+	}
+	if err := r.client.PresentTXTRecord(ctx, record); err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("Unable to present TXT record %s", record.Fqdn), err, nil)
+		return
+	}
+	data.Fqdn = types.StringValue(record.Fqdn)
+	data.Id = data.Fqdn
+
+	tflog.Trace(ctx, "presented challenge record", map[string]interface{}{"fqdn": record.Fqdn})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ChallengeRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The charm has no endpoint to look records up; keep the state as is.
+	var data ChallengeRecordModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ChallengeRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute requires replacement.
+	var data ChallengeRecordModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ChallengeRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ChallengeRecordModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	record := legocharmclient.TXTRecordData{Fqdn: data.Fqdn.ValueString(), Value: data.Value.ValueString()}
+	if err := r.client.CleanupTXTRecord(ctx, record); err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("Unable to clean up TXT record %s", record.Fqdn), err, nil)
+	}
+}
+
+// challengeFQDN returns the name of the DNS-01 challenge record for domain,
+// in the form lego sends to the httpreq endpoints.
+func challengeFQDN(domain string) string {
+	return "_acme-challenge." + strings.TrimPrefix(legocharmclient.NormalizeFQDN(domain), "*.") + "."
+}
+
+func (r *ChallengeRecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
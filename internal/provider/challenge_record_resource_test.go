@@ -0,0 +1,108 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestChallengeRecordResource_Metadata(t *testing.T) {
+	r := &ChallengeRecordResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+	require.Equal(t, "legocharm_challenge_record", resp.TypeName)
+}
+
+func TestChallengeFQDN(t *testing.T) {
+	require.Equal(t, "_acme-challenge.example.com.", challengeFQDN("Example.com."))
+	require.Equal(t, "_acme-challenge.example.com.", challengeFQDN("*.example.com"))
+}
+
+func TestChallengeRecordResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &ChallengeRecordResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := ChallengeRecordModel{
+		Domain: types.StringValue("staging.example.com"),
+		Value:  types.StringValue("smoke-test"),
+		Fqdn:   types.StringUnknown(),
+		Id:     types.StringUnknown(),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	require.Equal(t, map[string]string{"_acme-challenge.staging.example.com.": "smoke-test"}, api.txtRecords)
+
+	var created ChallengeRecordModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Equal(t, "_acme-challenge.staging.example.com.", created.Fqdn.ValueString())
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Empty(t, api.txtRecords)
+}
+
+func TestAccChallengeRecordResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	domain := testAccDomain(t, env)
+
+	// The provider account presents the record, so it needs access to the
+	// domain.
+	admin, err := env.API.GetUserByUsername(context.Background(), env.Username)
+	require.NoError(t, err)
+	testAccGrant(t, env, legocharmclient.LastPathSegment(admin.Url), domain.Fqdn, "domain")
+
+	config := func(value string) string {
+		return fmt.Sprintf(`
+resource "legocharm_challenge_record" "test" {
+  domain = %q
+  value  = %q
+}
+`, domain.Fqdn, value)
+	}
+
+	// Records cannot be read back through the API, so only the state is
+	// checked.
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config("tfacc-token-1"),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttr("legocharm_challenge_record.test", "fqdn", "_acme-challenge."+domain.Fqdn+"."),
+					resourcetest.TestCheckResourceAttr("legocharm_challenge_record.test", "value", "tfacc-token-1"),
+				),
+			},
+			{
+				Config: config("tfacc-token-2"),
+				Check:  resourcetest.TestCheckResourceAttr("legocharm_challenge_record.test", "value", "tfacc-token-2"),
+			},
+		},
+	})
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ datasource.DataSource = &ChallengeTestDataSource{}
+
+// NewChallengeTestDataSource creates a new challenge test data source.
+func NewChallengeTestDataSource() datasource.DataSource { return &ChallengeTestDataSource{} }
+
+// ChallengeTestDataSource is the data source implementation for an
+// end-to-end DNS-01 challenge round-trip through the charm.
+type ChallengeTestDataSource struct {
+	client *legocharmclient.Client
+}
+
+// ChallengeTestDataSourceModel maps Terraform schema to Go types for the
+// challenge test data source.
+type ChallengeTestDataSourceModel struct {
+	Domain             types.String `tfsdk:"domain"`
+	Username           types.String `tfsdk:"username"`
+	Password           types.String `tfsdk:"password"`
+	Value              types.String `tfsdk:"value"`
+	PropagationTimeout types.String `tfsdk:"propagation_timeout"`
+	Nameserver         types.String `tfsdk:"nameserver"`
+	Fqdn               types.String `tfsdk:"fqdn"`
+	Success            types.Bool   `tfsdk:"success"`
+	Error              types.String `tfsdk:"error"`
+	PropagationTime    types.String `tfsdk:"propagation_time"`
+}
+
+// lookupTXT returns the TXT records of name, querying nameserver ("host:port")
+// when it is not empty. It is a variable so tests can replace it.
+var lookupTXT = func(ctx context.Context, nameserver, name string) ([]string, error) {
+	resolver := net.DefaultResolver
+	if nameserver != "" {
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, nameserver)
+			},
+		}
+	}
+	return resolver.LookupTXT(ctx, name)
+}
+
+func (d *ChallengeTestDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_challenge_test"
+}
+
+func (d *ChallengeTestDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Performs a DNS-01 challenge round-trip with the given credentials on every read: a TXT record is presented through the charm, optionally waited for in DNS, and cleaned up again. Failures are reported in `success` and `error` rather than failing the read, for use in `check` blocks.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Domain to test, such as `example.com`. A wildcard such as `*.example.com` uses the record of the domain it covers.",
+				Required:            true,
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the ACME identity to test.",
+				Required:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the ACME identity to test.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Content of the TXT record. Defaults to a random token.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringLengthBetween(1, 255),
+				},
+			},
+			"propagation_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the record to be visible in DNS, as a duration such as `2m`. If unset, DNS is not queried and the test only covers the charm.",
+				Optional:            true,
+				Validators: []validator.String{
+					duration(),
+				},
+			},
+			"nameserver": schema.StringAttribute{
+				MarkdownDescription: "Nameserver to query for propagation, as `host:port`, such as an authoritative server of the zone. Defaults to the system resolver.",
+				Optional:            true,
+			},
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "Name of the TXT record, such as `_acme-challenge.example.com.`.",
+				Computed:            true,
+			},
+			"success": schema.BoolAttribute{
+				MarkdownDescription: "Whether the record was presented, seen in DNS if `propagation_timeout` is set, and cleaned up.",
+				Computed:            true,
+			},
+			"error": schema.StringAttribute{
+				MarkdownDescription: "Why the test failed, or null on success.",
+				Computed:            true,
+			},
+			"propagation_time": schema.StringAttribute{
+				MarkdownDescription: "Time from presenting the record until it was visible in DNS, such as `12.5s`, or null if propagation was not checked or not seen.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ChallengeTestDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*legocharmclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *legocharmclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ChallengeTestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ChallengeTestDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this data source")
+		return
+	}
+
+	// The round-trip is made as the identity under test, not as the
+	// provider's account.
+	username, password := data.Username.ValueString(), data.Password.ValueString()
+	client, err := legocharmclient.NewClient(&d.client.BaseURL, &username, &password)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Credentials", fmt.Sprintf("Unable to create client for %s: %s", username, err))
+		return
+	}
+	client.FailoverURLs = d.client.FailoverURLs
+	client.HTTPClient = d.client.HTTPClient
+	client.Clock = d.client.Clock
+
+	value := data.Value.ValueString()
+	if data.Value.IsNull() {
+		value, err = generatePassword(32, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Token Generation Failed", err.Error())
+			return
+		}
+	}
+
+	var timeout time.Duration
+	if !data.PropagationTimeout.IsNull() {
+		timeout, _ = time.ParseDuration(data.PropagationTimeout.ValueString())
+	}
+
+	record := legocharmclient.TXTRecordData{Fqdn: challengeFQDN(data.Domain.ValueString()), Value: value}
+	propagation, testErr := challengeRoundTrip(ctx, client, record, timeout, data.Nameserver.ValueString(), nil)
+
+	data.Fqdn = types.StringValue(record.Fqdn)
+	data.Success = types.BoolValue(testErr == nil)
+	data.Error = types.StringNull()
+	if testErr != nil {
+		data.Error = types.StringValue(testErr.Error())
+	}
+	data.PropagationTime = types.StringNull()
+	if propagation > 0 {
+		data.PropagationTime = types.StringValue(propagation.String())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// challengeRoundTrip presents record, waits up to timeout for it to be
+// visible in DNS if timeout is positive, and cleans it up again. It returns
+// how long propagation took, or zero if it was not observed. step, if not
+// nil, is called with a description of each step that succeeded.
+func challengeRoundTrip(ctx context.Context, client legocharmclient.API, record legocharmclient.TXTRecordData, timeout time.Duration, nameserver string, step func(string)) (time.Duration, error) {
+	if step == nil {
+		step = func(string) {}
+	}
+
+	clk := clientClock(client)
+	start := clk.Now()
+	if err := client.PresentTXTRecord(ctx, record); err != nil {
+		return 0, fmt.Errorf("present failed: %w", err)
+	}
+	step(fmt.Sprintf("Presented TXT record %q.", record.Fqdn))
+
+	var propagation time.Duration
+	var testErr error
+	if timeout > 0 {
+		pollCtx, cancel := clk.WithTimeout(ctx, timeout)
+		err := poll(pollCtx, clk, func() (bool, error) {
+			values, err := lookupTXT(pollCtx, nameserver, record.Fqdn)
+			if err != nil {
+				// NXDOMAIN and friends are expected until the record appears.
+				tflog.Debug(ctx, "TXT lookup failed", map[string]interface{}{"fqdn": record.Fqdn, "error": err.Error()})
+				return false, nil
+			}
+			for _, v := range values {
+				if v == record.Value {
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		cancel()
+		if err != nil {
+			testErr = fmt.Errorf("record not visible in DNS after %s", timeout)
+		} else {
+			propagation = clk.Now().Sub(start).Round(time.Millisecond)
+			step(fmt.Sprintf("TXT record visible in DNS after %s.", propagation))
+		}
+	}
+
+	// Always clean up, even if propagation was not observed.
+	if err := client.CleanupTXTRecord(ctx, record); err != nil {
+		if testErr != nil {
+			return propagation, fmt.Errorf("%w; cleanup failed: %s", testErr, err)
+		}
+		return propagation, fmt.Errorf("cleanup failed: %w", err)
+	}
+	step(fmt.Sprintf("Cleaned up TXT record %q.", record.Fqdn))
+	return propagation, testErr
+}
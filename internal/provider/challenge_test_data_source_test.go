@@ -0,0 +1,170 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestChallengeTestDataSource_Read(t *testing.T) {
+	var calls []string
+	records := map[string]string{}
+	failCleanup := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, _ := r.BasicAuth(); username != "ci-bot" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var record legocharmclient.TXTRecordData
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+		calls = append(calls, r.URL.Path+" "+record.Fqdn)
+		switch r.URL.Path {
+		case "/present":
+			records[record.Fqdn] = record.Value
+		case "/cleanup":
+			if failCleanup {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			delete(records, record.Fqdn)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	lookups := 0
+	defer func(orig func(context.Context, string, string) ([]string, error)) { lookupTXT = orig }(lookupTXT)
+	lookupTXT = func(ctx context.Context, nameserver, name string) ([]string, error) {
+		// The record only becomes visible on the second lookup.
+		lookups++
+		if value, ok := records[name]; ok && lookups > 1 {
+			return []string{"unrelated", value}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	client.Clock = newFakeClock()
+	d := &ChallengeTestDataSource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	read := func(data ChallengeTestDataSourceModel) ChallengeTestDataSourceModel {
+		data.Fqdn = types.StringNull()
+		data.Success = types.BoolNull()
+		data.Error = types.StringNull()
+		data.PropagationTime = types.StringNull()
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &data).HasError())
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var result ChallengeTestDataSourceModel
+		require.False(t, resp.State.Get(ctx, &result).HasError())
+		return result
+	}
+	config := ChallengeTestDataSourceModel{
+		Domain:             types.StringValue("*.Example.com"),
+		Username:           types.StringValue("ci-bot"),
+		Password:           types.StringValue("secret"),
+		Value:              types.StringNull(),
+		PropagationTimeout: types.StringNull(),
+		Nameserver:         types.StringNull(),
+	}
+
+	// Without a propagation timeout only the charm is exercised.
+	result := read(config)
+	require.True(t, result.Success.ValueBool())
+	require.True(t, result.Error.IsNull())
+	require.True(t, result.PropagationTime.IsNull())
+	require.Equal(t, "_acme-challenge.example.com.", result.Fqdn.ValueString())
+	require.Equal(t, []string{"/present _acme-challenge.example.com.", "/cleanup _acme-challenge.example.com."}, calls)
+	require.Empty(t, records)
+	require.Zero(t, lookups)
+
+	// With a timeout the record is polled for until visible.
+	calls = nil
+	config.Value = types.StringValue("token")
+	config.PropagationTimeout = types.StringValue("10s")
+	result = read(config)
+	require.True(t, result.Success.ValueBool(), result.Error.ValueString())
+	require.Equal(t, "250ms", result.PropagationTime.ValueString())
+	require.Equal(t, 2, lookups)
+	require.Len(t, calls, 2)
+	require.Empty(t, records)
+
+	// A failed cleanup is reported but not raised as a diagnostic.
+	failCleanup = true
+	config.PropagationTimeout = types.StringNull()
+	result = read(config)
+	require.False(t, result.Success.ValueBool())
+	require.Contains(t, result.Error.ValueString(), "cleanup failed")
+
+	// Bad credentials fail the present and skip the cleanup.
+	calls = nil
+	config.Password = types.StringValue("wrong")
+	result = read(config)
+	require.False(t, result.Success.ValueBool())
+	require.Contains(t, result.Error.ValueString(), "present failed")
+	require.Empty(t, calls)
+}
+
+func TestAccChallengeTestDataSource(t *testing.T) {
+	env := testAccPreCheck(t)
+	userID, username, password := testAccUser(t, env)
+	domain := testAccDomain(t, env)
+	testAccGrant(t, env, userID, domain.Fqdn, "domain")
+
+	config := func(fqdn string) string {
+		return fmt.Sprintf(`
+data "legocharm_challenge_test" "test" {
+  domain   = %q
+  username = %q
+  password = %q
+}
+`, fqdn, username, password)
+	}
+
+	// Without propagation_timeout DNS is not queried; the test domains are
+	// not delegated to the charm's nameservers.
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(domain.Fqdn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.legocharm_challenge_test.test", "success", "true"),
+					resource.TestCheckResourceAttr("data.legocharm_challenge_test.test", "fqdn", "_acme-challenge."+domain.Fqdn+"."),
+				),
+			},
+			{
+				Config: config("other-" + domain.Fqdn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.legocharm_challenge_test.test", "success", "false"),
+					resource.TestCheckResourceAttrSet("data.legocharm_challenge_test.test", "error"),
+				),
+			},
+		},
+	})
+}
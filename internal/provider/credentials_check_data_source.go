@@ -0,0 +1,98 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ datasource.DataSource = &CredentialsCheckDataSource{}
+
+// NewCredentialsCheckDataSource creates a new credentials check data source.
+func NewCredentialsCheckDataSource() datasource.DataSource { return &CredentialsCheckDataSource{} }
+
+// CredentialsCheckDataSource is the data source implementation for checking
+// a username and password against the LegoCharm API.
+type CredentialsCheckDataSource struct {
+	client legocharmclient.API
+}
+
+// CredentialsCheckDataSourceModel maps Terraform schema to Go types for the
+// credentials check data source.
+type CredentialsCheckDataSourceModel struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Valid    types.Bool   `tfsdk:"valid"`
+}
+
+func (d *CredentialsCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credentials_check"
+}
+
+func (d *CredentialsCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks whether a username and password authenticate against the API, for example to verify that credentials handed out to ACME clients still work. Each read makes one authentication attempt, which may count towards account lockout policies.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username to check.",
+				Required:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password to check.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"valid": schema.BoolAttribute{
+				MarkdownDescription: "Whether the credentials authenticate. `false` for a wrong password or an unknown user.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CredentialsCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *CredentialsCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CredentialsCheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this data source")
+		return
+	}
+
+	valid, err := d.client.HasValidUserPassword(ctx, data.Username.ValueString(), data.Password.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check credentials for %s, got error: %s", data.Username.ValueString(), err))
+		return
+	}
+	data.Valid = types.BoolValue(valid)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
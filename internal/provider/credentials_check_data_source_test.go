@@ -0,0 +1,87 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestCredentialsCheckDataSource_Read(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Regular users are authenticated but may not list users.
+		if username, password, _ := r.BasicAuth(); username == "ci-bot" && password == "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	d := &CredentialsCheckDataSource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	check := func(username, password string) bool {
+		data := CredentialsCheckDataSourceModel{Username: types.StringValue(username), Password: types.StringValue(password), Valid: types.BoolNull()}
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &data).HasError())
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var result CredentialsCheckDataSourceModel
+		require.False(t, resp.State.Get(ctx, &result).HasError())
+		return result.Valid.ValueBool()
+	}
+
+	require.True(t, check("ci-bot", "secret"))
+	require.False(t, check("ci-bot", "wrong"))
+	require.False(t, check("missing", "secret"))
+}
+
+func TestAccCredentialsCheckDataSource(t *testing.T) {
+	env := testAccPreCheck(t)
+	_, username, password := testAccUser(t, env)
+
+	config := func(password string) string {
+		return fmt.Sprintf(`
+data "legocharm_credentials_check" "test" {
+  username = %q
+  password = %q
+}
+`, username, password)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(password),
+				Check:  resource.TestCheckResourceAttr("data.legocharm_credentials_check.test", "valid", "true"),
+			},
+			{
+				Config: config("wrong-" + password),
+				Check:  resource.TestCheckResourceAttr("data.legocharm_credentials_check.test", "valid", "false"),
+			},
+		},
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// userAPIFields maps user API field names to legocharm_user attributes.
+var userAPIFields = map[string]path.Path{
+	"username":     path.Root("username"),
+	"password":     path.Root("password"),
+	"email":        path.Root("email"),
+	"is_staff":     path.Root("is_staff"),
+	"is_superuser": path.Root("is_superuser"),
+	"is_active":    path.Root("is_active"),
+}
+
+// domainAccessAPIFields maps domain-user-permission API field names to
+// legocharm_user_domain_access attributes.
+var domainAccessAPIFields = map[string]path.Path{
+	"user":         path.Root("user_id"),
+	"domain":       path.Root("domain"),
+	"access_level": path.Root("access_level"),
+}
+
+// domainAPIFields maps domain API field names to legocharm_domain attributes.
+var domainAPIFields = map[string]path.Path{
+	"fqdn": path.Root("fqdn"),
+}
+
+// adminPasswordAPIFields maps user API field names to legocharm_admin_password
+// attributes.
+var adminPasswordAPIFields = map[string]path.Path{
+	"password": path.Root("password_wo"),
+}
+
+// groupMembershipAPIFields maps user API field names to group membership
+// attributes; groups are set through the user endpoint.
+var groupMembershipAPIFields = map[string]path.Path{
+	"groups": path.Root("group"),
+}
+
+// addClientError appends a diagnostic for a failed client call. Rejected or
+// insufficient provider credentials and maintenance of the API get a
+// targeted explanation, validation messages the API returned for individual
+// fields are attached to the matching attribute in fields, and anything else
+// is reported as a generic "Client Error" with message as context. Every diagnostic includes the
+// request ID of the failed response, if the API returned one.
+func addClientError(diags *diag.Diagnostics, message string, err error, fields map[string]path.Path) {
+	switch {
+	case errors.Is(err, legocharmclient.ErrUnauthorized):
+		diags.AddError(
+			"LegoCharm API Authentication Failed",
+			fmt.Sprintf("%s: the API rejected the provider credentials (401 Unauthorized). "+
+				"Check the username and password in the provider configuration or the LEGOCHARM_USERNAME and LEGOCHARM_PASSWORD environment variables. "+
+				"State has not been modified.\n\n%s", message, err),
+		)
+		return
+	case errors.Is(err, legocharmclient.ErrForbidden):
+		diags.AddError(
+			"Insufficient LegoCharm API Permissions",
+			fmt.Sprintf("%s: the provider credentials are valid but not permitted to perform this request (403 Forbidden). "+
+				"The provider requires a superuser of the httprequest-lego-provider charm; the account may have lost its admin rights. "+
+				"State has not been modified.\n\n%s", message, err),
+		)
+		return
+	case errors.Is(err, legocharmclient.ErrMaintenance):
+		diags.AddError(
+			"LegoCharm API in Maintenance",
+			fmt.Sprintf("%s: the API answered that it is in maintenance (503 Service Unavailable), for example while the httprequest-lego-provider charm is upgraded. "+
+				"Retry once the maintenance is over, or set maintenance_wait in the provider configuration to wait for it. "+
+				"State has not been modified.\n\n%s", message, err),
+		)
+		return
+	}
+
+	var apiErr *legocharmclient.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.FieldErrors) == 0 {
+		diags.AddError("Client Error", fmt.Sprintf("%s, got error: %s", message, err))
+		return
+	}
+
+	names := make([]string, 0, len(apiErr.FieldErrors))
+	for name := range apiErr.FieldErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// The field messages replace the error, so carry over its request ID.
+	var requestID string
+	if apiErr.RequestID != "" {
+		requestID = fmt.Sprintf(" (request ID %s)", apiErr.RequestID)
+	}
+	for _, name := range names {
+		detail := strings.Join(apiErr.FieldErrors[name], " ") + requestID
+		if p, ok := fields[name]; ok {
+			diags.AddAttributeError(p, "Invalid Attribute Value", fmt.Sprintf("%s: %s", message, detail))
+			continue
+		}
+		diags.AddError("Client Error", fmt.Sprintf("%s, got error (status %d): %s: %s", message, apiErr.StatusCode, name, detail))
+	}
+}
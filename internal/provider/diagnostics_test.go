@@ -0,0 +1,93 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestAddClientError_FieldErrors(t *testing.T) {
+	var diags diag.Diagnostics
+	err := &legocharmclient.APIError{
+		Action:     "create user",
+		StatusCode: 400,
+		FieldErrors: map[string][]string{
+			"email":            {"Enter a valid email address."},
+			"non_field_errors": {"Something else went wrong."},
+		},
+	}
+
+	addClientError(&diags, "Unable to create user", err, userAPIFields)
+	require.Len(t, diags.Errors(), 2)
+
+	attrErr, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	require.True(t, ok, "email error should be attribute-scoped")
+	require.Equal(t, path.Root("email"), attrErr.Path())
+	require.Contains(t, attrErr.Detail(), "Enter a valid email address.")
+
+	_, ok = diags.Errors()[1].(diag.DiagnosticWithPath)
+	require.False(t, ok, "unmapped fields should not be attribute-scoped")
+	require.Equal(t, "Client Error", diags.Errors()[1].Summary())
+}
+
+func TestAddClientError_Generic(t *testing.T) {
+	var diags diag.Diagnostics
+	addClientError(&diags, "Unable to create user", errors.New("connection refused"), userAPIFields)
+	require.Len(t, diags.Errors(), 1)
+	require.Equal(t, "Client Error", diags.Errors()[0].Summary())
+	require.Contains(t, diags.Errors()[0].Detail(), "connection refused")
+}
+
+func TestAddClientError_Permissions(t *testing.T) {
+	for status, summary := range map[int]string{
+		401: "LegoCharm API Authentication Failed",
+		403: "Insufficient LegoCharm API Permissions",
+	} {
+		var diags diag.Diagnostics
+		err := fmt.Errorf("failed to get user data: %w", &legocharmclient.APIError{Action: "get user", StatusCode: status})
+		addClientError(&diags, "Unable to read user", err, nil)
+		require.Len(t, diags.Errors(), 1)
+		require.Equal(t, summary, diags.Errors()[0].Summary())
+	}
+}
+
+func TestAddClientError_Maintenance(t *testing.T) {
+	var diags diag.Diagnostics
+	err := fmt.Errorf("failed to get user: %w", legocharmclient.ErrMaintenance)
+	addClientError(&diags, "Unable to read user", err, nil)
+	require.Len(t, diags.Errors(), 1)
+	require.Equal(t, "LegoCharm API in Maintenance", diags.Errors()[0].Summary())
+	require.Contains(t, diags.Errors()[0].Detail(), "maintenance_wait")
+}
+
+func TestAddClientError_RequestID(t *testing.T) {
+	var diags diag.Diagnostics
+	err := &legocharmclient.APIError{
+		Action:      "create user",
+		StatusCode:  400,
+		RequestID:   "3f2a9c",
+		FieldErrors: map[string][]string{"email": {"Enter a valid email address."}, "detail": {"Rejected."}},
+	}
+	addClientError(&diags, "Unable to create user", err, userAPIFields)
+	require.Len(t, diags.Errors(), 2)
+	for _, d := range diags.Errors() {
+		require.Contains(t, d.Detail(), "(request ID 3f2a9c)")
+	}
+
+	for _, status := range []int{401, 403, 500} {
+		diags = nil
+		err := fmt.Errorf("failed to get user data: %w", &legocharmclient.APIError{Action: "get user", StatusCode: status, RequestID: "3f2a9c"})
+		addClientError(&diags, "Unable to read user", err, nil)
+		require.Len(t, diags.Errors(), 1)
+		require.Contains(t, diags.Errors()[0].Detail(), "(request ID 3f2a9c)")
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ list.ListResourceWithConfigure = &DomainResource{}
+
+// NewDomainListResource creates a new domain list resource.
+func NewDomainListResource() list.ListResource { return &DomainResource{} }
+
+// DomainListModel maps the list block configuration of legocharm_domain.
+type DomainListModel struct {
+	Suffix types.String `tfsdk:"suffix"`
+}
+
+// ListResourceConfigSchema implements list.ListResource.
+func (r *DomainResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists registered domains, for generating import blocks.",
+		Attributes: map[string]listschema.Attribute{
+			"suffix": listschema.StringAttribute{
+				MarkdownDescription: "Only list domains equal to or under this domain, such as `example.com`. Compared case-insensitively and ignoring a trailing dot.",
+				Optional:            true,
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+		},
+	}
+}
+
+// List implements list.ListResource.
+func (r *DomainResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config DomainListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if r.client == nil {
+		diags.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	domains, err := r.client.ListDomains(ctx)
+	if err != nil {
+		addClientError(&diags, "Unable to list domains", err, nil)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	suffix := legocharmclient.NormalizeFQDN(config.Suffix.ValueString())
+	var matched []legocharmclient.DomainData
+	for _, domain := range domains {
+		name := legocharmclient.NormalizeFQDN(domain.Fqdn)
+		if suffix != "" && name != suffix && !strings.HasSuffix(name, "."+suffix) {
+			continue
+		}
+		matched = append(matched, domain)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Fqdn < matched[j].Fqdn })
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, domain := range matched {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = domain.Fqdn
+			data := DomainModel{
+				Fqdn: types.StringValue(domain.Fqdn),
+				Id:   types.StringValue(strconv.Itoa(domain.ID)),
+			}
+			setDomainIdentity(ctx, result.Identity, data.Id, &result.Diagnostics)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ resource.Resource = &DomainResource{}
+var _ resource.ResourceWithImportState = &DomainResource{}
+var _ resource.ResourceWithIdentity = &DomainResource{}
+
+// NewDomainResource creates a new domain resource.
+func NewDomainResource() resource.Resource { return &DomainResource{} }
+
+// DomainResource is the resource implementation for LegoCharm domains. It
+// lets domains be managed explicitly rather than created implicitly by
+// domain access permissions.
+type DomainResource struct {
+	client legocharmclient.API
+}
+
+// DomainModel maps Terraform schema to Go types for domain resources.
+type DomainModel struct {
+	Fqdn types.String `tfsdk:"fqdn"`
+	Id   types.String `tfsdk:"id"`
+}
+
+// DomainIdentityModel maps the identity schema of domain resources.
+type DomainIdentityModel struct {
+	Id types.String `tfsdk:"id"`
+}
+
+func (r *DomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+// IdentitySchema implements resource.ResourceWithIdentity.
+func (r *DomainResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the domain.",
+			},
+		},
+	}
+}
+
+func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A domain which users can be granted access to. Domain access permissions create missing domains on demand; use this resource to manage the domain inventory explicitly, together with `manage_domain = false` on `legocharm_user_domain_access`.",
+		Attributes: map[string]schema.Attribute{
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "FQDN of the domain, such as `example.com` or the wildcard `*.example.com`. Compared case-insensitively and ignoring a trailing dot. Changing it renames the domain in place, keeping its permissions.",
+				Required:            true,
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the domain",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DomainModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	existing, err := r.client.GetDomain(ctx, data.Fqdn.ValueString())
+	if err == nil {
+		resp.Diagnostics.AddError("Domain Exists", fmt.Sprintf("The domain '%s' already exists (id=%d). Import it to manage it with Terraform.", existing.Fqdn, existing.ID))
+		return
+	} else if err != legocharmclient.ErrNotFound {
+		addClientError(&resp.Diagnostics, "Unable to check for existing domain", err, nil)
+		return
+	}
+
+	domain, err := r.client.CreateDomain(ctx, legocharmclient.DomainData{Fqdn: data.Fqdn.ValueString()})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to create domain", err, domainAPIFields)
+		return
+	}
+	data.Id = types.StringValue(strconv.Itoa(domain.ID))
+
+	tflog.Trace(ctx, "created domain", map[string]interface{}{"id": domain.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setDomainIdentity(ctx, resp.Identity, data.Id, &resp.Diagnostics)
+}
+
+func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DomainModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	id, err := strconv.Atoi(data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid State", fmt.Sprintf("Domain ID %q is not numeric", data.Id.ValueString()))
+		return
+	}
+
+	domain, err := r.client.GetDomainById(ctx, id)
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to read domain", err, nil)
+		return
+	}
+
+	// Keep the configured spelling unless the domain was renamed.
+	if legocharmclient.NormalizeFQDN(data.Fqdn.ValueString()) != legocharmclient.NormalizeFQDN(domain.Fqdn) {
+		data.Fqdn = types.StringValue(domain.Fqdn)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setDomainIdentity(ctx, resp.Identity, data.Id, &resp.Diagnostics)
+}
+
+func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state DomainModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	// A change in case or trailing dot only needs a state update.
+	if legocharmclient.NormalizeFQDN(plan.Fqdn.ValueString()) != legocharmclient.NormalizeFQDN(state.Fqdn.ValueString()) {
+		id, err := strconv.Atoi(state.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid State", fmt.Sprintf("Domain ID %q is not numeric", state.Id.ValueString()))
+			return
+		}
+		if _, err := r.client.UpdateDomain(ctx, id, plan.Fqdn.ValueString()); err != nil {
+			if errors.Is(err, legocharmclient.ErrNotFound) {
+				resp.Diagnostics.AddError("Domain Not Found", "The domain no longer exists. Refresh and apply again to recreate it.")
+				return
+			}
+			addClientError(&resp.Diagnostics, "Unable to rename domain", err, domainAPIFields)
+			return
+		}
+	}
+	plan.Id = state.Id
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setDomainIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+}
+
+func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DomainModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	id, err := strconv.Atoi(data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid State", fmt.Sprintf("Domain ID %q is not numeric", data.Id.ValueString()))
+		return
+	}
+	if err := r.client.DeleteDomain(ctx, id); err != nil {
+		addClientError(&resp.Diagnostics, "Unable to delete domain", err, nil)
+	}
+}
+
+// setDomainIdentity records the domain ID as the resource identity. identity
+// is nil when Terraform does not support resource identity.
+func setDomainIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String, diags *diag.Diagnostics) {
+	if identity == nil || diags.HasError() {
+		return
+	}
+	diags.Append(identity.Set(ctx, DomainIdentityModel{Id: id})...)
+}
+
+// ImportState implements resource import for DomainResource.
+func (r *DomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// id is a numeric domain ID or an FQDN
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	// Import blocks using identity carry the domain ID instead of an ID string.
+	importID := req.ID
+	if importID == "" && req.Identity != nil {
+		var identity DomainIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if _, err := strconv.Atoi(identity.Id.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("id"), "Invalid Import Identity", "id must be a numeric domain ID")
+			return
+		}
+		importID = identity.Id.ValueString()
+	}
+
+	var domain *legocharmclient.DomainData
+	var err error
+	if id, convErr := strconv.Atoi(importID); convErr == nil {
+		domain, err = r.client.GetDomainById(ctx, id)
+	} else if problem := fqdnProblem(importID); problem != "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Import ID must be a numeric domain ID or an FQDN: %s", problem))
+		return
+	} else {
+		var found legocharmclient.DomainData
+		found, err = r.client.GetDomain(ctx, importID)
+		domain = &found
+	}
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.Diagnostics.AddError("Domain Not Found", fmt.Sprintf("No domain %q exists.", importID))
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to read domain", err, nil)
+		return
+	}
+
+	data := DomainModel{
+		Fqdn: types.StringValue(domain.Fqdn),
+		Id:   types.StringValue(strconv.Itoa(domain.ID)),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setDomainIdentity(ctx, resp.Identity, data.Id, &resp.Diagnostics)
+}
+
+func (r *DomainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
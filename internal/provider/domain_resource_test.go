@@ -0,0 +1,372 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient/legocharmclienttest"
+)
+
+func TestDomainResource_Metadata(t *testing.T) {
+	r := &DomainResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+	require.Equal(t, "legocharm_domain", resp.TypeName)
+}
+
+func TestDomainResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &DomainResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := DomainModel{Fqdn: types.StringValue("Web.Example.com"), Id: types.StringUnknown()}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var created DomainModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	id, ok := api.domainID("web.example.com")
+	require.True(t, ok)
+	require.Equal(t, "Web.Example.com", created.Fqdn.ValueString())
+
+	// Creating an existing domain is rejected.
+	dupResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, dupResp)
+	require.True(t, dupResp.Diagnostics.HasError())
+	require.Equal(t, "Domain Exists", dupResp.Diagnostics.Errors()[0].Summary())
+
+	// Reading keeps the configured spelling.
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	var refreshed DomainModel
+	require.False(t, readResp.State.Get(ctx, &refreshed).HasError())
+	require.Equal(t, "Web.Example.com", refreshed.Fqdn.ValueString())
+
+	// Changing the FQDN renames the domain in place.
+	data = refreshed
+	data.Fqdn = types.StringValue("app.example.com")
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Equal(t, "app.example.com", api.domains[id])
+
+	// A rename outside Terraform shows up as drift.
+	api.domains[id] = "renamed.example.com"
+	driftResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, driftResp)
+	require.False(t, driftResp.Diagnostics.HasError(), driftResp.Diagnostics)
+	require.False(t, driftResp.State.Get(ctx, &refreshed).HasError())
+	require.Equal(t, "renamed.example.com", refreshed.Fqdn.ValueString())
+
+	deleteResp := &resource.DeleteResponse{State: driftResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: driftResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	_, ok = api.domains[id]
+	require.False(t, ok)
+
+	goneResp := &resource.ReadResponse{State: driftResp.State}
+	r.Read(ctx, resource.ReadRequest{State: driftResp.State}, goneResp)
+	require.False(t, goneResp.Diagnostics.HasError(), goneResp.Diagnostics)
+	require.True(t, goneResp.State.Raw.IsNull())
+}
+
+func TestDomainResource_APIErrors(t *testing.T) {
+	ctx := context.Background()
+	r := &DomainResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	unavailable := errors.New("connection refused")
+	api := &legocharmclienttest.APIMock{
+		GetDomainFunc: func(ctx context.Context, fqdn string) (legocharmclient.DomainData, error) {
+			return legocharmclient.DomainData{}, unavailable
+		},
+		GetDomainByIdFunc: func(ctx context.Context, id int) (*legocharmclient.DomainData, error) {
+			return nil, unavailable
+		},
+		UpdateDomainFunc: func(ctx context.Context, id int, fqdn string) (*legocharmclient.DomainData, error) {
+			return nil, legocharmclient.ErrNotFound
+		},
+		DeleteDomainFunc: func(ctx context.Context, id int) error {
+			return unavailable
+		},
+	}
+	r.client = api
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &DomainModel{Fqdn: types.StringValue("example.com"), Id: types.StringValue("7")}).HasError())
+
+	// A failed existence check does not go on to create the domain.
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &DomainModel{Fqdn: types.StringValue("example.com"), Id: types.StringUnknown()}).HasError())
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.True(t, createResp.Diagnostics.HasError())
+	require.Contains(t, createResp.Diagnostics.Errors()[0].Detail(), "Unable to check for existing domain")
+	require.Len(t, api.GetDomainCalls(), 1)
+
+	// Transient errors on read keep the resource in state.
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+	require.True(t, readResp.Diagnostics.HasError())
+	require.False(t, readResp.State.Raw.IsNull())
+	require.Equal(t, 7, api.GetDomainByIdCalls()[0].Id)
+
+	require.False(t, plan.Set(ctx, &DomainModel{Fqdn: types.StringValue("other.example.com"), Id: types.StringValue("7")}).HasError())
+	updateResp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, updateResp)
+	require.True(t, updateResp.Diagnostics.HasError())
+	require.Equal(t, "Domain Not Found", updateResp.Diagnostics.Errors()[0].Summary())
+
+	deleteResp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, deleteResp)
+	require.True(t, deleteResp.Diagnostics.HasError())
+	require.Contains(t, deleteResp.Diagnostics.Errors()[0].Detail(), "Unable to delete domain")
+}
+
+func TestDomainResource_ImportState(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &DomainResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for _, id := range []string{"2", "staging.example.com", "Staging.Example.com."} {
+		resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var data DomainModel
+		require.False(t, resp.State.Get(ctx, &data).HasError())
+		require.Equal(t, "2", data.Id.ValueString())
+		require.Equal(t, "staging.example.com", data.Fqdn.ValueString())
+	}
+
+	for id, summary := range map[string]string{"3": "Domain Not Found", "other.example.com": "Domain Not Found", "not a domain": "Invalid Import ID"} {
+		resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		require.True(t, resp.Diagnostics.HasError(), id)
+		require.Equal(t, summary, resp.Diagnostics.Errors()[0].Summary(), id)
+	}
+}
+
+func TestDomainResource_ImportState_Identity(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &DomainResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	newIdentity := func(id types.String) *tfsdk.ResourceIdentity {
+		identity := &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema}
+		require.False(t, identity.Set(ctx, DomainIdentityModel{Id: id}).HasError())
+		return identity
+	}
+
+	identity := newIdentity(types.StringValue("2"))
+	resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}, Identity: identity}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data DomainModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	require.Equal(t, "staging.example.com", data.Fqdn.ValueString())
+	var got DomainIdentityModel
+	require.False(t, resp.Identity.Get(ctx, &got).HasError())
+	require.Equal(t, "2", got.Id.ValueString())
+
+	invalid := newIdentity(types.StringValue("staging.example.com"))
+	bad := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}, Identity: invalid}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: invalid}, bad)
+	require.True(t, bad.Diagnostics.HasError())
+	require.Equal(t, "Invalid Import Identity", bad.Diagnostics.Errors()[0].Summary())
+}
+
+func TestDomainResource_List(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.domains[3] = "example.com"
+	api.domains[4] = "*.example.com"
+	api.domains[5] = "example.org"
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &DomainResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+	listSchemaResp := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, listSchemaResp)
+
+	run := func(suffix types.String, limit int64) []list.ListResult {
+		config := tfsdk.State{Schema: listSchemaResp.Schema}
+		require.False(t, config.Set(ctx, DomainListModel{Suffix: suffix}).HasError())
+
+		stream := &list.ListResultsStream{}
+		r.List(ctx, list.ListRequest{
+			Config:                 tfsdk.Config{Schema: listSchemaResp.Schema, Raw: config.Raw},
+			IncludeResource:        true,
+			Limit:                  limit,
+			ResourceSchema:         schemaResp.Schema,
+			ResourceIdentitySchema: identityResp.IdentitySchema,
+		}, stream)
+
+		var results []list.ListResult
+		for result := range stream.Results {
+			require.False(t, result.Diagnostics.HasError(), result.Diagnostics)
+			results = append(results, result)
+		}
+		return results
+	}
+
+	results := run(types.StringValue("Example.com"), 0)
+	var names []string
+	for _, result := range results {
+		names = append(names, result.DisplayName)
+	}
+	require.Equal(t, []string{"*.example.com", "example.com", "staging.example.com"}, names)
+
+	var identity DomainIdentityModel
+	require.False(t, results[1].Identity.Get(ctx, &identity).HasError())
+	require.Equal(t, "3", identity.Id.ValueString())
+	var data DomainModel
+	require.False(t, results[1].Resource.Get(ctx, &data).HasError())
+	require.Equal(t, DomainModel{Fqdn: types.StringValue("example.com"), Id: types.StringValue("3")}, data)
+
+	require.Len(t, run(types.StringNull(), 0), 4)
+	require.Len(t, run(types.StringNull(), 2), 2)
+}
+
+func TestAccDomainResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	fqdn := testAccName(t) + ".example.com"
+	renamed := testAccName(t) + ".example.com"
+	ctx := context.Background()
+
+	var id string
+	stored := func(fqdn string) resourcetest.TestCheckFunc {
+		return resourcetest.TestCheckResourceAttrWith("legocharm_domain.test", "id", func(value string) error {
+			domain, err := env.API.GetDomain(ctx, fqdn)
+			if err != nil {
+				return err
+			}
+			if value != strconv.Itoa(domain.ID) {
+				return fmt.Errorf("id is %s, but domain %s has ID %d", value, fqdn, domain.ID)
+			}
+			id = value
+			return nil
+		})
+	}
+	config := func(fqdn string) string {
+		return fmt.Sprintf(`
+resource "legocharm_domain" "test" {
+  fqdn = %q
+}
+`, fqdn)
+	}
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config(fqdn),
+				Check:  stored(fqdn),
+			},
+			{
+				ResourceName:      "legocharm_domain.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "legocharm_domain.test",
+				ImportState:       true,
+				ImportStateId:     fqdn,
+				ImportStateVerify: true,
+			},
+			// Renames happen in place.
+			{
+				Config: config(renamed),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttrPtr("legocharm_domain.test", "id", &id),
+					stored(renamed),
+				),
+			},
+			// A rename outside Terraform is detected and reverted.
+			{
+				PreConfig: func() {
+					domainID, _ := strconv.Atoi(id)
+					_, err := env.API.UpdateDomain(ctx, domainID, fqdn)
+					require.NoError(t, err)
+				},
+				Config:             config(renamed),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(renamed),
+				Check:  stored(renamed),
+			},
+			// Deleted outside Terraform, the domain is recreated.
+			{
+				PreConfig: func() {
+					domainID, _ := strconv.Atoi(id)
+					require.NoError(t, env.API.DeleteDomain(ctx, domainID))
+				},
+				Config: config(renamed),
+				Check:  stored(renamed),
+			},
+		},
+		CheckDestroy: testAccCheckDomainExists(env, renamed, false),
+	})
+}
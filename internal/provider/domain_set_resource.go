@@ -0,0 +1,410 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ resource.Resource = &DomainSetResource{}
+var _ resource.ResourceWithImportState = &DomainSetResource{}
+var _ resource.ResourceWithValidateConfig = &DomainSetResource{}
+
+// NewDomainSetResource creates a new domain set resource.
+func NewDomainSetResource() resource.Resource { return &DomainSetResource{} }
+
+// DomainSetResource is the resource implementation for a set of LegoCharm
+// domains managed as one object.
+type DomainSetResource struct {
+	client legocharmclient.API
+}
+
+// DomainSetModel maps Terraform schema to Go types for domain set resources.
+type DomainSetModel struct {
+	Fqdns     types.Set    `tfsdk:"fqdns"`
+	DomainIds types.Map    `tfsdk:"domain_ids"`
+	Id        types.String `tfsdk:"id"`
+}
+
+func (r *DomainSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_set"
+}
+
+func (r *DomainSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A set of domains managed as one object. Domains added to the set are created and domains removed from it are deleted. None of the domains may exist before they are added.",
+		Attributes: map[string]schema.Attribute{
+			"fqdns": schema.SetAttribute{
+				MarkdownDescription: "FQDNs of the domains, such as `example.com` or the wildcard `*.example.com`. Compared case-insensitively and ignoring a trailing dot.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"domain_ids": schema.MapAttribute{
+				MarkdownDescription: "IDs of the domains, keyed by normalized FQDN.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the domain set, derived from the FQDNs it was created with.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures each FQDN is valid and no domain appears twice after
+// normalization.
+func (r *DomainSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DomainSetModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Fqdns.IsUnknown() {
+		return
+	}
+
+	var fqdns []types.String
+	resp.Diagnostics.Append(data.Fqdns.ElementsAs(ctx, &fqdns, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, f := range fqdns {
+		if f.IsUnknown() {
+			continue
+		}
+		if problem := fqdnProblem(f.ValueString()); problem != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("fqdns"),
+				"Invalid Domain Name",
+				fmt.Sprintf("Attribute fqdns value must be a valid domain name, got: %s (%s)", f.ValueString(), problem),
+			)
+			continue
+		}
+		name := legocharmclient.NormalizeFQDN(f.ValueString())
+		if seen[name] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("fqdns"),
+				"Duplicate Domain",
+				fmt.Sprintf("The domain %q appears more than once. Each domain may appear only once.", name),
+			)
+		}
+		seen[name] = true
+	}
+}
+
+func (r *DomainSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DomainSetModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	var fqdns []string
+	resp.Diagnostics.Append(data.Fqdns.ElementsAs(ctx, &fqdns, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the domains; on failure undo everything created so far so a
+	// retry does not fail on domains that already exist.
+	ids := map[string]int64{}
+	r.createDomains(ctx, fqdns, ids, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		if err := r.deleteDomains(ctx, ids); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to roll back created domains: %s", err))
+		}
+		return
+	}
+
+	data.Id = types.StringValue(domainSetID(fqdns))
+	domainIds, diags := types.MapValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	data.DomainIds = domainIds
+
+	tflog.Trace(ctx, "created domain set", map[string]interface{}{"domains": len(ids)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DomainSetModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	var fqdns []string
+	resp.Diagnostics.Append(data.Fqdns.ElementsAs(ctx, &fqdns, false)...)
+	ids := map[string]int64{}
+	resp.Diagnostics.Append(data.DomainIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the configured spelling of each domain that still exists under
+	// the same name; report renamed domains under their new name and drop
+	// deleted ones so the next plan recreates them.
+	spelling := map[string]string{}
+	for _, f := range fqdns {
+		spelling[legocharmclient.NormalizeFQDN(f)] = f
+	}
+	current := []string{}
+	currentIds := map[string]int64{}
+	for name, id := range ids {
+		domain, err := r.client.GetDomainById(ctx, int(id))
+		if err != nil {
+			if errors.Is(err, legocharmclient.ErrNotFound) {
+				continue
+			}
+			addClientError(&resp.Diagnostics, fmt.Sprintf("Unable to read domain %s", name), err, nil)
+			return
+		}
+		actual := legocharmclient.NormalizeFQDN(domain.Fqdn)
+		if configured, ok := spelling[actual]; ok {
+			current = append(current, configured)
+		} else {
+			current = append(current, domain.Fqdn)
+		}
+		currentIds[actual] = id
+	}
+
+	resp.Diagnostics.Append(setDomainSet(ctx, &data, current, currentIds)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state DomainSetModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	var fqdns []string
+	resp.Diagnostics.Append(plan.Fqdns.ElementsAs(ctx, &fqdns, false)...)
+	ids := map[string]int64{}
+	resp.Diagnostics.Append(state.DomainIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wanted := map[string]bool{}
+	var added []string
+	for _, f := range fqdns {
+		name := legocharmclient.NormalizeFQDN(f)
+		wanted[name] = true
+		if _, ok := ids[name]; !ok {
+			added = append(added, f)
+		}
+	}
+	removed := map[string]int64{}
+	for name, id := range ids {
+		if !wanted[name] {
+			removed[name] = id
+		}
+	}
+
+	if err := r.deleteDomains(ctx, removed); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+	for name := range removed {
+		delete(ids, name)
+	}
+	r.createDomains(ctx, added, ids, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = state.Id
+	domainIds, diags := types.MapValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	plan.DomainIds = domainIds
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DomainSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DomainSetModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	ids := map[string]int64{}
+	resp.Diagnostics.Append(data.DomainIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.deleteDomains(ctx, ids); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+	}
+}
+
+// ImportState implements resource import for DomainSetResource.
+func (r *DomainSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// id is a comma-separated list of FQDNs
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	var fqdns []string
+	ids := map[string]int64{}
+	for _, f := range strings.Split(req.ID, ",") {
+		f = strings.TrimSpace(f)
+		if problem := fqdnProblem(f); problem != "" {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Import ID must be a comma-separated list of FQDNs: %q: %s", f, problem))
+			return
+		}
+		domain, err := r.client.GetDomain(ctx, f)
+		if err != nil {
+			if errors.Is(err, legocharmclient.ErrNotFound) {
+				resp.Diagnostics.AddError("Domain Not Found", fmt.Sprintf("No domain %q exists.", f))
+				return
+			}
+			addClientError(&resp.Diagnostics, "Unable to read domain", err, nil)
+			return
+		}
+		fqdns = append(fqdns, domain.Fqdn)
+		ids[legocharmclient.NormalizeFQDN(domain.Fqdn)] = int64(domain.ID)
+	}
+
+	data := DomainSetModel{Id: types.StringValue(domainSetID(fqdns))}
+	resp.Diagnostics.Append(setDomainSet(ctx, &data, fqdns, ids)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// createDomains creates each of fqdns, recording their IDs in ids. Domains
+// that already exist are reported together before anything is created.
+func (r *DomainSetResource) createDomains(ctx context.Context, fqdns []string, ids map[string]int64, diags *diag.Diagnostics) {
+	var existing []string
+	for _, f := range fqdns {
+		_, err := r.client.GetDomain(ctx, f)
+		if err == nil {
+			existing = append(existing, legocharmclient.NormalizeFQDN(f))
+		} else if !errors.Is(err, legocharmclient.ErrNotFound) {
+			addClientError(diags, fmt.Sprintf("Unable to check for existing domain %s", f), err, nil)
+			return
+		}
+	}
+	if len(existing) > 0 {
+		sort.Strings(existing)
+		diags.AddAttributeError(
+			path.Root("fqdns"),
+			"Domains Exist",
+			fmt.Sprintf("The following domains already exist: %s. Remove them from the set or delete them first.", strings.Join(existing, ", ")),
+		)
+		return
+	}
+
+	for _, f := range fqdns {
+		domain, err := r.client.CreateDomain(ctx, legocharmclient.DomainData{Fqdn: f})
+		if err != nil {
+			addClientError(diags, fmt.Sprintf("Unable to create domain %s", f), err, nil)
+			return
+		}
+		ids[legocharmclient.NormalizeFQDN(f)] = int64(domain.ID)
+	}
+}
+
+// deleteDomains deletes the domains in ids, ignoring ones that no longer
+// exist.
+func (r *DomainSetResource) deleteDomains(ctx context.Context, ids map[string]int64) error {
+	for name, id := range ids {
+		if err := r.client.DeleteDomain(ctx, int(id)); err != nil {
+			return fmt.Errorf("unable to delete domain %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setDomainSet stores fqdns and their domain IDs on data.
+func setDomainSet(ctx context.Context, data *DomainSetModel, fqdns []string, ids map[string]int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	set, d := types.SetValueFrom(ctx, types.StringType, fqdns)
+	diags.Append(d...)
+	data.Fqdns = set
+
+	domainIds, d := types.MapValueFrom(ctx, types.Int64Type, ids)
+	diags.Append(d...)
+	data.DomainIds = domainIds
+
+	return diags
+}
+
+// domainSetID derives a stable ID from the normalized, sorted fqdns.
+func domainSetID(fqdns []string) string {
+	names := make([]string, 0, len(fqdns))
+	for _, f := range fqdns {
+		names = append(names, legocharmclient.NormalizeFQDN(f))
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(names, ","))))[:16]
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestDomainSetResource_Metadata(t *testing.T) {
+	r := &DomainSetResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+	require.Equal(t, "legocharm_domain_set", resp.TypeName)
+}
+
+func TestDomainSetResource_ValidateConfig(t *testing.T) {
+	r := &DomainSetResource{}
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for name, tc := range map[string]struct {
+		fqdns []string
+		error string
+	}{
+		"valid":     {fqdns: []string{"a.example.com", "*.b.example.com"}},
+		"duplicate": {fqdns: []string{"a.example.com", "A.example.com."}, error: "Duplicate Domain"},
+		"invalid":   {fqdns: []string{"a..example.com"}, error: "Invalid Domain Name"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fqdns, _ := types.SetValueFrom(ctx, types.StringType, tc.fqdns)
+			data := DomainSetModel{Fqdns: fqdns, DomainIds: types.MapNull(types.Int64Type), Id: types.StringNull()}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(ctx, &data).HasError())
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+			if tc.error == "" {
+				require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			require.Equal(t, tc.error, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
+
+func TestDomainSetResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &DomainSetResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	fqdns, _ := types.SetValueFrom(ctx, types.StringType, []string{"A.example.com", "b.example.com"})
+	data := DomainSetModel{Fqdns: fqdns, DomainIds: types.MapUnknown(types.Int64Type), Id: types.StringUnknown()}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var created DomainSetModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.NotEmpty(t, created.Id.ValueString())
+	ids := map[string]int64{}
+	require.False(t, created.DomainIds.ElementsAs(ctx, &ids, false).HasError())
+	aID, ok := api.domainID("a.example.com")
+	require.True(t, ok)
+	require.Equal(t, int64(aID), ids["a.example.com"])
+	require.Len(t, ids, 2)
+
+	// Adding a domain that already exists fails without creating anything.
+	fqdns, _ = types.SetValueFrom(ctx, types.StringType, []string{"a.example.com", "b.example.com", "c.example.com", "staging.example.com"})
+	data = created
+	data.Fqdns = fqdns
+	data.DomainIds = types.MapUnknown(types.Int64Type)
+	require.False(t, plan.Set(ctx, &data).HasError())
+	failResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, failResp)
+	require.True(t, failResp.Diagnostics.HasError())
+	require.Equal(t, "Domains Exist", failResp.Diagnostics.Errors()[0].Summary())
+	_, ok = api.domainID("c.example.com")
+	require.False(t, ok)
+
+	// Replacing b with c deletes one domain and creates the other.
+	fqdns, _ = types.SetValueFrom(ctx, types.StringType, []string{"a.example.com", "c.example.com"})
+	data.Fqdns = fqdns
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	_, ok = api.domainID("b.example.com")
+	require.False(t, ok)
+	_, ok = api.domainID("c.example.com")
+	require.True(t, ok)
+	_, ok = api.domainID("a.example.com")
+	require.True(t, ok)
+
+	var updated DomainSetModel
+	require.False(t, updateResp.State.Get(ctx, &updated).HasError())
+	require.Equal(t, created.Id, updated.Id)
+
+	// A domain deleted outside Terraform drops out of state.
+	delete(api.domains, aID)
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	var refreshed DomainSetModel
+	require.False(t, readResp.State.Get(ctx, &refreshed).HasError())
+	var names []string
+	require.False(t, refreshed.Fqdns.ElementsAs(ctx, &names, false).HasError())
+	require.Equal(t, []string{"c.example.com"}, names)
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	_, ok = api.domainID("c.example.com")
+	require.False(t, ok)
+	_, ok = api.domainID("staging.example.com")
+	require.True(t, ok)
+}
+
+func TestDomainSetResource_ImportState(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &DomainSetResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "staging.example.com"}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var imported DomainSetModel
+	require.False(t, resp.State.Get(ctx, &imported).HasError())
+	ids := map[string]int64{}
+	require.False(t, imported.DomainIds.ElementsAs(ctx, &ids, false).HasError())
+	require.Equal(t, map[string]int64{"staging.example.com": 2}, ids)
+
+	resp = &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "staging.example.com,missing.example.com"}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Domain Not Found", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestAccDomainSetResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	first, second := testAccName(t)+".example.com", testAccName(t)+".example.com"
+	ctx := context.Background()
+
+	config := func(fqdn string) string {
+		return fmt.Sprintf(`
+resource "legocharm_domain_set" "test" {
+  fqdns = [%q]
+}
+`, fqdn)
+	}
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config(first),
+				Check:  testAccCheckDomainExists(env, first, true),
+			},
+			{
+				Config: config(second),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					testAccCheckDomainExists(env, first, false),
+					testAccCheckDomainExists(env, second, true),
+				),
+			},
+			{
+				ResourceName:      "legocharm_domain_set.test",
+				ImportState:       true,
+				ImportStateId:     second,
+				ImportStateVerify: true,
+				// The ID is derived from the FQDNs the set was created with.
+				ImportStateVerifyIdentifierAttribute: "fqdns.0",
+				ImportStateVerifyIgnore:              []string{"id"},
+			},
+			// A domain deleted outside Terraform is created again.
+			{
+				PreConfig: func() {
+					domain, err := env.API.GetDomain(ctx, second)
+					require.NoError(t, err)
+					require.NoError(t, env.API.DeleteDomain(ctx, domain.ID))
+				},
+				Config: config(second),
+				Check:  testAccCheckDomainExists(env, second, true),
+			},
+		},
+		CheckDestroy: testAccCheckDomainExists(env, second, false),
+	})
+}
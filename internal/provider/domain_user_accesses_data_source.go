@@ -0,0 +1,168 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ datasource.DataSource = &DomainUserAccessesDataSource{}
+
+// NewDomainUserAccessesDataSource creates a new domain user accesses data
+// source.
+func NewDomainUserAccessesDataSource() datasource.DataSource {
+	return &DomainUserAccessesDataSource{}
+}
+
+// DomainUserAccessesDataSource is the data source implementation for the
+// users permitted on a LegoCharm domain.
+type DomainUserAccessesDataSource struct {
+	client legocharmclient.API
+}
+
+// DomainUserAccessesDataSourceModel maps Terraform schema to Go types for the
+// domain user accesses data source.
+type DomainUserAccessesDataSourceModel struct {
+	Domain    types.String `tfsdk:"domain"`
+	Accesses  types.List   `tfsdk:"accesses"`
+	Usernames types.List   `tfsdk:"usernames"`
+}
+
+// domainUserAccessModel maps a single element of the accesses attribute.
+type domainUserAccessModel struct {
+	Username    types.String `tfsdk:"username"`
+	UserId      types.String `tfsdk:"user_id"`
+	AccessLevel types.String `tfsdk:"access_level"`
+	DatabaseID  types.Int64  `tfsdk:"database_id"`
+}
+
+// domainUserAccessObjectType is the object type of an element of the
+// accesses attribute.
+var domainUserAccessObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"username":     types.StringType,
+	"user_id":      types.StringType,
+	"access_level": types.StringType,
+	"database_id":  types.Int64Type,
+}}
+
+func (d *DomainUserAccessesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_user_accesses"
+}
+
+func (d *DomainUserAccessesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "All users with a permission on a domain, and their access levels. Only permissions on exactly this domain are returned, not those on parent domains.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "FQDN of the domain, such as `example.com` or the wildcard `*.example.com`. A domain that does not exist has no accesses.",
+				Required:            true,
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"accesses": schema.ListNestedAttribute{
+				MarkdownDescription: "The permissions on the domain, sorted by username.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
+							MarkdownDescription: "Username of the user holding the permission.",
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the user holding the permission.",
+							Computed:            true,
+						},
+						"access_level": schema.StringAttribute{
+							MarkdownDescription: "Access level of the permission: 'domain' or 'subdomain'.",
+							Computed:            true,
+						},
+						"database_id": schema.Int64Attribute{
+							MarkdownDescription: "The server-side ID of the permission.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"usernames": schema.ListAttribute{
+				MarkdownDescription: "Usernames of the users with a permission on the domain, sorted and without duplicates.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DomainUserAccessesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DomainUserAccessesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DomainUserAccessesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this data source")
+		return
+	}
+
+	permissions, err := domainPermissions(ctx, d.client, data.Domain.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read domain access", err, nil)
+		return
+	}
+
+	accesses := []domainUserAccessModel{}
+	usernames := []string{}
+	for _, p := range permissions {
+		accesses = append(accesses, domainUserAccessModel{
+			Username:    types.StringValue(p.username),
+			UserId:      types.StringValue(strconv.Itoa(p.userID)),
+			AccessLevel: types.StringValue(p.accessLevel),
+			DatabaseID:  types.Int64Value(int64(p.id)),
+		})
+		// permissions are sorted by username
+		if len(usernames) == 0 || usernames[len(usernames)-1] != p.username {
+			usernames = append(usernames, p.username)
+		}
+	}
+
+	accessList, diags := types.ListValueFrom(ctx, domainUserAccessObjectType, accesses)
+	resp.Diagnostics.Append(diags...)
+	usernameList, diags := types.ListValueFrom(ctx, types.StringType, usernames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Accesses = accessList
+	data.Usernames = usernameList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestDomainUserAccessesDataSource_Read(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "bob"
+	api.domains[3] = "other.example.com"
+	api.permissions[7] = legocharmclient.DomainUserPermissionData{ID: 7, UserID: 1005, Domain: 2, AccessLevel: "subdomain"}
+	api.permissions[8] = legocharmclient.DomainUserPermissionData{ID: 8, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	api.permissions[9] = legocharmclient.DomainUserPermissionData{ID: 9, UserID: 1004, Domain: 3, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	d := &DomainUserAccessesDataSource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	read := func(domain string) DomainUserAccessesDataSourceModel {
+		data := DomainUserAccessesDataSourceModel{Domain: types.StringValue(domain), Accesses: types.ListNull(domainUserAccessObjectType), Usernames: types.ListNull(types.StringType)}
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &data).HasError())
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var result DomainUserAccessesDataSourceModel
+		require.False(t, resp.State.Get(ctx, &result).HasError())
+		return result
+	}
+
+	result := read("Staging.example.com")
+	var usernames []string
+	require.False(t, result.Usernames.ElementsAs(ctx, &usernames, false).HasError())
+	require.Equal(t, []string{"alice", "bob"}, usernames)
+	var accesses []domainUserAccessModel
+	require.False(t, result.Accesses.ElementsAs(ctx, &accesses, false).HasError())
+	require.Equal(t, []domainUserAccessModel{
+		{Username: types.StringValue("alice"), UserId: types.StringValue("1004"), AccessLevel: types.StringValue("domain"), DatabaseID: types.Int64Value(8)},
+		{Username: types.StringValue("bob"), UserId: types.StringValue("1005"), AccessLevel: types.StringValue("subdomain"), DatabaseID: types.Int64Value(7)},
+	}, accesses)
+
+	result = read("missing.example.com")
+	require.Empty(t, result.Usernames.Elements())
+	require.Empty(t, result.Accesses.Elements())
+}
+
+func TestAccDomainUserAccessesDataSource(t *testing.T) {
+	env := testAccPreCheck(t)
+	userID, username, _ := testAccUser(t, env)
+	domain := testAccDomain(t, env)
+	access := testAccGrant(t, env, userID, domain.Fqdn, "domain")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "legocharm_domain_user_accesses" "test" {
+  domain = %q
+}
+`, domain.Fqdn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.legocharm_domain_user_accesses.test", "usernames.#", "1"),
+					resource.TestCheckResourceAttr("data.legocharm_domain_user_accesses.test", "usernames.0", username),
+					resource.TestCheckResourceAttr("data.legocharm_domain_user_accesses.test", "accesses.#", "1"),
+					resource.TestCheckResourceAttr("data.legocharm_domain_user_accesses.test", "accesses.0.username", username),
+					resource.TestCheckResourceAttr("data.legocharm_domain_user_accesses.test", "accesses.0.user_id", userID),
+					resource.TestCheckResourceAttr("data.legocharm_domain_user_accesses.test", "accesses.0.access_level", "domain"),
+					resource.TestCheckResourceAttr("data.legocharm_domain_user_accesses.test", "accesses.0.database_id", strconv.Itoa(access.ID)),
+				),
+			},
+		},
+	})
+}
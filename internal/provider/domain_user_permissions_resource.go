@@ -0,0 +1,391 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ resource.Resource = &DomainUserPermissionsResource{}
+var _ resource.ResourceWithImportState = &DomainUserPermissionsResource{}
+var _ resource.ResourceWithValidateConfig = &DomainUserPermissionsResource{}
+
+// NewDomainUserPermissionsResource creates a new domain user permissions
+// resource.
+func NewDomainUserPermissionsResource() resource.Resource {
+	return &DomainUserPermissionsResource{}
+}
+
+// DomainUserPermissionsResource is the resource implementation for the full
+// list of users permitted on a LegoCharm domain. Permissions for users not
+// listed are removed.
+type DomainUserPermissionsResource struct {
+	client legocharmclient.API
+}
+
+// DomainUserPermissionsModel maps Terraform schema to Go types for domain
+// user permissions resources.
+type DomainUserPermissionsModel struct {
+	Domain      types.String `tfsdk:"domain"`
+	Permissions types.Set    `tfsdk:"permissions"`
+	Id          types.String `tfsdk:"id"`
+}
+
+// domainUserPermissionModel maps a single element of the permissions
+// attribute.
+type domainUserPermissionModel struct {
+	Username    types.String `tfsdk:"username"`
+	AccessLevel types.String `tfsdk:"access_level"`
+}
+
+// domainUserPermissionObjectType is the object type of an element of the
+// permissions attribute.
+var domainUserPermissionObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{"username": types.StringType, "access_level": types.StringType}}
+
+// domainPermission is a permission on a domain together with the username
+// of the user holding it.
+type domainPermission struct {
+	id          int
+	userID      int
+	username    string
+	accessLevel string
+}
+
+func (r *DomainUserPermissionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_user_permissions"
+}
+
+func (r *DomainUserPermissionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The complete list of users permitted on a domain. Permissions granted on the domain outside Terraform are removed on the next apply. Do not combine with `legocharm_user_domain_access` or `grants` of `legocharm_service_user_with_access` for the same domain.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "FQDN of the domain, such as `example.com` or the wildcard `*.example.com`. The domain is created if it does not exist when a permission is granted.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"permissions": schema.SetNestedAttribute{
+				MarkdownDescription: "Users permitted on the domain. May be empty to revoke every permission on the domain.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
+							MarkdownDescription: "Username of the user to grant access to.",
+							Required:            true,
+						},
+						"access_level": schema.StringAttribute{
+							MarkdownDescription: "Access level. Possible values: 'domain' 'subdomain'",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the domain user permissions resource, equal to the normalized `domain`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures no user is listed twice and flags suspicious access
+// levels.
+func (r *DomainUserPermissionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DomainUserPermissionsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Permissions.IsNull() || data.Permissions.IsUnknown() {
+		return
+	}
+
+	var permissions []domainUserPermissionModel
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &permissions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, p := range permissions {
+		if p.Username.IsUnknown() {
+			continue
+		}
+		username := p.Username.ValueString()
+		if seen[username] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("permissions"),
+				"Duplicate User",
+				fmt.Sprintf("The user %q is listed more than once. Each user may appear only once.", username),
+			)
+		}
+		seen[username] = true
+
+		if data.Domain.IsUnknown() || data.Domain.IsNull() || p.AccessLevel.IsUnknown() {
+			continue
+		}
+		if problem := accessLevelProblem(data.Domain.ValueString(), p.AccessLevel.ValueString()); problem != "" {
+			resp.Diagnostics.AddAttributeWarning(path.Root("permissions"), "Suspicious Access Level", problem)
+		}
+	}
+}
+
+func (r *DomainUserPermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DomainUserPermissionsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	r.reconcile(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(legocharmclient.NormalizeFQDN(data.Domain.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainUserPermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DomainUserPermissionsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	current, err := domainPermissions(ctx, r.client, data.Domain.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read domain access", err, nil)
+		return
+	}
+
+	permissions := []domainUserPermissionModel{}
+	for _, p := range current {
+		permissions = append(permissions, domainUserPermissionModel{
+			Username:    types.StringValue(p.username),
+			AccessLevel: types.StringValue(p.accessLevel),
+		})
+	}
+	set, diags := types.SetValueFrom(ctx, domainUserPermissionObjectType, permissions)
+	resp.Diagnostics.Append(diags...)
+	data.Permissions = set
+	data.Id = types.StringValue(legocharmclient.NormalizeFQDN(data.Domain.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainUserPermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DomainUserPermissionsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	r.reconcile(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(legocharmclient.NormalizeFQDN(data.Domain.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainUserPermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DomainUserPermissionsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	data.Permissions = types.SetValueMust(domainUserPermissionObjectType, nil)
+	r.reconcile(ctx, data, &resp.Diagnostics)
+}
+
+// reconcile makes the permissions on data.Domain exactly data.Permissions.
+// Removals happen first so that access is never broader than configured.
+func (r *DomainUserPermissionsResource) reconcile(ctx context.Context, data DomainUserPermissionsModel, diags *diag.Diagnostics) {
+	var wanted []domainUserPermissionModel
+	diags.Append(data.Permissions.ElementsAs(ctx, &wanted, false)...)
+	if diags.HasError() {
+		return
+	}
+	domain := data.Domain.ValueString()
+
+	current, err := domainPermissions(ctx, r.client, domain)
+	if err != nil {
+		addClientError(diags, "Unable to read domain access", err, nil)
+		return
+	}
+
+	levels := map[string]string{}
+	for _, p := range wanted {
+		levels[p.Username.ValueString()] = p.AccessLevel.ValueString()
+	}
+
+	// Keep one permission per wanted user; remove the rest, including
+	// duplicates.
+	held := map[string]domainPermission{}
+	for _, p := range current {
+		_, isWanted := levels[p.username]
+		_, isHeld := held[p.username]
+		if isWanted && !isHeld {
+			held[p.username] = p
+			continue
+		}
+		tflog.Debug(ctx, "removing domain access", map[string]interface{}{"domain": domain, "username": p.username, "database_id": p.id})
+		if err := deletePermission(ctx, r.client, p.id); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove access to %s for %s: %s", domain, p.username, err))
+			return
+		}
+	}
+
+	for _, p := range wanted {
+		username := p.Username.ValueString()
+		level := p.AccessLevel.ValueString()
+		existing, ok := held[username]
+		switch {
+		case !ok:
+			user, err := r.client.GetUserByUsername(ctx, username)
+			if err != nil {
+				if errors.Is(err, legocharmclient.ErrNotFound) {
+					diags.AddAttributeError(path.Root("permissions"), "User Not Found", fmt.Sprintf("No user with username %q exists.", username))
+					return
+				}
+				addClientError(diags, fmt.Sprintf("Unable to read user %s", username), err, nil)
+				return
+			}
+			tflog.Debug(ctx, "granting domain access", map[string]interface{}{"domain": domain, "username": username})
+			_, err = r.client.CreateDomainAccess(ctx, legocharmclient.DomainUserPermissionCreateData{
+				UserID:      legocharmclient.LastPathSegment(user.Url),
+				Domain:      domain,
+				AccessLevel: level,
+			})
+			if err != nil {
+				addClientError(diags, fmt.Sprintf("Unable to grant access to %s for %s", domain, username), err, nil)
+				return
+			}
+		case existing.accessLevel != level:
+			if _, err := r.client.UpdateDomainAccess(ctx, existing.id, level); err != nil {
+				addClientError(diags, fmt.Sprintf("Unable to update access to %s for %s", domain, username), err, nil)
+				return
+			}
+		}
+	}
+}
+
+// domainPermissions returns the permissions on domain sorted by username, or
+// none if the domain does not exist.
+func domainPermissions(ctx context.Context, client legocharmclient.API, domain string) ([]domainPermission, error) {
+	domainData, err := client.GetDomain(ctx, domain)
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	list, err := client.ListDomainAccessByFqdn(ctx, domainData.Fqdn)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := map[int]string{}
+	var permissions []domainPermission
+	for _, access := range list {
+		// discard records for other domains that slip through the filter
+		if access.Domain != domainData.ID {
+			continue
+		}
+		username, ok := usernames[access.UserID]
+		if !ok {
+			user, err := client.GetUserById(ctx, strconv.Itoa(access.UserID))
+			if err != nil {
+				if errors.Is(err, legocharmclient.ErrNotFound) {
+					continue
+				}
+				return nil, err
+			}
+			username = user.Username
+			usernames[access.UserID] = username
+		}
+		permissions = append(permissions, domainPermission{id: access.ID, userID: access.UserID, username: username, accessLevel: access.AccessLevel})
+	}
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i].username < permissions[j].username })
+	return permissions, nil
+}
+
+// ImportState implements resource import for DomainUserPermissionsResource.
+func (r *DomainUserPermissionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// id is the domain FQDN
+	if problem := fqdnProblem(req.ID); problem != "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Import ID must be the domain FQDN: %s", problem))
+		return
+	}
+
+	data := DomainUserPermissionsModel{
+		Domain:      types.StringValue(req.ID),
+		Permissions: types.SetNull(domainUserPermissionObjectType),
+		Id:          types.StringValue(legocharmclient.NormalizeFQDN(req.ID)),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainUserPermissionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestDomainUserPermissionsResource_Metadata(t *testing.T) {
+	r := &DomainUserPermissionsResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+	require.Equal(t, "legocharm_domain_user_permissions", resp.TypeName)
+}
+
+func domainUserPermissions(t *testing.T, levels map[string]string) types.Set {
+	permissions := []domainUserPermissionModel{}
+	for username, level := range levels {
+		permissions = append(permissions, domainUserPermissionModel{Username: types.StringValue(username), AccessLevel: types.StringValue(level)})
+	}
+	set, diags := types.SetValueFrom(context.Background(), domainUserPermissionObjectType, permissions)
+	require.False(t, diags.HasError(), diags)
+	return set
+}
+
+func TestDomainUserPermissionsResource_ValidateConfig(t *testing.T) {
+	r := &DomainUserPermissionsResource{}
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	permissions, _ := types.SetValueFrom(ctx, domainUserPermissionObjectType, []domainUserPermissionModel{
+		{Username: types.StringValue("alice"), AccessLevel: types.StringValue("domain")},
+		{Username: types.StringValue("alice"), AccessLevel: types.StringValue("subdomain")},
+	})
+	data := DomainUserPermissionsModel{Domain: types.StringValue("*.example.com"), Permissions: permissions, Id: types.StringNull()}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Duplicate User", resp.Diagnostics.Errors()[0].Summary())
+	require.Equal(t, "Suspicious Access Level", resp.Diagnostics.Warnings()[0].Summary())
+}
+
+func TestDomainUserPermissionsResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "bob"
+	api.users[1006] = "carol"
+	// carol was granted access outside Terraform.
+	api.permissions[50] = legocharmclient.DomainUserPermissionData{ID: 50, UserID: 1006, Domain: 2, AccessLevel: "domain"}
+	// alice holds access to another domain, which must be left alone.
+	api.domains[3] = "other.example.com"
+	api.permissions[51] = legocharmclient.DomainUserPermissionData{ID: 51, UserID: 1004, Domain: 3, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &DomainUserPermissionsResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	levelsOn := func(domain int) map[string]string {
+		levels := map[string]string{}
+		for _, p := range api.permissions {
+			if p.Domain == domain {
+				levels[api.users[p.UserID]] = p.AccessLevel
+			}
+		}
+		return levels
+	}
+
+	data := DomainUserPermissionsModel{
+		Domain:      types.StringValue("Staging.example.com"),
+		Permissions: domainUserPermissions(t, map[string]string{"alice": "domain", "bob": "subdomain"}),
+		Id:          types.StringUnknown(),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	require.Equal(t, map[string]string{"alice": "domain", "bob": "subdomain"}, levelsOn(2))
+	require.Equal(t, map[string]string{"alice": "domain"}, levelsOn(3))
+
+	var created DomainUserPermissionsModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Equal(t, "staging.example.com", created.Id.ValueString())
+
+	// Changing a level updates the permission in place; dropping a user
+	// revokes their access.
+	data = created
+	data.Permissions = domainUserPermissions(t, map[string]string{"alice": "subdomain"})
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Equal(t, map[string]string{"alice": "subdomain"}, levelsOn(2))
+
+	// Access granted outside Terraform shows up as drift.
+	api.permissions[52] = legocharmclient.DomainUserPermissionData{ID: 52, UserID: 1006, Domain: 2, AccessLevel: "domain"}
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	var refreshed DomainUserPermissionsModel
+	require.False(t, readResp.State.Get(ctx, &refreshed).HasError())
+	require.True(t, refreshed.Permissions.Equal(domainUserPermissions(t, map[string]string{"alice": "subdomain", "carol": "domain"})))
+
+	// Unknown users are reported against permissions.
+	data.Permissions = domainUserPermissions(t, map[string]string{"mallory": "domain"})
+	require.False(t, plan.Set(ctx, &data).HasError())
+	failResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, failResp)
+	require.True(t, failResp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", failResp.Diagnostics.Errors()[0].Summary())
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Empty(t, levelsOn(2))
+	require.Equal(t, map[string]string{"alice": "domain"}, levelsOn(3))
+	_, ok := api.domainID("staging.example.com")
+	require.True(t, ok)
+}
+
+func TestDomainUserPermissionsResource_ImportState(t *testing.T) {
+	r := &DomainUserPermissionsResource{}
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "Staging.example.com"}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var imported DomainUserPermissionsModel
+	require.False(t, resp.State.Get(ctx, &imported).HasError())
+	require.Equal(t, "staging.example.com", imported.Id.ValueString())
+
+	resp = &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: ""}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid Import ID", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestAccDomainUserPermissionsResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	domain := testAccDomain(t, env)
+	_, alice, _ := testAccUser(t, env)
+	_, bob, _ := testAccUser(t, env)
+	ctx := context.Background()
+
+	levels := func(want map[string]string) resourcetest.TestCheckFunc {
+		return func(*terraform.State) error {
+			users, err := env.API.ListUsers(ctx)
+			if err != nil {
+				return err
+			}
+			usernames := map[int]string{}
+			for _, user := range users {
+				id, _ := strconv.Atoi(legocharmclient.LastPathSegment(user.Url))
+				usernames[id] = user.Username
+			}
+			accesses, err := env.API.ListDomainAccessByFqdn(ctx, domain.Fqdn)
+			if err != nil {
+				return err
+			}
+			got := map[string]string{}
+			for _, access := range accesses {
+				got[usernames[access.UserID]] = access.AccessLevel
+			}
+			if !maps.Equal(got, want) {
+				return fmt.Errorf("access levels on %s are %v, want %v", domain.Fqdn, got, want)
+			}
+			return nil
+		}
+	}
+	config := func(permissions map[string]string) string {
+		var blocks []string
+		for username, level := range permissions {
+			blocks = append(blocks, fmt.Sprintf("{ username = %q, access_level = %q }", username, level))
+		}
+		return fmt.Sprintf(`
+resource "legocharm_domain_user_permissions" "test" {
+  domain      = %q
+  permissions = [%s]
+}
+`, domain.Fqdn, strings.Join(blocks, ", "))
+	}
+	granted := map[string]string{alice: "subdomain", bob: "domain"}
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config(map[string]string{alice: "domain"}),
+				Check:  levels(map[string]string{alice: "domain"}),
+			},
+			{
+				Config: config(granted),
+				Check:  levels(granted),
+			},
+			{
+				ResourceName:      "legocharm_domain_user_permissions.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Permissions granted outside Terraform are detected and revoked.
+			{
+				PreConfig: func() {
+					id, _, _ := testAccUser(t, env)
+					_, err := env.API.CreateDomainAccess(ctx, legocharmclient.DomainUserPermissionCreateData{UserID: id, Domain: domain.Fqdn, AccessLevel: "domain"})
+					require.NoError(t, err)
+				},
+				Config:             config(granted),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(granted),
+				Check:  levels(granted),
+			},
+		},
+		CheckDestroy: levels(map[string]string{}),
+	})
+}
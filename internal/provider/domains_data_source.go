@@ -0,0 +1,151 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ datasource.DataSource = &DomainsDataSource{}
+
+// NewDomainsDataSource creates a new domains data source.
+func NewDomainsDataSource() datasource.DataSource { return &DomainsDataSource{} }
+
+// DomainsDataSource is the data source implementation for the registered
+// LegoCharm domains.
+type DomainsDataSource struct {
+	client legocharmclient.API
+}
+
+// DomainsDataSourceModel maps Terraform schema to Go types for the domains
+// data source.
+type DomainsDataSourceModel struct {
+	Suffix  types.String `tfsdk:"suffix"`
+	Domains types.List   `tfsdk:"domains"`
+	Fqdns   types.List   `tfsdk:"fqdns"`
+}
+
+// domainsDataSourceDomainModel maps a single element of the domains
+// attribute.
+type domainsDataSourceDomainModel struct {
+	Id   types.Int64  `tfsdk:"id"`
+	Fqdn types.String `tfsdk:"fqdn"`
+}
+
+// domainObjectType is the object type of an element of the domains
+// attribute.
+var domainObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{"id": types.Int64Type, "fqdn": types.StringType}}
+
+func (d *DomainsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domains"
+}
+
+func (d *DomainsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "All registered domains, optionally limited to those under a suffix.",
+		Attributes: map[string]schema.Attribute{
+			"suffix": schema.StringAttribute{
+				MarkdownDescription: "Only return domains equal to or under this domain, such as `example.com`, which matches `example.com`, `web.example.com` and `*.example.com`. Compared case-insensitively and ignoring a trailing dot.",
+				Optional:            true,
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"domains": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching domains, sorted by FQDN.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "The ID of the domain.",
+							Computed:            true,
+						},
+						"fqdn": schema.StringAttribute{
+							MarkdownDescription: "FQDN of the domain.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"fqdns": schema.ListAttribute{
+				MarkdownDescription: "FQDNs of the matching domains, sorted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DomainsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DomainsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this data source")
+		return
+	}
+
+	list, err := d.client.ListDomains(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list domains", err, nil)
+		return
+	}
+
+	suffix := legocharmclient.NormalizeFQDN(data.Suffix.ValueString())
+	domains := []domainsDataSourceDomainModel{}
+	fqdns := []string{}
+	for _, domain := range list {
+		name := legocharmclient.NormalizeFQDN(domain.Fqdn)
+		if suffix != "" && name != suffix && !strings.HasSuffix(name, "."+suffix) {
+			continue
+		}
+		domains = append(domains, domainsDataSourceDomainModel{Id: types.Int64Value(int64(domain.ID)), Fqdn: types.StringValue(domain.Fqdn)})
+		fqdns = append(fqdns, domain.Fqdn)
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i].Fqdn.ValueString() < domains[j].Fqdn.ValueString() })
+	sort.Strings(fqdns)
+
+	domainList, diags := types.ListValueFrom(ctx, domainObjectType, domains)
+	resp.Diagnostics.Append(diags...)
+	fqdnList, diags := types.ListValueFrom(ctx, types.StringType, fqdns)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Domains = domainList
+	data.Fqdns = fqdnList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestDomainsDataSource_Read(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.domains[3] = "*.staging.example.com"
+	api.domains[4] = "example.org"
+	api.domains[5] = "notexample.com"
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	d := &DomainsDataSource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	read := func(suffix types.String) []string {
+		data := DomainsDataSourceModel{Suffix: suffix, Domains: types.ListNull(domainObjectType), Fqdns: types.ListNull(types.StringType)}
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &data).HasError())
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var result DomainsDataSourceModel
+		require.False(t, resp.State.Get(ctx, &result).HasError())
+		var fqdns []string
+		require.False(t, result.Fqdns.ElementsAs(ctx, &fqdns, false).HasError())
+		var domains []domainsDataSourceDomainModel
+		require.False(t, result.Domains.ElementsAs(ctx, &domains, false).HasError())
+		require.Len(t, domains, len(fqdns))
+		return fqdns
+	}
+
+	require.Equal(t, []string{"*.staging.example.com", "example.org", "notexample.com", "staging.example.com"}, read(types.StringNull()))
+	require.Equal(t, []string{"*.staging.example.com", "staging.example.com"}, read(types.StringValue("Example.com.")))
+	require.Equal(t, []string{}, read(types.StringValue("example.net")))
+}
+
+func TestAccDomainsDataSource(t *testing.T) {
+	env := testAccPreCheck(t)
+	domain := testAccDomain(t, env)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "legocharm_domains" "test" {
+  suffix = %q
+}
+`, domain.Fqdn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.legocharm_domains.test", "fqdns.#", "1"),
+					resource.TestCheckResourceAttr("data.legocharm_domains.test", "fqdns.0", domain.Fqdn),
+					resource.TestCheckResourceAttr("data.legocharm_domains.test", "domains.#", "1"),
+					resource.TestCheckResourceAttr("data.legocharm_domains.test", "domains.0.fqdn", domain.Fqdn),
+					resource.TestCheckResourceAttr("data.legocharm_domains.test", "domains.0.id", strconv.Itoa(domain.ID)),
+				),
+			},
+		},
+	})
+}
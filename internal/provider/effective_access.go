@@ -0,0 +1,82 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// userGrant is a domain access permission held by a user, with the domain
+// resolved to its normalized FQDN.
+type userGrant struct {
+	id          int
+	domain      string
+	accessLevel string
+}
+
+// userGrants returns the permissions held by the user with the given
+// username, sorted by domain. Permissions on domains deleted concurrently are
+// skipped.
+func userGrants(ctx context.Context, client legocharmclient.API, username string) ([]userGrant, error) {
+	list, err := client.ListDomainAccessByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	fqdns := map[int]string{}
+	var grants []userGrant
+	for _, access := range list {
+		fqdn, ok := fqdns[access.Domain]
+		if !ok {
+			domain, err := client.GetDomainById(ctx, access.Domain)
+			if err != nil {
+				if errors.Is(err, legocharmclient.ErrNotFound) {
+					continue
+				}
+				return nil, err
+			}
+			fqdn = legocharmclient.NormalizeFQDN(domain.Fqdn)
+			fqdns[access.Domain] = fqdn
+		}
+		grants = append(grants, userGrant{id: access.ID, domain: fqdn, accessLevel: access.AccessLevel})
+	}
+	sort.Slice(grants, func(i, j int) bool { return grants[i].domain < grants[j].domain })
+	return grants, nil
+}
+
+// grantBase returns the domain under which a subdomain-level grant on domain
+// is effective: the domain itself, or for a wildcard the domain it covers.
+func grantBase(domain string) string {
+	return strings.TrimPrefix(domain, "*.")
+}
+
+// grantCovers reports whether grant gives access to the normalized name fqdn.
+// Both access levels cover the granted name itself; a subdomain-level grant
+// also covers every name below its base, walking up parent domains.
+func grantCovers(grant userGrant, fqdn string) bool {
+	if grant.domain == fqdn {
+		return true
+	}
+	return grant.accessLevel == "subdomain" && strings.HasSuffix(fqdn, "."+grantBase(grant.domain))
+}
+
+// coveringGrant returns the most specific of grants that covers the
+// normalized name fqdn, or nil if the name is not covered.
+func coveringGrant(grants []userGrant, fqdn string) *userGrant {
+	var best *userGrant
+	for i, grant := range grants {
+		if !grantCovers(grant, fqdn) {
+			continue
+		}
+		if best == nil || grant.domain == fqdn || (best.domain != fqdn && len(grantBase(grant.domain)) > len(grantBase(best.domain))) {
+			best = &grants[i]
+		}
+	}
+	return best
+}
@@ -0,0 +1,343 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ datasource.DataSource = &ExportDataSource{}
+
+// NewExportDataSource creates a new export data source.
+func NewExportDataSource() datasource.DataSource { return &ExportDataSource{} }
+
+// ExportDataSource is the data source implementation for the import blocks of
+// the existing users, domains and grants of a deployment.
+type ExportDataSource struct {
+	client legocharmclient.API
+}
+
+// ExportDataSourceModel maps Terraform schema to Go types for the export data
+// source.
+type ExportDataSourceModel struct {
+	Users        types.List   `tfsdk:"users"`
+	Domains      types.List   `tfsdk:"domains"`
+	Grants       types.List   `tfsdk:"grants"`
+	ImportBlocks types.String `tfsdk:"import_blocks"`
+}
+
+// exportUserModel maps a single element of the users attribute.
+type exportUserModel struct {
+	Username types.String `tfsdk:"username"`
+	UserId   types.String `tfsdk:"user_id"`
+	Address  types.String `tfsdk:"address"`
+	ImportId types.String `tfsdk:"import_id"`
+}
+
+// exportDomainModel maps a single element of the domains attribute.
+type exportDomainModel struct {
+	Fqdn     types.String `tfsdk:"fqdn"`
+	DomainId types.Int64  `tfsdk:"domain_id"`
+	Address  types.String `tfsdk:"address"`
+	ImportId types.String `tfsdk:"import_id"`
+}
+
+// exportGrantModel maps a single element of the grants attribute.
+type exportGrantModel struct {
+	Username    types.String `tfsdk:"username"`
+	Domain      types.String `tfsdk:"domain"`
+	AccessLevel types.String `tfsdk:"access_level"`
+	Address     types.String `tfsdk:"address"`
+	ImportId    types.String `tfsdk:"import_id"`
+}
+
+var (
+	exportUserObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"username":  types.StringType,
+		"user_id":   types.StringType,
+		"address":   types.StringType,
+		"import_id": types.StringType,
+	}}
+	exportDomainObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"fqdn":      types.StringType,
+		"domain_id": types.Int64Type,
+		"address":   types.StringType,
+		"import_id": types.StringType,
+	}}
+	exportGrantObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"username":     types.StringType,
+		"domain":       types.StringType,
+		"access_level": types.StringType,
+		"address":      types.StringType,
+		"import_id":    types.StringType,
+	}}
+)
+
+// nonIdentifierRegexp matches the characters a Terraform resource name
+// cannot contain.
+var nonIdentifierRegexp = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// resourceNames hands out unique resource names per resource type.
+type resourceNames map[string]bool
+
+// address returns the address of a resource of type typ named after parts,
+// with the characters Terraform does not allow in names replaced by "_", and
+// a numbered suffix if the name is already taken.
+func (n resourceNames) address(typ string, parts ...string) string {
+	name := nonIdentifierRegexp.ReplaceAllString(strings.Join(parts, "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	address := typ + "." + name
+	for i := 2; n[address]; i++ {
+		address = fmt.Sprintf("%s.%s_%d", typ, name, i)
+	}
+	n[address] = true
+	return address
+}
+
+func (d *ExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_export"
+}
+
+func (d *ExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The import IDs and `import` blocks of every existing user, domain and grant, to adopt the provider over an existing charm deployment. " +
+			"Paste `import_blocks` into the configuration and run `terraform plan -generate-config-out=generated.tf` to have Terraform write the matching resources. " +
+			"Requires a provider account allowed to list users.",
+		Attributes: map[string]schema.Attribute{
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "One element per user, sorted by username, to import as `legocharm_user`. This includes the account the provider authenticates with.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
+							MarkdownDescription: "Username of the user.",
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the user.",
+							Computed:            true,
+						},
+						"address": schema.StringAttribute{
+							MarkdownDescription: "Suggested resource address, such as `legocharm_user.alice`.",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "Import ID of the user: its username.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"domains": schema.ListNestedAttribute{
+				MarkdownDescription: "One element per domain, sorted by FQDN, to import as `legocharm_domain`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"fqdn": schema.StringAttribute{
+							MarkdownDescription: "FQDN of the domain.",
+							Computed:            true,
+						},
+						"domain_id": schema.Int64Attribute{
+							MarkdownDescription: "The ID of the domain.",
+							Computed:            true,
+						},
+						"address": schema.StringAttribute{
+							MarkdownDescription: "Suggested resource address, such as `legocharm_domain.example_com`.",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "Import ID of the domain: its FQDN.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"grants": schema.ListNestedAttribute{
+				MarkdownDescription: "One element per domain access permission, sorted by username and then domain, to import as `legocharm_user_domain_access`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
+							MarkdownDescription: "Username of the user holding the permission.",
+							Computed:            true,
+						},
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "FQDN of the domain the permission is on.",
+							Computed:            true,
+						},
+						"access_level": schema.StringAttribute{
+							MarkdownDescription: "Access level of the permission: 'domain' or 'subdomain'.",
+							Computed:            true,
+						},
+						"address": schema.StringAttribute{
+							MarkdownDescription: "Suggested resource address, such as `legocharm_user_domain_access.alice_example_com`.",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "Import ID of the permission, in the format 'username:domain:access_level'.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"import_blocks": schema.StringAttribute{
+				MarkdownDescription: "An `import` block for every user, domain and grant, ready to paste into the configuration.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ExportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this data source")
+		return
+	}
+
+	userList, err := d.client.ListUsers(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list users", err, nil)
+		return
+	}
+	domainList, err := d.client.ListDomains(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list domains", err, nil)
+		return
+	}
+	permissions, err := d.client.ListAllDomainAccess(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list domain access", err, nil)
+		return
+	}
+
+	sort.Slice(userList, func(i, j int) bool { return userList[i].Username < userList[j].Username })
+	sort.Slice(domainList, func(i, j int) bool { return domainList[i].Fqdn < domainList[j].Fqdn })
+
+	names := resourceNames{}
+	var blocks []string
+	block := func(address, importID string) {
+		blocks = append(blocks, fmt.Sprintf("import {\n  to = %s\n  id = %s\n}\n", address, strconv.Quote(importID)))
+	}
+
+	usernames := map[int]string{}
+	users := []exportUserModel{}
+	for _, user := range userList {
+		userID := legocharmclient.LastPathSegment(user.Url)
+		id, err := strconv.Atoi(userID)
+		if err != nil {
+			continue
+		}
+		usernames[id] = user.Username
+		address := names.address("legocharm_user", user.Username)
+		users = append(users, exportUserModel{
+			Username: types.StringValue(user.Username),
+			UserId:   types.StringValue(userID),
+			Address:  types.StringValue(address),
+			ImportId: types.StringValue(user.Username),
+		})
+		block(address, user.Username)
+	}
+
+	fqdns := map[int]string{}
+	domains := []exportDomainModel{}
+	for _, domain := range domainList {
+		fqdns[domain.ID] = domain.Fqdn
+		address := names.address("legocharm_domain", domain.Fqdn)
+		domains = append(domains, exportDomainModel{
+			Fqdn:     types.StringValue(domain.Fqdn),
+			DomainId: types.Int64Value(int64(domain.ID)),
+			Address:  types.StringValue(address),
+			ImportId: types.StringValue(domain.Fqdn),
+		})
+		block(address, domain.Fqdn)
+	}
+
+	grants := []exportGrantModel{}
+	for _, p := range permissions {
+		// skip permissions on users or domains deleted between the calls
+		username, ok := usernames[p.UserID]
+		if !ok {
+			continue
+		}
+		fqdn, ok := fqdns[p.Domain]
+		if !ok {
+			continue
+		}
+		grants = append(grants, exportGrantModel{
+			Username:    types.StringValue(username),
+			Domain:      types.StringValue(fqdn),
+			AccessLevel: types.StringValue(p.AccessLevel),
+			ImportId:    types.StringValue(username + ":" + fqdn + ":" + p.AccessLevel),
+		})
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		if a, b := grants[i].Username.ValueString(), grants[j].Username.ValueString(); a != b {
+			return a < b
+		}
+		if a, b := grants[i].Domain.ValueString(), grants[j].Domain.ValueString(); a != b {
+			return a < b
+		}
+		return grants[i].AccessLevel.ValueString() < grants[j].AccessLevel.ValueString()
+	})
+	// name the grants once sorted, so that their names do not depend on the
+	// order the API lists them in
+	for i, grant := range grants {
+		address := names.address("legocharm_user_domain_access", grant.Username.ValueString(), grant.Domain.ValueString())
+		grants[i].Address = types.StringValue(address)
+		block(address, grant.ImportId.ValueString())
+	}
+
+	usersValue, diags := types.ListValueFrom(ctx, exportUserObjectType, users)
+	resp.Diagnostics.Append(diags...)
+	domainsValue, diags := types.ListValueFrom(ctx, exportDomainObjectType, domains)
+	resp.Diagnostics.Append(diags...)
+	grantsValue, diags := types.ListValueFrom(ctx, exportGrantObjectType, grants)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Users = usersValue
+	data.Domains = domainsValue
+	data.Grants = grantsValue
+	data.ImportBlocks = types.StringValue(strings.Join(blocks, "\n"))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestExportDataSource_Read(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "bob.smith"
+	// Its resource name clashes with that of bob.smith.
+	api.users[1006] = "bob_smith"
+	api.domains[3] = "example.com"
+	api.permissions[7] = legocharmclient.DomainUserPermissionData{ID: 7, UserID: 1005, Domain: 2, AccessLevel: "subdomain"}
+	api.permissions[8] = legocharmclient.DomainUserPermissionData{ID: 8, UserID: 1004, Domain: 3, AccessLevel: "domain"}
+	// A permission on a domain deleted concurrently is skipped.
+	api.permissions[9] = legocharmclient.DomainUserPermissionData{ID: 9, UserID: 1004, Domain: 99, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	d := &ExportDataSource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	data := ExportDataSourceModel{
+		Users:   types.ListNull(exportUserObjectType),
+		Domains: types.ListNull(exportDomainObjectType),
+		Grants:  types.ListNull(exportGrantObjectType),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var result ExportDataSourceModel
+	require.False(t, resp.State.Get(ctx, &result).HasError())
+
+	var users []exportUserModel
+	require.False(t, result.Users.ElementsAs(ctx, &users, false).HasError())
+	user := func(username, userID, address string) exportUserModel {
+		return exportUserModel{
+			Username: types.StringValue(username),
+			UserId:   types.StringValue(userID),
+			Address:  types.StringValue(address),
+			ImportId: types.StringValue(username),
+		}
+	}
+	require.Equal(t, []exportUserModel{
+		user("alice", "1004", "legocharm_user.alice"),
+		user("bob.smith", "1005", "legocharm_user.bob_smith"),
+		user("bob_smith", "1006", "legocharm_user.bob_smith_2"),
+	}, users)
+
+	var domains []exportDomainModel
+	require.False(t, result.Domains.ElementsAs(ctx, &domains, false).HasError())
+	require.Equal(t, []exportDomainModel{
+		{Fqdn: types.StringValue("example.com"), DomainId: types.Int64Value(3), Address: types.StringValue("legocharm_domain.example_com"), ImportId: types.StringValue("example.com")},
+		{Fqdn: types.StringValue("staging.example.com"), DomainId: types.Int64Value(2), Address: types.StringValue("legocharm_domain.staging_example_com"), ImportId: types.StringValue("staging.example.com")},
+	}, domains)
+
+	var grants []exportGrantModel
+	require.False(t, result.Grants.ElementsAs(ctx, &grants, false).HasError())
+	require.Equal(t, []exportGrantModel{
+		{
+			Username:    types.StringValue("alice"),
+			Domain:      types.StringValue("example.com"),
+			AccessLevel: types.StringValue("domain"),
+			Address:     types.StringValue("legocharm_user_domain_access.alice_example_com"),
+			ImportId:    types.StringValue("alice:example.com:domain"),
+		},
+		{
+			Username:    types.StringValue("bob.smith"),
+			Domain:      types.StringValue("staging.example.com"),
+			AccessLevel: types.StringValue("subdomain"),
+			Address:     types.StringValue("legocharm_user_domain_access.bob_smith_staging_example_com"),
+			ImportId:    types.StringValue("bob.smith:staging.example.com:subdomain"),
+		},
+	}, grants)
+
+	require.Equal(t, `import {
+  to = legocharm_user.alice
+  id = "alice"
+}
+
+import {
+  to = legocharm_user.bob_smith
+  id = "bob.smith"
+}
+
+import {
+  to = legocharm_user.bob_smith_2
+  id = "bob_smith"
+}
+
+import {
+  to = legocharm_domain.example_com
+  id = "example.com"
+}
+
+import {
+  to = legocharm_domain.staging_example_com
+  id = "staging.example.com"
+}
+
+import {
+  to = legocharm_user_domain_access.alice_example_com
+  id = "alice:example.com:domain"
+}
+
+import {
+  to = legocharm_user_domain_access.bob_smith_staging_example_com
+  id = "bob.smith:staging.example.com:subdomain"
+}
+`, result.ImportBlocks.ValueString())
+}
+
+func TestResourceNames_Address(t *testing.T) {
+	names := resourceNames{}
+	require.Equal(t, "legocharm_domain.example_com", names.address("legocharm_domain", "example.com"))
+	require.Equal(t, "legocharm_domain.example_com_2", names.address("legocharm_domain", "example_com"))
+	require.Equal(t, "legocharm_user._1password", names.address("legocharm_user", "1password"))
+	require.Equal(t, "legocharm_user.a_b_c", names.address("legocharm_user", "a+b@c"))
+}
+
+func TestAccExportDataSource(t *testing.T) {
+	env := testAccPreCheck(t)
+	userID, username, _ := testAccUser(t, env)
+	domain := testAccDomain(t, env)
+	testAccGrant(t, env, userID, domain.Fqdn, "domain")
+
+	// The addresses depend on the other users and domains of the
+	// deployment, so only the import IDs are checked.
+	importID := username + ":" + domain.Fqdn + ":domain"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "legocharm_export" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.legocharm_export.test", "import_blocks", regexp.MustCompile(regexp.QuoteMeta(`id = "`+username+`"`))),
+					resource.TestMatchResourceAttr("data.legocharm_export.test", "import_blocks", regexp.MustCompile(regexp.QuoteMeta(`id = "`+domain.Fqdn+`"`))),
+					resource.TestMatchResourceAttr("data.legocharm_export.test", "import_blocks", regexp.MustCompile(regexp.QuoteMeta(`id = "`+importID+`"`))),
+				),
+			},
+		},
+	})
+}
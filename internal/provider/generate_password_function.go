@@ -0,0 +1,85 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &GeneratePasswordFunction{}
+
+// passwordCharsets are the character sets accepted by generate_password, each
+// a list of classes contributing at least one character.
+var passwordCharsets = map[string][]string{
+	"alphanumeric": {passwordLower, passwordUpper, passwordDigits},
+	"special":      {passwordLower, passwordUpper, passwordDigits, passwordSpecial},
+	"lower":        {passwordLower, passwordDigits},
+	"digits":       {passwordDigits},
+}
+
+// NewGeneratePasswordFunction creates a new generate_password function.
+func NewGeneratePasswordFunction() function.Function { return &GeneratePasswordFunction{} }
+
+// GeneratePasswordFunction is the function implementation for
+// provider::legocharm::generate_password.
+type GeneratePasswordFunction struct{}
+
+func (f *GeneratePasswordFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "generate_password"
+}
+
+func (f *GeneratePasswordFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Generate a random password",
+		MarkdownDescription: fmt.Sprintf("Returns a random password from a cryptographically secure source, with at least one character from each class of the character set. The result is different on every call, so only pass it where the value is not compared between plan and apply, such as write-only arguments like `password_wo` on `legocharm_admin_password`. Terraform reports an inconsistent result when it is stored in resource arguments; let `legocharm_user` generate the password instead in that case. The special characters are `%s`.", passwordSpecial),
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "length",
+				MarkdownDescription: fmt.Sprintf("Length of the password, at least %d.", minPasswordLength),
+			},
+			function.StringParameter{
+				Name:                "charset",
+				MarkdownDescription: "Character set: `alphanumeric` for letters and digits, `special` for letters, digits and special characters, `lower` for lower case letters and digits, or `digits`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *GeneratePasswordFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var length int64
+	var charset string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &length, &charset))
+	if resp.Error != nil {
+		return
+	}
+
+	if length < minPasswordLength {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("length must be at least %d, got %d", minPasswordLength, length))
+		return
+	}
+	classes, ok := passwordCharsets[charset]
+	if !ok {
+		var names []string
+		for name := range passwordCharsets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unknown charset %q, expected one of: %s", charset, strings.Join(names, ", ")))
+		return
+	}
+
+	password, err := generateFromClasses(int(length), classes)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, password))
+}
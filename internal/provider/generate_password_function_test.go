@@ -0,0 +1,55 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func runGeneratePassword(t *testing.T, length int64, charset string) *function.RunResponse {
+	t.Helper()
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	args := function.NewArgumentsData([]attr.Value{types.Int64Value(length), types.StringValue(charset)})
+	(&GeneratePasswordFunction{}).Run(context.Background(), function.RunRequest{Arguments: args}, resp)
+	return resp
+}
+
+func TestGeneratePasswordFunction_Run(t *testing.T) {
+	for charset, classes := range passwordCharsets {
+		resp := runGeneratePassword(t, 24, charset)
+		require.Nil(t, resp.Error, charset)
+
+		password := resp.Result.Value().(types.String).ValueString()
+		require.Len(t, password, 24, charset)
+		allowed := strings.Join(classes, "")
+		for _, c := range password {
+			require.True(t, strings.ContainsRune(allowed, c), "%s: unexpected character %q", charset, c)
+		}
+		for _, class := range classes {
+			require.True(t, strings.ContainsAny(password, class), "%s: missing class %q", charset, class)
+		}
+	}
+
+	first := runGeneratePassword(t, 32, "special").Result.Value()
+	second := runGeneratePassword(t, 32, "special").Result.Value()
+	require.NotEqual(t, first, second)
+}
+
+func TestGeneratePasswordFunction_Run_Invalid(t *testing.T) {
+	resp := runGeneratePassword(t, minPasswordLength-1, "alphanumeric")
+	require.NotNil(t, resp.Error)
+	require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+
+	resp = runGeneratePassword(t, 16, "emoji")
+	require.NotNil(t, resp.Error)
+	require.Equal(t, int64(1), *resp.Error.FunctionArgument)
+	require.Contains(t, resp.Error.Text, "alphanumeric, digits, lower, special")
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// serviceUserGrantModel maps a single element of a grants attribute.
+type serviceUserGrantModel struct {
+	Domain      types.String `tfsdk:"domain"`
+	AccessLevel types.String `tfsdk:"access_level"`
+}
+
+// grantObjectType is the object type of an element of a grants attribute.
+var grantObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{"domain": types.StringType, "access_level": types.StringType}}
+
+// grantAttributes returns the nested attributes of a grants attribute.
+func grantAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"domain": schema.StringAttribute{
+			MarkdownDescription: "FQDN of the domain to grant access to, such as `example.com` or the wildcard `*.example.com`.",
+			Required:            true,
+			Validators: []validator.String{
+				fqdn(),
+			},
+		},
+		"access_level": schema.StringAttribute{
+			MarkdownDescription: "Access level. Possible values: 'domain' 'subdomain'",
+			Required:            true,
+		},
+	}
+}
+
+// validateGrants ensures no domain in grants is granted twice and flags
+// suspicious access levels.
+func validateGrants(ctx context.Context, grantsValue types.Set, diags *diag.Diagnostics) {
+	if grantsValue.IsNull() || grantsValue.IsUnknown() {
+		return
+	}
+
+	var grants []serviceUserGrantModel
+	diags.Append(grantsValue.ElementsAs(ctx, &grants, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, grant := range grants {
+		if grant.Domain.IsUnknown() {
+			continue
+		}
+		domain := legocharmclient.NormalizeFQDN(grant.Domain.ValueString())
+		if seen[domain] {
+			diags.AddAttributeError(
+				path.Root("grants"),
+				"Duplicate Domain Grant",
+				fmt.Sprintf("The domain %q is granted more than once. Each domain may appear only once.", domain),
+			)
+		}
+		seen[domain] = true
+
+		if grant.AccessLevel.IsUnknown() {
+			continue
+		}
+		if problem := accessLevelProblem(domain, grant.AccessLevel.ValueString()); problem != "" {
+			diags.AddAttributeWarning(path.Root("grants"), "Suspicious Access Level", problem)
+		}
+	}
+}
+
+// createGrants grants each of grants to the user with ID userID and returns
+// the permission IDs keyed by normalized domain. On failure the grants
+// created so far are returned so that the caller can roll them back.
+func createGrants(ctx context.Context, client legocharmclient.API, userID string, grants []serviceUserGrantModel, diags *diag.Diagnostics) map[string]int64 {
+	ids := map[string]int64{}
+	for _, grant := range grants {
+		access, err := client.CreateDomainAccess(ctx, legocharmclient.DomainUserPermissionCreateData{
+			UserID:      userID,
+			Domain:      grant.Domain.ValueString(),
+			AccessLevel: grant.AccessLevel.ValueString(),
+		})
+		if err != nil {
+			addClientError(diags, fmt.Sprintf("Unable to grant access to %s", grant.Domain.ValueString()), err, nil)
+			return ids
+		}
+		ids[legocharmclient.NormalizeFQDN(grant.Domain.ValueString())] = int64(access.ID)
+	}
+	return ids
+}
+
+// refreshGrants reconciles grants and their permission IDs with the
+// permissions the user with the given username holds. Grants removed
+// out-of-band are dropped so the next plan recreates them, recreated grants
+// are adopted, and access level changes are picked up. When authoritative is
+// set, permissions not in grants are added so the next plan removes them.
+func refreshGrants(ctx context.Context, client legocharmclient.API, username string, grants []serviceUserGrantModel, ids map[string]int64, authoritative bool, diags *diag.Diagnostics) ([]serviceUserGrantModel, map[string]int64) {
+	permissions, err := client.ListDomainAccessByUsername(ctx, username)
+	if err != nil {
+		addClientError(diags, "Unable to read domain access", err, nil)
+		return nil, nil
+	}
+	levels := map[int64]string{}
+	byDomain := map[int]int64{}
+	for _, p := range permissions {
+		levels[int64(p.ID)] = p.AccessLevel
+		byDomain[p.Domain] = int64(p.ID)
+	}
+
+	var current []serviceUserGrantModel
+	currentIds := map[string]int64{}
+	for _, grant := range grants {
+		domain := legocharmclient.NormalizeFQDN(grant.Domain.ValueString())
+		level, ok := levels[ids[domain]]
+		if !ok {
+			// The grant may have been deleted and recreated with a new ID.
+			recreated := findRecreatedGrant(ctx, client, domain, byDomain, diags)
+			if diags.HasError() {
+				return nil, nil
+			}
+			if recreated == 0 {
+				continue
+			}
+			diags.AddWarning(
+				"Domain Access Recreated Outside Terraform",
+				fmt.Sprintf("The domain access permission for %s was deleted and recreated outside Terraform. Its database ID changed from %d to %d; the new permission is now tracked.", domain, ids[domain], recreated),
+			)
+			ids[domain] = recreated
+			level = levels[recreated]
+		}
+		grant.AccessLevel = types.StringValue(level)
+		current = append(current, grant)
+		currentIds[domain] = ids[domain]
+	}
+
+	// In authoritative mode, report undeclared grants so the next plan
+	// shows them being removed.
+	if authoritative {
+		tracked := map[int64]bool{}
+		for _, accessID := range currentIds {
+			tracked[accessID] = true
+		}
+		for _, p := range permissions {
+			if tracked[int64(p.ID)] {
+				continue
+			}
+			domainData, err := client.GetDomainById(ctx, p.Domain)
+			if err != nil {
+				if err == legocharmclient.ErrNotFound {
+					continue
+				}
+				addClientError(diags, "Unable to read domain", err, nil)
+				return nil, nil
+			}
+			domain := legocharmclient.NormalizeFQDN(domainData.Fqdn)
+			if _, ok := currentIds[domain]; ok {
+				continue
+			}
+			tflog.Debug(ctx, "found undeclared domain access", map[string]interface{}{"domain": domain, "database_id": p.ID})
+			current = append(current, serviceUserGrantModel{Domain: types.StringValue(domain), AccessLevel: types.StringValue(p.AccessLevel)})
+			currentIds[domain] = int64(p.ID)
+		}
+	}
+
+	return current, currentIds
+}
+
+// findRecreatedGrant returns the database ID of the user's permission on
+// domain from byDomain, keyed by domain ID, or zero if there is none.
+func findRecreatedGrant(ctx context.Context, client legocharmclient.API, domain string, byDomain map[int]int64, diags *diag.Diagnostics) int64 {
+	if len(byDomain) == 0 {
+		return 0
+	}
+	domainData, err := client.GetDomain(ctx, domain)
+	if err != nil {
+		if err != legocharmclient.ErrNotFound {
+			addClientError(diags, "Unable to read domain", err, nil)
+		}
+		return 0
+	}
+	return byDomain[domainData.ID]
+}
+
+// updateGrants changes the grants of the user with ID userID from prior to
+// planned, updating ids in place. Grants are removed first so that access is
+// never broader than configured.
+func updateGrants(ctx context.Context, client legocharmclient.API, userID string, planned, prior []serviceUserGrantModel, ids map[string]int64, diags *diag.Diagnostics) {
+	priorLevels := map[string]string{}
+	for _, grant := range prior {
+		priorLevels[legocharmclient.NormalizeFQDN(grant.Domain.ValueString())] = grant.AccessLevel.ValueString()
+	}
+	wanted := map[string]bool{}
+	for _, grant := range planned {
+		wanted[legocharmclient.NormalizeFQDN(grant.Domain.ValueString())] = true
+	}
+
+	for domain, accessID := range ids {
+		if wanted[domain] {
+			continue
+		}
+		if err := deletePermission(ctx, client, int(accessID)); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove access to %s: %s", domain, err))
+			return
+		}
+		delete(ids, domain)
+	}
+
+	for _, grant := range planned {
+		domain := legocharmclient.NormalizeFQDN(grant.Domain.ValueString())
+		level := grant.AccessLevel.ValueString()
+		accessID, ok := ids[domain]
+		switch {
+		case !ok:
+			access, err := client.CreateDomainAccess(ctx, legocharmclient.DomainUserPermissionCreateData{UserID: userID, Domain: grant.Domain.ValueString(), AccessLevel: level})
+			if err != nil {
+				addClientError(diags, fmt.Sprintf("Unable to grant access to %s", grant.Domain.ValueString()), err, nil)
+				return
+			}
+			ids[domain] = int64(access.ID)
+		case priorLevels[domain] != level:
+			if _, err := client.UpdateDomainAccess(ctx, int(accessID), level); err != nil {
+				addClientError(diags, fmt.Sprintf("Unable to update access to %s", grant.Domain.ValueString()), err, nil)
+				return
+			}
+		}
+	}
+}
+
+// deleteGrants deletes the permissions in ids, ignoring ones that no longer
+// exist.
+func deleteGrants(ctx context.Context, client legocharmclient.API, ids map[string]int64) error {
+	for domain, accessID := range ids {
+		if err := deletePermission(ctx, client, int(accessID)); err != nil {
+			return fmt.Errorf("unable to remove access to %s: %w", domain, err)
+		}
+	}
+	return nil
+}
+
+// grantValues converts grants and their permission IDs into the values of a
+// grants and grant_ids attribute.
+func grantValues(ctx context.Context, grants []serviceUserGrantModel, ids map[string]int64) (types.Set, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if grants == nil {
+		grants = []serviceUserGrantModel{}
+	}
+	set, d := types.SetValueFrom(ctx, grantObjectType, grants)
+	diags.Append(d...)
+
+	grantIds, d := types.MapValueFrom(ctx, types.Int64Type, ids)
+	diags.Append(d...)
+
+	return set, grantIds, diags
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ resource.Resource = &GroupMembershipResource{}
+var _ resource.ResourceWithImportState = &GroupMembershipResource{}
+
+// NewGroupMembershipResource creates a new group membership resource.
+func NewGroupMembershipResource() resource.Resource { return &GroupMembershipResource{} }
+
+// GroupMembershipResource is the resource implementation for the full member
+// list of a LegoCharm group. Users not listed are removed from the group.
+type GroupMembershipResource struct {
+	client legocharmclient.API
+}
+
+// GroupMembershipModel maps Terraform schema to Go types for group membership
+// resources.
+type GroupMembershipModel struct {
+	Group   types.String `tfsdk:"group"`
+	Members types.Set    `tfsdk:"members"`
+	Id      types.String `tfsdk:"id"`
+}
+
+func (r *GroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_membership"
+}
+
+func (r *GroupMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The complete member list of a group. Users added to the group outside Terraform are removed on the next apply. Do not combine with `legocharm_user_group_membership` for the same group.",
+		Attributes: map[string]schema.Attribute{
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Group to manage, as reported in the `groups` attribute of `legocharm_user`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringLengthBetween(1, 0),
+				},
+			},
+			"members": schema.SetAttribute{
+				MarkdownDescription: "Usernames of the group's members. May be empty to keep the group without members.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the group membership resource, equal to `group`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *GroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupMembershipModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	r.reconcile(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.Group
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GroupMembershipModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	members, err := r.groupMembers(ctx, data.Group.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read group members", err, nil)
+		return
+	}
+	set, diags := types.SetValueFrom(ctx, types.StringType, members)
+	resp.Diagnostics.Append(diags...)
+	data.Members = set
+	data.Id = data.Group
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GroupMembershipModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	r.reconcile(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.Group
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GroupMembershipModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	data.Members = types.SetValueMust(types.StringType, nil)
+	r.reconcile(ctx, data, &resp.Diagnostics)
+}
+
+// reconcile makes the members of data.Group exactly data.Members. Removals
+// happen first so that access is never broader than configured.
+func (r *GroupMembershipResource) reconcile(ctx context.Context, data GroupMembershipModel, diags *diag.Diagnostics) {
+	var wanted []string
+	diags.Append(data.Members.ElementsAs(ctx, &wanted, false)...)
+	if diags.HasError() {
+		return
+	}
+	group := data.Group.ValueString()
+
+	current, err := r.groupMembers(ctx, group)
+	if err != nil {
+		addClientError(diags, "Unable to read group members", err, nil)
+		return
+	}
+
+	isWanted := map[string]bool{}
+	for _, username := range wanted {
+		isWanted[username] = true
+	}
+	isMember := map[string]bool{}
+	for _, username := range current {
+		isMember[username] = true
+		if isWanted[username] {
+			continue
+		}
+		tflog.Debug(ctx, "removing user from group", map[string]interface{}{"group": group, "username": username})
+		if err := r.client.RemoveUserFromGroup(ctx, username, group); err != nil && err != legocharmclient.ErrNotFound {
+			addClientError(diags, fmt.Sprintf("Unable to remove %s from group %s", username, group), err, nil)
+			return
+		}
+	}
+
+	for _, username := range wanted {
+		if isMember[username] {
+			continue
+		}
+		tflog.Debug(ctx, "adding user to group", map[string]interface{}{"group": group, "username": username})
+		if err := r.client.AddUserToGroup(ctx, username, group); err != nil {
+			if err == legocharmclient.ErrNotFound {
+				diags.AddAttributeError(path.Root("members"), "User Not Found", fmt.Sprintf("No user with username %q exists.", username))
+				return
+			}
+			addClientError(diags, fmt.Sprintf("Unable to add %s to group %s", username, group), err, groupMembershipAPIFields)
+			return
+		}
+	}
+}
+
+// groupMembers returns the sorted usernames of the members of group.
+func (r *GroupMembershipResource) groupMembers(ctx context.Context, group string) ([]string, error) {
+	users, err := r.client.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []string{}
+	for _, user := range users {
+		for _, g := range user.Groups {
+			if g == group {
+				members = append(members, user.Username)
+				break
+			}
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// ImportState implements resource import for GroupMembershipResource.
+func (r *GroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// id is the group
+	if req.ID == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", "Import ID must be the group")
+		return
+	}
+
+	data := GroupMembershipModel{
+		Group:   types.StringValue(req.ID),
+		Members: types.SetNull(types.StringType),
+		Id:      types.StringValue(req.ID),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
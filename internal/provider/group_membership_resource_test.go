@@ -0,0 +1,150 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestGroupMembershipResource_Metadata(t *testing.T) {
+	r := &GroupMembershipResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+	require.Equal(t, "legocharm_group_membership", resp.TypeName)
+}
+
+func TestGroupMembershipResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "bob"
+	api.users[1006] = "carol"
+	api.groups[1006] = []string{"acme", "ops"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &GroupMembershipResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// Creating the membership adds alice and bob and removes carol.
+	data := GroupMembershipModel{
+		Group:   types.StringValue("acme"),
+		Members: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("alice"), types.StringValue("bob")}),
+		Id:      types.StringUnknown(),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	require.Equal(t, []string{"acme"}, api.groups[1004])
+	require.Equal(t, []string{"acme"}, api.groups[1005])
+	require.Equal(t, []string{"ops"}, api.groups[1006])
+
+	// A member added outside Terraform shows up as drift.
+	api.groups[1006] = append(api.groups[1006], "acme")
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	var refreshed GroupMembershipModel
+	require.False(t, readResp.State.Get(ctx, &refreshed).HasError())
+	require.Len(t, refreshed.Members.Elements(), 3)
+
+	// Updating to bob alone removes alice and carol.
+	data.Members = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("bob")})
+	data.Id = types.StringValue("acme")
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Empty(t, api.groups[1004])
+	require.Equal(t, []string{"acme"}, api.groups[1005])
+	require.Equal(t, []string{"ops"}, api.groups[1006])
+
+	// Unknown users are reported against members.
+	data.Members = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("mallory")})
+	require.False(t, plan.Set(ctx, &data).HasError())
+	badResp := &resource.UpdateResponse{State: updateResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: updateResp.State}, badResp)
+	require.True(t, badResp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", badResp.Diagnostics.Errors()[0].Summary())
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Empty(t, api.groups[1005])
+	require.Equal(t, []string{"ops"}, api.groups[1006])
+}
+
+func TestAccGroupMembershipResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	group := testAccGroup(t)
+	_, alice, _ := testAccUser(t, env)
+	_, bob, _ := testAccUser(t, env)
+	ctx := context.Background()
+
+	config := func(username string) string {
+		return fmt.Sprintf(`
+resource "legocharm_group_membership" "test" {
+  group   = %q
+  members = [%q]
+}
+`, group, username)
+	}
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config(alice),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					testAccCheckGroupMember(env, alice, group, true),
+					testAccCheckGroupMember(env, bob, group, false),
+				),
+			},
+			{
+				Config: config(bob),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					testAccCheckGroupMember(env, alice, group, false),
+					testAccCheckGroupMember(env, bob, group, true),
+				),
+			},
+			{
+				ResourceName:      "legocharm_group_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Members added outside Terraform are detected and removed.
+			{
+				PreConfig: func() {
+					require.NoError(t, env.API.AddUserToGroup(ctx, alice, group))
+				},
+				Config:             config(bob),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(bob),
+				Check:  testAccCheckGroupMember(env, alice, group, false),
+			},
+		},
+		CheckDestroy: testAccCheckGroupMember(env, bob, group, false),
+	})
+}
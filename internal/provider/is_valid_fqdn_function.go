@@ -0,0 +1,47 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &IsValidFQDNFunction{}
+
+// NewIsValidFQDNFunction creates a new is_valid_fqdn function.
+func NewIsValidFQDNFunction() function.Function { return &IsValidFQDNFunction{} }
+
+// IsValidFQDNFunction is the function implementation for
+// provider::legocharm::is_valid_fqdn.
+type IsValidFQDNFunction struct{}
+
+func (f *IsValidFQDNFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_fqdn"
+}
+
+func (f *IsValidFQDNFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check whether a string is an acceptable domain name",
+		MarkdownDescription: "Returns whether a string is a domain name the resources accept, such as `example.com` or the wildcard `*.example.com`, applying exactly the same rules. Case and a trailing dot are ignored. Intended for `validation` blocks of input variables.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "fqdn",
+				MarkdownDescription: "Domain name to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *IsValidFQDNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fqdn string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fqdn))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, fqdnProblem(fqdn) == ""))
+}
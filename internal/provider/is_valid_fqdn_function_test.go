@@ -0,0 +1,43 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidFQDNFunction_Run(t *testing.T) {
+	ctx := context.Background()
+	f := &IsValidFQDNFunction{}
+
+	tests := map[string]bool{
+		"example.com":          true,
+		"Staging.Example.com.": true,
+		"*.example.com":        true,
+		"xn--bcher-kva.com":    true,
+		"":                     false,
+		"com":                  false,
+		"*.com":                false,
+		"web.*.example.com":    false,
+		"w*.example.com":       false,
+		"foo_bar.example.com":  false,
+		"a..example.com":       false,
+		"-web.example.com":     false,
+	}
+	for input, want := range tests {
+		// The function must agree with the fqdn validator the resources use.
+		require.Equal(t, want, fqdnProblem(input) == "", input)
+
+		resp := &function.RunResponse{Result: function.NewResultData(types.BoolUnknown())}
+		f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(input)})}, resp)
+		require.Nil(t, resp.Error, input)
+		require.Equal(t, types.BoolValue(want), resp.Result.Value(), input)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/internal/kubeservice"
+)
+
+// resolveKubeService resolves the kubernetes attribute, replaced in tests.
+var resolveKubeService = kubeservice.Resolve
+
+// schemeRegexp matches the values of the scheme attribute of kubernetes.
+var schemeRegexp = regexp.MustCompile(`^https?$`)
+
+// kubernetesSchema returns the schema of the kubernetes attribute of the
+// provider.
+func kubernetesSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Description: "Look up the address of the httprequest-lego-provider charm deployed on Kubernetes from its Service, instead of address, so that no external ingress URL is required. " +
+			"The address of the Service's load balancer is used or, when Terraform runs in a pod of the cluster, its DNS name in the cluster. " +
+			"The Service is read with the credentials of a kubeconfig file (bearer tokens, client certificates or basic authentication; exec and auth-provider plugins are not supported) or, without one, of the pod's service account.",
+		Attributes: map[string]schema.Attribute{
+			"service": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the Service. With Juju, the name of the application, such as \"httprequest-lego-provider\".",
+			},
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace of the Service. With Juju, the name of the model. Defaults to the namespace of the kubeconfig context or of the service account, or else \"default\".",
+			},
+			"port": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name or number of the port of the Service. Defaults to its first port.",
+			},
+			"scheme": schema.StringAttribute{
+				Optional:    true,
+				Description: "The scheme the Service is reached with, \"http\" or \"https\". Defaults to \"http\".",
+				Validators: []validator.String{
+					stringMatches(schemeRegexp, "value must be http or https"),
+				},
+			},
+			"config_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "The kubeconfig file. Defaults to the first file of the KUBECONFIG environment variable, or else ~/.kube/config, or else, if there is none, the service account of the pod Terraform runs in.",
+			},
+			"config_context": schema.StringAttribute{
+				Optional:    true,
+				Description: "The context of the kubeconfig file. Defaults to its current context.",
+			},
+		},
+	}
+}
+
+// kubernetesAttributeTypes returns the attribute types of the kubernetes
+// object.
+func kubernetesAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"service":        types.StringType,
+		"namespace":      types.StringType,
+		"port":           types.StringType,
+		"scheme":         types.StringType,
+		"config_path":    types.StringType,
+		"config_context": types.StringType,
+	}
+}
+
+// kubernetesConfig returns the Service selected by the kubernetes object.
+func kubernetesConfig(kubernetes types.Object) kubeservice.Config {
+	attrs := kubernetes.Attributes()
+	value := func(name string) string {
+		v, _ := attrs[name].(types.String)
+		return v.ValueString()
+	}
+	return kubeservice.Config{
+		ConfigPath: value("config_path"),
+		Context:    value("config_context"),
+		Namespace:  value("namespace"),
+		Service:    value("service"),
+		Port:       value("port"),
+		Scheme:     value("scheme"),
+	}
+}
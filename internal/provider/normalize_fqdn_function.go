@@ -0,0 +1,81 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/net/idna"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ function.Function = &NormalizeFQDNFunction{}
+
+// NewNormalizeFQDNFunction creates a new normalize_fqdn function.
+func NewNormalizeFQDNFunction() function.Function { return &NormalizeFQDNFunction{} }
+
+// NormalizeFQDNFunction is the function implementation for
+// provider::legocharm::normalize_fqdn.
+type NormalizeFQDNFunction struct{}
+
+func (f *NormalizeFQDNFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_fqdn"
+}
+
+func (f *NormalizeFQDNFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Canonicalize a domain name",
+		MarkdownDescription: "Returns the canonical form of a domain name: lower case, without surrounding whitespace or a trailing dot, and with internationalized labels encoded as IDNA A-labels such as `xn--bcher-kva`. A leading `*` wildcard label is kept. Names the resources would reject even so, such as `example.com..`, are an error. The resources do not encode internationalized labels themselves and reject them, so pass such names through this function first.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "fqdn",
+				MarkdownDescription: "Domain name to canonicalize, such as `Bücher.Example.com.`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeFQDNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fqdn string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fqdn))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeIDNA(fqdn)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalized))
+}
+
+// normalizeIDNA returns fqdn normalized like legocharmclient.NormalizeFQDN,
+// with every label except a wildcard converted to its IDNA ASCII form. Names
+// the resources would reject once so converted, such as ones with empty
+// labels, are an error.
+func normalizeIDNA(fqdn string) (string, error) {
+	labels := strings.Split(strings.TrimSpace(fqdn), ".")
+	for i, label := range labels {
+		if label == "*" || label == "" {
+			continue
+		}
+		ascii, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			return "", fmt.Errorf("invalid domain name %q: %s", fqdn, err)
+		}
+		labels[i] = ascii
+	}
+	ascii := strings.Join(labels, ".")
+	if problem := fqdnProblem(ascii); problem != "" {
+		return "", fmt.Errorf("invalid domain name %q: %s", fqdn, problem)
+	}
+	return legocharmclient.NormalizeFQDN(ascii), nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeFQDNFunction_Run(t *testing.T) {
+	ctx := context.Background()
+	f := &NormalizeFQDNFunction{}
+
+	tests := map[string]string{
+		"Staging.Example.com.": "staging.example.com",
+		" example.com ":        "example.com",
+		"*.Example.com":        "*.example.com",
+		"Bücher.example.com":   "xn--bcher-kva.example.com",
+		"*.ÉXAMPLE.com.":       "*.xn--xample-9ua.com",
+		"xn--bcher-kva.com":    "xn--bcher-kva.com",
+	}
+	for input, want := range tests {
+		resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(input)})}, resp)
+		require.Nil(t, resp.Error, input)
+		require.Equal(t, types.StringValue(want), resp.Result.Value(), input)
+	}
+
+	// Whatever is returned passes the resources' validation.
+	for _, input := range []string{"foo_bar.example.com", "example.com..", "example..com", "com", "example.*.com", ""} {
+		resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(input)})}, resp)
+		require.NotNil(t, resp.Error, input)
+		require.NotNil(t, resp.Error.FunctionArgument, input)
+		require.Equal(t, int64(0), *resp.Error.FunctionArgument, input)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ function.Function = &ParseAccessIDFunction{}
+
+// NewParseAccessIDFunction creates a new parse_access_id function.
+func NewParseAccessIDFunction() function.Function { return &ParseAccessIDFunction{} }
+
+// ParseAccessIDFunction is the function implementation for
+// provider::legocharm::parse_access_id.
+type ParseAccessIDFunction struct{}
+
+// parsedAccessIDModel maps the object returned by parse_access_id.
+type parsedAccessIDModel struct {
+	UserId      types.String `tfsdk:"user_id"`
+	Username    types.String `tfsdk:"username"`
+	Domain      types.String `tfsdk:"domain"`
+	AccessLevel types.String `tfsdk:"access_level"`
+}
+
+// parsedAccessIDAttrTypes are the attribute types of the object returned by
+// parse_access_id.
+var parsedAccessIDAttrTypes = map[string]attr.Type{
+	"user_id":      types.StringType,
+	"username":     types.StringType,
+	"domain":       types.StringType,
+	"access_level": types.StringType,
+}
+
+func (f *ParseAccessIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_access_id"
+}
+
+func (f *ParseAccessIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parse a domain access ID",
+		MarkdownDescription: "Parses an ID in format `user:domain:access_level`, as used by `legocharm_user_domain_access` for `id` and import, into an object with the attributes `user_id`, `username`, `domain` and `access_level`. The user part is returned as `user_id` if it is numeric and as `username` otherwise, with the other attribute null. The domain is normalized.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "ID to parse, such as `1004:staging.example.com:subdomain` or `ci-bot:staging.example.com:domain`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedAccessIDAttrTypes,
+		},
+	}
+}
+
+func (f *ParseAccessIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	user, domain, accessLevel, ok := splitDomainAccessID(id)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("ID %q must be in the format 'user:domain:access_level', where user is a user ID or username", id))
+		return
+	}
+	if problem := fqdnProblem(domain); problem != "" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("ID %q has an invalid domain: %s", id, problem))
+		return
+	}
+	if accessLevel != "domain" && accessLevel != "subdomain" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("ID %q has access level %q, expected 'domain' or 'subdomain'", id, accessLevel))
+		return
+	}
+
+	parsed := parsedAccessIDModel{
+		UserId:      types.StringNull(),
+		Username:    types.StringNull(),
+		Domain:      types.StringValue(legocharmclient.NormalizeFQDN(domain)),
+		AccessLevel: types.StringValue(accessLevel),
+	}
+	if userIDRegexp.MatchString(user) {
+		parsed.UserId = types.StringValue(user)
+	} else {
+		parsed.Username = types.StringValue(user)
+	}
+
+	result, diags := types.ObjectValueFrom(ctx, parsedAccessIDAttrTypes, parsed)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccessIDFunction_Run(t *testing.T) {
+	ctx := context.Background()
+	f := &ParseAccessIDFunction{}
+
+	run := func(id string) *function.RunResponse {
+		resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(parsedAccessIDAttrTypes))}
+		f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(id)})}, resp)
+		return resp
+	}
+	parse := func(id string) parsedAccessIDModel {
+		resp := run(id)
+		require.Nil(t, resp.Error, id)
+		var parsed parsedAccessIDModel
+		require.False(t, resp.Result.Value().(types.Object).As(ctx, &parsed, basetypes.ObjectAsOptions{}).HasError())
+		return parsed
+	}
+
+	require.Equal(t, parsedAccessIDModel{
+		UserId:      types.StringValue("1004"),
+		Username:    types.StringNull(),
+		Domain:      types.StringValue("staging.example.com"),
+		AccessLevel: types.StringValue("subdomain"),
+	}, parse("1004:Staging.Example.com.:subdomain"))
+	require.Equal(t, parsedAccessIDModel{
+		UserId:      types.StringNull(),
+		Username:    types.StringValue("ci-bot"),
+		Domain:      types.StringValue("*.example.com"),
+		AccessLevel: types.StringValue("domain"),
+	}, parse("ci-bot:*.example.com:domain"))
+
+	for _, id := range []string{"", "1004", "1004:example.com", ":example.com:domain", "1004::domain", "1004:example.com:owner", "1004:not a domain:domain", "1004:example.com:domain:extra"} {
+		resp := run(id)
+		require.NotNil(t, resp.Error, id)
+		require.Equal(t, int64(0), *resp.Error.FunctionArgument, id)
+	}
+}
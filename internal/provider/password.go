@@ -0,0 +1,135 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	passwordLower   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits  = "0123456789"
+	passwordSpecial = "!#$%&*()-_=+[]{}<>:?"
+
+	// defaultPasswordLength is the length of generated passwords when the
+	// user does not configure one.
+	defaultPasswordLength = 32
+	// minPasswordLength is the shortest password the provider will generate.
+	minPasswordLength = 8
+
+	// passwordHashAlgorithm prefixes stored password hashes, using the same
+	// "algorithm$iterations$salt$hash" layout as Django.
+	passwordHashAlgorithm = "pbkdf2_sha256"
+	// passwordHashIterations follows the OWASP recommendation for
+	// PBKDF2-HMAC-SHA256.
+	passwordHashIterations = 600000
+	passwordHashSaltBytes  = 16
+	passwordHashKeyBytes   = 32
+)
+
+// generatePassword returns a random password of the given length made of
+// letters and digits, plus special characters when special is true. At least
+// one character from each enabled class is always included.
+func generatePassword(length int, special bool) (string, error) {
+	classes := []string{passwordLower, passwordUpper, passwordDigits}
+	if special {
+		classes = append(classes, passwordSpecial)
+	}
+	return generateFromClasses(length, classes)
+}
+
+// generateFromClasses returns a random string of the given length drawn from
+// the union of classes, with at least one character from each class.
+func generateFromClasses(length int, classes []string) (string, error) {
+	if length < len(classes) {
+		return "", fmt.Errorf("password length must be at least %d", len(classes))
+	}
+
+	var charset string
+	for _, class := range classes {
+		charset += class
+	}
+
+	out := make([]byte, length)
+	for i := range out {
+		// Seed the first positions with one character from each class so the
+		// password satisfies common complexity rules; the shuffle below
+		// removes the positional bias.
+		set := charset
+		if i < len(classes) {
+			set = classes[i]
+		}
+		c, err := randomChar(set)
+		if err != nil {
+			return "", err
+		}
+		out[i] = c
+	}
+
+	for i := len(out) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate password: %w", err)
+		}
+		out[i], out[j.Int64()] = out[j.Int64()], out[i]
+	}
+
+	return string(out), nil
+}
+
+// randomChar returns a uniformly random character from set.
+func randomChar(set string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate password: %w", err)
+	}
+	return set[n.Int64()], nil
+}
+
+// hashPassword returns a salted PBKDF2 hash of password suitable for storing
+// in state in place of the plaintext.
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, passwordHashSaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return derivePasswordHash(password, base64.RawStdEncoding.EncodeToString(salt), passwordHashIterations)
+}
+
+// passwordMatchesHash reports whether password produces the given hash when
+// derived with the hash's own salt and iteration count. Malformed hashes never
+// match.
+func passwordMatchesHash(password, hash string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passwordHashAlgorithm {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+
+	derived, err := derivePasswordHash(password, parts[2], iterations)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(derived), []byte(hash)) == 1
+}
+
+func derivePasswordHash(password, salt string, iterations int) (string, error) {
+	key, err := pbkdf2.Key(sha256.New, password, []byte(salt), iterations, passwordHashKeyBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return fmt.Sprintf("%s$%d$%s$%s", passwordHashAlgorithm, iterations, salt, base64.RawStdEncoding.EncodeToString(key)), nil
+}
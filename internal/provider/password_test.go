@@ -0,0 +1,67 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePassword_LengthAndClasses(t *testing.T) {
+	password, err := generatePassword(defaultPasswordLength, true)
+	require.NoError(t, err)
+	require.Len(t, password, defaultPasswordLength)
+
+	for _, class := range []string{passwordLower, passwordUpper, passwordDigits, passwordSpecial} {
+		require.True(t, strings.ContainsAny(password, class), "password %q should contain one of %q", password, class)
+	}
+}
+
+func TestGeneratePassword_NoSpecial(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		password, err := generatePassword(minPasswordLength, false)
+		require.NoError(t, err)
+		require.Len(t, password, minPasswordLength)
+		require.False(t, strings.ContainsAny(password, passwordSpecial), "password %q should not contain special characters", password)
+	}
+}
+
+func TestGeneratePassword_Unique(t *testing.T) {
+	a, err := generatePassword(defaultPasswordLength, true)
+	require.NoError(t, err)
+	b, err := generatePassword(defaultPasswordLength, true)
+	require.NoError(t, err)
+	require.NotEqual(t, a, b)
+}
+
+func TestGeneratePassword_TooShort(t *testing.T) {
+	_, err := generatePassword(2, true)
+	require.Error(t, err)
+}
+
+func TestHashPassword_RoundTrip(t *testing.T) {
+	hash, err := hashPassword("correct horse battery staple")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, passwordHashAlgorithm+"$"))
+	require.NotContains(t, hash, "correct horse")
+
+	require.True(t, passwordMatchesHash("correct horse battery staple", hash))
+	require.False(t, passwordMatchesHash("Tr0ub4dor&3", hash))
+}
+
+func TestHashPassword_Salted(t *testing.T) {
+	a, err := hashPassword("secret")
+	require.NoError(t, err)
+	b, err := hashPassword("secret")
+	require.NoError(t, err)
+	require.NotEqual(t, a, b)
+}
+
+func TestPasswordMatchesHash_Malformed(t *testing.T) {
+	for _, hash := range []string{"", "secret", "md5$1$salt$hash", "pbkdf2_sha256$abc$salt$hash", "pbkdf2_sha256$0$salt$hash"} {
+		require.False(t, passwordMatchesHash("secret", hash), "hash %q should not match", hash)
+	}
+}
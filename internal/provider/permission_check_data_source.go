@@ -0,0 +1,164 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ datasource.DataSource = &PermissionCheckDataSource{}
+
+// NewPermissionCheckDataSource creates a new permission check data source.
+func NewPermissionCheckDataSource() datasource.DataSource { return &PermissionCheckDataSource{} }
+
+// PermissionCheckDataSource is the data source implementation for checking
+// whether a user may solve challenges for a name.
+type PermissionCheckDataSource struct {
+	client legocharmclient.API
+}
+
+// PermissionCheckDataSourceModel maps Terraform schema to Go types for the
+// permission check data source.
+type PermissionCheckDataSourceModel struct {
+	Username types.String `tfsdk:"username"`
+	Fqdn     types.String `tfsdk:"fqdn"`
+	Allowed  types.Bool   `tfsdk:"allowed"`
+	Grant    types.Object `tfsdk:"grant"`
+}
+
+// permissionCheckGrantModel maps the grant attribute.
+type permissionCheckGrantModel struct {
+	Domain      types.String `tfsdk:"domain"`
+	AccessLevel types.String `tfsdk:"access_level"`
+	DatabaseID  types.Int64  `tfsdk:"database_id"`
+}
+
+// permissionCheckGrantAttrTypes are the attribute types of the grant
+// attribute.
+var permissionCheckGrantAttrTypes = map[string]attr.Type{
+	"domain":       types.StringType,
+	"access_level": types.StringType,
+	"database_id":  types.Int64Type,
+}
+
+func (d *PermissionCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission_check"
+}
+
+func (d *PermissionCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks whether a user has effective access to a name, either through a permission on the name itself or through a `subdomain` permission on a parent domain. Intended for policy assertions in `check` blocks.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the user to check. Reading fails if the user does not exist.",
+				Required:            true,
+			},
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "Name to check, such as `web.example.com` or the wildcard `*.example.com`. Compared case-insensitively and ignoring a trailing dot.",
+				Required:            true,
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"allowed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user has access to `fqdn`.",
+				Computed:            true,
+			},
+			"grant": schema.SingleNestedAttribute{
+				MarkdownDescription: "The permission giving access, or null if `allowed` is false. If several permissions apply, the one on the most specific domain is returned.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"domain": schema.StringAttribute{
+						MarkdownDescription: "FQDN of the domain the permission is on, normalized.",
+						Computed:            true,
+					},
+					"access_level": schema.StringAttribute{
+						MarkdownDescription: "Access level of the permission: 'domain' or 'subdomain'.",
+						Computed:            true,
+					},
+					"database_id": schema.Int64Attribute{
+						MarkdownDescription: "The server-side ID of the permission.",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PermissionCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PermissionCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PermissionCheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this data source")
+		return
+	}
+
+	// An unknown user would otherwise silently have no access.
+	username := data.Username.ValueString()
+	if _, err := d.client.GetUserByUsername(ctx, username); err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("username"), "User Not Found", fmt.Sprintf("No user with username %q exists.", username))
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to look up user", err, nil)
+		return
+	}
+
+	grants, err := userGrants(ctx, d.client, username)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read domain access", err, nil)
+		return
+	}
+
+	grant := coveringGrant(grants, legocharmclient.NormalizeFQDN(data.Fqdn.ValueString()))
+	data.Allowed = types.BoolValue(grant != nil)
+	data.Grant = types.ObjectNull(permissionCheckGrantAttrTypes)
+	if grant != nil {
+		grantValue, diags := types.ObjectValueFrom(ctx, permissionCheckGrantAttrTypes, permissionCheckGrantModel{
+			Domain:      types.StringValue(grant.domain),
+			AccessLevel: types.StringValue(grant.accessLevel),
+			DatabaseID:  types.Int64Value(int64(grant.id)),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Grant = grantValue
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
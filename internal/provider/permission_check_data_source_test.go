@@ -0,0 +1,155 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestPermissionCheckDataSource_Read(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.domains[3] = "example.com"
+	api.domains[4] = "*.prod.example.com"
+	api.domains[5] = "db.prod.example.com"
+	api.permissions[7] = legocharmclient.DomainUserPermissionData{ID: 7, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	api.permissions[8] = legocharmclient.DomainUserPermissionData{ID: 8, UserID: 1004, Domain: 4, AccessLevel: "domain"}
+	api.permissions[9] = legocharmclient.DomainUserPermissionData{ID: 9, UserID: 1004, Domain: 5, AccessLevel: "domain"}
+	api.users[1005] = "bob"
+	api.permissions[10] = legocharmclient.DomainUserPermissionData{ID: 10, UserID: 1005, Domain: 3, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	d := &PermissionCheckDataSource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	read := func(username, fqdn string) *datasource.ReadResponse {
+		data := PermissionCheckDataSourceModel{Username: types.StringValue(username), Fqdn: types.StringValue(fqdn), Allowed: types.BoolNull(), Grant: types.ObjectNull(permissionCheckGrantAttrTypes)}
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &data).HasError())
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	check := func(username, fqdn string) *permissionCheckGrantModel {
+		resp := read(username, fqdn)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var result PermissionCheckDataSourceModel
+		require.False(t, resp.State.Get(ctx, &result).HasError())
+		require.Equal(t, !result.Grant.IsNull(), result.Allowed.ValueBool())
+		if result.Grant.IsNull() {
+			return nil
+		}
+		var grant permissionCheckGrantModel
+		require.False(t, result.Grant.As(ctx, &grant, basetypes.ObjectAsOptions{}).HasError())
+		return &grant
+	}
+
+	// The subdomain permission covers the domain and every name below it.
+	grant := check("alice", "Staging.example.com.")
+	require.Equal(t, &permissionCheckGrantModel{Domain: types.StringValue("staging.example.com"), AccessLevel: types.StringValue("subdomain"), DatabaseID: types.Int64Value(7)}, grant)
+	require.Equal(t, int64(7), check("alice", "a.b.staging.example.com").DatabaseID.ValueInt64())
+	require.Equal(t, int64(7), check("alice", "*.staging.example.com").DatabaseID.ValueInt64())
+
+	// A domain permission on a wildcard only covers the wildcard itself.
+	require.Equal(t, int64(8), check("alice", "*.prod.example.com").DatabaseID.ValueInt64())
+	require.Nil(t, check("alice", "web.prod.example.com"))
+	require.Equal(t, int64(9), check("alice", "db.prod.example.com").DatabaseID.ValueInt64())
+
+	// A domain permission does not extend to subdomains.
+	require.Equal(t, int64(10), check("bob", "example.com").DatabaseID.ValueInt64())
+	require.Nil(t, check("bob", "staging.example.com"))
+	require.Nil(t, check("alice", "example.com"))
+
+	resp := read("missing", "example.com")
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", resp.Diagnostics[0].Summary())
+}
+
+func TestCoveringGrant(t *testing.T) {
+	grants := []userGrant{
+		{id: 1, domain: "*.example.com", accessLevel: "subdomain"},
+		{id: 2, domain: "example.com", accessLevel: "domain"},
+		{id: 3, domain: "prod.example.com", accessLevel: "subdomain"},
+	}
+	tests := map[string]int{
+		"example.com":          2,
+		"web.example.com":      1,
+		"*.example.com":        1,
+		"prod.example.com":     3,
+		"db.prod.example.com":  3,
+		"example.org":          0,
+		"notexample.com":       0,
+		"web.notexample.com":   0,
+		"*.prod.example.com":   3,
+		"a.b.prod.example.com": 3,
+	}
+	for fqdn, want := range tests {
+		got := coveringGrant(grants, fqdn)
+		if want == 0 {
+			require.Nil(t, got, fqdn)
+			continue
+		}
+		require.NotNil(t, got, fqdn)
+		require.Equal(t, want, got.id, fqdn)
+	}
+}
+
+func TestAccPermissionCheckDataSource(t *testing.T) {
+	env := testAccPreCheck(t)
+	userID, username, _ := testAccUser(t, env)
+	domain := testAccDomain(t, env)
+	access := testAccGrant(t, env, userID, domain.Fqdn, "subdomain")
+
+	config := func(fqdn string) string {
+		return fmt.Sprintf(`
+data "legocharm_permission_check" "test" {
+  username = %q
+  fqdn     = %q
+}
+`, username, fqdn)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("www." + domain.Fqdn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.legocharm_permission_check.test", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.legocharm_permission_check.test", "grant.domain", domain.Fqdn),
+					resource.TestCheckResourceAttr("data.legocharm_permission_check.test", "grant.access_level", "subdomain"),
+					resource.TestCheckResourceAttr("data.legocharm_permission_check.test", "grant.database_id", strconv.Itoa(access.ID)),
+				),
+			},
+			{
+				Config: config("other-" + domain.Fqdn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.legocharm_permission_check.test", "allowed", "false"),
+					resource.TestCheckNoResourceAttr("data.legocharm_permission_check.test", "grant.domain"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ planmodifier.String = useStateForUnconfiguredModifier{}
+
+// useStateForUnconfiguredModifier copies the prior state value into the plan
+// when the attribute is not set in configuration. Unlike
+// stringplanmodifier.UseStateForUnknown it also copies null state values, so
+// an optional+computed attribute that was deliberately left null (for example
+// a password supplied through a write-only argument) does not show up as
+// unknown on every update.
+type useStateForUnconfiguredModifier struct{}
+
+// useStateForUnconfigured returns a plan modifier that keeps the prior state
+// value for an optional+computed string attribute omitted from configuration.
+func useStateForUnconfigured() planmodifier.String {
+	return useStateForUnconfiguredModifier{}
+}
+
+func (m useStateForUnconfiguredModifier) Description(_ context.Context) string {
+	return "Once set, the value of this attribute in state will not change unless it is configured."
+}
+
+func (m useStateForUnconfiguredModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnconfiguredModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Do nothing on resource creation or destruction.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+// requiresReplaceIfFQDNChanged returns a plan modifier that requires the
+// resource to be replaced only when the normalized FQDN changes, so that
+// changing the case or adding a trailing dot updates state in place.
+func requiresReplaceIfFQDNChanged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = legocharmclient.NormalizeFQDN(req.StateValue.ValueString()) != legocharmclient.NormalizeFQDN(req.PlanValue.ValueString())
+		},
+		"Changing the domain, other than its case or trailing dot, requires replacement.",
+		"Changing the domain, other than its case or trailing dot, requires replacement.",
+	)
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiresReplaceIfFQDNChanged(t *testing.T) {
+	tests := map[string]struct {
+		state, plan string
+		want        bool
+	}{
+		"unchanged":    {state: "example.com", plan: "example.com"},
+		"case":         {state: "example.com", plan: "Example.COM"},
+		"trailing dot": {state: "example.com", plan: "example.com."},
+		"different":    {state: "example.com", plan: "example.org", want: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// A non-null raw state and plan so the modifier does not treat
+			// the request as a create or destroy.
+			raw := tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+			req := planmodifier.StringRequest{
+				ConfigValue: types.StringValue(tt.plan),
+				StateValue:  types.StringValue(tt.state),
+				PlanValue:   types.StringValue(tt.plan),
+				State:       tfsdk.State{Raw: raw},
+				Plan:        tfsdk.Plan{Raw: raw},
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			requiresReplaceIfFQDNChanged().PlanModifyString(context.Background(), req, resp)
+			require.Equal(t, tt.want, resp.RequiresReplace)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/clock"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// clientClock returns the clock of client, which times its polling, timeouts
+// and measurements along with its requests. Clients of tests have a
+// clock.Fake; others, such as mocks, have the real clock.
+func clientClock(client legocharmclient.API) clock.Clock {
+	if c, ok := client.(*legocharmclient.Client); ok && c.Clock != nil {
+		return c.Clock
+	}
+	return clock.Real{}
+}
+
+// Backoff bounds used when polling the API for eventually-consistent reads.
+const (
+	pollInitialInterval = 250 * time.Millisecond
+	pollMaxInterval     = 5 * time.Second
+)
+
+// poll calls check until it reports done or returns an error, sleeping on
+// clk with exponential backoff between attempts. It gives up when ctx is done
+// and returns the last error reported by check, if any, alongside the context
+// error.
+func poll(ctx context.Context, clk clock.Clock, check func() (done bool, err error)) error {
+	interval := pollInitialInterval
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-clk.After(interval):
+		}
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("gave up waiting: %w", err)
+		}
+
+		interval *= 2
+		if interval > pollMaxInterval {
+			interval = pollMaxInterval
+		}
+	}
+}
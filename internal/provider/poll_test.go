@@ -0,0 +1,81 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/clock"
+)
+
+// newFakeClock returns a fake clock for the polling of a test.
+func newFakeClock() *clock.Fake {
+	return clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestPoll_SucceedsAfterRetries(t *testing.T) {
+	fake := newFakeClock()
+	attempts := 0
+	err := poll(context.Background(), fake, func() (bool, error) {
+		attempts++
+		return attempts == 3, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, []time.Duration{250 * time.Millisecond, 500 * time.Millisecond}, fake.Slept())
+}
+
+func TestPoll_StopsOnError(t *testing.T) {
+	fake := newFakeClock()
+	boom := errors.New("boom")
+	attempts := 0
+	err := poll(context.Background(), fake, func() (bool, error) {
+		attempts++
+		return false, boom
+	})
+	require.ErrorIs(t, err, boom)
+	require.Equal(t, 1, attempts)
+	require.Empty(t, fake.Slept())
+}
+
+func TestPoll_GivesUpAtDeadline(t *testing.T) {
+	fake := newFakeClock()
+	ctx, cancel := fake.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	attempts := 0
+	start := fake.Now()
+	err := poll(ctx, fake, func() (bool, error) {
+		attempts++
+		return false, nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	// The interval doubles up to the cap: 0.25+0.5+1+2+4+5+5+5+5+5 = 32.75s.
+	require.Equal(t, 10, attempts)
+	require.Equal(t, pollMaxInterval, fake.Slept()[len(fake.Slept())-1])
+	require.Equal(t, 32750*time.Millisecond, fake.Now().Sub(start))
+}
+
+func TestPoll_GivesUpWhenCanceled(t *testing.T) {
+	fake := newFakeClock()
+	ctx, cancel := context.WithCancel(context.Background())
+	err := poll(ctx, fake, func() (bool, error) {
+		cancel()
+		return false, nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPoll_RealClock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := poll(ctx, clock.Real{}, func() (bool, error) { return false, nil })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -5,28 +5,49 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
-	"terraform-provider-legocharm/internal/legocharmclient"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/clock"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &legocharmProvider{}
+	_ provider.Provider                       = &legocharmProvider{}
+	_ provider.ProviderWithListResources      = &legocharmProvider{}
+	_ provider.ProviderWithFunctions          = &legocharmProvider{}
+	_ provider.ProviderWithEphemeralResources = &legocharmProvider{}
+	_ provider.ProviderWithActions            = &legocharmProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &legocharmProvider{
-			version: version,
+			version:      version,
+			calls:        &legocharmclient.CallStats{},
+			deprecations: &legocharmclient.DeprecationNotices{},
+			clock:        clock.Real{},
 		}
 	}
 }
@@ -39,14 +60,62 @@ type legocharmProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// calls counts the API calls of every client the provider configures.
+	// Terraform runs several provider instances per operation, for validate,
+	// plan and apply, so it holds the calls of one of them.
+	calls *legocharmclient.CallStats
+	// deprecations collects the deprecations announced by the API to every
+	// client the provider configures.
+	deprecations *legocharmclient.DeprecationNotices
+	// clock times the requests, polling and timeouts of the clients the
+	// provider configures.
+	clock clock.Clock
 }
 
+// httpLoggingRegexp matches the values of the http_logging attribute.
+var httpLoggingRegexp = regexp.MustCompile(`^(none|summary|headers|bodies)$`)
+
+// lookupSRV resolves the srv_record attribute, replaced in tests.
+var lookupSRV = legocharmclient.LookupSRV
+
+// defaultReadCacheTTL is how long API answers are kept without read_cache_ttl.
+const defaultReadCacheTTL = 30 * time.Second
+
+// negotiateAPIVersion detects the API version of the client, replaced in
+// tests.
+var negotiateAPIVersion = (*legocharmclient.Client).NegotiateAPIVersion
+
+// apiVersions are the values of the api_version attribute.
+var apiVersions = func() []string {
+	var versions []string
+	for _, v := range legocharmclient.SupportedAPIVersions() {
+		versions = append(versions, string(v))
+	}
+	return versions
+}()
+
+// apiVersionRegexp matches the values of the api_version attribute.
+var apiVersionRegexp = regexp.MustCompile(`^(` + strings.Join(apiVersions, "|") + `)$`)
+
 // legocharmProviderModel maps provider schema data to a Go type.
 // It contains the configuration needed to connect to the LegoCharm API.
 type legocharmProviderModel struct {
-	Address  types.String `tfsdk:"address"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Address    types.String `tfsdk:"address"`
+	Addresses  types.List   `tfsdk:"addresses"`
+	SRVRecord  types.String `tfsdk:"srv_record"`
+	Kubernetes types.Object `tfsdk:"kubernetes"`
+	Username   types.String `tfsdk:"username"`
+	Password   types.String `tfsdk:"password"`
+
+	DebugTranscript types.Bool   `tfsdk:"debug_transcript"`
+	HTTPLogging     types.String `tfsdk:"http_logging"`
+	MaintenanceWait types.String `tfsdk:"maintenance_wait"`
+	MaxConcurrent   types.Int64  `tfsdk:"max_concurrent_requests"`
+	ReadCacheTTL    types.String `tfsdk:"read_cache_ttl"`
+	APIVersion      types.String `tfsdk:"api_version"`
+	TLS             types.Object `tfsdk:"tls"`
+	Audit           types.Object `tfsdk:"audit"`
 }
 
 // Metadata returns the provider type name.
@@ -62,6 +131,22 @@ func (p *legocharmProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			Optional:    true,
 			Description: "The address of the httprequest-lego-provider server. Can also be provided via LEGOCHARM_ADDRESS environment variable.",
 		},
+		"addresses": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "The addresses of the httprequest-lego-provider servers of a highly available deployment, such as its ingress units, instead of address. " +
+				"Requests go to the first that can be reached, in order, and fail over to the next when the provider cannot connect to it. " +
+				"Can also be provided, comma-separated, via LEGOCHARM_ADDRESSES environment variable.",
+		},
+		"srv_record": schema.StringAttribute{
+			Optional: true,
+			Description: "The name of a DNS SRV record publishing the httprequest-lego-provider servers, such as \"_legocharm._tcp.example.com\", instead of address, " +
+				"so that the provider follows changes of the charm's ingress host or port without changes to its configuration. " +
+				"The record is resolved whenever the provider is configured, and its targets are used as addresses, by priority and weight. " +
+				"They are reached over HTTPS, unless the name is prefixed with \"http://\". " +
+				"Can also be provided via LEGOCHARM_SRV_RECORD environment variable, which takes precedence over LEGOCHARM_ADDRESS and LEGOCHARM_ADDRESSES.",
+		},
+		"kubernetes": kubernetesSchema(),
 		"username": schema.StringAttribute{
 			Optional:    true,
 			Description: "The username for authenticating with the httprequest-lego-provider server. Can also be provided via LEGOCHARM_USERNAME environment variable.",
@@ -71,6 +156,57 @@ func (p *legocharmProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			Sensitive:   true,
 			Description: "The password for authenticating with the httprequest-lego-provider server. Can also be provided via LEGOCHARM_PASSWORD environment variable.",
 		},
+		"debug_transcript": schema.BoolAttribute{
+			Optional: true,
+			Description: "Log the full HTTP request and response of every API call at DEBUG level, with credentials and password fields replaced by placeholders, to troubleshoot incompatibilities with the API. " +
+				"Requires TF_LOG=DEBUG or more verbose. Can also be enabled via the LEGOCHARM_DEBUG_TRANSCRIPT environment variable. Defaults to false.",
+		},
+		"http_logging": schema.StringAttribute{
+			Optional: true,
+			Description: "How much of the HTTP traffic with the LegoCharm API to log, in the legocharm_api log subsystem: " +
+				"\"none\", \"summary\" (method, path, status and duration of every call), \"headers\" (the requests and responses without their bodies) or \"bodies\" (the requests and responses in full). " +
+				"Credentials and password fields are always replaced by placeholders. Everything is logged at DEBUG level, whose output can be enabled for this subsystem alone with TF_LOG_PROVIDER_LEGOCHARM_API=DEBUG, whatever TF_LOG is set to. " +
+				"By default, a summary of every call is logged, and the request bodies at TRACE level. debug_transcript = true is the same as \"bodies\". " +
+				"Can also be set via the LEGOCHARM_HTTP_LOGGING environment variable.",
+			Validators: []validator.String{
+				stringMatches(httpLoggingRegexp, "value must be one of none, summary, headers or bodies"),
+			},
+		},
+		"maintenance_wait": schema.StringAttribute{
+			Optional: true,
+			Description: "How long to wait, in all, for the LegoCharm API to come back when it answers that it is in maintenance (503 Service Unavailable with a Retry-After header), " +
+				"for example while the charm is upgraded, such as \"10m\". Requests are resent after the delay the API asks for. " +
+				"Once the wait is over, or if it is not set, operations fail with an \"API in maintenance\" error. " +
+				"Can also be set via the LEGOCHARM_MAINTENANCE_WAIT environment variable.",
+			Validators: []validator.String{duration()},
+		},
+		"max_concurrent_requests": schema.Int64Attribute{
+			Optional: true,
+			Description: "The most requests the provider sends to the LegoCharm API at once, such as 2, so that small charm deployments are not overwhelmed by Terraform refreshing many resources in parallel. " +
+				"Further requests wait for one to be answered. Defaults to no limit other than Terraform's -parallelism. " +
+				"Can also be set via the LEGOCHARM_MAX_CONCURRENT_REQUESTS environment variable.",
+			Validators: []validator.Int64{int64AtLeast(1)},
+		},
+		"read_cache_ttl": schema.StringAttribute{
+			Optional: true,
+			Description: "How long the answers of the LegoCharm API to reads are kept and shared by all resources and data sources, such as \"1m\", " +
+				"so that refreshing many grants of the same user or domain does not read them again for each grant. Concurrent identical reads also share one API call. " +
+				"Any change made by the provider empties the cache, but changes made by others are only seen once the answers expire. " +
+				"Defaults to \"30s\"; \"0s\" disables the cache. Can also be set via the LEGOCHARM_READ_CACHE_TTL environment variable.",
+			Validators: []validator.String{durationOrZero()},
+		},
+		"api_version": schema.StringAttribute{
+			Optional: true,
+			Description: "The version of the LegoCharm API to use: any of " + strings.Join(apiVersions, ", ") + ". " +
+				"By default, the provider detects the newest version the charm serves when it is configured, so that it works with both older and newer charm revisions. " +
+				"Setting it skips the detection, which costs an API call once the provider supports more than one version. " +
+				"Can also be set via the LEGOCHARM_API_VERSION environment variable.",
+			Validators: []validator.String{
+				stringMatches(apiVersionRegexp, "value must be one of "+strings.Join(apiVersions, ", ")),
+			},
+		},
+		"tls":   tlsSchema(),
+		"audit": auditSchema(),
 	},
 	}
 }
@@ -93,6 +229,81 @@ func (p *legocharmProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if config.Addresses.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("addresses"),
+			"Unknown LegoCharm API Addresses",
+			"The provider cannot create the LegoCharm API client as there is an unknown configuration value for the LegoCharm API addresses. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LEGOCHARM_ADDRESSES environment variable.",
+		)
+	}
+
+	if !config.Address.IsNull() && !config.Addresses.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("addresses"),
+			"Conflicting LegoCharm API Addresses",
+			"The provider cannot create the LegoCharm API client as both address and addresses are configured. "+
+				"Set only addresses, with the address first, to fail over to the others.",
+		)
+	}
+
+	if config.SRVRecord.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("srv_record"),
+			"Unknown LegoCharm API SRV Record",
+			"The provider cannot create the LegoCharm API client as there is an unknown configuration value for the LegoCharm API SRV record. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LEGOCHARM_SRV_RECORD environment variable.",
+		)
+	}
+
+	if !config.SRVRecord.IsNull() && (!config.Address.IsNull() || !config.Addresses.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("srv_record"),
+			"Conflicting LegoCharm API Addresses",
+			"The provider cannot create the LegoCharm API client as both srv_record and address or addresses are configured. "+
+				"Set only one of them.",
+		)
+	}
+
+	kubernetesUnknown := config.Kubernetes.IsUnknown()
+	if !config.Kubernetes.IsNull() && !kubernetesUnknown {
+		for _, v := range config.Kubernetes.Attributes() {
+			kubernetesUnknown = kubernetesUnknown || v.IsUnknown()
+		}
+	}
+	if kubernetesUnknown {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kubernetes"),
+			"Unknown LegoCharm API Kubernetes Service",
+			"The provider cannot create the LegoCharm API client as there is an unknown configuration value for the Kubernetes Service of the LegoCharm API. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if !config.Kubernetes.IsNull() && (!config.Address.IsNull() || !config.Addresses.IsNull() || !config.SRVRecord.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kubernetes"),
+			"Conflicting LegoCharm API Addresses",
+			"The provider cannot create the LegoCharm API client as both kubernetes and address, addresses or srv_record are configured. "+
+				"Set only one of them.",
+		)
+	}
+
+	tlsUnknown := config.TLS.IsUnknown()
+	if !config.TLS.IsNull() && !tlsUnknown {
+		for _, v := range config.TLS.Attributes() {
+			tlsUnknown = tlsUnknown || v.IsUnknown()
+		}
+	}
+	if tlsUnknown {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls"),
+			"Unknown LegoCharm API TLS Configuration",
+			"The provider cannot create the LegoCharm API client as there is an unknown configuration value for its TLS configuration. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LEGOCHARM_TLS_* environment variables.",
+		)
+	}
+
 	if config.Username.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("username"),
@@ -122,8 +333,80 @@ func (p *legocharmProvider) Configure(ctx context.Context, req provider.Configur
 	username := os.Getenv("LEGOCHARM_USERNAME")
 	password := os.Getenv("LEGOCHARM_PASSWORD")
 
+	var failoverAddresses []string
+	if v := os.Getenv("LEGOCHARM_ADDRESSES"); v != "" {
+		address, failoverAddresses = "", nil
+		for _, a := range strings.Split(v, ",") {
+			if a = strings.TrimSpace(a); a == "" {
+				continue
+			}
+			if address == "" {
+				address = a
+			} else {
+				failoverAddresses = append(failoverAddresses, a)
+			}
+		}
+	}
+
 	if !config.Address.IsNull() {
-		address = config.Address.ValueString()
+		address, failoverAddresses = config.Address.ValueString(), nil
+	}
+
+	if !config.Addresses.IsNull() {
+		var addresses []types.String
+		resp.Diagnostics.Append(config.Addresses.ElementsAs(ctx, &addresses, false)...)
+		address, failoverAddresses = "", nil
+		for _, a := range addresses {
+			if a.IsNull() || a.IsUnknown() || a.ValueString() == "" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("addresses"),
+					"Invalid LegoCharm API Addresses",
+					"The provider cannot create the LegoCharm API client as the addresses contain an empty or unknown value.",
+				)
+				break
+			}
+			if address == "" {
+				address = a.ValueString()
+			} else {
+				failoverAddresses = append(failoverAddresses, a.ValueString())
+			}
+		}
+	}
+
+	srvRecord := os.Getenv("LEGOCHARM_SRV_RECORD")
+	if !config.Address.IsNull() || !config.Addresses.IsNull() || !config.Kubernetes.IsNull() {
+		srvRecord = ""
+	}
+	if !config.SRVRecord.IsNull() {
+		srvRecord = config.SRVRecord.ValueString()
+	}
+	if srvRecord != "" {
+		urls, err := lookupSRV(ctx, srvRecord)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("srv_record"),
+				"Unable to Discover LegoCharm API Address",
+				"The provider cannot create the LegoCharm API client as the addresses of the LegoCharm API could not be looked up: "+err.Error(),
+			)
+		} else {
+			address, failoverAddresses = urls[0], urls[1:]
+			tflog.Debug(ctx, "Discovered LegoCharm API addresses", map[string]interface{}{"srv_record": srvRecord, "addresses": urls})
+		}
+	}
+
+	if !config.Kubernetes.IsNull() {
+		kubeConfig := kubernetesConfig(config.Kubernetes)
+		u, err := resolveKubeService(ctx, kubeConfig)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("kubernetes"),
+				"Unable to Discover LegoCharm API Address",
+				"The provider cannot create the LegoCharm API client as the address of its Kubernetes Service could not be looked up: "+err.Error(),
+			)
+		} else {
+			address, failoverAddresses = u, nil
+			tflog.Debug(ctx, "Discovered LegoCharm API address", map[string]interface{}{"kubernetes_service": kubeConfig.Service, "address": u})
+		}
 	}
 
 	if !config.Username.IsNull() {
@@ -137,12 +420,12 @@ func (p *legocharmProvider) Configure(ctx context.Context, req provider.Configur
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if address == "" {
+	if address == "" && srvRecord == "" && config.Kubernetes.IsNull() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("address"),
 			"LegoCharm API Address Not Set",
 			"The provider cannot create the LegoCharm API client as there is no configured address. "+
-				"Set the address value in the provider configuration or use the LEGOCHARM_ADDRESS environment variable.",
+				"Set the address, addresses, srv_record or kubernetes value in the provider configuration or use the LEGOCHARM_ADDRESS, LEGOCHARM_ADDRESSES or LEGOCHARM_SRV_RECORD environment variable.",
 		)
 	}
 
@@ -164,6 +447,106 @@ func (p *legocharmProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	debugTranscript := false
+	if v := os.Getenv("LEGOCHARM_DEBUG_TRANSCRIPT"); v != "" {
+		var err error
+		if debugTranscript, err = strconv.ParseBool(v); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("debug_transcript"),
+				"Invalid LEGOCHARM_DEBUG_TRANSCRIPT",
+				fmt.Sprintf("The LEGOCHARM_DEBUG_TRANSCRIPT environment variable must be true or false, got %q.", v),
+			)
+		}
+	}
+	if !config.DebugTranscript.IsNull() && !config.DebugTranscript.IsUnknown() {
+		debugTranscript = config.DebugTranscript.ValueBool()
+	}
+
+	httpLogging := os.Getenv("LEGOCHARM_HTTP_LOGGING")
+	if httpLogging != "" && !httpLoggingRegexp.MatchString(httpLogging) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("http_logging"),
+			"Invalid LEGOCHARM_HTTP_LOGGING",
+			fmt.Sprintf("The LEGOCHARM_HTTP_LOGGING environment variable must be one of none, summary, headers or bodies, got %q.", httpLogging),
+		)
+	}
+	if !config.HTTPLogging.IsNull() && !config.HTTPLogging.IsUnknown() {
+		httpLogging = config.HTTPLogging.ValueString()
+	}
+
+	var maintenanceWait time.Duration
+	if v := os.Getenv("LEGOCHARM_MAINTENANCE_WAIT"); v != "" {
+		var err error
+		if maintenanceWait, err = time.ParseDuration(v); err != nil || maintenanceWait < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("maintenance_wait"),
+				"Invalid LEGOCHARM_MAINTENANCE_WAIT",
+				fmt.Sprintf("The LEGOCHARM_MAINTENANCE_WAIT environment variable must be a duration such as \"10m\", got %q.", v),
+			)
+		}
+	}
+	if !config.MaintenanceWait.IsNull() && !config.MaintenanceWait.IsUnknown() {
+		// The attribute is validated as a duration.
+		maintenanceWait, _ = time.ParseDuration(config.MaintenanceWait.ValueString())
+	}
+
+	var maxConcurrent int64
+	if v := os.Getenv("LEGOCHARM_MAX_CONCURRENT_REQUESTS"); v != "" {
+		var err error
+		if maxConcurrent, err = strconv.ParseInt(v, 10, 64); err != nil || maxConcurrent < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid LEGOCHARM_MAX_CONCURRENT_REQUESTS",
+				fmt.Sprintf("The LEGOCHARM_MAX_CONCURRENT_REQUESTS environment variable must be a positive integer, got %q.", v),
+			)
+		}
+	}
+	if !config.MaxConcurrent.IsNull() && !config.MaxConcurrent.IsUnknown() {
+		maxConcurrent = config.MaxConcurrent.ValueInt64()
+	}
+
+	readCacheTTL := defaultReadCacheTTL
+	if v := os.Getenv("LEGOCHARM_READ_CACHE_TTL"); v != "" {
+		var err error
+		if readCacheTTL, err = time.ParseDuration(v); err != nil || readCacheTTL < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_cache_ttl"),
+				"Invalid LEGOCHARM_READ_CACHE_TTL",
+				fmt.Sprintf("The LEGOCHARM_READ_CACHE_TTL environment variable must be a duration such as \"1m\", or \"0s\", got %q.", v),
+			)
+		}
+	}
+	if !config.ReadCacheTTL.IsNull() && !config.ReadCacheTTL.IsUnknown() {
+		// The attribute is validated as a duration.
+		readCacheTTL, _ = time.ParseDuration(config.ReadCacheTTL.ValueString())
+	}
+
+	apiVersion := os.Getenv("LEGOCHARM_API_VERSION")
+	if apiVersion != "" && !apiVersionRegexp.MatchString(apiVersion) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_version"),
+			"Invalid LEGOCHARM_API_VERSION",
+			fmt.Sprintf("The LEGOCHARM_API_VERSION environment variable must be one of %s, got %q.", strings.Join(apiVersions, ", "), apiVersion),
+		)
+	}
+	if !config.APIVersion.IsNull() && !config.APIVersion.IsUnknown() {
+		apiVersion = config.APIVersion.ValueString()
+	}
+
+	tlsOpts, diags := tlsOptions(ctx, config.TLS)
+	resp.Diagnostics.Append(diags...)
+	var tlsConfig *tls.Config
+	if !tlsOpts.IsZero() {
+		var err error
+		if tlsConfig, err = tlsOpts.Config(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tls"),
+				"Invalid LegoCharm API TLS Configuration",
+				"The provider cannot create the LegoCharm API client as its TLS configuration is invalid: "+err.Error(),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -179,16 +562,82 @@ func (p *legocharmProvider) Configure(ctx context.Context, req provider.Configur
 		)
 		return
 	}
+	for _, a := range failoverAddresses {
+		u, err := legocharmclient.NormalizeAddress(a)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("addresses"),
+				"Invalid LegoCharm API Addresses",
+				"The provider cannot create the LegoCharm API client as one of its addresses is invalid: "+err.Error(),
+			)
+			return
+		}
+		client.FailoverURLs = append(client.FailoverURLs, u)
+	}
+	if tlsConfig != nil {
+		client.UseTLSConfig(tlsConfig)
+	}
+	client.Transcript = debugTranscript
+	client.HTTPLogging = legocharmclient.HTTPLogging(httpLogging)
+	client.Stats = p.calls
+	client.Deprecations = p.deprecations
+	client.MaintenanceWait = maintenanceWait
+	client.Clock = p.clock
+	if maxConcurrent > 0 {
+		client.RequestLimit = legocharmclient.NewRequestLimit(int(maxConcurrent))
+	}
+	if readCacheTTL > 0 {
+		client.ReadCache = legocharmclient.NewReadCache(readCacheTTL)
+	}
+	client.Headers = auditHeaders(config.Audit)
+	if debugTranscript || httpLogging == string(legocharmclient.HTTPLoggingBodies) {
+		tflog.Warn(ctx, "Logging transcripts of LegoCharm API calls; credentials and password fields are redacted, but the transcripts contain usernames and domains")
+	}
 
-	// Make the LegoCharm client available during DataSource and Resource
-	// type Configure methods.
+	if apiVersion != "" {
+		client.APIVersion = legocharmclient.APIVersion(apiVersion)
+	} else {
+		version, err := negotiateAPIVersion(client, ctx)
+		switch {
+		case errors.Is(err, legocharmclient.ErrUnsupportedAPIVersion):
+			resp.Diagnostics.AddError(
+				"Unsupported LegoCharm API Version",
+				"The provider cannot use the LegoCharm API as the charm serves none of the API versions it supports. "+
+					"Upgrade the provider, or the charm, to a release supporting the other's API.\n\n"+
+					"LegoCharm Client Error: "+err.Error(),
+			)
+			return
+		case err != nil:
+			// Operations fail with their own errors if the API cannot be
+			// reached, rather than the provider failing to configure.
+			tflog.Warn(ctx, "Unable to detect the LegoCharm API version, using "+string(legocharmclient.APIVersion1), map[string]any{"error": err.Error()})
+		default:
+			tflog.Debug(ctx, "Detected the LegoCharm API version", map[string]any{"api_version": string(version)})
+		}
+	}
+
+	// Make the LegoCharm client available during DataSource, Resource,
+	// ListResource, EphemeralResource and Action type Configure methods.
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.ListResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ActionData = client
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *legocharmProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewDomainsDataSource,
+		NewUserDomainAccessDataSource,
+		NewDomainUserAccessesDataSource,
+		NewCredentialsCheckDataSource,
+		NewChallengeTestDataSource,
+		NewPermissionCheckDataSource,
+		NewUserEffectiveDomainsDataSource,
+		NewAccessMatrixDataSource,
+		NewExportDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
@@ -196,5 +645,52 @@ func (p *legocharmProvider) Resources(_ context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewUserResource,
 		NewUserDomainAccessResource,
+		NewServiceUserWithAccessResource,
+		NewDomainResource,
+		NewGroupMembershipResource,
+		NewUserGroupMembershipResource,
+		NewServiceAccountResource,
+		NewDomainSetResource,
+		NewDomainUserPermissionsResource,
+		NewAdminPasswordResource,
+		NewChallengeRecordResource,
+	}
+}
+
+// ListResources defines the list resources implemented in the provider.
+func (p *legocharmProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewDomainListResource,
+		NewUserDomainAccessListResource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the
+// provider.
+func (p *legocharmProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewUserCredentialsEphemeralResource,
+		NewBasicAuthHeaderEphemeralResource,
+	}
+}
+
+// Actions defines the actions implemented in the provider.
+func (p *legocharmProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewRotateUserPasswordAction,
+		NewRevokeUserAccessAction,
+		NewVerifyChallengeAction,
+		NewSetUserActiveAction,
+	}
+}
+
+// Functions defines the provider-defined functions implemented in the
+// provider.
+func (p *legocharmProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewNormalizeFQDNFunction,
+		NewIsValidFQDNFunction,
+		NewParseAccessIDFunction,
+		NewGeneratePasswordFunction,
 	}
 }
@@ -0,0 +1,100 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/metaschema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ provider.ProviderWithMetaSchema = &legocharmProvider{}
+
+// providerMetaField is a value modules can set in the provider_meta block
+// of their terraform block, to attribute the objects they manage to them.
+type providerMetaField struct {
+	// attribute is the name of the attribute in provider_meta, also used
+	// as log field.
+	attribute string
+	// header is the request header carrying the value.
+	header string
+	// description documents the attribute.
+	description string
+}
+
+// providerMetaFields are the attributes of the provider_meta schema.
+var providerMetaFields = []providerMetaField{
+	{
+		attribute:   "module_name",
+		header:      "X-Terraform-Module-Name",
+		description: "The name of the module, sent with the API calls made for its resources and data sources as the X-Terraform-Module-Name header.",
+	},
+	{
+		attribute:   "module_version",
+		header:      "X-Terraform-Module-Version",
+		description: "The version of the module, sent as the X-Terraform-Module-Version header.",
+	},
+}
+
+// MetaSchema defines the schema of the provider_meta block, which shared
+// modules set to attribute the users and grants they create.
+func (p *legocharmProvider) MetaSchema(_ context.Context, _ provider.MetaSchemaRequest, resp *provider.MetaSchemaResponse) {
+	attributes := make(map[string]metaschema.Attribute, len(providerMetaFields))
+	for _, f := range providerMetaFields {
+		attributes[f.attribute] = metaschema.StringAttribute{
+			Optional:    true,
+			Description: f.description,
+		}
+	}
+	resp.Schema = metaschema.Schema{Attributes: attributes}
+}
+
+// withProviderMeta returns ctx with the values of the provider_meta of the
+// module of an RPC set as headers of the API calls made with it and as log
+// fields. A missing or undecodable provider_meta is ignored: attribution is
+// best effort and never fails an operation.
+func withProviderMeta(ctx context.Context, meta *tfprotov6.DynamicValue) context.Context {
+	if meta == nil {
+		return ctx
+	}
+	attrTypes := make(map[string]tftypes.Type, len(providerMetaFields))
+	for _, f := range providerMetaFields {
+		attrTypes[f.attribute] = tftypes.String
+	}
+	value, err := meta.Unmarshal(tftypes.Object{AttributeTypes: attrTypes})
+	if err != nil || !value.IsKnown() || value.IsNull() {
+		return ctx
+	}
+	var attrs map[string]tftypes.Value
+	if err := value.As(&attrs); err != nil {
+		return ctx
+	}
+
+	headers := http.Header{}
+	for _, f := range providerMetaFields {
+		v, ok := attrs[f.attribute]
+		if !ok || !v.IsKnown() || v.IsNull() {
+			continue
+		}
+		var s string
+		if err := v.As(&s); err != nil {
+			continue
+		}
+		if s = headerValue(s); s != "" {
+			headers.Set(f.header, s)
+			ctx = tflog.SetField(ctx, f.attribute, s)
+		}
+	}
+	if len(headers) == 0 {
+		return ctx
+	}
+	return legocharmclient.WithHeaders(ctx, headers)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func providerMetaValue(t *testing.T, name, version any) *tfprotov6.DynamicValue {
+	t.Helper()
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"module_name":    tftypes.String,
+		"module_version": tftypes.String,
+	}}
+	meta, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, map[string]tftypes.Value{
+		"module_name":    tftypes.NewValue(tftypes.String, name),
+		"module_version": tftypes.NewValue(tftypes.String, version),
+	}))
+	require.NoError(t, err)
+	return &meta
+}
+
+func TestProvider_MetaSchema(t *testing.T) {
+	server, err := NewProtocol6Server("test")()
+	require.NoError(t, err)
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, resp.ProviderMeta)
+	var names []string
+	for _, a := range resp.ProviderMeta.Block.Attributes {
+		names = append(names, a.Name)
+	}
+	require.ElementsMatch(t, []string{"module_name", "module_version"}, names)
+}
+
+func TestProviderServer_ForwardsProviderMeta(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+	username, password := "admin", "secret"
+	client, err := legocharmclient.NewClient(&srv.URL, &username, &password)
+	require.NoError(t, err)
+	server := &providerServer{protocol6Server: &readStubServer{client: client}}
+
+	_, err = server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		ProviderMeta: providerMetaValue(t, "certbot-users", "1.4.0"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "certbot-users", got.Get("X-Terraform-Module-Name"))
+	require.Equal(t, "1.4.0", got.Get("X-Terraform-Module-Version"))
+
+	for name, meta := range map[string]*tfprotov6.DynamicValue{
+		"no provider_meta":   nil,
+		"null values":        providerMetaValue(t, nil, nil),
+		"unknown value":      providerMetaValue(t, tftypes.UnknownValue, nil),
+		"undecodable":        {JSON: []byte(`{"module_name": 42`)},
+		"control characters": providerMetaValue(t, "\r\n", nil),
+	} {
+		_, err = server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{ProviderMeta: meta})
+		require.NoError(t, err, name)
+		require.Empty(t, got.Get("X-Terraform-Module-Name"), name)
+		require.Empty(t, got.Get("X-Terraform-Module-Version"), name)
+	}
+}
@@ -0,0 +1,729 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/internal/kubeservice"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestProvider_Resources(t *testing.T) {
+	p := New("test")()
+
+	names := map[string]bool{}
+	for _, f := range p.Resources(context.Background()) {
+		resp := &resource.MetadataResponse{}
+		f().Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+		require.False(t, names[resp.TypeName], "duplicate resource type %s", resp.TypeName)
+		names[resp.TypeName] = true
+	}
+
+	require.True(t, names["legocharm_user"])
+	require.True(t, names["legocharm_user_domain_access"])
+	require.True(t, names["legocharm_service_user_with_access"])
+	require.True(t, names["legocharm_domain"])
+	require.True(t, names["legocharm_group_membership"])
+	require.True(t, names["legocharm_user_group_membership"])
+	require.True(t, names["legocharm_service_account"])
+	require.True(t, names["legocharm_domain_set"])
+	require.True(t, names["legocharm_domain_user_permissions"])
+	require.True(t, names["legocharm_admin_password"])
+	require.True(t, names["legocharm_challenge_record"])
+}
+
+func TestProvider_DataSources(t *testing.T) {
+	p := New("test")()
+
+	names := map[string]bool{}
+	for _, f := range p.DataSources(context.Background()) {
+		resp := &datasource.MetadataResponse{}
+		f().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+		require.False(t, names[resp.TypeName], "duplicate data source type %s", resp.TypeName)
+		names[resp.TypeName] = true
+	}
+
+	require.True(t, names["legocharm_domains"])
+	require.True(t, names["legocharm_user_domain_access"])
+	require.True(t, names["legocharm_domain_user_accesses"])
+	require.True(t, names["legocharm_credentials_check"])
+	require.True(t, names["legocharm_challenge_test"])
+	require.True(t, names["legocharm_permission_check"])
+	require.True(t, names["legocharm_user_effective_domains"])
+	require.True(t, names["legocharm_access_matrix"])
+	require.True(t, names["legocharm_export"])
+}
+
+func TestProvider_ListResources(t *testing.T) {
+	p := New("test")().(provider.ProviderWithListResources)
+
+	resources := map[string]resource.Resource{}
+	for _, f := range p.Resources(context.Background()) {
+		r := f()
+		resp := &resource.MetadataResponse{}
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+		resources[resp.TypeName] = r
+	}
+
+	names := map[string]bool{}
+	for _, f := range p.ListResources(context.Background()) {
+		resp := &resource.MetadataResponse{}
+		f().Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+		require.False(t, names[resp.TypeName], "duplicate list resource type %s", resp.TypeName)
+		names[resp.TypeName] = true
+
+		// A list resource must match a managed resource with an identity.
+		_, ok := resources[resp.TypeName].(resource.ResourceWithIdentity)
+		require.True(t, ok, "list resource %s has no managed resource with identity", resp.TypeName)
+	}
+
+	require.True(t, names["legocharm_domain"])
+	require.True(t, names["legocharm_user_domain_access"])
+}
+
+func TestProvider_EphemeralResources(t *testing.T) {
+	p := New("test")().(provider.ProviderWithEphemeralResources)
+
+	names := map[string]bool{}
+	for _, f := range p.EphemeralResources(context.Background()) {
+		resp := &ephemeral.MetadataResponse{}
+		f().Metadata(context.Background(), ephemeral.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+		require.False(t, names[resp.TypeName], "duplicate ephemeral resource type %s", resp.TypeName)
+		names[resp.TypeName] = true
+	}
+
+	require.True(t, names["legocharm_user_credentials"])
+	require.True(t, names["legocharm_basic_auth_header"])
+}
+
+func TestProvider_Actions(t *testing.T) {
+	p := New("test")().(provider.ProviderWithActions)
+
+	names := map[string]bool{}
+	for _, f := range p.Actions(context.Background()) {
+		resp := &action.MetadataResponse{}
+		f().Metadata(context.Background(), action.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+		require.False(t, names[resp.TypeName], "duplicate action type %s", resp.TypeName)
+		names[resp.TypeName] = true
+	}
+
+	require.True(t, names["legocharm_rotate_user_password"])
+	require.True(t, names["legocharm_revoke_user_access"])
+	require.True(t, names["legocharm_verify_challenge"])
+	require.True(t, names["legocharm_set_user_active"])
+}
+
+func TestProvider_Functions(t *testing.T) {
+	p := New("test")().(provider.ProviderWithFunctions)
+
+	names := map[string]bool{}
+	for _, f := range p.Functions(context.Background()) {
+		resp := &function.MetadataResponse{}
+		f().Metadata(context.Background(), function.MetadataRequest{}, resp)
+		require.False(t, names[resp.Name], "duplicate function %s", resp.Name)
+		names[resp.Name] = true
+	}
+
+	require.True(t, names["normalize_fqdn"])
+	require.True(t, names["is_valid_fqdn"])
+	require.True(t, names["parse_access_id"])
+	require.True(t, names["generate_password"])
+}
+
+// stubAPIVersion makes Configure detect the API version v1, or fail with err,
+// without calling the API.
+func stubAPIVersion(t *testing.T, err error) *int {
+	calls := 0
+	old := negotiateAPIVersion
+	negotiateAPIVersion = func(client *legocharmclient.Client, _ context.Context) (legocharmclient.APIVersion, error) {
+		calls++
+		if err != nil {
+			return "", err
+		}
+		client.APIVersion = legocharmclient.APIVersion1
+		return legocharmclient.APIVersion1, nil
+	}
+	t.Cleanup(func() { negotiateAPIVersion = old })
+	return &calls
+}
+
+func TestProvider_ConfigureDebugTranscript(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	stubAPIVersion(t, nil)
+
+	configure := func(debugTranscript types.Bool) *provider.ConfigureResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:         types.StringValue("https://lego.example.com"),
+			Addresses:       types.ListNull(types.StringType),
+			Username:        types.StringValue("admin"),
+			Password:        types.StringValue("secret"),
+			DebugTranscript: debugTranscript,
+			MaintenanceWait: types.StringNull(),
+			Audit:           types.ObjectNull(auditAttributeTypes()),
+			TLS:             types.ObjectNull(tlsAttributeTypes()),
+			Kubernetes:      types.ObjectNull(kubernetesAttributeTypes()),
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	transcript := func(resp *provider.ConfigureResponse) bool {
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		return resp.ResourceData.(*legocharmclient.Client).Transcript
+	}
+
+	t.Setenv("LEGOCHARM_DEBUG_TRANSCRIPT", "")
+	require.False(t, transcript(configure(types.BoolNull())))
+	require.True(t, transcript(configure(types.BoolValue(true))))
+
+	t.Setenv("LEGOCHARM_DEBUG_TRANSCRIPT", "1")
+	require.True(t, transcript(configure(types.BoolNull())))
+	require.False(t, transcript(configure(types.BoolValue(false))), "the attribute overrides the environment")
+
+	t.Setenv("LEGOCHARM_DEBUG_TRANSCRIPT", "sometimes")
+	resp := configure(types.BoolNull())
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LEGOCHARM_DEBUG_TRANSCRIPT", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestProvider_ConfigureMaintenanceWait(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	stubAPIVersion(t, nil)
+
+	configure := func(maintenanceWait types.String) *provider.ConfigureResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:         types.StringValue("https://lego.example.com"),
+			Addresses:       types.ListNull(types.StringType),
+			Username:        types.StringValue("admin"),
+			Password:        types.StringValue("secret"),
+			MaintenanceWait: maintenanceWait,
+			Audit:           types.ObjectNull(auditAttributeTypes()),
+			TLS:             types.ObjectNull(tlsAttributeTypes()),
+			Kubernetes:      types.ObjectNull(kubernetesAttributeTypes()),
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	wait := func(resp *provider.ConfigureResponse) time.Duration {
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		return resp.ResourceData.(*legocharmclient.Client).MaintenanceWait
+	}
+
+	t.Setenv("LEGOCHARM_MAINTENANCE_WAIT", "")
+	require.Zero(t, wait(configure(types.StringNull())))
+	require.Equal(t, 10*time.Minute, wait(configure(types.StringValue("10m"))))
+
+	t.Setenv("LEGOCHARM_MAINTENANCE_WAIT", "90s")
+	require.Equal(t, 90*time.Second, wait(configure(types.StringNull())))
+	require.Equal(t, 5*time.Minute, wait(configure(types.StringValue("5m"))), "the attribute overrides the environment")
+
+	t.Setenv("LEGOCHARM_MAINTENANCE_WAIT", "a while")
+	resp := configure(types.StringNull())
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LEGOCHARM_MAINTENANCE_WAIT", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestProvider_ConfigureMaxConcurrentRequests(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	stubAPIVersion(t, nil)
+
+	configure := func(maxConcurrent types.Int64) *provider.ConfigureResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:       types.StringValue("https://lego.example.com"),
+			Addresses:     types.ListNull(types.StringType),
+			Username:      types.StringValue("admin"),
+			Password:      types.StringValue("secret"),
+			MaxConcurrent: maxConcurrent,
+			Audit:         types.ObjectNull(auditAttributeTypes()),
+			TLS:           types.ObjectNull(tlsAttributeTypes()),
+			Kubernetes:    types.ObjectNull(kubernetesAttributeTypes()),
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	limit := func(resp *provider.ConfigureResponse) int {
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		if l := resp.ResourceData.(*legocharmclient.Client).RequestLimit; l != nil {
+			return l.Max()
+		}
+		return 0
+	}
+
+	t.Setenv("LEGOCHARM_MAX_CONCURRENT_REQUESTS", "")
+	require.Zero(t, limit(configure(types.Int64Null())), "requests are not limited by default")
+	require.Equal(t, 2, limit(configure(types.Int64Value(2))))
+
+	t.Setenv("LEGOCHARM_MAX_CONCURRENT_REQUESTS", "4")
+	require.Equal(t, 4, limit(configure(types.Int64Null())))
+	require.Equal(t, 1, limit(configure(types.Int64Value(1))), "the attribute overrides the environment")
+
+	t.Setenv("LEGOCHARM_MAX_CONCURRENT_REQUESTS", "0")
+	resp := configure(types.Int64Null())
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LEGOCHARM_MAX_CONCURRENT_REQUESTS", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestProvider_ConfigureReadCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	stubAPIVersion(t, nil)
+
+	configure := func(readCacheTTL types.String) *provider.ConfigureResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:      types.StringValue("https://lego.example.com"),
+			Addresses:    types.ListNull(types.StringType),
+			Username:     types.StringValue("admin"),
+			Password:     types.StringValue("secret"),
+			ReadCacheTTL: readCacheTTL,
+			Audit:        types.ObjectNull(auditAttributeTypes()),
+			TLS:          types.ObjectNull(tlsAttributeTypes()),
+			Kubernetes:   types.ObjectNull(kubernetesAttributeTypes()),
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	ttl := func(resp *provider.ConfigureResponse) time.Duration {
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		if cache := resp.ResourceData.(*legocharmclient.Client).ReadCache; cache != nil {
+			return cache.TTL()
+		}
+		return 0
+	}
+
+	t.Setenv("LEGOCHARM_READ_CACHE_TTL", "")
+	require.Equal(t, 30*time.Second, ttl(configure(types.StringNull())))
+	require.Equal(t, time.Minute, ttl(configure(types.StringValue("1m"))))
+	require.Zero(t, ttl(configure(types.StringValue("0s"))), "0s disables the cache")
+
+	t.Setenv("LEGOCHARM_READ_CACHE_TTL", "0")
+	require.Zero(t, ttl(configure(types.StringNull())))
+	require.Equal(t, 5*time.Second, ttl(configure(types.StringValue("5s"))), "the attribute overrides the environment")
+
+	t.Setenv("LEGOCHARM_READ_CACHE_TTL", "forever")
+	resp := configure(types.StringNull())
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LEGOCHARM_READ_CACHE_TTL", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestProvider_ConfigureHTTPLogging(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	stubAPIVersion(t, nil)
+
+	configure := func(httpLogging types.String) *provider.ConfigureResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:     types.StringValue("https://lego.example.com"),
+			Addresses:   types.ListNull(types.StringType),
+			Username:    types.StringValue("admin"),
+			Password:    types.StringValue("secret"),
+			HTTPLogging: httpLogging,
+			Audit:       types.ObjectNull(auditAttributeTypes()),
+			TLS:         types.ObjectNull(tlsAttributeTypes()),
+			Kubernetes:  types.ObjectNull(kubernetesAttributeTypes()),
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	logging := func(resp *provider.ConfigureResponse) legocharmclient.HTTPLogging {
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		return resp.ResourceData.(*legocharmclient.Client).HTTPLogging
+	}
+
+	t.Setenv("LEGOCHARM_HTTP_LOGGING", "")
+	require.Equal(t, legocharmclient.HTTPLoggingDefault, logging(configure(types.StringNull())))
+	require.Equal(t, legocharmclient.HTTPLoggingHeaders, logging(configure(types.StringValue("headers"))))
+
+	t.Setenv("LEGOCHARM_HTTP_LOGGING", "none")
+	require.Equal(t, legocharmclient.HTTPLoggingNone, logging(configure(types.StringNull())))
+	require.Equal(t, legocharmclient.HTTPLoggingSummary, logging(configure(types.StringValue("summary"))), "the attribute overrides the environment")
+
+	t.Setenv("LEGOCHARM_HTTP_LOGGING", "everything")
+	resp := configure(types.StringNull())
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LEGOCHARM_HTTP_LOGGING", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestProvider_ConfigureAddresses(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	stubAPIVersion(t, nil)
+
+	configure := func(address types.String, addresses ...string) *provider.ConfigureResponse {
+		list := types.ListNull(types.StringType)
+		if addresses != nil {
+			var elems []attr.Value
+			for _, a := range addresses {
+				elems = append(elems, types.StringValue(a))
+			}
+			list = types.ListValueMust(types.StringType, elems)
+		}
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:    address,
+			Addresses:  list,
+			Username:   types.StringValue("admin"),
+			Password:   types.StringValue("secret"),
+			Audit:      types.ObjectNull(auditAttributeTypes()),
+			TLS:        types.ObjectNull(tlsAttributeTypes()),
+			Kubernetes: types.ObjectNull(kubernetesAttributeTypes()),
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	endpoints := func(resp *provider.ConfigureResponse) []string {
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		client := resp.ResourceData.(*legocharmclient.Client)
+		return append([]string{client.BaseURL}, client.FailoverURLs...)
+	}
+
+	t.Setenv("LEGOCHARM_ADDRESS", "")
+	t.Setenv("LEGOCHARM_ADDRESSES", "")
+	require.Equal(t, []string{"https://lego-0.example.com", "https://lego-1.example.com"},
+		endpoints(configure(types.StringNull(), "lego-0.example.com", "https://lego-1.example.com/")))
+
+	t.Setenv("LEGOCHARM_ADDRESSES", "lego-0.example.com, lego-1.example.com,")
+	require.Equal(t, []string{"https://lego-0.example.com", "https://lego-1.example.com"}, endpoints(configure(types.StringNull())))
+	require.Equal(t, []string{"https://lego.example.com"}, endpoints(configure(types.StringValue("lego.example.com"))),
+		"the attribute overrides the environment")
+
+	resp := configure(types.StringValue("lego.example.com"), "lego-0.example.com")
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Conflicting LegoCharm API Addresses", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = configure(types.StringNull(), "lego-0.example.com", "")
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LegoCharm API Addresses", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestProvider_ConfigureSRVRecord(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	stubAPIVersion(t, nil)
+
+	old := lookupSRV
+	lookupSRV = func(_ context.Context, name string) ([]string, error) {
+		if name != "_legocharm._tcp.example.com" {
+			return nil, errors.New("no such host")
+		}
+		return []string{"https://lego-1.example.com:8443", "https://lego-0.example.com:443"}, nil
+	}
+	t.Cleanup(func() { lookupSRV = old })
+
+	configure := func(address, srvRecord types.String) *provider.ConfigureResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:    address,
+			Addresses:  types.ListNull(types.StringType),
+			SRVRecord:  srvRecord,
+			Username:   types.StringValue("admin"),
+			Password:   types.StringValue("secret"),
+			Audit:      types.ObjectNull(auditAttributeTypes()),
+			TLS:        types.ObjectNull(tlsAttributeTypes()),
+			Kubernetes: types.ObjectNull(kubernetesAttributeTypes()),
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	endpoints := func(resp *provider.ConfigureResponse) []string {
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		client := resp.ResourceData.(*legocharmclient.Client)
+		return append([]string{client.BaseURL}, client.FailoverURLs...)
+	}
+	discovered := []string{"https://lego-1.example.com:8443", "https://lego-0.example.com:443"}
+
+	t.Setenv("LEGOCHARM_ADDRESS", "lego.example.com")
+	t.Setenv("LEGOCHARM_SRV_RECORD", "")
+	require.Equal(t, discovered, endpoints(configure(types.StringNull(), types.StringValue("_legocharm._tcp.example.com"))))
+
+	t.Setenv("LEGOCHARM_SRV_RECORD", "_legocharm._tcp.example.com")
+	require.Equal(t, discovered, endpoints(configure(types.StringNull(), types.StringNull())))
+	require.Equal(t, []string{"https://lego.example.com"}, endpoints(configure(types.StringValue("lego.example.com"), types.StringNull())),
+		"the address attribute overrides the environment")
+
+	resp := configure(types.StringValue("lego.example.com"), types.StringValue("_legocharm._tcp.example.com"))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Conflicting LegoCharm API Addresses", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = configure(types.StringNull(), types.StringValue("_legocharm._tcp.missing.example.com"))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Unable to Discover LegoCharm API Address", resp.Diagnostics.Errors()[0].Summary())
+	require.Len(t, resp.Diagnostics.Errors(), 1)
+}
+
+func TestProvider_ConfigureKubernetes(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	stubAPIVersion(t, nil)
+
+	var resolved []kubeservice.Config
+	old := resolveKubeService
+	resolveKubeService = func(_ context.Context, cfg kubeservice.Config) (string, error) {
+		resolved = append(resolved, cfg)
+		if cfg.Service != "httprequest-lego-provider" {
+			return "", errors.New(`services "` + cfg.Service + `" not found`)
+		}
+		return "http://10.1.2.3:8080", nil
+	}
+	t.Cleanup(func() { resolveKubeService = old })
+
+	kubernetes := func(service string) types.Object {
+		return types.ObjectValueMust(kubernetesAttributeTypes(), map[string]attr.Value{
+			"service":        types.StringValue(service),
+			"namespace":      types.StringValue("lego"),
+			"port":           types.StringNull(),
+			"scheme":         types.StringNull(),
+			"config_path":    types.StringValue("/etc/kube/config"),
+			"config_context": types.StringNull(),
+		})
+	}
+	configure := func(address types.String, kubernetes types.Object) *provider.ConfigureResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:    address,
+			Addresses:  types.ListNull(types.StringType),
+			Kubernetes: kubernetes,
+			Username:   types.StringValue("admin"),
+			Password:   types.StringValue("secret"),
+			Audit:      types.ObjectNull(auditAttributeTypes()),
+			TLS:        types.ObjectNull(tlsAttributeTypes()),
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+
+	t.Setenv("LEGOCHARM_ADDRESS", "lego.example.com")
+	resp := configure(types.StringNull(), kubernetes("httprequest-lego-provider"))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, "http://10.1.2.3:8080", resp.ResourceData.(*legocharmclient.Client).BaseURL)
+	require.Equal(t, []kubeservice.Config{{ConfigPath: "/etc/kube/config", Namespace: "lego", Service: "httprequest-lego-provider"}}, resolved)
+
+	resp = configure(types.StringValue("lego.example.com"), kubernetes("httprequest-lego-provider"))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Conflicting LegoCharm API Addresses", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = configure(types.StringNull(), kubernetes("missing"))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Unable to Discover LegoCharm API Address", resp.Diagnostics.Errors()[0].Summary())
+	require.Len(t, resp.Diagnostics.Errors(), 1)
+}
+
+func TestProvider_ConfigureTLS(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	stubAPIVersion(t, nil)
+
+	configure := func(tlsConfig types.Object) *provider.ConfigureResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:    types.StringValue("https://lego.example.com"),
+			Addresses:  types.ListNull(types.StringType),
+			Username:   types.StringValue("admin"),
+			Password:   types.StringValue("secret"),
+			Audit:      types.ObjectNull(auditAttributeTypes()),
+			Kubernetes: types.ObjectNull(kubernetesAttributeTypes()),
+			TLS:        tlsConfig,
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	tlsObject := func(minVersion string, cipherSuites ...string) types.Object {
+		suites := types.ListNull(types.StringType)
+		if cipherSuites != nil {
+			var elems []attr.Value
+			for _, s := range cipherSuites {
+				elems = append(elems, types.StringValue(s))
+			}
+			suites = types.ListValueMust(types.StringType, elems)
+		}
+		return types.ObjectValueMust(tlsAttributeTypes(), map[string]attr.Value{
+			"min_version":        types.StringValue(minVersion),
+			"cipher_suites":      suites,
+			"client_certificate": types.StringNull(),
+			"client_key":         types.StringNull(),
+			"pkcs11":             types.ObjectNull(pkcs11AttributeTypes()),
+		})
+	}
+	tlsClientConfig := func(resp *provider.ConfigureResponse) *tls.Config {
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		transport, ok := resp.ResourceData.(*legocharmclient.Client).HTTPClient.Transport.(*http.Transport)
+		if !ok {
+			return nil
+		}
+		return transport.TLSClientConfig
+	}
+
+	t.Setenv("LEGOCHARM_TLS_MIN_VERSION", "")
+	t.Setenv("LEGOCHARM_TLS_CIPHER_SUITES", "")
+	t.Setenv("LEGOCHARM_TLS_CLIENT_CERTIFICATE", "")
+	t.Setenv("LEGOCHARM_TLS_CLIENT_KEY", "")
+	for _, name := range []string{"MODULE", "SLOT", "TOKEN_LABEL", "KEY_LABEL", "PIN"} {
+		t.Setenv("LEGOCHARM_TLS_PKCS11_"+name, "")
+	}
+	require.Nil(t, tlsClientConfig(configure(types.ObjectNull(tlsAttributeTypes()))))
+
+	cfg := tlsClientConfig(configure(tlsObject("1.2", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384")))
+	require.Equal(t, uint16(tls.VersionTLS12), cfg.MinVersion)
+	require.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}, cfg.CipherSuites)
+
+	t.Setenv("LEGOCHARM_TLS_MIN_VERSION", "1.3")
+	require.Equal(t, uint16(tls.VersionTLS13), tlsClientConfig(configure(types.ObjectNull(tlsAttributeTypes()))).MinVersion)
+	require.Equal(t, uint16(tls.VersionTLS12), tlsClientConfig(configure(tlsObject("1.2"))).MinVersion, "the attribute overrides the environment")
+
+	t.Setenv("LEGOCHARM_TLS_MIN_VERSION", "1.0")
+	resp := configure(types.ObjectNull(tlsAttributeTypes()))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LEGOCHARM_TLS_MIN_VERSION", resp.Diagnostics.Errors()[0].Summary())
+
+	t.Setenv("LEGOCHARM_TLS_MIN_VERSION", "")
+	resp = configure(tlsObject("1.2", "TLS_RSA_WITH_RC4_128_SHA"))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LegoCharm API TLS Configuration", resp.Diagnostics.Errors()[0].Summary())
+
+	t.Setenv("LEGOCHARM_TLS_CLIENT_CERTIFICATE", "/etc/legocharm/client.crt")
+	resp = configure(types.ObjectNull(tlsAttributeTypes()))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Incomplete LegoCharm API Client Certificate", resp.Diagnostics.Errors()[0].Summary())
+
+	t.Setenv("LEGOCHARM_TLS_CLIENT_KEY", "/etc/legocharm/client.key")
+	resp = configure(types.ObjectNull(tlsAttributeTypes()))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LegoCharm API Client Certificate", resp.Diagnostics.Errors()[0].Summary())
+
+	t.Setenv("LEGOCHARM_TLS_PKCS11_MODULE", "/usr/lib/softhsm/libsofthsm2.so")
+	resp = configure(types.ObjectNull(tlsAttributeTypes()))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Conflicting LegoCharm API Client Keys", resp.Diagnostics.Errors()[0].Summary())
+
+	t.Setenv("LEGOCHARM_TLS_CLIENT_KEY", "")
+	resp = configure(types.ObjectNull(tlsAttributeTypes()))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Incomplete LegoCharm API Client Key", resp.Diagnostics.Errors()[0].Summary())
+
+	t.Setenv("LEGOCHARM_TLS_PKCS11_SLOT", "first")
+	resp = configure(types.ObjectNull(tlsAttributeTypes()))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LEGOCHARM_TLS_PKCS11_SLOT", resp.Diagnostics.Errors()[0].Summary())
+
+	t.Setenv("LEGOCHARM_TLS_PKCS11_SLOT", "")
+	pkcs11Object := types.ObjectValueMust(pkcs11AttributeTypes(), map[string]attr.Value{
+		"module":      types.StringValue(filepath.Join(t.TempDir(), "missing.so")),
+		"slot":        types.Int64Value(0),
+		"token_label": types.StringNull(),
+		"key_label":   types.StringValue("client"),
+		"pin":         types.StringValue("1234"),
+	})
+	tlsAttrs := tlsObject("1.2").Attributes()
+	tlsAttrs["pkcs11"] = pkcs11Object
+	resp = configure(types.ObjectValueMust(tlsAttributeTypes(), tlsAttrs))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LegoCharm API Client Certificate", resp.Diagnostics.Errors()[0].Summary())
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "failed to load client key", "the attribute overrides the environment module")
+}
+
+func TestProvider_ConfigureAPIVersion(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	configure := func(apiVersion types.String) *provider.ConfigureResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &legocharmProviderModel{
+			Address:    types.StringValue("https://lego.example.com"),
+			Addresses:  types.ListNull(types.StringType),
+			Username:   types.StringValue("admin"),
+			Password:   types.StringValue("secret"),
+			APIVersion: apiVersion,
+			Audit:      types.ObjectNull(auditAttributeTypes()),
+			TLS:        types.ObjectNull(tlsAttributeTypes()),
+			Kubernetes: types.ObjectNull(kubernetesAttributeTypes()),
+		}).HasError())
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	version := func(resp *provider.ConfigureResponse) legocharmclient.APIVersion {
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		return resp.ResourceData.(*legocharmclient.Client).APIVersion
+	}
+
+	t.Setenv("LEGOCHARM_API_VERSION", "")
+	calls := stubAPIVersion(t, nil)
+	require.Equal(t, legocharmclient.APIVersion1, version(configure(types.StringNull())))
+	require.Equal(t, 1, *calls)
+	require.Equal(t, legocharmclient.APIVersion1, version(configure(types.StringValue("v1"))))
+	require.Equal(t, 1, *calls, "setting the version skips the detection")
+
+	t.Setenv("LEGOCHARM_API_VERSION", "v1")
+	require.Equal(t, legocharmclient.APIVersion1, version(configure(types.StringNull())))
+	require.Equal(t, 1, *calls, "setting the version skips the detection")
+
+	t.Setenv("LEGOCHARM_API_VERSION", "v0")
+	resp := configure(types.StringNull())
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid LEGOCHARM_API_VERSION", resp.Diagnostics.Errors()[0].Summary())
+
+	t.Setenv("LEGOCHARM_API_VERSION", "")
+	stubAPIVersion(t, errors.New("connection refused"))
+	require.Equal(t, legocharmclient.APIVersion(""), version(configure(types.StringNull())),
+		"an unreachable API does not fail the configuration")
+
+	stubAPIVersion(t, fmt.Errorf("%w: the API serves none of v1", legocharmclient.ErrUnsupportedAPIVersion))
+	resp = configure(types.StringNull())
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Unsupported LegoCharm API Version", resp.Diagnostics.Errors()[0].Summary())
+}
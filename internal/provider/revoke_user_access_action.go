@@ -0,0 +1,123 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ action.ActionWithConfigure = &RevokeUserAccessAction{}
+
+// NewRevokeUserAccessAction creates a new revoke user access action.
+func NewRevokeUserAccessAction() action.Action { return &RevokeUserAccessAction{} }
+
+// RevokeUserAccessAction is the action implementation for deleting every
+// domain access permission held by a LegoCharm user.
+type RevokeUserAccessAction struct {
+	client legocharmclient.API
+}
+
+// RevokeUserAccessActionModel maps Terraform schema to Go types for the
+// revoke user access action.
+type RevokeUserAccessActionModel struct {
+	Username types.String `tfsdk:"username"`
+}
+
+func (a *RevokeUserAccessAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_revoke_user_access"
+}
+
+func (a *RevokeUserAccessAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deletes every domain access permission held by a user, for example to lock out a compromised ACME client with `terraform apply -invoke`. The user itself is kept. Permissions that fail to delete are reported after the others have been deleted. Permissions managed by `legocharm_user_domain_access` or similar resources are created again by their next apply, unless the configuration is changed too. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the user whose permissions are deleted.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *RevokeUserAccessAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+func (a *RevokeUserAccessAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data RevokeUserAccessActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if a.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this action")
+		return
+	}
+
+	username := data.Username.ValueString()
+	if _, err := a.client.GetUserByUsername(ctx, username); err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("username"), "User Not Found", fmt.Sprintf("No user %q exists.", username))
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to read user", err, nil)
+		return
+	}
+
+	permissions, err := a.client.ListDomainAccessByUsername(ctx, username)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list domain access", err, nil)
+		return
+	}
+	// Domain names only make the progress messages readable, so fall back
+	// to IDs when they cannot be listed.
+	fqdns := map[int]string{}
+	if domains, err := a.client.ListDomains(ctx); err == nil {
+		for _, domain := range domains {
+			fqdns[domain.ID] = domain.Fqdn
+		}
+	}
+
+	revoked := 0
+	for _, p := range permissions {
+		domain, ok := fqdns[p.Domain]
+		if !ok {
+			domain = "domain " + strconv.Itoa(p.Domain)
+		}
+		if err := deletePermission(ctx, a.client, p.ID); err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("Unable to revoke %s access to %s", p.AccessLevel, domain), err, nil)
+			continue
+		}
+		tflog.Trace(ctx, "deleted domain access", map[string]interface{}{"id": p.ID})
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Revoked %s access to %s.", p.AccessLevel, domain)})
+		revoked++
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Revoked %d of %d permissions held by %s.", revoked, len(permissions), username)})
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestRevokeUserAccessAction_Invoke(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "bob"
+	api.domains[3] = "*.example.com"
+	api.permissions[7] = legocharmclient.DomainUserPermissionData{ID: 7, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	api.permissions[8] = legocharmclient.DomainUserPermissionData{ID: 8, UserID: 1004, Domain: 3, AccessLevel: "domain"}
+	api.permissions[9] = legocharmclient.DomainUserPermissionData{ID: 9, UserID: 1005, Domain: 2, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	a := &RevokeUserAccessAction{client: client}
+
+	ctx := context.Background()
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, schemaResp)
+
+	invoke := func(username string) (*action.InvokeResponse, []string) {
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, config.Set(ctx, &RevokeUserAccessActionModel{Username: types.StringValue(username)}).HasError())
+
+		var messages []string
+		resp := &action.InvokeResponse{SendProgress: func(event action.InvokeProgressEvent) {
+			messages = append(messages, event.Message)
+		}}
+		a.Invoke(ctx, action.InvokeRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		return resp, messages
+	}
+
+	resp, messages := invoke("alice")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.ElementsMatch(t, []string{
+		"Revoked subdomain access to staging.example.com.",
+		"Revoked domain access to *.example.com.",
+		"Revoked 2 of 2 permissions held by alice.",
+	}, messages)
+	require.Len(t, api.permissions, 1)
+	require.Contains(t, api.permissions, 9)
+
+	// Nothing left to revoke.
+	resp, messages = invoke("alice")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, []string{"Revoked 0 of 0 permissions held by alice."}, messages)
+
+	resp, _ = invoke("nobody")
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", resp.Diagnostics.Errors()[0].Summary())
+	require.Len(t, api.permissions, 1)
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ action.ActionWithConfigure = &RotateUserPasswordAction{}
+
+// NewRotateUserPasswordAction creates a new rotate user password action.
+func NewRotateUserPasswordAction() action.Action { return &RotateUserPasswordAction{} }
+
+// RotateUserPasswordAction is the action implementation for changing the
+// password of an existing LegoCharm user on demand.
+type RotateUserPasswordAction struct {
+	client legocharmclient.API
+}
+
+// RotateUserPasswordActionModel maps Terraform schema to Go types for the
+// rotate user password action.
+type RotateUserPasswordActionModel struct {
+	Username        types.String `tfsdk:"username"`
+	PasswordWO      types.String `tfsdk:"password_wo"`
+	PasswordLength  types.Int64  `tfsdk:"password_length"`
+	PasswordSpecial types.Bool   `tfsdk:"password_special"`
+}
+
+func (a *RotateUserPasswordAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rotate_user_password"
+}
+
+func (a *RotateUserPasswordAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sets a new password for an existing user, for example to rotate a leaked credential with `terraform apply -invoke`. Unless `password_wo` is set, a random password is generated and printed once in the progress output of the invocation; it is not stored in the plan or state, but may end up in CI logs. A `legocharm_user` managing the same user keeps the old password in state. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the existing user. The user the provider authenticates as cannot be rotated this way; use `legocharm_admin_password` instead.",
+				Required:            true,
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "New password, for example from an ephemeral resource. It is not printed.",
+				Optional:            true,
+				WriteOnly:           true,
+			},
+			"password_length": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Length of the generated password. Only used when `password_wo` is omitted. Defaults to `%d`.", defaultPasswordLength),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64AtLeast(minPasswordLength),
+				},
+			},
+			"password_special": schema.BoolAttribute{
+				MarkdownDescription: "Whether the generated password includes special characters. Only used when `password_wo` is omitted. Defaults to `true`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *RotateUserPasswordAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+func (a *RotateUserPasswordAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data RotateUserPasswordActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if a.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this action")
+		return
+	}
+
+	password := data.PasswordWO.ValueString()
+	generated := data.PasswordWO.IsNull()
+	if generated {
+		length := int64(defaultPasswordLength)
+		if !data.PasswordLength.IsNull() {
+			length = data.PasswordLength.ValueInt64()
+		}
+		special := data.PasswordSpecial.IsNull() || data.PasswordSpecial.ValueBool()
+		var err error
+		password, err = generatePassword(int(length), special)
+		if err != nil {
+			resp.Diagnostics.AddError("Password Generation Failed", err.Error())
+			return
+		}
+	}
+
+	username := data.Username.ValueString()
+	setUserPassword(ctx, a.client, username, password, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	message := fmt.Sprintf("Changed the password of %s.", username)
+	if generated {
+		message = fmt.Sprintf("Changed the password of %s to: %s", username, password)
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: message})
+}
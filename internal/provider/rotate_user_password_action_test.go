@@ -0,0 +1,75 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestRotateUserPasswordAction_Invoke(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	a := &RotateUserPasswordAction{client: client}
+
+	ctx := context.Background()
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, schemaResp)
+
+	invoke := func(data RotateUserPasswordActionModel) (*action.InvokeResponse, []string) {
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, config.Set(ctx, &data).HasError())
+
+		var messages []string
+		resp := &action.InvokeResponse{SendProgress: func(event action.InvokeProgressEvent) {
+			messages = append(messages, event.Message)
+		}}
+		a.Invoke(ctx, action.InvokeRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		return resp, messages
+	}
+
+	resp, messages := invoke(RotateUserPasswordActionModel{
+		Username:        types.StringValue("alice"),
+		PasswordWO:      types.StringNull(),
+		PasswordLength:  types.Int64Value(16),
+		PasswordSpecial: types.BoolNull(),
+	})
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Len(t, api.passwords[1004], 16)
+	require.Equal(t, []string{"Changed the password of alice to: " + api.passwords[1004]}, messages)
+
+	// A configured password is set but never printed.
+	resp, messages = invoke(RotateUserPasswordActionModel{
+		Username:        types.StringValue("alice"),
+		PasswordWO:      types.StringValue("correct-horse-battery"),
+		PasswordLength:  types.Int64Null(),
+		PasswordSpecial: types.BoolNull(),
+	})
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, "correct-horse-battery", api.passwords[1004])
+	require.Equal(t, []string{"Changed the password of alice."}, messages)
+
+	resp, messages = invoke(RotateUserPasswordActionModel{
+		Username:        types.StringValue("nobody"),
+		PasswordWO:      types.StringNull(),
+		PasswordLength:  types.Int64Null(),
+		PasswordSpecial: types.BoolNull(),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", resp.Diagnostics.Errors()[0].Summary())
+	require.Empty(t, messages)
+}
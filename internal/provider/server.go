@@ -0,0 +1,185 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// protocol6Server is the set of RPCs the framework server implements.
+type protocol6Server interface {
+	tfprotov6.ProviderServer
+	tfprotov6.ListResourceServer
+	tfprotov6.ActionServer
+}
+
+// NewProtocol6Server returns the provider's protocol version 6 server: the
+// framework server, which attributes the API calls made while handling an RPC
+// to the module named in its provider_meta, and adds the deprecations that
+// the API announced meanwhile to its response as warnings. Each endpoint is
+// reported once per provider instance. Terraform runs several instances per
+// operation, for validate, plan and apply, so it may be reported in each.
+func NewProtocol6Server(version string) func() (tfprotov6.ProviderServer, error) {
+	return func() (tfprotov6.ProviderServer, error) {
+		p := New(version)().(*legocharmProvider)
+		server, err := providerserver.NewProtocol6WithError(p)()
+		if err != nil {
+			return nil, err
+		}
+		return &providerServer{protocol6Server: server.(protocol6Server), calls: p.calls, deprecations: p.deprecations}, nil
+	}
+}
+
+// providerServer wraps the RPCs that call the API, see NewProtocol6Server.
+type providerServer struct {
+	protocol6Server
+	// calls and deprecations are those of the provider served.
+	calls        *legocharmclient.CallStats
+	deprecations *legocharmclient.DeprecationNotices
+	// summary logs the summary of calls once.
+	summary sync.Once
+}
+
+// StopProvider logs the summary of the API calls made before the provider is
+// stopped, while Terraform still collects its logs.
+func (s *providerServer) StopProvider(ctx context.Context, req *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error) {
+	s.logAPICallSummary(ctx)
+	return s.protocol6Server.StopProvider(ctx, req)
+}
+
+func (s *providerServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	resp, err := s.protocol6Server.ReadResource(withProviderMeta(ctx, req.ProviderMeta), req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, s.deprecationWarnings()...)
+	}
+	return resp, err
+}
+
+func (s *providerServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	resp, err := s.protocol6Server.PlanResourceChange(withProviderMeta(ctx, req.ProviderMeta), req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, s.deprecationWarnings()...)
+	}
+	return resp, err
+}
+
+func (s *providerServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	resp, err := s.protocol6Server.ApplyResourceChange(withProviderMeta(ctx, req.ProviderMeta), req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, s.deprecationWarnings()...)
+	}
+	return resp, err
+}
+
+func (s *providerServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	resp, err := s.protocol6Server.ImportResourceState(ctx, req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, s.deprecationWarnings()...)
+	}
+	return resp, err
+}
+
+func (s *providerServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	resp, err := s.protocol6Server.ReadDataSource(withProviderMeta(ctx, req.ProviderMeta), req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, s.deprecationWarnings()...)
+	}
+	return resp, err
+}
+
+func (s *providerServer) OpenEphemeralResource(ctx context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	resp, err := s.protocol6Server.OpenEphemeralResource(ctx, req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, s.deprecationWarnings()...)
+	}
+	return resp, err
+}
+
+func (s *providerServer) PlanAction(ctx context.Context, req *tfprotov6.PlanActionRequest) (*tfprotov6.PlanActionResponse, error) {
+	resp, err := s.protocol6Server.PlanAction(ctx, req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, s.deprecationWarnings()...)
+	}
+	return resp, err
+}
+
+func (s *providerServer) InvokeAction(ctx context.Context, req *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
+	stream, err := s.protocol6Server.InvokeAction(ctx, req)
+	if stream == nil || stream.Events == nil {
+		return stream, err
+	}
+	// The action runs as its events are read, so the deprecations it hit are
+	// only known by its completion.
+	events := stream.Events
+	stream.Events = func(yield func(tfprotov6.InvokeActionEvent) bool) {
+		for event := range events {
+			if completed, ok := event.Type.(tfprotov6.CompletedInvokeActionEventType); ok {
+				completed.Diagnostics = append(completed.Diagnostics, s.deprecationWarnings()...)
+				event.Type = completed
+			}
+			if !yield(event) {
+				return
+			}
+		}
+	}
+	return stream, err
+}
+
+// deprecationWarnings returns a warning for each deprecation announced since
+// the previous call.
+func (s *providerServer) deprecationWarnings() []*tfprotov6.Diagnostic {
+	if s.deprecations == nil {
+		return nil
+	}
+	var diags []*tfprotov6.Diagnostic
+	for _, d := range s.deprecations.Pending() {
+		summary := "Deprecated LegoCharm API Endpoint"
+		if !d.Deprecated {
+			summary = "LegoCharm API Endpoint Scheduled for Removal"
+		}
+		diags = append(diags, &tfprotov6.Diagnostic{
+			Severity: tfprotov6.DiagnosticSeverityWarning,
+			Summary:  summary,
+			Detail:   deprecationDetail(d),
+		})
+	}
+	return diags
+}
+
+// deprecationDetail explains d and what to do about it.
+func deprecationDetail(d legocharmclient.Deprecation) string {
+	var clauses []string
+	switch {
+	case d.Deprecated && !d.Since.IsZero():
+		clauses = append(clauses, "is deprecated as of "+d.Since.Format("2006-01-02"))
+	case d.Deprecated:
+		clauses = append(clauses, "is deprecated")
+	}
+	switch {
+	case !d.Sunset.IsZero():
+		clauses = append(clauses, "will be removed on "+d.Sunset.Format("2006-01-02"))
+	case !d.Deprecated:
+		clauses = append(clauses, "will be removed")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The LegoCharm API announced that %s %s, which the provider uses, %s. ", d.Method, d.Endpoint, strings.Join(clauses, " and "))
+	b.WriteString("Check for a provider release supporting its replacement before upgrading the httprequest-lego-provider charm")
+	if !d.Sunset.IsZero() {
+		b.WriteString(" past that date")
+	}
+	b.WriteString(".")
+	if d.Link != "" {
+		fmt.Fprintf(&b, "\n\nSee %s", d.Link)
+	}
+	return b.String()
+}
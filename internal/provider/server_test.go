@@ -0,0 +1,163 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// readStubServer answers ReadResource by calling the API with client.
+type readStubServer struct {
+	protocol6Server
+	client *legocharmclient.Client
+}
+
+func (s *readStubServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	_, err := s.client.GetDomainById(ctx, 7)
+	return &tfprotov6.ReadResourceResponse{}, err
+}
+
+func TestDeprecationWarningServer(t *testing.T) {
+	deprecations := &legocharmclient.DeprecationNotices{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "@1767225600")
+		w.Header().Set("Sunset", "Thu, 31 Dec 2026 23:59:59 GMT")
+		w.Header().Set("Link", `<https://example.com/deprecations>; rel="deprecation"`)
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+	username, password := "admin", "secret"
+	client, err := legocharmclient.NewClient(&srv.URL, &username, &password)
+	require.NoError(t, err)
+	client.Deprecations = deprecations
+
+	server := &providerServer{protocol6Server: &readStubServer{client: client}, deprecations: deprecations}
+	resp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []*tfprotov6.Diagnostic{{
+		Severity: tfprotov6.DiagnosticSeverityWarning,
+		Summary:  "Deprecated LegoCharm API Endpoint",
+		Detail: "The LegoCharm API announced that GET /api/v1/domains/{id}/, which the provider uses, " +
+			"is deprecated as of 2026-01-01 and will be removed on 2026-12-31. " +
+			"Check for a provider release supporting its replacement before upgrading the httprequest-lego-provider charm past that date." +
+			"\n\nSee https://example.com/deprecations",
+	}}, resp.Diagnostics)
+
+	resp, err = server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics, "each endpoint is reported once")
+}
+
+// actionStubServer answers InvokeAction with an action calling the API with
+// client as its events are read.
+type actionStubServer struct {
+	protocol6Server
+	client *legocharmclient.Client
+}
+
+func (s *actionStubServer) InvokeAction(ctx context.Context, req *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
+	return &tfprotov6.InvokeActionServerStream{Events: func(yield func(tfprotov6.InvokeActionEvent) bool) {
+		if _, err := s.client.GetDomainById(ctx, 7); err != nil {
+			return
+		}
+		yield(tfprotov6.InvokeActionEvent{Type: tfprotov6.CompletedInvokeActionEventType{}})
+	}}, nil
+}
+
+func TestDeprecationWarningServer_InvokeAction(t *testing.T) {
+	deprecations := &legocharmclient.DeprecationNotices{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+	username, password := "admin", "secret"
+	client, err := legocharmclient.NewClient(&srv.URL, &username, &password)
+	require.NoError(t, err)
+	client.Deprecations = deprecations
+
+	server := &providerServer{protocol6Server: &actionStubServer{client: client}, deprecations: deprecations}
+	stream, err := server.InvokeAction(context.Background(), &tfprotov6.InvokeActionRequest{})
+	require.NoError(t, err)
+	var events []tfprotov6.InvokeActionEvent
+	for event := range stream.Events {
+		events = append(events, event)
+	}
+	require.Len(t, events, 1)
+	completed, ok := events[0].Type.(tfprotov6.CompletedInvokeActionEventType)
+	require.True(t, ok)
+	require.Len(t, completed.Diagnostics, 1)
+	require.Equal(t, "Deprecated LegoCharm API Endpoint", completed.Diagnostics[0].Summary)
+}
+
+func TestDeprecationDetail(t *testing.T) {
+	sunset := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		deprecation legocharmclient.Deprecation
+		want        string
+	}{
+		"deprecated": {
+			deprecation: legocharmclient.Deprecation{Method: "GET", Endpoint: "/api/v1/users/", Deprecated: true},
+			want: "The LegoCharm API announced that GET /api/v1/users/, which the provider uses, is deprecated. " +
+				"Check for a provider release supporting its replacement before upgrading the httprequest-lego-provider charm.",
+		},
+		"sunset": {
+			deprecation: legocharmclient.Deprecation{Method: "POST", Endpoint: "/api/v1/domains/", Sunset: sunset},
+			want: "The LegoCharm API announced that POST /api/v1/domains/, which the provider uses, will be removed on 2026-12-31. " +
+				"Check for a provider release supporting its replacement before upgrading the httprequest-lego-provider charm past that date.",
+		},
+		"sunset without date": {
+			deprecation: legocharmclient.Deprecation{Method: "POST", Endpoint: "/api/v1/domains/"},
+			want: "The LegoCharm API announced that POST /api/v1/domains/, which the provider uses, will be removed. " +
+				"Check for a provider release supporting its replacement before upgrading the httprequest-lego-provider charm.",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, deprecationDetail(tt.deprecation))
+		})
+	}
+}
+
+func TestProviderServer_StopProvider(t *testing.T) {
+	calls := &legocharmclient.CallStats{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+	username, password := "admin", "secret"
+	client, err := legocharmclient.NewClient(&srv.URL, &username, &password)
+	require.NoError(t, err)
+	client.Stats = calls
+
+	inner, err := providerserver.NewProtocol6WithError(New("test")())()
+	require.NoError(t, err)
+	server := &providerServer{protocol6Server: inner.(protocol6Server), calls: calls}
+	_, err = client.GetDomainById(context.Background(), 7)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	_, err = server.StopProvider(ctx, &tfprotov6.StopProviderRequest{})
+	require.NoError(t, err)
+	// The summary is logged when the provider is stopped, not again on exit.
+	LogAPICallSummary(ctx, server)
+
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "LegoCharm API calls: 1 GET", entries[0]["@message"])
+}
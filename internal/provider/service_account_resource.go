@@ -0,0 +1,455 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ resource.Resource = &ServiceAccountResource{}
+var _ resource.ResourceWithValidateConfig = &ServiceAccountResource{}
+var _ resource.ResourceWithModifyPlan = &ServiceAccountResource{}
+
+// NewServiceAccountResource creates a new service account resource.
+func NewServiceAccountResource() resource.Resource { return &ServiceAccountResource{} }
+
+// ServiceAccountResource is the resource implementation for a non-staff
+// LegoCharm user used by an ACME client, with optional domain access
+// permissions and ready-made httpreq credentials.
+type ServiceAccountResource struct {
+	client legocharmclient.API
+}
+
+// ServiceAccountModel maps Terraform schema to Go types for service account
+// resources.
+type ServiceAccountModel struct {
+	Username          types.String `tfsdk:"username"`
+	Email             types.String `tfsdk:"email"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.String `tfsdk:"password_wo_version"`
+	Password          types.String `tfsdk:"password"`
+	IsStaff           types.Bool   `tfsdk:"is_staff"`
+	Grants            types.Set    `tfsdk:"grants"`
+	GrantIds          types.Map    `tfsdk:"grant_ids"`
+	HttpreqConfig     types.Map    `tfsdk:"httpreq_config"`
+	Id                types.String `tfsdk:"id"`
+}
+
+func (r *ServiceAccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account"
+}
+
+func (r *ServiceAccountResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A non-staff user for an ACME client, with optional domain access permissions. `httpreq_config` holds the settings for lego's `httpreq` DNS provider, ready to be passed to an ACME provider's DNS challenge configuration.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username. 150 characters or fewer; letters, digits and `@`, `.`, `+`, `-` and `_` only.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					username(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					emailAddress(),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only password, for example from an ephemeral `random_password`. The value is sent to the API but never stored in the Terraform plan or state, and is left out of `httpreq_config`. If unset, a password is generated and stored in `password`. Requires Terraform 1.11 or later.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringLengthAtLeast(minPasswordLength),
+				},
+			},
+			"password_wo_version": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value whose change rotates the password in place: `password_wo` is sent to the API again, or a new password is generated.",
+				Optional:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Generated password, or null when `password_wo` is set.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_staff": schema.BoolAttribute{
+				MarkdownDescription: "Always `false`. A service account given staff status outside Terraform is demoted on the next apply.",
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"grants": schema.SetNestedAttribute{
+				MarkdownDescription: "Domain access permissions granted to the account. Each domain may appear only once.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: grantAttributes(),
+				},
+			},
+			"grant_ids": schema.MapAttribute{
+				MarkdownDescription: "Database IDs of the domain access permissions, keyed by normalized domain.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+			"httpreq_config": schema.MapAttribute{
+				MarkdownDescription: "Settings for lego's `httpreq` DNS provider: `HTTPREQ_ENDPOINT`, `HTTPREQ_USERNAME` and, unless `password_wo` is set, `HTTPREQ_PASSWORD`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures no domain is granted twice and flags suspicious
+// access levels.
+func (r *ServiceAccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ServiceAccountModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateGrants(ctx, data.Grants, &resp.Diagnostics)
+}
+
+// ModifyPlan plans password changes: a generated password is regenerated
+// when password_wo_version changes, and switching between a generated and a
+// write-only password rotates it.
+func (r *ServiceAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config ServiceAccountModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	usingWO := !config.PasswordWO.IsNull()
+
+	if req.State.Raw.IsNull() {
+		if usingWO {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringNull())...)
+		}
+		return
+	}
+
+	var state ServiceAccountModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !serviceAccountRotates(plan, state, usingWO) {
+		return
+	}
+	password := types.StringUnknown()
+	if usingWO {
+		password = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), password)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("httpreq_config"), types.MapUnknown(types.StringType))...)
+}
+
+// serviceAccountRotates reports whether applying plan over state sets a new
+// password.
+func serviceAccountRotates(plan, state ServiceAccountModel, usingWO bool) bool {
+	return !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) || usingWO == !state.Password.IsNull()
+}
+
+func (r *ServiceAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ServiceAccountModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	var grants []serviceUserGrantModel
+	if !data.Grants.IsNull() {
+		resp.Diagnostics.Append(data.Grants.ElementsAs(ctx, &grants, false)...)
+	}
+	password := serviceAccountPassword(ctx, req.Config, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user := createServiceUser(ctx, r.client, legocharmclient.UserCreateData{
+		Username: data.Username.ValueString(),
+		Password: password,
+		Email:    data.Email.ValueString(),
+		Groups:   []string{},
+		IsActive: true,
+	}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id := legocharmclient.LastPathSegment(user.Url)
+
+	// Grant access; on failure undo everything created so far so a retry
+	// starts from a clean slate instead of hitting "User Exists".
+	ids := createGrants(ctx, r.client, id, grants, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		deleteUserAndGrants(ctx, r.client, id, ids, &resp.Diagnostics)
+		return
+	}
+
+	data.Id = types.StringValue(id)
+	data.Email = types.StringValue(user.Email)
+	data.IsStaff = types.BoolValue(user.IsStaff)
+	resp.Diagnostics.Append(r.setGrants(ctx, &data, grants, ids)...)
+	resp.Diagnostics.Append(r.setHttpreqConfig(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created service account", map[string]interface{}{"grants": len(ids)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ServiceAccountModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	user, err := r.client.GetUserById(ctx, data.Id.ValueString())
+	if err != nil {
+		if err == legocharmclient.ErrNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to read user", err, nil)
+		return
+	}
+	data.Username = types.StringValue(user.Username)
+	data.Email = types.StringValue(user.Email)
+	data.IsStaff = types.BoolValue(user.IsStaff)
+
+	if !data.Grants.IsNull() {
+		var grants []serviceUserGrantModel
+		resp.Diagnostics.Append(data.Grants.ElementsAs(ctx, &grants, false)...)
+		ids := map[string]int64{}
+		resp.Diagnostics.Append(data.GrantIds.ElementsAs(ctx, &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		current, currentIds := refreshGrants(ctx, r.client, user.Username, grants, ids, false, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.setGrants(ctx, &data, current, currentIds)...)
+	}
+	resp.Diagnostics.Append(r.setHttpreqConfig(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ServiceAccountModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	id := state.Id.ValueString()
+	var update legocharmclient.UserUpdateData
+	if !plan.Email.Equal(state.Email) {
+		email := plan.Email.ValueString()
+		update.Email = &email
+	}
+	if state.IsStaff.ValueBool() {
+		isStaff := false
+		update.IsStaff = &isStaff
+	}
+	if plan.Password.IsUnknown() || (plan.Password.IsNull() && !state.Password.IsNull()) || !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		update.Password = serviceAccountPassword(ctx, req.Config, &plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if update.Email != nil || update.IsStaff != nil || update.Password != "" {
+		if _, err := r.client.UpdateUser(ctx, id, update); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to update user", err, userAPIFields)
+			return
+		}
+	}
+	plan.IsStaff = types.BoolValue(false)
+
+	var planned, prior []serviceUserGrantModel
+	if !plan.Grants.IsNull() {
+		resp.Diagnostics.Append(plan.Grants.ElementsAs(ctx, &planned, false)...)
+	}
+	if !state.Grants.IsNull() {
+		resp.Diagnostics.Append(state.Grants.ElementsAs(ctx, &prior, false)...)
+	}
+	ids := map[string]int64{}
+	resp.Diagnostics.Append(state.GrantIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateGrants(ctx, r.client, id, planned, prior, ids, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setGrants(ctx, &plan, planned, ids)...)
+	resp.Diagnostics.Append(r.setHttpreqConfig(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ServiceAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ServiceAccountModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	ids := map[string]int64{}
+	resp.Diagnostics.Append(data.GrantIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteUserAndGrants(ctx, r.client, data.Id.ValueString(), ids, &resp.Diagnostics)
+}
+
+func (r *ServiceAccountResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// serviceAccountPassword returns the password to send to the API: the
+// write-only password from configuration, or a newly generated one which is
+// stored on data.
+func serviceAccountPassword(ctx context.Context, config tfsdk.Config, data *ServiceAccountModel, diags *diag.Diagnostics) string {
+	// Write-only values are only available from configuration
+	var passwordWO types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if diags.HasError() {
+		return ""
+	}
+
+	if !passwordWO.IsNull() {
+		data.Password = types.StringNull()
+		return passwordWO.ValueString()
+	}
+
+	password, err := generatePassword(defaultPasswordLength, true)
+	if err != nil {
+		diags.AddError("Password Generation Failed", err.Error())
+		return ""
+	}
+	data.Password = types.StringValue(password)
+	return password
+}
+
+// setGrants stores grants and their database IDs on data. Grants stay null
+// when they are not configured.
+func (r *ServiceAccountResource) setGrants(ctx context.Context, data *ServiceAccountModel, grants []serviceUserGrantModel, ids map[string]int64) diag.Diagnostics {
+	set, grantIds, diags := grantValues(ctx, grants, ids)
+	if !data.Grants.IsNull() {
+		data.Grants = set
+	}
+	data.GrantIds = grantIds
+	return diags
+}
+
+// setHttpreqConfig derives httpreq_config from the API address, username and
+// stored password.
+func (r *ServiceAccountResource) setHttpreqConfig(ctx context.Context, data *ServiceAccountModel) diag.Diagnostics {
+	config := map[string]string{
+		"HTTPREQ_ENDPOINT": r.client.Endpoint(),
+		"HTTPREQ_USERNAME": data.Username.ValueString(),
+	}
+	if !data.Password.IsNull() {
+		config["HTTPREQ_PASSWORD"] = data.Password.ValueString()
+	}
+
+	value, diags := types.MapValueFrom(ctx, types.StringType, config)
+	data.HttpreqConfig = value
+	return diags
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// serviceAccountPlan builds a plan and matching configuration for a service
+// account with the given grants, given as domain/access level pairs. Grants
+// are null when none are given.
+func serviceAccountPlan(t *testing.T, r *ServiceAccountResource, passwordWO types.String, grants ...string) (tfsdk.Plan, tfsdk.Config, ServiceAccountModel) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := ServiceAccountModel{
+		Username:          types.StringValue("svc-acme"),
+		Email:             types.StringValue(""),
+		PasswordWO:        types.StringNull(),
+		PasswordWOVersion: types.StringNull(),
+		Password:          types.StringUnknown(),
+		IsStaff:           types.BoolValue(false),
+		Grants:            types.SetNull(grantObjectType),
+		GrantIds:          types.MapUnknown(types.Int64Type),
+		HttpreqConfig:     types.MapUnknown(types.StringType),
+		Id:                types.StringUnknown(),
+	}
+	if len(grants) > 0 {
+		var models []serviceUserGrantModel
+		for i := 0; i < len(grants); i += 2 {
+			models = append(models, serviceUserGrantModel{Domain: types.StringValue(grants[i]), AccessLevel: types.StringValue(grants[i+1])})
+		}
+		set, _, diags := grantValues(ctx, models, nil)
+		require.False(t, diags.HasError())
+		data.Grants = set
+	}
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	configData := data
+	configData.PasswordWO = passwordWO
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &configData).HasError())
+	return plan, tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}, data
+}
+
+func TestServiceAccountResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &ServiceAccountResource{client: client}
+	ctx := context.Background()
+
+	plan, config, _ := serviceAccountPlan(t, r, types.StringNull(), "staging.example.com", "domain")
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan, Config: config}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var created ServiceAccountModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Len(t, created.Password.ValueString(), defaultPasswordLength)
+	require.False(t, created.IsStaff.ValueBool())
+	require.Len(t, api.permissions, 1)
+
+	httpreq := map[string]string{}
+	require.False(t, created.HttpreqConfig.ElementsAs(ctx, &httpreq, false).HasError())
+	require.Equal(t, map[string]string{
+		"HTTPREQ_ENDPOINT": srv.URL,
+		"HTTPREQ_USERNAME": "svc-acme",
+		"HTTPREQ_PASSWORD": created.Password.ValueString(),
+	}, httpreq)
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	// Dropping the grants removes the permission.
+	plan, config, data := serviceAccountPlan(t, r, types.StringNull())
+	data.Id = created.Id
+	data.Password = created.Password
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, Config: config, State: readResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Empty(t, api.permissions)
+
+	var updated ServiceAccountModel
+	require.False(t, updateResp.State.Get(ctx, &updated).HasError())
+	require.Equal(t, created.Password, updated.Password)
+	require.True(t, updated.Grants.IsNull())
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	_, ok := api.userID("svc-acme")
+	require.False(t, ok)
+}
+
+func TestServiceAccountResource_Create_WriteOnlyPassword(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &ServiceAccountResource{client: client}
+	ctx := context.Background()
+
+	plan, config, _ := serviceAccountPlan(t, r, types.StringValue("a-long-enough-password"))
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan, Config: config}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var created ServiceAccountModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.True(t, created.Password.IsNull())
+	require.True(t, created.Grants.IsNull())
+	require.Empty(t, created.GrantIds.Elements())
+	require.NotContains(t, created.HttpreqConfig.Elements(), "HTTPREQ_PASSWORD")
+}
+
+func TestServiceAccountRotates(t *testing.T) {
+	generated := ServiceAccountModel{Password: types.StringValue("generated"), PasswordWOVersion: types.StringNull()}
+	writeOnly := ServiceAccountModel{Password: types.StringNull(), PasswordWOVersion: types.StringNull()}
+	bumped := ServiceAccountModel{PasswordWOVersion: types.StringValue("2")}
+
+	require.False(t, serviceAccountRotates(generated, generated, false))
+	require.False(t, serviceAccountRotates(writeOnly, writeOnly, true))
+	require.True(t, serviceAccountRotates(bumped, generated, false))
+	require.True(t, serviceAccountRotates(bumped, writeOnly, true))
+	require.True(t, serviceAccountRotates(generated, generated, true))
+	require.True(t, serviceAccountRotates(writeOnly, writeOnly, false))
+}
+
+func TestAccServiceAccountResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	username := testAccName(t)
+	domain := testAccDomain(t, env)
+	ctx := context.Background()
+
+	grants := func(want int) resourcetest.TestCheckFunc {
+		return func(*terraform.State) error {
+			accesses, err := env.API.ListDomainAccessByUsername(ctx, username)
+			if err != nil {
+				return err
+			}
+			if len(accesses) != want {
+				return fmt.Errorf("user %s holds %d grants, want %d", username, len(accesses), want)
+			}
+			for _, access := range accesses {
+				if access.AccessLevel != "subdomain" {
+					return fmt.Errorf("user %s holds a %s grant, want subdomain", username, access.AccessLevel)
+				}
+			}
+			return nil
+		}
+	}
+	config := func(extra string) string {
+		return fmt.Sprintf(`
+resource "legocharm_service_account" "test" {
+  username = %[1]q
+  email    = "%[1]s@example.com"
+  grants   = [{ domain = %[2]q, access_level = "subdomain" }]
+  %[3]s
+}
+`, username, domain.Fqdn, extra)
+	}
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks:   []tfversion.TerraformVersionCheck{tfversion.SkipBelow(tfversion.Version1_11_0)},
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config(""),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttr("legocharm_service_account.test", "httpreq_config.%", "3"),
+					resourcetest.TestCheckResourceAttr("legocharm_service_account.test", "httpreq_config.HTTPREQ_ENDPOINT", env.Address),
+					resourcetest.TestCheckResourceAttr("legocharm_service_account.test", "httpreq_config.HTTPREQ_USERNAME", username),
+					resourcetest.TestCheckResourceAttrPair("legocharm_service_account.test", "httpreq_config.HTTPREQ_PASSWORD", "legocharm_service_account.test", "password"),
+					testAccCheckPassword(env, "legocharm_service_account.test", username),
+					grants(1),
+				),
+			},
+			// A grant revoked outside Terraform is granted again.
+			{
+				PreConfig: func() {
+					accesses, err := env.API.ListDomainAccessByUsername(ctx, username)
+					require.NoError(t, err)
+					for _, access := range accesses {
+						_, err := env.API.DeleteDomainAccess(ctx, access.ID)
+						require.NoError(t, err)
+					}
+				},
+				Config: config(""),
+				Check:  grants(1),
+			},
+			// A write-only password replaces the generated one.
+			{
+				Config: config(`
+  password_wo         = "tfacc-Secret-123"
+  password_wo_version = "1"`),
+				Check: func(*terraform.State) error {
+					valid, err := env.API.HasValidUserPassword(ctx, username, "tfacc-Secret-123")
+					if err == nil && !valid {
+						err = fmt.Errorf("the write-only password of %s was not set", username)
+					}
+					return err
+				},
+			},
+		},
+		CheckDestroy: testAccCheckUserDestroyed(env, username),
+	})
+}
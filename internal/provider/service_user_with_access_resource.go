@@ -0,0 +1,429 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ resource.Resource = &ServiceUserWithAccessResource{}
+var _ resource.ResourceWithValidateConfig = &ServiceUserWithAccessResource{}
+var _ resource.ResourceWithModifyPlan = &ServiceUserWithAccessResource{}
+
+// NewServiceUserWithAccessResource creates a new service user with access resource.
+func NewServiceUserWithAccessResource() resource.Resource { return &ServiceUserWithAccessResource{} }
+
+// ServiceUserWithAccessResource is the resource implementation for a LegoCharm
+// user together with its domain access permissions. It covers the common case
+// of one ACME client identity per service in a single object.
+type ServiceUserWithAccessResource struct {
+	client legocharmclient.API
+}
+
+// ServiceUserWithAccessModel maps Terraform schema to Go types for service
+// user with access resources.
+type ServiceUserWithAccessModel struct {
+	Username        types.String `tfsdk:"username"`
+	Email           types.String `tfsdk:"email"`
+	Password        types.String `tfsdk:"password"`
+	PasswordVersion types.String `tfsdk:"password_version"`
+	Grants          types.Set    `tfsdk:"grants"`
+	GrantIds        types.Map    `tfsdk:"grant_ids"`
+	Authoritative   types.Bool   `tfsdk:"authoritative"`
+	Id              types.String `tfsdk:"id"`
+}
+
+func (r *ServiceUserWithAccessResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_user_with_access"
+}
+
+func (r *ServiceUserWithAccessResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A user with a generated password and a set of domain access permissions, managed as one object. Grants are created after the user and removed before it.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username. 150 characters or fewer; letters, digits and `@`, `.`, `+`, `-` and `_` only.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					username(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					emailAddress(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Generated password for the user.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"password_version": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value which, when changed, generates and sets a new password.",
+				Optional:            true,
+			},
+			"grants": schema.SetNestedAttribute{
+				MarkdownDescription: "Domain access permissions granted to the user. Each domain may appear only once.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: grantAttributes(),
+				},
+			},
+			"grant_ids": schema.MapAttribute{
+				MarkdownDescription: "Database IDs of the domain access permissions, keyed by normalized domain.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+			"authoritative": schema.BoolAttribute{
+				MarkdownDescription: "When true, domain access permissions held by the user but not declared in `grants` are removed, reverting grants made outside Terraform. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures no domain is granted twice and flags suspicious
+// access levels.
+func (r *ServiceUserWithAccessResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ServiceUserWithAccessModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateGrants(ctx, data.Grants, &resp.Diagnostics)
+}
+
+// ModifyPlan marks the password for regeneration when password_version changes.
+func (r *ServiceUserWithAccessResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ServiceUserWithAccessModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.PasswordVersion.Equal(state.PasswordVersion) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringUnknown())...)
+	}
+}
+
+func (r *ServiceUserWithAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ServiceUserWithAccessModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	var grants []serviceUserGrantModel
+	resp.Diagnostics.Append(data.Grants.ElementsAs(ctx, &grants, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := generatePassword(defaultPasswordLength, true)
+	if err != nil {
+		resp.Diagnostics.AddError("Password Generation Failed", err.Error())
+		return
+	}
+
+	user := createServiceUser(ctx, r.client, legocharmclient.UserCreateData{
+		Username: data.Username.ValueString(),
+		Password: password,
+		Email:    data.Email.ValueString(),
+		Groups:   []string{},
+		IsActive: true,
+	}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id := legocharmclient.LastPathSegment(user.Url)
+
+	// Grant access; on failure undo everything created so far so a retry
+	// starts from a clean slate instead of hitting "User Exists".
+	ids := createGrants(ctx, r.client, id, grants, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		deleteUserAndGrants(ctx, r.client, id, ids, &resp.Diagnostics)
+		return
+	}
+
+	data.Id = types.StringValue(id)
+	data.Email = types.StringValue(user.Email)
+	data.Password = types.StringValue(password)
+	grantIds, diags := types.MapValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	data.GrantIds = grantIds
+
+	tflog.Trace(ctx, "created service user with access", map[string]interface{}{"grants": len(ids)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceUserWithAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ServiceUserWithAccessModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	user, err := r.client.GetUserById(ctx, data.Id.ValueString())
+	if err != nil {
+		if err == legocharmclient.ErrNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to read user", err, nil)
+		return
+	}
+	data.Username = types.StringValue(user.Username)
+	data.Email = types.StringValue(user.Email)
+	if data.Authoritative.IsNull() {
+		data.Authoritative = types.BoolValue(false)
+	}
+
+	var grants []serviceUserGrantModel
+	resp.Diagnostics.Append(data.Grants.ElementsAs(ctx, &grants, false)...)
+	ids := map[string]int64{}
+	resp.Diagnostics.Append(data.GrantIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, currentIds := refreshGrants(ctx, r.client, user.Username, grants, ids, data.Authoritative.ValueBool(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setServiceUserGrants(ctx, &data, current, currentIds)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceUserWithAccessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ServiceUserWithAccessModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	id := state.Id.ValueString()
+	var planned, prior []serviceUserGrantModel
+	resp.Diagnostics.Append(plan.Grants.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.Grants.ElementsAs(ctx, &prior, false)...)
+	ids := map[string]int64{}
+	resp.Diagnostics.Append(state.GrantIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The grants are reconciled before the password is rotated, so that a
+	// failure leaves the password in state valid. The grants created and
+	// deleted until then are saved, so that they stay managed; the next
+	// refresh reads the access levels they have.
+	updateGrants(ctx, r.client, id, planned, prior, ids, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(setServiceUserGrants(ctx, &state, appliedGrants(planned, prior, ids), ids)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+	resp.Diagnostics.Append(setServiceUserGrants(ctx, &plan, planned, ids)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var update legocharmclient.UserUpdateData
+	if !plan.Email.Equal(state.Email) {
+		email := plan.Email.ValueString()
+		update.Email = &email
+	}
+	if plan.Password.IsUnknown() {
+		if password, err := generatePassword(defaultPasswordLength, true); err != nil {
+			resp.Diagnostics.AddError("Password Generation Failed", err.Error())
+		} else {
+			update.Password = password
+		}
+	}
+	if !resp.Diagnostics.HasError() && (update.Email != nil || update.Password != "") {
+		if _, err := r.client.UpdateUser(ctx, id, update); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to update user", err, userAPIFields)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		// The grants were reconciled, but the user is as before.
+		plan.Email, plan.Password, plan.PasswordVersion = state.Email, state.Password, state.PasswordVersion
+	} else if update.Password != "" {
+		plan.Password = types.StringValue(update.Password)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ServiceUserWithAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ServiceUserWithAccessModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	ids := map[string]int64{}
+	resp.Diagnostics.Append(data.GrantIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteUserAndGrants(ctx, r.client, data.Id.ValueString(), ids, &resp.Diagnostics)
+}
+
+// createServiceUser creates a user and returns it as the API reports it. A
+// user with the same username must not exist yet.
+func createServiceUser(ctx context.Context, client legocharmclient.API, create legocharmclient.UserCreateData, diags *diag.Diagnostics) *legocharmclient.UserData {
+	existing, err := client.GetUserByUsername(ctx, create.Username)
+	if err == nil {
+		diags.AddError("User Exists", fmt.Sprintf("A user with username '%s' already exists (id=%s).", create.Username, legocharmclient.LastPathSegment(existing.Url)))
+		return nil
+	} else if err != legocharmclient.ErrNotFound {
+		addClientError(diags, "Unable to check for existing user", err, nil)
+		return nil
+	}
+
+	created, err := client.CreateUser(ctx, create)
+	if err != nil {
+		addClientError(diags, "Unable to create user", err, userAPIFields)
+		return nil
+	}
+
+	user, err := createdUser(ctx, client, created, create.Username)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("User created but failed to read back: %s", err))
+		return nil
+	}
+	return user
+}
+
+// deleteUserAndGrants deletes the given grants and then the user, recording
+// failures in diags. Grants and users that no longer exist are ignored.
+func deleteUserAndGrants(ctx context.Context, client legocharmclient.API, userID string, ids map[string]int64, diags *diag.Diagnostics) {
+	if err := deleteGrants(ctx, client, ids); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete user: %s", err))
+		return
+	}
+
+	res, err := client.DeleteUserById(ctx, userID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete user: %s", err))
+		return
+	}
+	res.Body.Close()
+	if res.StatusCode >= 400 && res.StatusCode != http.StatusNotFound {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete user: status %d", res.StatusCode))
+	}
+}
+
+func (r *ServiceUserWithAccessResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// appliedGrants returns the grants in ids, which updateGrants left partly
+// reconciled: the planned grant for domains planned, the prior one otherwise.
+func appliedGrants(planned, prior []serviceUserGrantModel, ids map[string]int64) []serviceUserGrantModel {
+	byDomain := map[string]serviceUserGrantModel{}
+	for _, grant := range prior {
+		byDomain[legocharmclient.NormalizeFQDN(grant.Domain.ValueString())] = grant
+	}
+	for _, grant := range planned {
+		byDomain[legocharmclient.NormalizeFQDN(grant.Domain.ValueString())] = grant
+	}
+	var grants []serviceUserGrantModel
+	for domain := range ids {
+		if grant, ok := byDomain[domain]; ok {
+			grants = append(grants, grant)
+		}
+	}
+	return grants
+}
+
+// setServiceUserGrants stores grants and their database IDs on data.
+func setServiceUserGrants(ctx context.Context, data *ServiceUserWithAccessModel, grants []serviceUserGrantModel, ids map[string]int64) diag.Diagnostics {
+	set, grantIds, diags := grantValues(ctx, grants, ids)
+	data.Grants = set
+	data.GrantIds = grantIds
+	return diags
+}
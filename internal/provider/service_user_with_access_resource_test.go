@@ -0,0 +1,364 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/internal/fakeserver"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestServiceUserWithAccessResource_Metadata(t *testing.T) {
+	r := &ServiceUserWithAccessResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
+	require.Equal(t, "legocharm_service_user_with_access", resp.TypeName)
+}
+
+// serviceUserPlan builds a plan for a service user with the given grants,
+// given as domain/access level pairs.
+func serviceUserPlan(t *testing.T, r *ServiceUserWithAccessResource, grants ...string) (tfsdk.Plan, ServiceUserWithAccessModel) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	var models []serviceUserGrantModel
+	for i := 0; i < len(grants); i += 2 {
+		models = append(models, serviceUserGrantModel{Domain: types.StringValue(grants[i]), AccessLevel: types.StringValue(grants[i+1])})
+	}
+	data := ServiceUserWithAccessModel{
+		Username:        types.StringValue("svc-web"),
+		Email:           types.StringValue(""),
+		Password:        types.StringUnknown(),
+		PasswordVersion: types.StringNull(),
+		Authoritative:   types.BoolValue(false),
+		Id:              types.StringUnknown(),
+	}
+	require.False(t, setServiceUserGrants(ctx, &data, models, map[string]int64{}).HasError())
+	data.GrantIds = types.MapUnknown(types.Int64Type)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+	return plan, data
+}
+
+func TestServiceUserWithAccessResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &ServiceUserWithAccessResource{client: client}
+	ctx := context.Background()
+
+	plan, _ := serviceUserPlan(t, r, "staging.example.com", "domain", "*.apps.example.com", "subdomain")
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var created ServiceUserWithAccessModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Len(t, created.Password.ValueString(), defaultPasswordLength)
+	require.Len(t, created.GrantIds.Elements(), 2)
+	require.Len(t, api.permissions, 2)
+	userID, ok := api.userID("svc-web")
+	require.True(t, ok)
+	require.Equal(t, legocharmclient.LastPathSegment(fakeUser(userID, "").Url), created.Id.ValueString())
+
+	ids := map[string]int64{}
+	require.False(t, created.GrantIds.ElementsAs(ctx, &ids, false).HasError())
+
+	// A grant recreated out-of-band is adopted under its new ID.
+	recreated := api.permissions[int(ids["staging.example.com"])]
+	delete(api.permissions, recreated.ID)
+	recreated.ID = 900
+	api.permissions[900] = recreated
+	adoptResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, adoptResp)
+	require.False(t, adoptResp.Diagnostics.HasError(), adoptResp.Diagnostics)
+	require.Equal(t, "Domain Access Recreated Outside Terraform", adoptResp.Diagnostics.Warnings()[0].Summary())
+	ids["staging.example.com"] = 900
+
+	// A grant removed out-of-band disappears from state on refresh.
+	delete(api.permissions, int(ids["*.apps.example.com"]))
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	var refreshed ServiceUserWithAccessModel
+	require.False(t, readResp.State.Get(ctx, &refreshed).HasError())
+	require.Len(t, refreshed.Grants.Elements(), 1)
+
+	// Change one level, add one grant; the missing grant is recreated.
+	plan, data := serviceUserPlan(t, r, "staging.example.com", "subdomain", "*.apps.example.com", "subdomain", "ci.example.com", "domain")
+	data.Id = created.Id
+	data.Password = created.Password
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Len(t, api.permissions, 3)
+	require.Equal(t, "subdomain", api.permissions[int(ids["staging.example.com"])].AccessLevel)
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Empty(t, api.permissions)
+	_, ok = api.userID("svc-web")
+	require.False(t, ok)
+}
+
+func TestServiceUserWithAccessResource_Authoritative(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.domains[3] = "manual.example.com"
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &ServiceUserWithAccessResource{client: client}
+	ctx := context.Background()
+
+	plan, data := serviceUserPlan(t, r, "staging.example.com", "domain")
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	userID, ok := api.userID("svc-web")
+	require.True(t, ok)
+
+	// A grant made outside Terraform is ignored by default.
+	api.permissions[500] = legocharmclient.DomainUserPermissionData{ID: 500, UserID: userID, Domain: 3, AccessLevel: "subdomain"}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	var refreshed ServiceUserWithAccessModel
+	require.False(t, readResp.State.Get(ctx, &refreshed).HasError())
+	require.Len(t, refreshed.Grants.Elements(), 1)
+
+	// In authoritative mode it is read into state and removed on update.
+	refreshed.Authoritative = types.BoolValue(true)
+	require.False(t, readResp.State.Set(ctx, &refreshed).HasError())
+	authResp := &resource.ReadResponse{State: readResp.State}
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, authResp)
+	require.False(t, authResp.Diagnostics.HasError(), authResp.Diagnostics)
+	require.False(t, authResp.State.Get(ctx, &refreshed).HasError())
+	require.Len(t, refreshed.Grants.Elements(), 2)
+	ids := map[string]int64{}
+	require.False(t, refreshed.GrantIds.ElementsAs(ctx, &ids, false).HasError())
+	require.Equal(t, int64(500), ids["manual.example.com"])
+
+	data.Id = refreshed.Id
+	data.Password = refreshed.Password
+	data.Authoritative = types.BoolValue(true)
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: authResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: authResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Len(t, api.permissions, 1)
+	_, ok = api.permissions[500]
+	require.False(t, ok)
+}
+
+func TestServiceUserWithAccessResource_Create_RollsBack(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &ServiceUserWithAccessResource{client: client}
+	ctx := context.Background()
+
+	// Grants are a set, so either may be attempted first; both orders must
+	// leave nothing behind.
+	plan, _ := serviceUserPlan(t, r, "staging.example.com", "domain", "rejected.example.com", "domain")
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Empty(t, api.permissions)
+	_, ok := api.userID("svc-web")
+	require.False(t, ok)
+}
+
+func TestServiceUserWithAccessResource_Update_GrantFails(t *testing.T) {
+	fake := fakeserver.New("admin", "admin")
+	fake.AddDomain("staging.example.com")
+	fake.AddDomain("old.example.com")
+	fake.AddDomain("rejected.example.com")
+	var rejectGrants atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rejectGrants.Load() && r.Method == http.MethodPost && r.URL.Path == "/api/v1/domain-user-permissions/" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &ServiceUserWithAccessResource{client: client}
+	ctx := context.Background()
+
+	plan, _ := serviceUserPlan(t, r, "staging.example.com", "domain", "old.example.com", "domain")
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	var created ServiceUserWithAccessModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	createdIds := map[string]int64{}
+	require.False(t, created.GrantIds.ElementsAs(ctx, &createdIds, false).HasError())
+
+	// Rotate the password, drop one grant and add one the API rejects.
+	rejectGrants.Store(true)
+	plan, data := serviceUserPlan(t, r, "staging.example.com", "domain", "rejected.example.com", "domain")
+	data.Id = created.Id
+	data.PasswordVersion = types.StringValue("2")
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	require.True(t, updateResp.Diagnostics.HasError())
+
+	// State holds the password the API still accepts, and only the grant
+	// that remains.
+	var got ServiceUserWithAccessModel
+	require.False(t, updateResp.State.Get(ctx, &got).HasError())
+	current, ok := fake.UserPassword("svc-web")
+	require.True(t, ok)
+	require.Equal(t, current, got.Password.ValueString())
+	require.Equal(t, created.Password, got.Password)
+	require.True(t, got.PasswordVersion.IsNull())
+	ids := map[string]int64{}
+	require.False(t, got.GrantIds.ElementsAs(ctx, &ids, false).HasError())
+	require.Equal(t, map[string]int64{"staging.example.com": createdIds["staging.example.com"]}, ids)
+	require.Len(t, got.Grants.Elements(), 1)
+
+	// Once the API accepts the grant, the update completes.
+	rejectGrants.Store(false)
+	retryResp := &resource.UpdateResponse{State: updateResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: updateResp.State}, retryResp)
+	require.False(t, retryResp.Diagnostics.HasError(), retryResp.Diagnostics)
+	require.False(t, retryResp.State.Get(ctx, &got).HasError())
+	current, _ = fake.UserPassword("svc-web")
+	require.Equal(t, current, got.Password.ValueString())
+	require.NotEqual(t, created.Password, got.Password)
+	require.Len(t, got.GrantIds.Elements(), 2)
+}
+
+func TestServiceUserWithAccessResource_ValidateConfig_DuplicateDomain(t *testing.T) {
+	r := &ServiceUserWithAccessResource{}
+	plan, _ := serviceUserPlan(t, r, "example.com", "domain", "Example.COM.", "subdomain")
+
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Duplicate Domain Grant", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestServiceUserWithAccessResource_ValidateConfig_SuspiciousAccessLevel(t *testing.T) {
+	r := &ServiceUserWithAccessResource{}
+	plan, _ := serviceUserPlan(t, r, "*.example.com", "domain", "web.example.com", "domain")
+
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, 1, resp.Diagnostics.WarningsCount())
+	require.Equal(t, "Suspicious Access Level", resp.Diagnostics.Warnings()[0].Summary())
+}
+
+func TestAccServiceUserWithAccessResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	username := testAccName(t)
+	first, second := testAccDomain(t, env), testAccDomain(t, env)
+	ctx := context.Background()
+
+	levels := func(want map[int]string) resourcetest.TestCheckFunc {
+		return func(*terraform.State) error {
+			accesses, err := env.API.ListDomainAccessByUsername(ctx, username)
+			if err != nil {
+				return err
+			}
+			got := map[int]string{}
+			for _, access := range accesses {
+				got[access.Domain] = access.AccessLevel
+			}
+			if !maps.Equal(got, want) {
+				return fmt.Errorf("access levels of %s by domain ID are %v, want %v", username, got, want)
+			}
+			return nil
+		}
+	}
+	config := func(version, grants string) string {
+		return fmt.Sprintf(`
+resource "legocharm_service_user_with_access" "test" {
+  username         = %q
+  grants           = [%s]
+  authoritative    = true
+  password_version = %q
+}
+`, username, grants, version)
+	}
+	grants := fmt.Sprintf(`
+    { domain = %q, access_level = "subdomain" },
+    { domain = %q, access_level = "domain" },
+  `, first.Fqdn, second.Fqdn)
+
+	var userID string
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config("1", fmt.Sprintf(`{ domain = %q, access_level = "domain" }`, first.Fqdn)),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					levels(map[int]string{first.ID: "domain"}),
+					testAccCheckPassword(env, "legocharm_service_user_with_access.test", username),
+					testAccStoreAttr("legocharm_service_user_with_access.test", "id", &userID),
+				),
+			},
+			{
+				Config: config("1", grants),
+				Check:  levels(map[int]string{first.ID: "subdomain", second.ID: "domain"}),
+			},
+			// With authoritative set, a grant made outside Terraform is
+			// detected and revoked.
+			{
+				PreConfig: func() {
+					_, err := env.API.CreateDomainAccess(ctx, legocharmclient.DomainUserPermissionCreateData{UserID: userID, Domain: testAccDomain(t, env).Fqdn, AccessLevel: "domain"})
+					require.NoError(t, err)
+				},
+				Config:             config("1", grants),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("1", grants),
+				Check:  levels(map[int]string{first.ID: "subdomain", second.ID: "domain"}),
+			},
+			// Changing password_version sets a new password.
+			{
+				Config: config("2", grants),
+				Check:  testAccCheckPassword(env, "legocharm_service_user_with_access.test", username),
+			},
+		},
+		CheckDestroy: testAccCheckUserDestroyed(env, username),
+	})
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ action.ActionWithConfigure = &SetUserActiveAction{}
+
+// NewSetUserActiveAction creates a new set user active action.
+func NewSetUserActiveAction() action.Action { return &SetUserActiveAction{} }
+
+// SetUserActiveAction is the action implementation for suspending or
+// reinstating a LegoCharm user by toggling is_active.
+type SetUserActiveAction struct {
+	client legocharmclient.API
+}
+
+// SetUserActiveActionModel maps Terraform schema to Go types for the set
+// user active action.
+type SetUserActiveActionModel struct {
+	Username types.String `tfsdk:"username"`
+	Active   types.Bool   `tfsdk:"active"`
+}
+
+func (a *SetUserActiveAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_set_user_active"
+}
+
+func (a *SetUserActiveAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deactivates or reactivates a user, for example to suspend a compromised ACME client with `terraform apply -invoke` while keeping the account and its domain access permissions. Inactive users cannot authenticate. A `legocharm_user` managing the same user sets `is_active` back to its configured value on its next apply. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the existing user. The user the provider authenticates as cannot be deactivated.",
+				Required:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "`false` to deactivate the user, `true` to reactivate it.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *SetUserActiveAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+func (a *SetUserActiveAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data SetUserActiveActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if a.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this action")
+		return
+	}
+
+	username, active := data.Username.ValueString(), data.Active.ValueBool()
+	if username == a.client.AuthenticatedUsername() && !active {
+		resp.Diagnostics.AddAttributeError(path.Root("username"), "Provider User", fmt.Sprintf("%q is the user the provider authenticates as and cannot deactivate itself.", username))
+		return
+	}
+
+	user, err := a.client.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("username"), "User Not Found", fmt.Sprintf("No user %q exists.", username))
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to read user", err, nil)
+		return
+	}
+
+	state := "active"
+	if !active {
+		state = "inactive"
+	}
+	if user.IsActive == active {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("%s is already %s.", username, state)})
+		return
+	}
+
+	id := legocharmclient.LastPathSegment(user.Url)
+	if _, err := a.client.UpdateUser(ctx, id, legocharmclient.UserUpdateData{IsActive: &active}); err != nil {
+		addClientError(&resp.Diagnostics, "Unable to update user", err, nil)
+		return
+	}
+
+	tflog.Trace(ctx, "updated user", map[string]interface{}{"id": id, "is_active": active})
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("%s is now %s.", username, state)})
+}
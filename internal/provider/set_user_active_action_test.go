@@ -0,0 +1,73 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestSetUserActiveAction_Invoke(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "admin"
+	api.permissions[7] = legocharmclient.DomainUserPermissionData{ID: 7, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	a := &SetUserActiveAction{client: client}
+
+	ctx := context.Background()
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, schemaResp)
+
+	invoke := func(username string, active bool) (*action.InvokeResponse, []string) {
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		data := SetUserActiveActionModel{Username: types.StringValue(username), Active: types.BoolValue(active)}
+		require.False(t, config.Set(ctx, &data).HasError())
+
+		var messages []string
+		resp := &action.InvokeResponse{SendProgress: func(event action.InvokeProgressEvent) {
+			messages = append(messages, event.Message)
+		}}
+		a.Invoke(ctx, action.InvokeRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		return resp, messages
+	}
+
+	resp, messages := invoke("alice", false)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, []string{"alice is now inactive."}, messages)
+	require.True(t, api.inactive[1004])
+	// The account and its permissions are kept.
+	require.Equal(t, "alice", api.users[1004])
+	require.Contains(t, api.permissions, 7)
+
+	resp, messages = invoke("alice", false)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, []string{"alice is already inactive."}, messages)
+
+	resp, messages = invoke("alice", true)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, []string{"alice is now active."}, messages)
+	require.False(t, api.inactive[1004])
+
+	resp, _ = invoke("admin", false)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Provider User", resp.Diagnostics.Errors()[0].Summary())
+	require.False(t, api.inactive[1005])
+
+	resp, _ = invoke("nobody", true)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", resp.Diagnostics.Errors()[0].Summary())
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/internal/fakeserver"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// Sweepers delete the objects acceptance tests leave behind on a shared
+// deployment when a run is aborted or a test fails before cleaning up, so
+// that the next run does not trip over "User Exists" or "Domain Exists"
+// errors. They are terraform-plugin-testing sweepers, which run instead of
+// the tests when the -sweep flag is given:
+//
+//	go test ./internal/provider -v -sweep=all [-sweep-run=legocharm_user]
+//
+// The value of -sweep is ignored: they sweep the API in the LEGOCHARM_*
+// environment variables. They only ever touch objects named with
+// testAccNamePrefix.
+func init() {
+	resource.AddTestSweepers("legocharm_user_domain_access", &resource.Sweeper{
+		Name: "legocharm_user_domain_access",
+		F:    sweeper(sweepPermissions),
+	})
+	// Permissions go before the users and domains they reference.
+	resource.AddTestSweepers("legocharm_user", &resource.Sweeper{
+		Name:         "legocharm_user",
+		Dependencies: []string{"legocharm_user_domain_access"},
+		F:            sweeper(sweepUsers),
+	})
+	resource.AddTestSweepers("legocharm_domain", &resource.Sweeper{
+		Name:         "legocharm_domain",
+		Dependencies: []string{"legocharm_user_domain_access"},
+		F:            sweeper(sweepDomains),
+	})
+}
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// sweeper returns a sweeper function running sweep against the API in the
+// LEGOCHARM_* environment variables.
+func sweeper(sweep func(ctx context.Context, api legocharmclient.API) error) resource.SweeperFunc {
+	return func(string) error {
+		address, username, password := os.Getenv("LEGOCHARM_ADDRESS"), os.Getenv("LEGOCHARM_USERNAME"), os.Getenv("LEGOCHARM_PASSWORD")
+		client, err := legocharmclient.NewClient(&address, &username, &password)
+		if err != nil {
+			return fmt.Errorf("sweeping requires LEGOCHARM_ADDRESS, LEGOCHARM_USERNAME and LEGOCHARM_PASSWORD: %w", err)
+		}
+		return sweep(context.Background(), client)
+	}
+}
+
+// isSweepable reports whether name belongs to an acceptance test object.
+func isSweepable(name string) bool {
+	return strings.HasPrefix(legocharmclient.NormalizeFQDN(name), testAccNamePrefix)
+}
+
+// sweepPermissions deletes the permissions held by test users or on test
+// domains.
+func sweepPermissions(ctx context.Context, api legocharmclient.API) error {
+	users, err := api.ListUsers(ctx)
+	if err != nil {
+		return err
+	}
+	testUsers := map[int]bool{}
+	for _, user := range users {
+		id, _ := strconv.Atoi(legocharmclient.LastPathSegment(user.Url))
+		testUsers[id] = isSweepable(user.Username)
+	}
+	domains, err := api.ListDomains(ctx)
+	if err != nil {
+		return err
+	}
+	testDomains := map[int]bool{}
+	for _, domain := range domains {
+		testDomains[domain.ID] = isSweepable(domain.Fqdn)
+	}
+
+	accesses, err := api.ListAllDomainAccess(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, access := range accesses {
+		if !testUsers[access.UserID] && !testDomains[access.Domain] {
+			continue
+		}
+		if err := deletePermission(ctx, api, access.ID); err != nil {
+			errs = append(errs, fmt.Errorf("permission %d: %w", access.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sweepUsers deletes test users, never the provider's own account.
+func sweepUsers(ctx context.Context, api legocharmclient.API) error {
+	users, err := api.ListUsers(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, user := range users {
+		if !isSweepable(user.Username) || user.Username == api.AuthenticatedUsername() {
+			continue
+		}
+		id := legocharmclient.LastPathSegment(user.Url)
+		if _, err := api.DeleteUserById(ctx, id); err != nil && !errors.Is(err, legocharmclient.ErrNotFound) {
+			errs = append(errs, fmt.Errorf("user %s: %w", user.Username, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sweepDomains deletes test domains.
+func sweepDomains(ctx context.Context, api legocharmclient.API) error {
+	domains, err := api.ListDomains(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, domain := range domains {
+		if !isSweepable(domain.Fqdn) {
+			continue
+		}
+		if err := api.DeleteDomain(ctx, domain.ID); err != nil && !errors.Is(err, legocharmclient.ErrNotFound) {
+			errs = append(errs, fmt.Errorf("domain %s: %w", domain.Fqdn, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func TestSweepers(t *testing.T) {
+	s := fakeserver.New("admin", "admin")
+	keptUser := s.AddUser("alice", "pw", false)
+	testUser := s.AddUser(testAccNamePrefix+"user", "pw", false)
+	keptDomain := s.AddDomain("example.com")
+	testDomain := s.AddDomain(testAccNamePrefix + "domain.example.com")
+	kept := s.AddPermission(keptUser, keptDomain, "domain")
+	s.AddPermission(testUser, keptDomain, "domain")
+	s.AddPermission(keptUser, testDomain, "subdomain")
+
+	srv := s.Start()
+	defer srv.Close()
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Sweeping users alone leaves the domains.
+	require.NoError(t, sweepPermissions(ctx, client))
+	require.NoError(t, sweepUsers(ctx, client))
+	_, err = client.GetUserByUsername(ctx, testAccNamePrefix+"user")
+	require.ErrorIs(t, err, legocharmclient.ErrNotFound)
+	_, err = client.GetDomain(ctx, testAccNamePrefix+"domain.example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, sweepDomains(ctx, client))
+	_, err = client.GetDomain(ctx, testAccNamePrefix+"domain.example.com")
+	require.ErrorIs(t, err, legocharmclient.ErrNotFound)
+
+	// Everything else is untouched.
+	accesses, err := client.ListAllDomainAccess(ctx)
+	require.NoError(t, err)
+	require.Len(t, accesses, 1)
+	require.Equal(t, kept, accesses[0].ID)
+	_, err = client.GetUserByUsername(ctx, "alice")
+	require.NoError(t, err)
+	_, err = client.GetDomain(ctx, "example.com")
+	require.NoError(t, err)
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogAPICallSummary logs the API calls made so far by the provider served by
+// server, a server returned by NewProtocol6Server, by method and in total,
+// and their breakdown by endpoint and outcome, at INFO. It logs nothing if no
+// call was made, and nothing if the summary was already logged when
+// Terraform stopped the provider. The summary makes a refresh making far more
+// calls than expected stand out.
+func LogAPICallSummary(ctx context.Context, server tfprotov6.ProviderServer) {
+	s, ok := server.(*providerServer)
+	if !ok {
+		return
+	}
+	s.logAPICallSummary(ctx)
+}
+
+// logAPICallSummary logs the calls of the provider served by s, once.
+func (s *providerServer) logAPICallSummary(ctx context.Context) {
+	if s.calls == nil {
+		return
+	}
+	s.summary.Do(func() { logAPICallSummary(ctx, s.calls) })
+}
+
+// logAPICallSummary logs calls, see LogAPICallSummary.
+func logAPICallSummary(ctx context.Context, calls *legocharmclient.CallStats) {
+	counts := calls.Counts()
+	if len(counts) == 0 {
+		return
+	}
+	breakdown := make([]string, len(counts))
+	for i, c := range counts {
+		breakdown[i] = fmt.Sprintf("%s %s %s: %d", c.Method, c.Endpoint, c.Outcome, c.Count)
+		if c.Retry {
+			breakdown[i] = fmt.Sprintf("%s %s %s retry: %d", c.Method, c.Endpoint, c.Outcome, c.Count)
+		}
+	}
+	tflog.Info(ctx, "LegoCharm API calls: "+calls.Summary(), map[string]interface{}{
+		"api_calls": breakdown,
+	})
+}
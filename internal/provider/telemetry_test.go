@@ -0,0 +1,54 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestLogAPICallSummary(t *testing.T) {
+	calls := &legocharmclient.CallStats{}
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	logAPICallSummary(ctx, calls)
+	require.Empty(t, out.String(), "nothing is logged without calls")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+	username, password := "admin", "secret"
+	client, err := legocharmclient.NewClient(&srv.URL, &username, &password)
+	require.NoError(t, err)
+	client.Stats = calls
+	for range 3 {
+		_, err := client.GetDomainById(context.Background(), 7)
+		require.NoError(t, err)
+	}
+	_, err = client.CreateDomain(context.Background(), legocharmclient.DomainData{Fqdn: "example.com"})
+	require.Error(t, err)
+
+	logAPICallSummary(ctx, calls)
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "LegoCharm API calls: 3 GETs, 1 POST; 1 failed", entries[0]["@message"])
+	require.Equal(t, []interface{}{
+		"POST /api/v1/domains/ 4xx: 1",
+		"GET /api/v1/domains/{id}/ 2xx: 3",
+	}, entries[0]["api_calls"])
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default operation timeouts, used when the timeouts block or the relevant
+// attribute is not configured.
+const (
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
+
+// nullTimeouts returns a null timeouts block of the schema built with opts,
+// for models not read from a configuration, such as those of imports.
+func nullTimeouts(opts timeouts.Opts) timeouts.Value {
+	// The context is not used to build the block.
+	t := timeouts.Block(context.Background(), opts).Type().(timeouts.Type)
+	return timeouts.Value{Object: types.ObjectNull(t.AttrTypes)}
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullTimeouts(t *testing.T) {
+	v := nullTimeouts(domainAccessTimeouts)
+	require.True(t, v.IsNull())
+	require.Equal(t, []string{"create", "delete", "read"}, slices.Sorted(maps.Keys(v.AttributeTypes(context.Background()))))
+
+	d, diags := v.Create(context.Background(), time.Minute)
+	require.False(t, diags.HasError())
+	require.Equal(t, time.Minute, d)
+}
+
+func TestDurationValidator(t *testing.T) {
+	require.True(t, validateString(duration(), types.StringValue("2h45m")))
+	require.False(t, validateString(duration(), types.StringValue("0s")))
+	require.False(t, validateString(duration(), types.StringValue("10")))
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// tlsVersionRegexp matches the values of the min_version attribute of tls.
+var tlsVersionRegexp = regexp.MustCompile(`^1\.[23]$`)
+
+// tlsSchema returns the schema of the tls attribute of the provider.
+func tlsSchema() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "Restrict the TLS connections to the LegoCharm API, for environments that must enforce approved protocol versions and ciphers, or authenticate with a client certificate.",
+		Attributes: map[string]schema.Attribute{
+			"min_version": schema.StringAttribute{
+				Optional: true,
+				Description: "The minimum TLS version, \"1.2\" or \"1.3\". Defaults to \"1.2\". " +
+					"Can also be set via the LEGOCHARM_TLS_MIN_VERSION environment variable.",
+				Validators: []validator.String{
+					stringMatches(tlsVersionRegexp, "value must be 1.2 or 1.3"),
+				},
+			},
+			"cipher_suites": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The names of the allowed cipher suites, such as \"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384\": any of " + strings.Join(legocharmclient.CipherSuiteNames(), ", ") + ". " +
+					"The TLS 1.3 cipher suites cannot be restricted, so either all of them are listed, or none and TLS 1.3 is not used. Defaults to Go's cipher suites. " +
+					"Can also be set, comma-separated, via the LEGOCHARM_TLS_CIPHER_SUITES environment variable.",
+			},
+			"client_certificate": schema.StringAttribute{
+				Optional: true,
+				Description: "The client certificate presented to the LegoCharm API, for deployments that authenticate clients with mutual TLS, as PEM data or the path of a PEM file, followed by its intermediate certificates if any. " +
+					"Requires client_key or pkcs11; with pkcs11, defaults to the certificate on the token labelled as its key. " +
+					"Can also be set via the LEGOCHARM_TLS_CLIENT_CERTIFICATE environment variable.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "The private key of client_certificate, as PEM data or the path of a PEM file. Conflicts with pkcs11. " +
+					"Can also be set via the LEGOCHARM_TLS_CLIENT_KEY environment variable.",
+			},
+			"pkcs11": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "The private key of client_certificate held by a PKCS#11 token, such as a hardware security module, which signs the TLS handshakes without revealing it. " +
+					"RSA and ECDSA keys are supported, on Linux and macOS. Conflicts with client_key.",
+				Attributes: map[string]schema.Attribute{
+					"module": schema.StringAttribute{
+						Optional: true,
+						Description: "The path of the PKCS#11 module of the token, such as \"/usr/lib/softhsm/libsofthsm2.so\". Required. " +
+							"Can also be set via the LEGOCHARM_TLS_PKCS11_MODULE environment variable.",
+					},
+					"slot": schema.Int64Attribute{
+						Optional: true,
+						Description: "The ID of the slot of the token, if token_label is not set. Without either, the token must be the only one present. " +
+							"Can also be set via the LEGOCHARM_TLS_PKCS11_SLOT environment variable.",
+						Validators: []validator.Int64{
+							int64AtLeast(0),
+						},
+					},
+					"token_label": schema.StringAttribute{
+						Optional: true,
+						Description: "The label of the token. " +
+							"Can also be set via the LEGOCHARM_TLS_PKCS11_TOKEN_LABEL environment variable.",
+					},
+					"key_label": schema.StringAttribute{
+						Optional: true,
+						Description: "The label of the private key on the token. Required. " +
+							"Can also be set via the LEGOCHARM_TLS_PKCS11_KEY_LABEL environment variable.",
+					},
+					"pin": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						Description: "The user PIN of the token, unless it needs no login. " +
+							"Can also be set via the LEGOCHARM_TLS_PKCS11_PIN environment variable.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// tlsAttributeTypes returns the attribute types of the tls object.
+func tlsAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"min_version":        types.StringType,
+		"cipher_suites":      types.ListType{ElemType: types.StringType},
+		"client_certificate": types.StringType,
+		"client_key":         types.StringType,
+		"pkcs11":             types.ObjectType{AttrTypes: pkcs11AttributeTypes()},
+	}
+}
+
+// pkcs11AttributeTypes returns the attribute types of the tls.pkcs11 object.
+func pkcs11AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"module":      types.StringType,
+		"slot":        types.Int64Type,
+		"token_label": types.StringType,
+		"key_label":   types.StringType,
+		"pin":         types.StringType,
+	}
+}
+
+// tlsOptions returns the TLS options of the tls object of the provider
+// configuration, each from its attribute or else from its environment
+// variable.
+func tlsOptions(ctx context.Context, tlsConfig types.Object) (legocharmclient.TLSOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := legocharmclient.TLSOptions{MinVersion: os.Getenv("LEGOCHARM_TLS_MIN_VERSION")}
+	if opts.MinVersion != "" && !tlsVersionRegexp.MatchString(opts.MinVersion) {
+		diags.AddAttributeError(
+			path.Root("tls").AtName("min_version"),
+			"Invalid LEGOCHARM_TLS_MIN_VERSION",
+			fmt.Sprintf("The LEGOCHARM_TLS_MIN_VERSION environment variable must be 1.2 or 1.3, got %q.", opts.MinVersion),
+		)
+	}
+	for _, name := range strings.Split(os.Getenv("LEGOCHARM_TLS_CIPHER_SUITES"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			opts.CipherSuites = append(opts.CipherSuites, name)
+		}
+	}
+
+	clientCertificate := os.Getenv("LEGOCHARM_TLS_CLIENT_CERTIFICATE")
+	clientKey := os.Getenv("LEGOCHARM_TLS_CLIENT_KEY")
+	pkcs11Key := legocharmclient.PKCS11Key{
+		Module:     os.Getenv("LEGOCHARM_TLS_PKCS11_MODULE"),
+		TokenLabel: os.Getenv("LEGOCHARM_TLS_PKCS11_TOKEN_LABEL"),
+		KeyLabel:   os.Getenv("LEGOCHARM_TLS_PKCS11_KEY_LABEL"),
+		PIN:        os.Getenv("LEGOCHARM_TLS_PKCS11_PIN"),
+	}
+	if v := os.Getenv("LEGOCHARM_TLS_PKCS11_SLOT"); v != "" {
+		if slot, err := strconv.ParseUint(v, 10, 0); err == nil {
+			id := uint(slot)
+			pkcs11Key.Slot = &id
+		} else {
+			diags.AddAttributeError(
+				path.Root("tls").AtName("pkcs11").AtName("slot"),
+				"Invalid LEGOCHARM_TLS_PKCS11_SLOT",
+				fmt.Sprintf("The LEGOCHARM_TLS_PKCS11_SLOT environment variable must be a slot ID, got %q.", v),
+			)
+		}
+	}
+
+	if !tlsConfig.IsNull() && !tlsConfig.IsUnknown() {
+		attrs := tlsConfig.Attributes()
+		if v, ok := attrs["min_version"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			opts.MinVersion = v.ValueString()
+		}
+		if v, ok := attrs["cipher_suites"].(types.List); ok && !v.IsNull() && !v.IsUnknown() {
+			opts.CipherSuites = nil
+			diags.Append(v.ElementsAs(ctx, &opts.CipherSuites, false)...)
+		}
+		if v, ok := attrs["client_certificate"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			clientCertificate = v.ValueString()
+		}
+		if v, ok := attrs["client_key"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			clientKey = v.ValueString()
+		}
+		if v, ok := attrs["pkcs11"].(types.Object); ok && !v.IsNull() && !v.IsUnknown() {
+			pkcs11Attrs := v.Attributes()
+			for name, value := range map[string]*string{
+				"module":      &pkcs11Key.Module,
+				"token_label": &pkcs11Key.TokenLabel,
+				"key_label":   &pkcs11Key.KeyLabel,
+				"pin":         &pkcs11Key.PIN,
+			} {
+				if v, ok := pkcs11Attrs[name].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+					*value = v.ValueString()
+				}
+			}
+			if v, ok := pkcs11Attrs["slot"].(types.Int64); ok && !v.IsNull() && !v.IsUnknown() {
+				id := uint(v.ValueInt64())
+				pkcs11Key.Slot = &id
+			}
+		}
+	}
+
+	switch {
+	case pkcs11Key != (legocharmclient.PKCS11Key{}) && clientKey != "":
+		diags.AddAttributeError(
+			path.Root("tls"),
+			"Conflicting LegoCharm API Client Keys",
+			"The provider cannot create the LegoCharm API client as both client_key and pkcs11 are set. "+
+				"Set either, in the tls configuration or with the LEGOCHARM_TLS_CLIENT_KEY and LEGOCHARM_TLS_PKCS11_* environment variables.",
+		)
+	case pkcs11Key != (legocharmclient.PKCS11Key{}):
+		if pkcs11Key.Module == "" || pkcs11Key.KeyLabel == "" {
+			diags.AddAttributeError(
+				path.Root("tls").AtName("pkcs11"),
+				"Incomplete LegoCharm API Client Key",
+				"The provider cannot create the LegoCharm API client as the PKCS#11 token holding its client key is incomplete. "+
+					"Set both module and key_label, in the tls.pkcs11 configuration or with the LEGOCHARM_TLS_PKCS11_MODULE and LEGOCHARM_TLS_PKCS11_KEY_LABEL environment variables.",
+			)
+			break
+		}
+		cert, err := legocharmclient.LoadPKCS11ClientCertificate(clientCertificate, pkcs11Key)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("tls"),
+				"Invalid LegoCharm API Client Certificate",
+				"The provider cannot create the LegoCharm API client as its client certificate could not be loaded: "+err.Error(),
+			)
+		}
+		opts.ClientCertificate = cert
+	case clientCertificate == "" && clientKey == "":
+	case clientCertificate == "" || clientKey == "":
+		diags.AddAttributeError(
+			path.Root("tls"),
+			"Incomplete LegoCharm API Client Certificate",
+			"The provider cannot create the LegoCharm API client as only one of client_certificate and client_key is set. "+
+				"Set both, in the tls configuration or with the LEGOCHARM_TLS_CLIENT_CERTIFICATE and LEGOCHARM_TLS_CLIENT_KEY environment variables, or set pkcs11 instead of client_key.",
+		)
+	default:
+		cert, err := legocharmclient.LoadClientCertificate(clientCertificate, clientKey)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("tls"),
+				"Invalid LegoCharm API Client Certificate",
+				"The provider cannot create the LegoCharm API client as its client certificate could not be loaded: "+err.Error(),
+			)
+		}
+		opts.ClientCertificate = cert
+	}
+	return opts, diags
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &UserCredentialsEphemeralResource{}
+
+// NewUserCredentialsEphemeralResource creates a new user credentials
+// ephemeral resource.
+func NewUserCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &UserCredentialsEphemeralResource{}
+}
+
+// UserCredentialsEphemeralResource is the ephemeral resource implementation
+// for rotating the password of an existing LegoCharm user without storing it.
+type UserCredentialsEphemeralResource struct {
+	client legocharmclient.API
+}
+
+// UserCredentialsEphemeralModel maps Terraform schema to Go types for the
+// user credentials ephemeral resource.
+type UserCredentialsEphemeralModel struct {
+	Username        types.String `tfsdk:"username"`
+	PasswordLength  types.Int64  `tfsdk:"password_length"`
+	PasswordSpecial types.Bool   `tfsdk:"password_special"`
+	UserId          types.String `tfsdk:"user_id"`
+	Password        types.String `tfsdk:"password"`
+	HttpreqConfig   types.Map    `tfsdk:"httpreq_config"`
+}
+
+func (r *UserCredentialsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_credentials"
+}
+
+func (r *UserCredentialsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sets a new random password for an existing user and returns it without storing it in the plan or state, for example to hand short-lived credentials to an ACME provider in the same configuration. Terraform opens ephemeral resources during both plan and apply, so every run rotates the password, and credentials returned by earlier runs stop working. The user the provider authenticates as cannot be rotated this way; use `legocharm_admin_password` instead. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the existing user.",
+				Required:            true,
+			},
+			"password_length": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Length of the generated password. Defaults to `%d`.", defaultPasswordLength),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64AtLeast(minPasswordLength),
+				},
+			},
+			"password_special": schema.BoolAttribute{
+				MarkdownDescription: "Whether the generated password includes special characters. Defaults to `true`.",
+				Optional:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user.",
+				Computed:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The new password.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"httpreq_config": schema.MapAttribute{
+				MarkdownDescription: "Settings for lego's `httpreq` DNS provider: `HTTPREQ_ENDPOINT`, `HTTPREQ_USERNAME` and `HTTPREQ_PASSWORD`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *UserCredentialsEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *UserCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data UserCredentialsEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this ephemeral resource")
+		return
+	}
+
+	length := int64(defaultPasswordLength)
+	if !data.PasswordLength.IsNull() {
+		length = data.PasswordLength.ValueInt64()
+	}
+	special := data.PasswordSpecial.IsNull() || data.PasswordSpecial.ValueBool()
+	password, err := generatePassword(int(length), special)
+	if err != nil {
+		resp.Diagnostics.AddError("Password Generation Failed", err.Error())
+		return
+	}
+
+	username := data.Username.ValueString()
+	userID := setUserPassword(ctx, r.client, username, password, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.UserId = types.StringValue(userID)
+	data.Password = types.StringValue(password)
+	httpreqConfig, diags := types.MapValueFrom(ctx, types.StringType, map[string]string{
+		"HTTPREQ_ENDPOINT": r.client.Endpoint(),
+		"HTTPREQ_USERNAME": username,
+		"HTTPREQ_PASSWORD": password,
+	})
+	resp.Diagnostics.Append(diags...)
+	data.HttpreqConfig = httpreqConfig
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// setUserPassword sets the password of the named user and returns the user's
+// ID. It refuses to change the password of the user the provider
+// authenticates as, which would break the client for the rest of the run.
+func setUserPassword(ctx context.Context, client legocharmclient.API, username, password string, diags *diag.Diagnostics) string {
+	if username == client.AuthenticatedUsername() {
+		diags.AddAttributeError(path.Root("username"), "Provider User", fmt.Sprintf("%q is the user the provider authenticates as. Use legocharm_admin_password to change its password.", username))
+		return ""
+	}
+
+	user, err := client.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			diags.AddAttributeError(path.Root("username"), "User Not Found", fmt.Sprintf("No user %q exists.", username))
+			return ""
+		}
+		addClientError(diags, "Unable to read user", err, nil)
+		return ""
+	}
+	userID := legocharmclient.LastPathSegment(user.Url)
+
+	if _, err := client.UpdateUser(ctx, userID, legocharmclient.UserUpdateData{Password: password}); err != nil {
+		addClientError(diags, "Unable to set user password", err, nil)
+		return ""
+	}
+
+	tflog.Trace(ctx, "rotated user password", map[string]interface{}{"id": userID})
+	return userID
+}
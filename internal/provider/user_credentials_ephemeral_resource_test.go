@@ -0,0 +1,98 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestUserCredentialsEphemeralResource_Open(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "admin"
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserCredentialsEphemeralResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &ephemeral.SchemaResponse{}
+	r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+
+	open := func(data UserCredentialsEphemeralModel) (*ephemeral.OpenResponse, UserCredentialsEphemeralModel) {
+		data.UserId = types.StringNull()
+		data.Password = types.StringNull()
+		data.HttpreqConfig = types.MapNull(types.StringType)
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, config.Set(ctx, &data).HasError())
+
+		resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema}}
+		r.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		var result UserCredentialsEphemeralModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.Result.Get(ctx, &result).HasError())
+		}
+		return resp, result
+	}
+
+	resp, result := open(UserCredentialsEphemeralModel{
+		Username:        types.StringValue("alice"),
+		PasswordLength:  types.Int64Null(),
+		PasswordSpecial: types.BoolValue(false),
+	})
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, "1004", result.UserId.ValueString())
+	require.Len(t, result.Password.ValueString(), defaultPasswordLength)
+	require.NotContains(t, result.Password.ValueString(), "!")
+	require.Equal(t, api.passwords[1004], result.Password.ValueString())
+
+	var httpreq map[string]string
+	require.False(t, result.HttpreqConfig.ElementsAs(ctx, &httpreq, false).HasError())
+	require.Equal(t, map[string]string{
+		"HTTPREQ_ENDPOINT": srv.URL,
+		"HTTPREQ_USERNAME": "alice",
+		"HTTPREQ_PASSWORD": result.Password.ValueString(),
+	}, httpreq)
+
+	// Every open rotates the password again.
+	previous := result.Password.ValueString()
+	resp, result = open(UserCredentialsEphemeralModel{
+		Username:        types.StringValue("alice"),
+		PasswordLength:  types.Int64Value(12),
+		PasswordSpecial: types.BoolNull(),
+	})
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Len(t, result.Password.ValueString(), 12)
+	require.NotEqual(t, previous, result.Password.ValueString())
+	require.Equal(t, api.passwords[1004], result.Password.ValueString())
+
+	resp, _ = open(UserCredentialsEphemeralModel{
+		Username:        types.StringValue("nobody"),
+		PasswordLength:  types.Int64Null(),
+		PasswordSpecial: types.BoolNull(),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", resp.Diagnostics.Errors()[0].Summary())
+
+	// The provider's own user is left alone.
+	resp, _ = open(UserCredentialsEphemeralModel{
+		Username:        types.StringValue("admin"),
+		PasswordLength:  types.Int64Null(),
+		PasswordSpecial: types.BoolNull(),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Provider User", resp.Diagnostics.Errors()[0].Summary())
+	require.Empty(t, api.passwords[1005])
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ datasource.DataSource = &UserDomainAccessDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &UserDomainAccessDataSource{}
+
+// NewUserDomainAccessDataSource creates a new user domain access data source.
+func NewUserDomainAccessDataSource() datasource.DataSource { return &UserDomainAccessDataSource{} }
+
+// UserDomainAccessDataSource is the data source implementation for a single
+// domain access permission.
+type UserDomainAccessDataSource struct {
+	client legocharmclient.API
+}
+
+// UserDomainAccessDataSourceModel maps Terraform schema to Go types for the
+// user domain access data source.
+type UserDomainAccessDataSourceModel struct {
+	DatabaseID  types.Int64  `tfsdk:"database_id"`
+	UserId      types.String `tfsdk:"user_id"`
+	Username    types.String `tfsdk:"username"`
+	Domain      types.String `tfsdk:"domain"`
+	AccessLevel types.String `tfsdk:"access_level"`
+	DomainId    types.Int64  `tfsdk:"domain_id"`
+	Id          types.String `tfsdk:"id"`
+}
+
+func (d *UserDomainAccessDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_domain_access"
+}
+
+func (d *UserDomainAccessDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A single domain access permission, looked up either by `database_id` or by user and `domain`. Reading fails if the permission does not exist.",
+		Attributes: map[string]schema.Attribute{
+			"database_id": schema.Int64Attribute{
+				MarkdownDescription: "The server-side ID of the permission. Conflicts with `user_id`, `username` and `domain`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user holding the permission. Conflicts with `username`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringMatches(userIDRegexp, "value must be a positive integer"),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the user holding the permission. Conflicts with `user_id`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "FQDN of the domain, such as `example.com` or the wildcard `*.example.com`. Required unless `database_id` is set. Read back as stored by the server.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"access_level": schema.StringAttribute{
+				MarkdownDescription: "Access level of the permission: 'domain' or 'subdomain'.",
+				Computed:            true,
+			},
+			"domain_id": schema.Int64Attribute{
+				MarkdownDescription: "The ID of the domain.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the permission in format `user_id:domain:access_level`, as used by `legocharm_user_domain_access`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures the permission is identified either by database ID
+// or by exactly one of user ID and username together with a domain.
+func (d *UserDomainAccessDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data UserDomainAccessDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values may still satisfy the constraints once known.
+	if data.DatabaseID.IsUnknown() || data.UserId.IsUnknown() || data.Username.IsUnknown() || data.Domain.IsUnknown() {
+		return
+	}
+
+	if !data.DatabaseID.IsNull() {
+		if !data.UserId.IsNull() || !data.Username.IsNull() || !data.Domain.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("database_id"),
+				"Invalid Attribute Combination",
+				"`database_id` cannot be combined with `user_id`, `username` or `domain`.",
+			)
+		}
+		return
+	}
+
+	if data.UserId.IsNull() == data.Username.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_id"),
+			"Invalid Attribute Combination",
+			"Exactly one of `user_id` and `username` must be set unless `database_id` is set.",
+		)
+	}
+	if data.Domain.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Missing Attribute",
+			"`domain` must be set unless `database_id` is set.",
+		)
+	}
+}
+
+func (d *UserDomainAccessDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UserDomainAccessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserDomainAccessDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this data source")
+		return
+	}
+
+	var access *legocharmclient.DomainUserPermissionData
+	if !data.DatabaseID.IsNull() {
+		access = d.getByDatabaseID(ctx, int(data.DatabaseID.ValueInt64()), &resp.Diagnostics)
+	} else {
+		access = d.getByUserAndDomain(ctx, &data, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := d.client.GetUserById(ctx, strconv.Itoa(access.UserID))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read user for domain access", err, nil)
+		return
+	}
+	domain, err := d.client.GetDomainById(ctx, access.Domain)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read domain for domain access", err, nil)
+		return
+	}
+
+	data.DatabaseID = types.Int64Value(int64(access.ID))
+	data.UserId = types.StringValue(strconv.Itoa(access.UserID))
+	data.Username = types.StringValue(user.Username)
+	data.Domain = types.StringValue(domain.Fqdn)
+	data.DomainId = types.Int64Value(int64(domain.ID))
+	data.AccessLevel = types.StringValue(access.AccessLevel)
+	data.Id = types.StringValue(data.UserId.ValueString() + ":" + legocharmclient.NormalizeFQDN(domain.Fqdn) + ":" + access.AccessLevel)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// getByDatabaseID returns the permission with the given database ID.
+func (d *UserDomainAccessDataSource) getByDatabaseID(ctx context.Context, id int, diags *diag.Diagnostics) *legocharmclient.DomainUserPermissionData {
+	access, err := d.client.GetDomainAccessById(ctx, id)
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			diags.AddAttributeError(path.Root("database_id"), "Domain Access Not Found", fmt.Sprintf("No domain access permission with ID %d exists.", id))
+			return nil
+		}
+		addClientError(diags, "Unable to read user domain access", err, nil)
+		return nil
+	}
+	return access
+}
+
+// getByUserAndDomain returns the permission of the user identified by
+// data.UserId or data.Username on data.Domain.
+func (d *UserDomainAccessDataSource) getByUserAndDomain(ctx context.Context, data *UserDomainAccessDataSourceModel, diags *diag.Diagnostics) *legocharmclient.DomainUserPermissionData {
+	userID := data.UserId.ValueString()
+	if data.UserId.IsNull() {
+		user, err := d.client.GetUserByUsername(ctx, data.Username.ValueString())
+		if err != nil {
+			if errors.Is(err, legocharmclient.ErrNotFound) {
+				diags.AddAttributeError(path.Root("username"), "User Not Found", fmt.Sprintf("No user with username %q exists.", data.Username.ValueString()))
+				return nil
+			}
+			addClientError(diags, "Unable to look up user", err, nil)
+			return nil
+		}
+		userID = legocharmclient.LastPathSegment(user.Url)
+	}
+
+	access, err := d.client.GetDomainAccess(ctx, userID, data.Domain.ValueString())
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			diags.AddError("Domain Access Not Found", fmt.Sprintf("No domain access permission exists for user %s on %s.", userID, data.Domain.ValueString()))
+			return nil
+		}
+		addClientError(diags, "Unable to read user domain access", err, nil)
+		return nil
+	}
+	return access
+}
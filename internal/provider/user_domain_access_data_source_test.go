@@ -0,0 +1,146 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// userDomainAccessDataSourceConfig returns a configuration for the user domain
+// access data source with the given lookup attributes.
+func userDomainAccessDataSourceConfig(t *testing.T, schemaResp *datasource.SchemaResponse, databaseID types.Int64, userID, username, domain types.String) tfsdk.Config {
+	data := UserDomainAccessDataSourceModel{
+		DatabaseID:  databaseID,
+		UserId:      userID,
+		Username:    username,
+		Domain:      domain,
+		AccessLevel: types.StringNull(),
+		DomainId:    types.Int64Null(),
+		Id:          types.StringNull(),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(context.Background(), &data).HasError())
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+}
+
+func TestUserDomainAccessDataSource_ValidateConfig(t *testing.T) {
+	d := &UserDomainAccessDataSource{}
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	for name, tc := range map[string]struct {
+		databaseID types.Int64
+		userID     types.String
+		username   types.String
+		domain     types.String
+		valid      bool
+	}{
+		"database_id":            {databaseID: types.Int64Value(7), userID: types.StringNull(), username: types.StringNull(), domain: types.StringNull(), valid: true},
+		"username and domain":    {databaseID: types.Int64Null(), userID: types.StringNull(), username: types.StringValue("alice"), domain: types.StringValue("example.com"), valid: true},
+		"database_id and domain": {databaseID: types.Int64Value(7), userID: types.StringNull(), username: types.StringNull(), domain: types.StringValue("example.com")},
+		"both users":             {databaseID: types.Int64Null(), userID: types.StringValue("1004"), username: types.StringValue("alice"), domain: types.StringValue("example.com")},
+		"no domain":              {databaseID: types.Int64Null(), userID: types.StringValue("1004"), username: types.StringNull(), domain: types.StringNull()},
+		"nothing":                {databaseID: types.Int64Null(), userID: types.StringNull(), username: types.StringNull(), domain: types.StringNull()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &datasource.ValidateConfigResponse{}
+			d.ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: userDomainAccessDataSourceConfig(t, schemaResp, tc.databaseID, tc.userID, tc.username, tc.domain)}, resp)
+			require.Equal(t, !tc.valid, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+func TestUserDomainAccessDataSource_Read(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[7] = legocharmclient.DomainUserPermissionData{ID: 7, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	d := &UserDomainAccessDataSource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	read := func(config tfsdk.Config) (UserDomainAccessDataSourceModel, *datasource.ReadResponse) {
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+		var result UserDomainAccessDataSourceModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.State.Get(ctx, &result).HasError())
+		}
+		return result, resp
+	}
+
+	byID, resp := read(userDomainAccessDataSourceConfig(t, schemaResp, types.Int64Value(7), types.StringNull(), types.StringNull(), types.StringNull()))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, "alice", byID.Username.ValueString())
+	require.Equal(t, "1004", byID.UserId.ValueString())
+	require.Equal(t, "staging.example.com", byID.Domain.ValueString())
+	require.Equal(t, int64(2), byID.DomainId.ValueInt64())
+	require.Equal(t, "subdomain", byID.AccessLevel.ValueString())
+	require.Equal(t, "1004:staging.example.com:subdomain", byID.Id.ValueString())
+
+	byUser, resp := read(userDomainAccessDataSourceConfig(t, schemaResp, types.Int64Null(), types.StringNull(), types.StringValue("alice"), types.StringValue("Staging.example.com.")))
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, byID, byUser)
+
+	_, resp = read(userDomainAccessDataSourceConfig(t, schemaResp, types.Int64Value(8), types.StringNull(), types.StringNull(), types.StringNull()))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Domain Access Not Found", resp.Diagnostics.Errors()[0].Summary())
+
+	_, resp = read(userDomainAccessDataSourceConfig(t, schemaResp, types.Int64Null(), types.StringValue("1004"), types.StringNull(), types.StringValue("other.example.com")))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Domain Access Not Found", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestAccUserDomainAccessDataSource(t *testing.T) {
+	env := testAccPreCheck(t)
+	userID, username, _ := testAccUser(t, env)
+	domain := testAccDomain(t, env)
+	access := testAccGrant(t, env, userID, domain.Fqdn, "subdomain")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "legocharm_user_domain_access" "test" {
+  username = %q
+  domain   = %q
+}
+`, username, domain.Fqdn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.legocharm_user_domain_access.test", "access_level", "subdomain"),
+					resource.TestCheckResourceAttr("data.legocharm_user_domain_access.test", "database_id", strconv.Itoa(access.ID)),
+					resource.TestCheckResourceAttr("data.legocharm_user_domain_access.test", "user_id", userID),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "legocharm_user_domain_access" "test" {
+  database_id = %d
+}
+`, access.ID),
+				Check: resource.TestCheckResourceAttr("data.legocharm_user_domain_access.test", "username", username),
+			},
+		},
+	})
+}
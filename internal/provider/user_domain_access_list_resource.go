@@ -0,0 +1,177 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ list.ListResourceWithConfigure = &UserDomainAccessResource{}
+
+// NewUserDomainAccessListResource creates a new user domain access list
+// resource.
+func NewUserDomainAccessListResource() list.ListResource { return &UserDomainAccessResource{} }
+
+// UserDomainAccessListModel maps the list block configuration of
+// legocharm_user_domain_access.
+type UserDomainAccessListModel struct {
+	Username types.String `tfsdk:"username"`
+	Domain   types.String `tfsdk:"domain"`
+}
+
+// ListResourceConfigSchema implements list.ListResource.
+func (r *UserDomainAccessResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists domain access permissions, for generating import blocks.",
+		Attributes: map[string]listschema.Attribute{
+			"username": listschema.StringAttribute{
+				MarkdownDescription: "Only list permissions held by this user.",
+				Optional:            true,
+			},
+			"domain": listschema.StringAttribute{
+				MarkdownDescription: "Only list permissions on exactly this domain, such as `example.com` or the wildcard `*.example.com`.",
+				Optional:            true,
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+		},
+	}
+}
+
+// List implements list.ListResource.
+func (r *UserDomainAccessResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config UserDomainAccessListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if r.client == nil {
+		diags.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	permissions, err := r.listPermissions(ctx, config)
+	if err != nil {
+		addClientError(&diags, "Unable to list domain access", err, nil)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	users, err := r.client.ListUsers(ctx)
+	if err != nil {
+		addClientError(&diags, "Unable to list users", err, nil)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	domains, err := r.client.ListDomains(ctx)
+	if err != nil {
+		addClientError(&diags, "Unable to list domains", err, nil)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	usernames := map[string]string{}
+	for _, user := range users {
+		usernames[legocharmclient.LastPathSegment(user.Url)] = user.Username
+	}
+	fqdns := map[int]string{}
+	for _, domain := range domains {
+		fqdns[domain.ID] = domain.Fqdn
+	}
+
+	var models []UserDomainAccessModel
+	for _, p := range permissions {
+		// skip permissions on users or domains deleted between the calls
+		userID := strconv.Itoa(p.UserID)
+		username, ok := usernames[userID]
+		if !ok {
+			continue
+		}
+		fqdn, ok := fqdns[p.Domain]
+		if !ok {
+			continue
+		}
+		data := importedDomainAccessModel()
+		data.UserId = types.StringValue(userID)
+		data.Username = types.StringValue(username)
+		data.Domain = types.StringValue(fqdn)
+		data.DomainId = types.Int64Value(int64(p.Domain))
+		data.Fqdn = types.StringValue(fqdn)
+		data.AccessLevel = types.StringValue(p.AccessLevel)
+		data.DatabaseID = types.Int64Value(int64(p.ID))
+		data.Id = types.StringValue(domainAccessID(data))
+		models = append(models, data)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Id.ValueString() < models[j].Id.ValueString() })
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, data := range models {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = data.Username.ValueString() + " on " + data.Domain.ValueString() + " (" + data.AccessLevel.ValueString() + ")"
+			setDomainAccessIdentity(ctx, result.Identity, data.DatabaseID, &result.Diagnostics)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listPermissions returns the permissions matching the list configuration,
+// using the narrowest server-side filter available.
+func (r *UserDomainAccessResource) listPermissions(ctx context.Context, config UserDomainAccessListModel) ([]legocharmclient.DomainUserPermissionData, error) {
+	domainID := 0
+	if !config.Domain.IsNull() {
+		domain, err := r.client.GetDomain(ctx, config.Domain.ValueString())
+		if err != nil {
+			if errors.Is(err, legocharmclient.ErrNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		domainID = domain.ID
+	}
+
+	var permissions []legocharmclient.DomainUserPermissionData
+	var err error
+	switch {
+	case !config.Username.IsNull():
+		permissions, err = r.client.ListDomainAccessByUsername(ctx, config.Username.ValueString())
+	case domainID != 0:
+		permissions, err = r.client.ListDomainAccessByFqdn(ctx, config.Domain.ValueString())
+	default:
+		permissions, err = r.client.ListAllDomainAccess(ctx)
+	}
+	if err != nil || domainID == 0 {
+		return permissions, err
+	}
+
+	// discard records for other domains that slip through the filter
+	var matched []legocharmclient.DomainUserPermissionData
+	for _, p := range permissions {
+		if p.Domain == domainID {
+			matched = append(matched, p)
+		}
+	}
+	return matched, nil
+}
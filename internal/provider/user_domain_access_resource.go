@@ -7,20 +7,41 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	"terraform-provider-legocharm/internal/legocharmclient"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
 )
 
+// userIDRegexp matches the decimal database ID of a user.
+var userIDRegexp = regexp.MustCompile(`^[1-9][0-9]*$`)
+
 var _ resource.Resource = &UserDomainAccessResource{}
 var _ resource.ResourceWithImportState = &UserDomainAccessResource{}
+var _ resource.ResourceWithValidateConfig = &UserDomainAccessResource{}
+var _ resource.ResourceWithIdentity = &UserDomainAccessResource{}
+var _ resource.ResourceWithConfigValidators = &UserDomainAccessResource{}
+var _ resource.ResourceWithModifyPlan = &UserDomainAccessResource{}
 
 // NewUserDomainAccessResource creates a new user domain access resource.
 func NewUserDomainAccessResource() resource.Resource { return &UserDomainAccessResource{} }
@@ -28,54 +49,134 @@ func NewUserDomainAccessResource() resource.Resource { return &UserDomainAccessR
 // UserDomainAccessResource is the resource implementation for LegoCharm user domain access permissions.
 // It manages user permissions for accessing specific domains.
 type UserDomainAccessResource struct {
-	client *legocharmclient.Client
+	client legocharmclient.API
 }
 
 // UserDomainAccessModel maps Terraform schema to Go types for user domain access resources.
 type UserDomainAccessModel struct {
-	UserId      types.String `tfsdk:"user_id"`
-	Domain      types.String `tfsdk:"domain"`
-	AccessLevel types.String `tfsdk:"access_level"`
-	Id          types.String `tfsdk:"id"`
-	DatabaseID  types.Int64  `tfsdk:"database_id"`
+	UserId        types.String   `tfsdk:"user_id"`
+	Username      types.String   `tfsdk:"username"`
+	Domain        types.String   `tfsdk:"domain"`
+	AccessLevel   types.String   `tfsdk:"access_level"`
+	ManageDomain  types.Bool     `tfsdk:"manage_domain"`
+	CleanupDomain types.Bool     `tfsdk:"cleanup_domain"`
+	Dedupe        types.Bool     `tfsdk:"dedupe"`
+	DomainCreated types.Bool     `tfsdk:"domain_created"`
+	DomainId      types.Int64    `tfsdk:"domain_id"`
+	Fqdn          types.String   `tfsdk:"fqdn"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	Id            types.String   `tfsdk:"id"`
+	DatabaseID    types.Int64    `tfsdk:"database_id"`
+}
+
+// UserDomainAccessIdentityModel maps the identity schema of user domain
+// access resources. The server-side permission ID alone identifies a grant.
+type UserDomainAccessIdentityModel struct {
+	DatabaseID types.Int64 `tfsdk:"database_id"`
 }
 
+// domainAccessTimeouts are the operations configurable in the timeouts
+// block of legocharm_user_domain_access.
+var domainAccessTimeouts = timeouts.Opts{Create: true, Read: true, Delete: true}
+
 func (r *UserDomainAccessResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_user_domain_access"
 }
 
+// IdentitySchema implements resource.ResourceWithIdentity.
+func (r *UserDomainAccessResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"database_id": identityschema.Int64Attribute{
+				RequiredForImport: true,
+				Description:       "The server-side ID of the domain access permission.",
+			},
+		},
+	}
+}
+
 func (r *UserDomainAccessResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "User domain access resource for httprequest-lego-provider.",
 		Attributes: map[string]schema.Attribute{
 			"user_id": schema.StringAttribute{
-				MarkdownDescription: "ID of user to grant domain access to",
-				Required:            true,
+				MarkdownDescription: "ID of user to grant domain access to. Exactly one of `user_id` and `username` must be set; the other is computed.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringMatches(userIDRegexp, "value must be a positive integer"),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of user to grant domain access to. Exactly one of `user_id` and `username` must be set; the other is computed.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "FQDN of the domain to grant access to",
+				MarkdownDescription: "FQDN of the domain to grant access to, such as `example.com` or the wildcard `*.example.com`. A wildcard may only be the leftmost label. Compared case-insensitively and ignoring a trailing dot.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceIfFQDNChanged(),
+				},
+				Validators: []validator.String{
+					fqdn(),
 				},
 			},
 			"access_level": schema.StringAttribute{
-				MarkdownDescription: "Access level. Possible values: 'domain' 'subdomain'",
+				MarkdownDescription: "Access level. Possible values: 'domain' 'subdomain'. Changing it updates the permission in place.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+			},
+			"manage_domain": schema.BoolAttribute{
+				MarkdownDescription: "Whether to create the domain if it does not exist yet. When `false`, creating the permission fails unless the domain already exists. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"cleanup_domain": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete the domain on destroy if it was created for this permission and no other permissions reference it. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"dedupe": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete, on apply, the duplicates of the tracked permission: other permissions of the same user on the same domain with the same access level. Refresh only reports duplicates as a warning. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"domain_created": schema.BoolAttribute{
+				MarkdownDescription: "Whether the domain was created by the provider when this permission was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"id": schema.StringAttribute{
+			"domain_id": schema.Int64Attribute{
+				MarkdownDescription: "Numeric ID of the domain the permission grants access to.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "FQDN of the domain as normalized and stored by the server.",
 				Computed:            true,
-				MarkdownDescription: "The ID of the user domain access resource, in format 'user_id:domain:access_level'",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user domain access resource, in format 'user_id:domain:access_level'",
+			},
 			"database_id": schema.Int64Attribute{
 				MarkdownDescription: "Internal database ID for the domain access permission",
 				Computed:            true,
@@ -84,6 +185,35 @@ func (r *UserDomainAccessResource) Schema(ctx context.Context, req resource.Sche
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, domainAccessTimeouts),
+		},
+	}
+}
+
+// ValidateConfig ensures the user is identified by exactly one of user_id and
+// username.
+func (r *UserDomainAccessResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data UserDomainAccessModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UserId.IsNull() == data.Username.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_id"),
+			"Invalid User Arguments",
+			"Exactly one of `user_id` and `username` must be set.",
+		)
+	}
+}
+
+// ConfigValidators flags domain and access level combinations that are
+// unlikely to grant what was intended.
+func (r *UserDomainAccessResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		domainAccessLevel(path.Root("domain"), path.Root("access_level")),
 	}
 }
 
@@ -99,25 +229,60 @@ func (r *UserDomainAccessResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	timeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := clientClock(r.client).WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r.resolveUser(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// check if a domain access already exists for this user+domain
-	existing, err := r.client.GetDomainAccess(data.UserId.ValueString(), data.Domain.ValueString())
-	if err == nil && existing != nil {
+	existing, err := r.client.GetDomainAccess(ctx, data.UserId.ValueString(), data.Domain.ValueString())
+	if err != nil && !errors.Is(err, legocharmclient.ErrNotFound) {
+		addClientError(&resp.Diagnostics, "Unable to check for existing user domain access", err, domainAccessAPIFields)
+		return
+	}
+	if existing != nil {
 		resp.Diagnostics.AddError("Domain Access Already Exists", "A domain access permission already exists for this user and domain combination.")
 		return
 	}
 
-	createData := &legocharmclient.DomainUserPermissionCreateData{UserID: data.UserId.ValueString(), Domain: data.Domain.ValueString(), AccessLevel: data.AccessLevel.ValueString()}
-	domain, err := r.client.CreateDomainAccess(*createData)
+	createData := &legocharmclient.DomainUserPermissionCreateData{
+		UserID:                data.UserId.ValueString(),
+		Domain:                data.Domain.ValueString(),
+		AccessLevel:           data.AccessLevel.ValueString(),
+		RequireExistingDomain: !data.ManageDomain.ValueBool(),
+	}
+	domain, err := r.client.CreateDomainAccess(ctx, *createData)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user domain access: %s", err))
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("domain"),
+				"Domain Not Found",
+				fmt.Sprintf("The domain %q does not exist and manage_domain is false. Create the domain first or set manage_domain to true.", data.Domain.ValueString()),
+			)
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to create user domain access", err, domainAccessAPIFields)
 		return
 	}
 
-	// Placeholder: set a fake ID for now
-	data.Id = types.StringValue(data.UserId.ValueString() + ":" + data.Domain.ValueString() + ":" + data.AccessLevel.ValueString())
+	data.Id = types.StringValue(domainAccessID(data))
 	data.DatabaseID = types.Int64Value(int64(domain.ID))
+	data.DomainCreated = types.BoolValue(domain.DomainCreated)
+	r.setDomainAttributes(ctx, &data, domain.Domain, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...) // Save state
+	setDomainAccessIdentity(ctx, resp.Identity, data.DatabaseID, &resp.Diagnostics)
 }
 
 func (r *UserDomainAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -132,25 +297,75 @@ func (r *UserDomainAccessResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	timeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := clientClock(r.client).WithTimeout(ctx, timeout)
+	defer cancel()
+
 	if data.UserId.IsNull() || data.Domain.IsNull() {
 		resp.Diagnostics.AddError("Invalid State", "User ID or Domain is null in state")
 		return
 	}
 
-	found, err := r.client.GetDomainAccess(data.UserId.ValueString(), data.Domain.ValueString())
-	// If not found, resp.State.RemoveResource(ctx)
+	matches, err := r.client.ListDomainAccess(ctx, data.UserId.ValueString(), data.Domain.ValueString())
 	if err != nil {
 		if errors.Is(err, legocharmclient.ErrNotFound) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user domain access: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to read user domain access", err, nil)
 		return
 	}
+	// A grant at another access level is not this one, even if the tracked
+	// grant is gone; plan a create instead of adopting it.
+	found := selectDomainAccess(matches, data)
+	if found == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if duplicates := duplicateDomainAccess(matches, found.ID, found.AccessLevel); len(duplicates) > 0 {
+		warnDuplicates(duplicates, found.ID, data, &resp.Diagnostics)
+	}
+	if known := data.DatabaseID.ValueInt64(); known != 0 && known != int64(found.ID) {
+		tflog.Warn(ctx, "domain access was recreated outside Terraform", map[string]interface{}{"old_database_id": known, "new_database_id": found.ID})
+		resp.Diagnostics.AddWarning(
+			"Domain Access Recreated Outside Terraform",
+			fmt.Sprintf("The domain access permission for user %s on %s was deleted and recreated outside Terraform. Its database ID changed from %d to %d; the new permission is now tracked.", data.UserId.ValueString(), data.Domain.ValueString(), known, found.ID),
+		)
+	}
 	data.AccessLevel = types.StringValue(found.AccessLevel)
 	data.DatabaseID = types.Int64Value(int64(found.ID))
+	r.setDomainAttributes(ctx, &data, found.Domain, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ManageDomain.IsNull() {
+		data.ManageDomain = types.BoolValue(true)
+	}
+	if data.CleanupDomain.IsNull() {
+		data.CleanupDomain = types.BoolValue(false)
+	}
+	if data.Dedupe.IsNull() {
+		data.Dedupe = types.BoolValue(false)
+	}
+	if data.DomainCreated.IsNull() {
+		data.DomainCreated = types.BoolValue(false)
+	}
+
+	// State written before username existed, or by import, lacks it.
+	if data.Username.IsNull() || data.Username.IsUnknown() {
+		r.resolveUser(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...) // Save state
+	setDomainAccessIdentity(ctx, resp.Identity, data.DatabaseID, &resp.Diagnostics)
 }
 
 // Update implements resource updating for UserDomainAccessResource.
@@ -171,27 +386,44 @@ func (r *UserDomainAccessResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 	if data.DatabaseID.IsNull() || data.DatabaseID.ValueInt64() == 0 {
-		resp.Diagnostics.AddError("Invalid State", "Database ID is null or zero in state")
-		return
+		// The permission still has the access level in state, not the
+		// planned one.
+		var state UserDomainAccessModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.resolveDatabaseID(ctx, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.DatabaseID = state.DatabaseID
 	}
 
-	_, err := r.client.DeleteDomainAccess(int(data.DatabaseID.ValueInt64()))
+	// Only access_level can change without replacement, so update it in
+	// place rather than leaving a window with no permission.
+	permission, err := r.client.UpdateDomainAccess(ctx, int(data.DatabaseID.ValueInt64()), data.AccessLevel.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user domain access: %s", err))
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.Diagnostics.AddError("Domain Access Not Found", "The domain access permission no longer exists. Refresh and apply again to recreate it.")
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to update user domain access", err, domainAccessAPIFields)
 		return
 	}
+	data.AccessLevel = types.StringValue(permission.AccessLevel)
+	data.DatabaseID = types.Int64Value(int64(permission.ID))
+	data.Id = types.StringValue(domainAccessID(data))
 
-	// recreate with new access level
-	createData := &legocharmclient.DomainUserPermissionCreateData{UserID: data.UserId.ValueString(), Domain: data.Domain.ValueString(), AccessLevel: data.AccessLevel.ValueString()}
-	domain, err := r.client.CreateDomainAccess(*createData)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user domain access: %s", err))
-		return
+	if data.Dedupe.ValueBool() {
+		r.deleteDuplicates(ctx, permission, data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
-	data.DatabaseID = types.Int64Value(int64(domain.ID))
-	data.Id = types.StringValue(data.UserId.ValueString() + ":" + data.Domain.ValueString() + ":" + data.AccessLevel.ValueString())
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...) // Save state
+	setDomainAccessIdentity(ctx, resp.Identity, data.DatabaseID, &resp.Diagnostics)
 }
 
 // Delete implements resource deletion for UserDomainAccessResource.
@@ -207,36 +439,436 @@ func (r *UserDomainAccessResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	if data.DatabaseID.IsNull() || data.DatabaseID.ValueInt64() == 0 {
-		resp.Diagnostics.AddError("Invalid State", "Database ID is null or zero in state")
+	timeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := clientClock(r.client).WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// TODO: Call client to delete domain access resource
-	_, err := r.client.DeleteDomainAccess(int(data.DatabaseID.ValueInt64()))
-	if err != nil {
+	if data.DatabaseID.IsNull() || data.DatabaseID.ValueInt64() == 0 {
+		r.resolveDatabaseID(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if err := deletePermission(ctx, r.client, int(data.DatabaseID.ValueInt64())); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user domain access: %s", err))
 		return
 	}
 
+	if data.CleanupDomain.ValueBool() && data.DomainCreated.ValueBool() {
+		r.cleanupDomain(ctx, data.Domain.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Remove resource from state
 	resp.State.RemoveResource(ctx)
 }
 
+// deletePermission deletes the domain access permission with the given
+// database ID. A permission that no longer exists is treated as already
+// deleted.
+func deletePermission(ctx context.Context, client legocharmclient.API, id int) error {
+	res, err := client.DeleteDomainAccess(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 && res.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("status %d, body: %s", res.StatusCode, body)
+	}
+	return nil
+}
+
+// cleanupDomain deletes the domain with the given FQDN unless other
+// permissions still reference it.
+func (r *UserDomainAccessResource) cleanupDomain(ctx context.Context, fqdn string, diags *diag.Diagnostics) {
+	deleted, err := r.client.DeleteDomainIfUnused(ctx, fqdn)
+	if err != nil {
+		addClientError(diags, "Unable to clean up domain", err, nil)
+		return
+	}
+	if !deleted {
+		tflog.Debug(ctx, "keeping domain still referenced by other permissions or already deleted", map[string]interface{}{"fqdn": fqdn})
+	}
+}
+
+// resolveDatabaseID looks up the permission for the model's user, domain and
+// access level and records its database ID, for state written without one.
+// It returns the ID of the permission's domain, or zero on error.
+func (r *UserDomainAccessResource) resolveDatabaseID(ctx context.Context, data *UserDomainAccessModel, diags *diag.Diagnostics) int {
+	matches, err := r.client.ListDomainAccess(ctx, data.UserId.ValueString(), data.Domain.ValueString())
+	if err != nil && !errors.Is(err, legocharmclient.ErrNotFound) {
+		addClientError(diags, "Unable to read user domain access", err, nil)
+		return 0
+	}
+	// The user may hold grants at other access levels on the same domain,
+	// which are not this one.
+	found := selectDomainAccess(matches, *data)
+	if found == nil {
+		diags.AddError("Domain Access Not Found", fmt.Sprintf("No %s domain access permission exists for user %s on %s.", data.AccessLevel.ValueString(), data.UserId.ValueString(), data.Domain.ValueString()))
+		return 0
+	}
+	data.DatabaseID = types.Int64Value(int64(found.ID))
+	return found.Domain
+}
+
+// setDomainAttributes records the domain ID of a permission and the FQDN the
+// server stores for it. The domain is only fetched when the ID changes.
+func (r *UserDomainAccessResource) setDomainAttributes(ctx context.Context, data *UserDomainAccessModel, domainID int, diags *diag.Diagnostics) {
+	if data.DomainId.ValueInt64() == int64(domainID) && !data.Fqdn.IsNull() && !data.Fqdn.IsUnknown() {
+		return
+	}
+
+	domain, err := r.client.GetDomainById(ctx, domainID)
+	if err != nil {
+		addClientError(diags, "Unable to read domain for user domain access", err, nil)
+		return
+	}
+	data.DomainId = types.Int64Value(int64(domain.ID))
+	data.Fqdn = types.StringValue(domain.Fqdn)
+}
+
+// resolveUser fills in whichever of user_id and username is not known by
+// looking the user up by the other.
+func (r *UserDomainAccessResource) resolveUser(ctx context.Context, data *UserDomainAccessModel, diags *diag.Diagnostics) {
+	var (
+		user *legocharmclient.UserData
+		err  error
+	)
+	if !data.UserId.IsNull() && !data.UserId.IsUnknown() {
+		user, err = r.client.GetUserById(ctx, data.UserId.ValueString())
+	} else {
+		user, err = r.client.GetUserByUsername(ctx, data.Username.ValueString())
+	}
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			diags.AddError("User Not Found", "The user to grant domain access to does not exist.")
+			return
+		}
+		addClientError(diags, "Unable to look up user", err, nil)
+		return
+	}
+
+	data.UserId = types.StringValue(legocharmclient.LastPathSegment(user.Url))
+	data.Username = types.StringValue(user.Username)
+}
+
+// ModifyPlan plans an update when dedupe is enabled and the tracked
+// permission has duplicates, so that apply deletes them.
+func (r *UserDomainAccessResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state UserDomainAccessModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.Dedupe.ValueBool() || plan.AccessLevel.IsUnknown() || state.UserId.IsNull() || state.Domain.IsNull() {
+		return
+	}
+
+	matches, err := r.client.ListDomainAccess(ctx, state.UserId.ValueString(), state.Domain.ValueString())
+	if err != nil {
+		if !errors.Is(err, legocharmclient.ErrNotFound) {
+			addClientError(&resp.Diagnostics, "Unable to read user domain access", err, nil)
+		}
+		return
+	}
+	keep := selectDomainAccess(matches, state)
+	if keep == nil {
+		return
+	}
+	duplicates := duplicateDomainAccess(matches, keep.ID, plan.AccessLevel.ValueString())
+	if len(duplicates) == 0 {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	resp.Diagnostics.AddWarning(
+		"Duplicate Domain Access Will Be Deleted",
+		fmt.Sprintf("User %s holds %d duplicates of permission %d on %s: %s. Applying this plan deletes them.", state.UserId.ValueString(), len(duplicates), keep.ID, state.Domain.ValueString(), permissionIDs(duplicates)),
+	)
+}
+
+// deleteDuplicates deletes the duplicates of keep.
+func (r *UserDomainAccessResource) deleteDuplicates(ctx context.Context, keep *legocharmclient.DomainUserPermissionData, data UserDomainAccessModel, diags *diag.Diagnostics) {
+	matches, err := r.client.ListDomainAccess(ctx, data.UserId.ValueString(), data.Domain.ValueString())
+	if err != nil {
+		addClientError(diags, "Unable to read user domain access", err, nil)
+		return
+	}
+	duplicates := duplicateDomainAccess(matches, keep.ID, keep.AccessLevel)
+	if len(duplicates) == 0 {
+		return
+	}
+	for _, p := range duplicates {
+		tflog.Info(ctx, "deleting duplicate domain access", map[string]interface{}{"database_id": p.ID, "kept_database_id": keep.ID})
+		if err := deletePermission(ctx, r.client, p.ID); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete duplicate domain access %d: %s", p.ID, err))
+			return
+		}
+	}
+	diags.AddWarning(
+		"Duplicate Domain Access Removed",
+		fmt.Sprintf("User %s held %d duplicates of permission %d on %s. Deleted %s.", data.UserId.ValueString(), len(duplicates), keep.ID, data.Domain.ValueString(), permissionIDs(duplicates)),
+	)
+}
+
+// warnDuplicates reports the duplicates of the tracked permission keepID.
+func warnDuplicates(duplicates []legocharmclient.DomainUserPermissionData, keepID int, data UserDomainAccessModel, diags *diag.Diagnostics) {
+	action := "Set dedupe = true to delete them on apply."
+	if data.Dedupe.ValueBool() {
+		action = "The next apply deletes them."
+	}
+	diags.AddWarning(
+		"Duplicate Domain Access",
+		fmt.Sprintf("User %s holds %d duplicates of permission %d on %s: %s. %s", data.UserId.ValueString(), len(duplicates), keepID, data.Domain.ValueString(), permissionIDs(duplicates), action),
+	)
+}
+
+// duplicateDomainAccess returns the permissions in matches, which share a
+// user and domain, with the access level accessLevel other than keepID, by
+// ID. Permissions at other access levels are separate grants.
+func duplicateDomainAccess(matches []legocharmclient.DomainUserPermissionData, keepID int, accessLevel string) []legocharmclient.DomainUserPermissionData {
+	var duplicates []legocharmclient.DomainUserPermissionData
+	for _, p := range matches {
+		if p.ID != keepID && p.AccessLevel == accessLevel {
+			duplicates = append(duplicates, p)
+		}
+	}
+	slices.SortFunc(duplicates, func(a, b legocharmclient.DomainUserPermissionData) int { return a.ID - b.ID })
+	return duplicates
+}
+
+// permissionIDs returns the comma-separated database IDs of permissions.
+func permissionIDs(permissions []legocharmclient.DomainUserPermissionData) string {
+	ids := make([]string, len(permissions))
+	for i, p := range permissions {
+		ids[i] = strconv.Itoa(p.ID)
+	}
+	return strings.Join(ids, ", ")
+}
+
+// selectDomainAccess picks the record tracked by data from permissions that
+// already match its user and domain: the one with the same database ID if
+// known, otherwise the one with the same access level. Grants at other access
+// levels may be managed by other resources, so it returns nil rather than
+// pick one of them.
+func selectDomainAccess(permissions []legocharmclient.DomainUserPermissionData, data UserDomainAccessModel) *legocharmclient.DomainUserPermissionData {
+	if !data.DatabaseID.IsNull() && !data.DatabaseID.IsUnknown() && data.DatabaseID.ValueInt64() != 0 {
+		for i := range permissions {
+			if int64(permissions[i].ID) == data.DatabaseID.ValueInt64() {
+				return &permissions[i]
+			}
+		}
+	}
+	for i := range permissions {
+		if permissions[i].AccessLevel == data.AccessLevel.ValueString() {
+			return &permissions[i]
+		}
+	}
+	return nil
+}
+
+// setDomainAccessIdentity records the permission ID as the resource identity.
+// identity is nil when Terraform does not support resource identity.
+func setDomainAccessIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, databaseID types.Int64, diags *diag.Diagnostics) {
+	if identity == nil || diags.HasError() {
+		return
+	}
+	diags.Append(identity.Set(ctx, UserDomainAccessIdentityModel{DatabaseID: databaseID})...)
+}
+
+// splitDomainAccessID splits an ID in format 'user:domain:access_level',
+// where user is a user ID or username. ok is false if the ID is malformed.
+func splitDomainAccessID(id string) (user, domain, accessLevel string, ok bool) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[2], true
+}
+
+// domainAccessID returns the resource ID, in format 'user_id:domain:access_level'.
+func domainAccessID(data UserDomainAccessModel) string {
+	return data.UserId.ValueString() + ":" + legocharmclient.NormalizeFQDN(data.Domain.ValueString()) + ":" + data.AccessLevel.ValueString()
+}
+
 // ImportState implements resource import for UserDomainAccessResource.
 func (r *UserDomainAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// id is of format "username:domain:access_level"
-	parts := strings.Split(req.ID, ":")
-	if len(parts) != 3 {
-		resp.Diagnostics.AddError("Invalid Import ID", "Import ID must be in the format 'username:domain:access_level'")
+	// Import blocks using identity carry the database ID instead of an ID string.
+	if req.ID == "" && req.Identity != nil {
+		var identity UserDomainAccessIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if identity.DatabaseID.IsNull() || identity.DatabaseID.IsUnknown() || identity.DatabaseID.ValueInt64() <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("database_id"), "Invalid Import Identity", "database_id must be a positive integer")
+			return
+		}
+		r.importByDatabaseID(ctx, int(identity.DatabaseID.ValueInt64()), resp)
 		return
 	}
-	var data UserDomainAccessModel
-	data.UserId = types.StringValue(parts[0])
-	data.Domain = types.StringValue(parts[1])
-	data.AccessLevel = types.StringValue(parts[2])
-	data.Id = types.StringValue(req.ID)
+
+	// id is a numeric database ID, or of format "user:domain:access_level"
+	// where user is a user ID or username, optionally prefixed with "id:" to
+	// never read it as a username.
+	if id, err := strconv.Atoi(req.ID); err == nil {
+		if id <= 0 {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Database ID %q must be a positive integer.", req.ID))
+			return
+		}
+		r.importByDatabaseID(ctx, id, resp)
+		return
+	}
+
+	// A user named "id" is imported as "id:domain:access_level".
+	importID, byID := req.ID, false
+	if strings.Count(req.ID, ":") == 3 {
+		importID, byID = strings.CutPrefix(req.ID, "id:")
+	}
+	user, domain, accessLevel, ok := splitDomainAccessID(importID)
+	if !ok {
+		resp.Diagnostics.AddError("Invalid Import ID", "Import ID must be a numeric database ID or in the format '[id:]user:domain:access_level', where user is a user ID or username")
+		return
+	}
+	if byID && !userIDRegexp.MatchString(user) {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("User ID %q must be a positive integer.", user))
+		return
+	}
+	if accessLevel != "domain" && accessLevel != "subdomain" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Import ID %q has access level %q, expected 'domain' or 'subdomain'", req.ID, accessLevel))
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	data := importedDomainAccessModel()
+	data.Domain = types.StringValue(domain)
+	data.AccessLevel = types.StringValue(accessLevel)
+
+	r.resolveImportedUser(ctx, &data, user, byID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domainID := r.resolveDatabaseID(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Fill in the computed domain attributes too, so the import plan shows
+	// no unknown values.
+	r.setDomainAttributes(ctx, &data, domainID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(domainAccessID(data))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...) // Save state
+	setDomainAccessIdentity(ctx, resp.Identity, data.DatabaseID, &resp.Diagnostics)
+}
+
+// resolveImportedUser fills in user_id and username from the user part of an
+// import ID. A number is a user ID, unless byID is unset and no user has it as
+// ID: usernames may be all digits too, so it is then taken as a username.
+func (r *UserDomainAccessResource) resolveImportedUser(ctx context.Context, data *UserDomainAccessModel, user string, byID bool, diags *diag.Diagnostics) {
+	if !userIDRegexp.MatchString(user) {
+		data.Username = types.StringValue(user)
+		r.resolveUser(ctx, data, diags)
+		return
+	}
+
+	data.UserId = types.StringValue(user)
+	if byID {
+		r.resolveUser(ctx, data, diags)
+		return
+	}
+	found, err := r.client.GetUserById(ctx, user)
+	if errors.Is(err, legocharmclient.ErrNotFound) {
+		data.UserId = types.StringNull()
+		data.Username = types.StringValue(user)
+		r.resolveUser(ctx, data, diags)
+		return
+	}
+	if err != nil {
+		addClientError(diags, "Unable to look up user", err, nil)
+		return
+	}
+	data.Username = types.StringValue(found.Username)
+}
+
+// importByDatabaseID imports the permission with the given database ID,
+// fetching its user, domain and access level from the API.
+func (r *UserDomainAccessResource) importByDatabaseID(ctx context.Context, id int, resp *resource.ImportStateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	access, err := r.client.GetDomainAccessById(ctx, id)
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.Diagnostics.AddError("Domain Access Not Found", fmt.Sprintf("No domain access permission with ID %d exists.", id))
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to read user domain access", err, nil)
+		return
+	}
+
+	domain, err := r.client.GetDomainById(ctx, access.Domain)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read domain for user domain access", err, nil)
+		return
+	}
+
+	data := importedDomainAccessModel()
+	data.UserId = types.StringValue(strconv.Itoa(access.UserID))
+	data.Domain = types.StringValue(domain.Fqdn)
+	data.DomainId = types.Int64Value(int64(domain.ID))
+	data.Fqdn = types.StringValue(domain.Fqdn)
+	data.AccessLevel = types.StringValue(access.AccessLevel)
+	data.DatabaseID = types.Int64Value(int64(access.ID))
+	r.resolveUser(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = types.StringValue(domainAccessID(data))
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...) // Save state
+	setDomainAccessIdentity(ctx, resp.Identity, data.DatabaseID, &resp.Diagnostics)
+}
+
+// importedDomainAccessModel returns a model with the defaults applied, for
+// building state without a plan.
+func importedDomainAccessModel() UserDomainAccessModel {
+	return UserDomainAccessModel{
+		UserId:        types.StringNull(),
+		Username:      types.StringNull(),
+		Domain:        types.StringNull(),
+		AccessLevel:   types.StringNull(),
+		ManageDomain:  types.BoolValue(true),
+		CleanupDomain: types.BoolValue(false),
+		Dedupe:        types.BoolValue(false),
+		DomainCreated: types.BoolValue(false),
+		DomainId:      types.Int64Null(),
+		Fqdn:          types.StringNull(),
+		Id:            types.StringNull(),
+		Timeouts:      nullTimeouts(domainAccessTimeouts),
+		DatabaseID:    types.Int64Null(),
+	}
 }
 
 func (r *UserDomainAccessResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -244,11 +876,11 @@ func (r *UserDomainAccessResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*legocharmclient.Client)
+	client, ok := req.ProviderData.(legocharmclient.API)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *legocharmclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
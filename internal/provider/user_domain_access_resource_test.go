@@ -4,11 +4,29 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient/legocharmclienttest"
 )
 
 func TestUserDomainAccessResource_Schema(t *testing.T) {
@@ -18,9 +36,17 @@ func TestUserDomainAccessResource_Schema(t *testing.T) {
 	require.NotNil(t, resp.Schema)
 	attrs := resp.Schema.Attributes
 	require.Contains(t, attrs, "user_id")
+	require.Contains(t, attrs, "username")
 	require.Contains(t, attrs, "domain")
 	require.Contains(t, attrs, "access_level")
 	require.Contains(t, attrs, "id")
+
+	require.Contains(t, resp.Schema.Blocks, "timeouts")
+	timeouts := resp.Schema.Blocks["timeouts"].GetNestedObject().GetAttributes()
+	require.Contains(t, timeouts, "create")
+	require.Contains(t, timeouts, "read")
+	require.Contains(t, timeouts, "delete")
+	require.NotContains(t, timeouts, "update")
 }
 
 func TestUserDomainAccessResource_Metadata(t *testing.T) {
@@ -29,3 +55,1452 @@ func TestUserDomainAccessResource_Metadata(t *testing.T) {
 	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "legocharm"}, resp)
 	require.Equal(t, "legocharm_user_domain_access", resp.TypeName)
 }
+
+// fakeDomainAccessAPI serves the subset of the LegoCharm API used by
+// UserDomainAccessResource and ServiceUserWithAccessResource, keeping users,
+// domains and permissions in memory.
+type fakeDomainAccessAPI struct {
+	mu          sync.Mutex
+	users       map[int]string
+	groups      map[int][]string
+	passwords   map[int]string
+	inactive    map[int]bool
+	domains     map[int]string
+	permissions map[int]legocharmclient.DomainUserPermissionData
+	txtRecords  map[string]string
+	nextID      int
+}
+
+// newFakeDomainAccessAPI returns a fake API that already knows the user alice,
+// with ID 1004, and the domain staging.example.com.
+func newFakeDomainAccessAPI() *fakeDomainAccessAPI {
+	return &fakeDomainAccessAPI{
+		users:       map[int]string{1004: "alice"},
+		groups:      map[int][]string{},
+		passwords:   map[int]string{},
+		inactive:    map[int]bool{},
+		domains:     map[int]string{2: "staging.example.com"},
+		permissions: map[int]legocharmclient.DomainUserPermissionData{},
+		txtRecords:  map[string]string{},
+		nextID:      100,
+	}
+}
+
+func (f *fakeDomainAccessAPI) domainID(fqdn string) (int, bool) {
+	for id, d := range f.domains {
+		if d == fqdn {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (f *fakeDomainAccessAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var id int
+	query := r.URL.Query()
+	switch {
+	case r.Method == "GET" && scanID(r.URL.Path, "/api/v1/users/%d/", &id):
+		_, ok := f.users[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(f.user(id)) // nolint:errcheck
+	case r.Method == "GET" && r.URL.Path == "/api/v1/users/":
+		list := []legocharmclient.UserData{}
+		if !query.Has("username") {
+			for id := range f.users {
+				list = append(list, f.user(id))
+			}
+		} else if id, ok := f.userID(query.Get("username")); ok {
+			list = append(list, f.user(id))
+		}
+		json.NewEncoder(w).Encode(list) // nolint:errcheck
+	case r.Method == "POST" && r.URL.Path == "/api/v1/users/":
+		var user legocharmclient.UserCreateData
+		if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		f.users[f.nextID] = user.Username
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(fakeUser(f.nextID, user.Username)) // nolint:errcheck
+	case r.Method == "PATCH" && scanID(r.URL.Path, "/api/v1/users/%d/", &id):
+		var update legocharmclient.UserUpdateData
+		if _, ok := f.users[id]; !ok || json.NewDecoder(r.Body).Decode(&update) != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if update.Groups != nil {
+			f.groups[id] = *update.Groups
+		}
+		if update.Password != "" {
+			f.passwords[id] = update.Password
+		}
+		if update.IsActive != nil {
+			f.inactive[id] = !*update.IsActive
+		}
+		json.NewEncoder(w).Encode(f.user(id)) // nolint:errcheck
+	case r.Method == "DELETE" && scanID(r.URL.Path, "/api/v1/users/%d/", &id):
+		if _, ok := f.users[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.users, id)
+		delete(f.groups, id)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "GET" && r.URL.Path == "/api/v1/domains/":
+		list := []legocharmclient.DomainData{}
+		if !query.Has("fqdn") {
+			for id, fqdn := range f.domains {
+				list = append(list, legocharmclient.DomainData{ID: id, Fqdn: fqdn})
+			}
+		} else if id, ok := f.domainID(query.Get("fqdn")); ok {
+			list = append(list, legocharmclient.DomainData{ID: id, Fqdn: f.domains[id]})
+		}
+		json.NewEncoder(w).Encode(list) // nolint:errcheck
+	case r.Method == "GET" && scanID(r.URL.Path, "/api/v1/domains/%d/", &id):
+		fqdn, ok := f.domains[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(legocharmclient.DomainData{ID: id, Fqdn: fqdn}) // nolint:errcheck
+	case r.Method == "POST" && r.URL.Path == "/api/v1/domains/":
+		var domain legocharmclient.DomainData
+		if err := json.NewDecoder(r.Body).Decode(&domain); err != nil || domain.Fqdn == "rejected.example.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"fqdn":["Domain rejected."]}`)) // nolint:errcheck
+			return
+		}
+		f.nextID++
+		domain.ID = f.nextID
+		f.domains[domain.ID] = domain.Fqdn
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(domain) // nolint:errcheck
+	case r.Method == "PATCH" && scanID(r.URL.Path, "/api/v1/domains/%d/", &id):
+		var domain legocharmclient.DomainData
+		if _, ok := f.domains[id]; !ok || json.NewDecoder(r.Body).Decode(&domain) != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		domain.ID = id
+		f.domains[id] = domain.Fqdn
+		json.NewEncoder(w).Encode(domain) // nolint:errcheck
+	case r.Method == "DELETE" && scanID(r.URL.Path, "/api/v1/domains/%d/", &id):
+		if _, ok := f.domains[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.domains, id)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "GET" && r.URL.Path == "/api/v1/domain-user-permissions/":
+		list := []legocharmclient.DomainUserPermissionData{}
+		for _, p := range f.permissions {
+			if username := query.Get("username"); username != "" && f.users[p.UserID] != username {
+				continue
+			}
+			if fqdn := query.Get("fqdn"); fqdn != "" && f.domains[p.Domain] != fqdn {
+				continue
+			}
+			list = append(list, p)
+		}
+		json.NewEncoder(w).Encode(list) // nolint:errcheck
+	case r.Method == "POST" && r.URL.Path == "/api/v1/domain-user-permissions/":
+		var payload legocharmclient.DomainUserPermissionCreatePayloadData
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		userID, _ := strconv.Atoi(payload.UserID)
+		p := legocharmclient.DomainUserPermissionData{ID: f.nextID, UserID: userID, Domain: payload.Domain, AccessLevel: payload.AccessLevel}
+		f.permissions[p.ID] = p
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(p) // nolint:errcheck
+	case r.Method == "GET" && scanID(r.URL.Path, "/api/v1/domain-user-permissions/%d/", &id):
+		p, ok := f.permissions[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(p) // nolint:errcheck
+	case r.Method == "PATCH" && scanID(r.URL.Path, "/api/v1/domain-user-permissions/%d/", &id):
+		var payload legocharmclient.DomainUserPermissionUpdateData
+		p, ok := f.permissions[id]
+		if !ok || json.NewDecoder(r.Body).Decode(&payload) != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		p.AccessLevel = payload.AccessLevel
+		f.permissions[id] = p
+		json.NewEncoder(w).Encode(p) // nolint:errcheck
+	case r.Method == "DELETE" && scanID(r.URL.Path, "/api/v1/domain-user-permissions/%d/", &id):
+		if _, ok := f.permissions[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.permissions, id)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "POST" && (r.URL.Path == "/present" || r.URL.Path == "/cleanup"):
+		var record legocharmclient.TXTRecordData
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/present" {
+			f.txtRecords[record.Fqdn] = record.Value
+		} else {
+			delete(f.txtRecords, record.Fqdn)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeDomainAccessAPI) userID(username string) (int, bool) {
+	for id, u := range f.users {
+		if u == username {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// user returns the API representation of the user with the given ID.
+func (f *fakeDomainAccessAPI) user(id int) legocharmclient.UserData {
+	user := fakeUser(id, f.users[id])
+	user.Groups = f.groups[id]
+	user.IsActive = !f.inactive[id]
+	return user
+}
+
+func fakeUser(id int, username string) legocharmclient.UserData {
+	return legocharmclient.UserData{Username: username, Url: fmt.Sprintf("http://example.com/api/v1/users/%d/", id), IsActive: true}
+}
+
+// scanID parses the numeric ID out of path according to format.
+func scanID(path, format string, id *int) bool {
+	_, err := fmt.Sscanf(path, format, id)
+	return err == nil
+}
+
+func TestUserDomainAccessResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := UserDomainAccessModel{
+		UserId:       types.StringValue("1004"),
+		Username:     types.StringUnknown(),
+		Domain:       types.StringValue("staging.example.com"),
+		AccessLevel:  types.StringValue("subdomain"),
+		ManageDomain: types.BoolValue(true),
+		Id:           types.StringUnknown(),
+		DatabaseID:   types.Int64Unknown(),
+		Timeouts:     nullTimeouts(domainAccessTimeouts),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var created UserDomainAccessModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Equal(t, "1004:staging.example.com:subdomain", created.Id.ValueString())
+	require.Equal(t, "alice", created.Username.ValueString())
+	require.Equal(t, int64(2), created.DomainId.ValueInt64())
+	require.Equal(t, "staging.example.com", created.Fqdn.ValueString())
+	require.Equal(t, int64(101), created.DatabaseID.ValueInt64())
+
+	// A second create for the same user and domain is rejected.
+	dupResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, dupResp)
+	require.True(t, dupResp.Diagnostics.HasError())
+	require.Equal(t, "Domain Access Already Exists", dupResp.Diagnostics.Errors()[0].Summary())
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.False(t, readResp.State.Raw.IsNull())
+
+	// Changing the access level updates the existing permission.
+	data = created
+	data.AccessLevel = types.StringValue("domain")
+	data.Id = types.StringUnknown()
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+
+	var updated UserDomainAccessModel
+	require.False(t, updateResp.State.Get(ctx, &updated).HasError())
+	require.Equal(t, "1004:staging.example.com:domain", updated.Id.ValueString())
+	require.Equal(t, created.DatabaseID, updated.DatabaseID)
+	require.Equal(t, "domain", api.permissions[101].AccessLevel)
+
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Empty(t, api.permissions)
+
+	// Reading a deleted permission removes it from state.
+	goneResp := &resource.ReadResponse{State: readResp.State}
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, goneResp)
+	require.False(t, goneResp.Diagnostics.HasError(), goneResp.Diagnostics)
+	require.True(t, goneResp.State.Raw.IsNull())
+
+	// Deleting an already-deleted permission succeeds.
+	againResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, againResp)
+	require.False(t, againResp.Diagnostics.HasError(), againResp.Diagnostics)
+}
+
+func TestUserDomainAccessResource_CRUD(t *testing.T) {
+	ctx := context.Background()
+	r := &UserDomainAccessResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	permission := legocharmclient.DomainUserPermissionData{ID: 101, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	api := &legocharmclienttest.APIMock{
+		GetUserByIdFunc: func(ctx context.Context, userId string) (*legocharmclient.UserData, error) {
+			return &legocharmclient.UserData{Username: "alice", Url: "http://example.com/api/v1/users/1004/"}, nil
+		},
+		GetDomainAccessFunc: func(ctx context.Context, userId, domain string) (*legocharmclient.DomainUserPermissionData, error) {
+			return nil, legocharmclient.ErrNotFound
+		},
+		CreateDomainAccessFunc: func(ctx context.Context, access legocharmclient.DomainUserPermissionCreateData) (*legocharmclient.DomainUserPermissionData, error) {
+			return &permission, nil
+		},
+		GetDomainByIdFunc: func(ctx context.Context, id int) (*legocharmclient.DomainData, error) {
+			return &legocharmclient.DomainData{ID: 2, Fqdn: "staging.example.com"}, nil
+		},
+		ListDomainAccessFunc: func(ctx context.Context, userId, domain string) ([]legocharmclient.DomainUserPermissionData, error) {
+			return []legocharmclient.DomainUserPermissionData{permission}, nil
+		},
+		UpdateDomainAccessFunc: func(ctx context.Context, id int, accessLevel string) (*legocharmclient.DomainUserPermissionData, error) {
+			updated := permission
+			updated.AccessLevel = accessLevel
+			return &updated, nil
+		},
+		DeleteDomainAccessFunc: func(ctx context.Context, id int) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		},
+	}
+	r.client = api
+
+	data := UserDomainAccessModel{
+		UserId:       types.StringValue("1004"),
+		Username:     types.StringUnknown(),
+		Domain:       types.StringValue("staging.example.com"),
+		AccessLevel:  types.StringValue("subdomain"),
+		ManageDomain: types.BoolValue(false),
+		Id:           types.StringUnknown(),
+		DatabaseID:   types.Int64Unknown(),
+		Timeouts:     nullTimeouts(domainAccessTimeouts),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	require.Equal(t, legocharmclient.DomainUserPermissionCreateData{
+		UserID:                "1004",
+		Domain:                "staging.example.com",
+		AccessLevel:           "subdomain",
+		RequireExistingDomain: true,
+	}, api.CreateDomainAccessCalls()[0].Access)
+	require.Equal(t, 2, api.GetDomainByIdCalls()[0].Id)
+
+	var created UserDomainAccessModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Equal(t, "alice", created.Username.ValueString())
+	require.Equal(t, int64(101), created.DatabaseID.ValueInt64())
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.Equal(t, "1004", api.ListDomainAccessCalls()[0].UserId)
+	require.Equal(t, "staging.example.com", api.ListDomainAccessCalls()[0].Domain)
+	// The domain is not fetched again while its ID is unchanged.
+	require.Len(t, api.GetDomainByIdCalls(), 1)
+
+	data = created
+	data.AccessLevel = types.StringValue("domain")
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Equal(t, 101, api.UpdateDomainAccessCalls()[0].Id)
+	require.Equal(t, "domain", api.UpdateDomainAccessCalls()[0].AccessLevel)
+
+	var updated UserDomainAccessModel
+	require.False(t, updateResp.State.Get(ctx, &updated).HasError())
+	require.Equal(t, "1004:staging.example.com:domain", updated.Id.ValueString())
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.True(t, deleteResp.State.Raw.IsNull())
+	require.Equal(t, 101, api.DeleteDomainAccessCalls()[0].Id)
+	require.Empty(t, api.DeleteDomainIfUnusedCalls())
+}
+
+func TestUserDomainAccessResource_APIErrors(t *testing.T) {
+	ctx := context.Background()
+	r := &UserDomainAccessResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	unavailable := errors.New("connection refused")
+	api := &legocharmclienttest.APIMock{
+		GetUserByIdFunc: func(ctx context.Context, userId string) (*legocharmclient.UserData, error) {
+			return nil, legocharmclient.ErrNotFound
+		},
+		ListDomainAccessFunc: func(ctx context.Context, userId, domain string) ([]legocharmclient.DomainUserPermissionData, error) {
+			return nil, unavailable
+		},
+		UpdateDomainAccessFunc: func(ctx context.Context, id int, accessLevel string) (*legocharmclient.DomainUserPermissionData, error) {
+			return nil, legocharmclient.ErrNotFound
+		},
+		DeleteDomainAccessFunc: func(ctx context.Context, id int) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader("boom"))}, nil
+		},
+	}
+	r.client = api
+
+	data := importedDomainAccessModel()
+	data.UserId = types.StringValue("1004")
+	data.Username = types.StringValue("alice")
+	data.Domain = types.StringValue("staging.example.com")
+	data.AccessLevel = types.StringValue("subdomain")
+	data.DomainId = types.Int64Value(2)
+	data.Fqdn = types.StringValue("staging.example.com")
+	data.Id = types.StringValue("1004:staging.example.com:subdomain")
+	data.DatabaseID = types.Int64Value(101)
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	// A missing user is reported before anything is created.
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw}
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.True(t, createResp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", createResp.Diagnostics.Errors()[0].Summary())
+	require.Empty(t, api.CreateDomainAccessCalls())
+
+	// Transient errors on read keep the resource in state.
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+	require.True(t, readResp.Diagnostics.HasError())
+	require.Contains(t, readResp.Diagnostics.Errors()[0].Detail(), "Unable to read user domain access")
+	require.False(t, readResp.State.Raw.IsNull())
+
+	data.AccessLevel = types.StringValue("domain")
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, updateResp)
+	require.True(t, updateResp.Diagnostics.HasError())
+	require.Equal(t, "Domain Access Not Found", updateResp.Diagnostics.Errors()[0].Summary())
+
+	deleteResp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, deleteResp)
+	require.True(t, deleteResp.Diagnostics.HasError())
+	require.Contains(t, deleteResp.Diagnostics.Errors()[0].Detail(), "status 500, body: boom")
+	require.False(t, deleteResp.State.Raw.IsNull())
+
+	// A permission deleted outside Terraform is removed from state.
+	api.ListDomainAccessFunc = func(ctx context.Context, userId, domain string) ([]legocharmclient.DomainUserPermissionData, error) {
+		return nil, legocharmclient.ErrNotFound
+	}
+	goneResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, goneResp)
+	require.False(t, goneResp.Diagnostics.HasError(), goneResp.Diagnostics)
+	require.True(t, goneResp.State.Raw.IsNull())
+}
+
+func TestUserDomainAccessResource_ImportState(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[42] = legocharmclient.DomainUserPermissionData{ID: 42, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for _, id := range []string{"1004:staging.example.com:domain", "alice:staging.example.com:domain"} {
+		resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var data UserDomainAccessModel
+		require.False(t, resp.State.Get(ctx, &data).HasError())
+		require.Equal(t, "1004", data.UserId.ValueString())
+		require.Equal(t, "alice", data.Username.ValueString())
+		require.Equal(t, "staging.example.com", data.Domain.ValueString())
+		require.Equal(t, "domain", data.AccessLevel.ValueString())
+		require.Equal(t, int64(42), data.DatabaseID.ValueInt64())
+		require.Equal(t, "1004:staging.example.com:domain", data.Id.ValueString())
+		require.Equal(t, int64(2), data.DomainId.ValueInt64())
+		require.Equal(t, "staging.example.com", data.Fqdn.ValueString())
+	}
+
+	bad := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "staging.example.com"}, bad)
+	require.True(t, bad.Diagnostics.HasError())
+
+	for _, id := range []string{"1004:staging.example.com:", "1004:staging.example.com:admin"} {
+		bad := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, bad)
+		require.True(t, bad.Diagnostics.HasError(), id)
+		require.Equal(t, "Invalid Import ID", bad.Diagnostics.Errors()[0].Summary(), id)
+	}
+
+	// The user holds no subdomain grant, only a domain one.
+	for _, id := range []string{"1004:other.example.com:domain", "1004:staging.example.com:subdomain"} {
+		missing := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, missing)
+		require.True(t, missing.Diagnostics.HasError(), id)
+		require.Equal(t, "Domain Access Not Found", missing.Diagnostics.Errors()[0].Summary(), id)
+	}
+
+	// With grants at both levels, the import picks the one of its ID.
+	api.permissions[43] = legocharmclient.DomainUserPermissionData{ID: 43, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "1004:staging.example.com:subdomain"}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var data UserDomainAccessModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	require.Equal(t, int64(43), data.DatabaseID.ValueInt64())
+	require.Equal(t, "subdomain", data.AccessLevel.ValueString())
+}
+
+func TestUserDomainAccessResource_ImportState_NumericUsername(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "2024"
+	api.users[1006] = "id"
+	api.permissions[42] = legocharmclient.DomainUserPermissionData{ID: 42, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	api.permissions[43] = legocharmclient.DomainUserPermissionData{ID: 43, UserID: 1005, Domain: 2, AccessLevel: "domain"}
+	api.permissions[44] = legocharmclient.DomainUserPermissionData{ID: 44, UserID: 1006, Domain: 2, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// No user has the ID 2024, so it is the name of one; "id:" forces
+	// reading it as an ID, and a user named "id" needs no prefix.
+	for id, want := range map[string]int64{
+		"2024:staging.example.com:domain":    43,
+		"id:1004:staging.example.com:domain": 42,
+		"id:staging.example.com:domain":      44,
+	} {
+		resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		require.False(t, resp.Diagnostics.HasError(), id, resp.Diagnostics)
+
+		var data UserDomainAccessModel
+		require.False(t, resp.State.Get(ctx, &data).HasError())
+		require.Equal(t, want, data.DatabaseID.ValueInt64(), id)
+	}
+
+	missing := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "id:2024:staging.example.com:domain"}, missing)
+	require.True(t, missing.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", missing.Diagnostics.Errors()[0].Summary())
+
+	for _, id := range []string{"0", "-5", "id:alice:staging.example.com:domain", "id:0:staging.example.com:domain"} {
+		bad := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, bad)
+		require.True(t, bad.Diagnostics.HasError(), id)
+		require.Equal(t, "Invalid Import ID", bad.Diagnostics.Errors()[0].Summary(), id)
+		require.Contains(t, bad.Diagnostics.Errors()[0].Detail(), "must be a positive integer", id)
+	}
+}
+
+func TestUserDomainAccessResource_Delete_MissingDatabaseID(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[42] = legocharmclient.DomainUserPermissionData{ID: 42, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// State written by the old composite import had no database ID.
+	data := importedDomainAccessModel()
+	data.UserId = types.StringValue("1004")
+	data.Domain = types.StringValue("staging.example.com")
+	data.AccessLevel = types.StringValue("domain")
+	data.Id = types.StringValue("1004:staging.example.com:domain")
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Empty(t, api.permissions)
+}
+
+func TestUserDomainAccessResource_MissingDatabaseID_OtherAccessLevel(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[42] = legocharmclient.DomainUserPermissionData{ID: 42, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	api.permissions[43] = legocharmclient.DomainUserPermissionData{ID: 43, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// State written by the old composite import had no database ID.
+	data := importedDomainAccessModel()
+	data.UserId = types.StringValue("1004")
+	data.Domain = types.StringValue("staging.example.com")
+	data.AccessLevel = types.StringValue("subdomain")
+	data.Id = types.StringValue("1004:staging.example.com:subdomain")
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	// Update patches the subdomain grant, found by its level in state.
+	data.AccessLevel = types.StringValue("domain")
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Equal(t, "domain", api.permissions[43].AccessLevel)
+
+	// Delete removes the subdomain grant, not the domain one.
+	api.permissions[43] = legocharmclient.DomainUserPermissionData{ID: 43, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	deleteResp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Contains(t, api.permissions, 42)
+	require.NotContains(t, api.permissions, 43)
+}
+
+func TestUserDomainAccessResource_ValidateConfig(t *testing.T) {
+	r := &UserDomainAccessResource{}
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		userId, username types.String
+		wantErr          bool
+	}{
+		"user_id":  {userId: types.StringValue("1004"), username: types.StringNull()},
+		"username": {userId: types.StringNull(), username: types.StringValue("alice")},
+		"unknown":  {userId: types.StringNull(), username: types.StringUnknown()},
+		"both":     {userId: types.StringValue("1004"), username: types.StringValue("alice"), wantErr: true},
+		"neither":  {userId: types.StringNull(), username: types.StringNull(), wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := UserDomainAccessModel{
+				UserId:      tt.userId,
+				Username:    tt.username,
+				Domain:      types.StringValue("staging.example.com"),
+				AccessLevel: types.StringValue("domain"),
+				Id:          types.StringNull(),
+				DatabaseID:  types.Int64Null(),
+				Timeouts:    nullTimeouts(domainAccessTimeouts),
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema}
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, &data).HasError())
+			config.Raw = state.Raw
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+			require.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+func TestUserDomainAccessResource_ConfigValidators(t *testing.T) {
+	r := &UserDomainAccessResource{}
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		domain, accessLevel types.String
+		wantWarning         bool
+	}{
+		"subdomain":          {domain: types.StringValue("staging.example.com"), accessLevel: types.StringValue("subdomain")},
+		"domain":             {domain: types.StringValue("example.com"), accessLevel: types.StringValue("domain")},
+		"wildcard subdomain": {domain: types.StringValue("*.example.com"), accessLevel: types.StringValue("subdomain")},
+		"wildcard domain":    {domain: types.StringValue("*.example.com"), accessLevel: types.StringValue("domain"), wantWarning: true},
+		"apex subdomain":     {domain: types.StringValue("Example.com."), accessLevel: types.StringValue("subdomain"), wantWarning: true},
+		"unknown level":      {domain: types.StringValue("*.example.com"), accessLevel: types.StringUnknown()},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := UserDomainAccessModel{
+				UserId:      types.StringValue("1004"),
+				Username:    types.StringNull(),
+				Domain:      tt.domain,
+				AccessLevel: tt.accessLevel,
+				Id:          types.StringNull(),
+				DatabaseID:  types.Int64Null(),
+				Timeouts:    nullTimeouts(domainAccessTimeouts),
+			}
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, &data).HasError())
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+
+			resp := &resource.ValidateConfigResponse{}
+			for _, v := range r.ConfigValidators(ctx) {
+				v.ValidateResource(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+			}
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			require.Equal(t, tt.wantWarning, resp.Diagnostics.WarningsCount() > 0, resp.Diagnostics)
+		})
+	}
+}
+
+func TestUserDomainAccessResource_Create_ByUsername(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := UserDomainAccessModel{
+		UserId:       types.StringUnknown(),
+		Username:     types.StringValue("alice"),
+		Domain:       types.StringValue("staging.example.com"),
+		AccessLevel:  types.StringValue("domain"),
+		ManageDomain: types.BoolValue(true),
+		Id:           types.StringUnknown(),
+		DatabaseID:   types.Int64Unknown(),
+		Timeouts:     nullTimeouts(domainAccessTimeouts),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var created UserDomainAccessModel
+	require.False(t, resp.State.Get(ctx, &created).HasError())
+	require.Equal(t, "1004", created.UserId.ValueString())
+	require.Equal(t, "1004:staging.example.com:domain", created.Id.ValueString())
+
+	data.Username = types.StringValue("mallory")
+	require.False(t, plan.Set(ctx, &data).HasError())
+	missing := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, missing)
+	require.True(t, missing.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", missing.Diagnostics.Errors()[0].Summary())
+}
+
+func TestUserDomainAccessResource_Create_UnmanagedDomain(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := UserDomainAccessModel{
+		UserId:       types.StringValue("1004"),
+		Username:     types.StringUnknown(),
+		Domain:       types.StringValue("missing.example.com"),
+		AccessLevel:  types.StringValue("domain"),
+		ManageDomain: types.BoolValue(false),
+		Id:           types.StringUnknown(),
+		DatabaseID:   types.Int64Unknown(),
+		Timeouts:     nullTimeouts(domainAccessTimeouts),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Domain Not Found", resp.Diagnostics.Errors()[0].Summary())
+	require.Empty(t, api.permissions)
+}
+
+func TestUserDomainAccessResource_Delete_CleanupDomain(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := UserDomainAccessModel{
+		UserId:        types.StringValue("1004"),
+		Username:      types.StringUnknown(),
+		Domain:        types.StringValue("new.example.com"),
+		AccessLevel:   types.StringValue("domain"),
+		ManageDomain:  types.BoolValue(true),
+		CleanupDomain: types.BoolValue(true),
+		DomainCreated: types.BoolUnknown(),
+		Id:            types.StringUnknown(),
+		DatabaseID:    types.Int64Unknown(),
+		Timeouts:      nullTimeouts(domainAccessTimeouts),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var created UserDomainAccessModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.True(t, created.DomainCreated.ValueBool())
+	domainID, ok := api.domainID("new.example.com")
+	require.True(t, ok)
+
+	// Another user's permission on the domain keeps it alive.
+	api.permissions[500] = legocharmclient.DomainUserPermissionData{ID: 500, UserID: 2000, Domain: domainID, AccessLevel: "domain"}
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	_, ok = api.domainID("new.example.com")
+	require.True(t, ok)
+
+	// Once unreferenced, the domain is removed with the last permission.
+	delete(api.permissions, 500)
+	deleteResp = &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	_, ok = api.domainID("new.example.com")
+	require.False(t, ok)
+	_, ok = api.domainID("staging.example.com")
+	require.True(t, ok)
+}
+
+func TestUserDomainAccessResource_Create_DuplicateDifferentCase(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[50] = legocharmclient.DomainUserPermissionData{ID: 50, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := UserDomainAccessModel{
+		UserId:        types.StringValue("1004"),
+		Username:      types.StringUnknown(),
+		Domain:        types.StringValue("Staging.Example.COM."),
+		AccessLevel:   types.StringValue("domain"),
+		ManageDomain:  types.BoolValue(true),
+		CleanupDomain: types.BoolValue(false),
+		DomainCreated: types.BoolUnknown(),
+		Id:            types.StringUnknown(),
+		DatabaseID:    types.Int64Unknown(),
+		Timeouts:      nullTimeouts(domainAccessTimeouts),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Domain Access Already Exists", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestUserDomainAccessResource_ImportState_DatabaseID(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[42] = legocharmclient.DomainUserPermissionData{ID: 42, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "42"}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data UserDomainAccessModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	require.Equal(t, "1004", data.UserId.ValueString())
+	require.Equal(t, "alice", data.Username.ValueString())
+	require.Equal(t, "staging.example.com", data.Domain.ValueString())
+	require.Equal(t, "subdomain", data.AccessLevel.ValueString())
+	require.Equal(t, int64(42), data.DatabaseID.ValueInt64())
+	require.Equal(t, "1004:staging.example.com:subdomain", data.Id.ValueString())
+	require.Equal(t, int64(2), data.DomainId.ValueInt64())
+	require.Equal(t, "staging.example.com", data.Fqdn.ValueString())
+
+	missing := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "43"}, missing)
+	require.True(t, missing.Diagnostics.HasError())
+	require.Equal(t, "Domain Access Not Found", missing.Diagnostics.Errors()[0].Summary())
+}
+
+func TestUserDomainAccessResource_ImportState_Identity(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[42] = legocharmclient.DomainUserPermissionData{ID: 42, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+	require.False(t, identityResp.Diagnostics.HasError(), identityResp.Diagnostics)
+
+	newIdentity := func(id types.Int64) *tfsdk.ResourceIdentity {
+		identity := &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema}
+		require.False(t, identity.Set(ctx, UserDomainAccessIdentityModel{DatabaseID: id}).HasError())
+		return identity
+	}
+
+	identity := newIdentity(types.Int64Value(42))
+	resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}, Identity: identity}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data UserDomainAccessModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	require.Equal(t, "1004:staging.example.com:subdomain", data.Id.ValueString())
+	require.Equal(t, int64(42), data.DatabaseID.ValueInt64())
+
+	var got UserDomainAccessIdentityModel
+	require.False(t, resp.Identity.Get(ctx, &got).HasError())
+	require.Equal(t, int64(42), got.DatabaseID.ValueInt64())
+
+	invalid := newIdentity(types.Int64Null())
+	bad := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}, Identity: invalid}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: invalid}, bad)
+	require.True(t, bad.Diagnostics.HasError())
+	require.Equal(t, "Invalid Import Identity", bad.Diagnostics.Errors()[0].Summary())
+}
+
+func TestUserDomainAccessResource_Create_SetsIdentity(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	data := UserDomainAccessModel{
+		UserId:       types.StringValue("1004"),
+		Username:     types.StringUnknown(),
+		Domain:       types.StringValue("staging.example.com"),
+		AccessLevel:  types.StringValue("subdomain"),
+		ManageDomain: types.BoolValue(true),
+		Id:           types.StringUnknown(),
+		DatabaseID:   types.Int64Unknown(),
+		Timeouts:     nullTimeouts(domainAccessTimeouts),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{
+		State:    tfsdk.State{Schema: schemaResp.Schema},
+		Identity: &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema},
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var got UserDomainAccessIdentityModel
+	require.False(t, createResp.Identity.Get(ctx, &got).HasError())
+	require.Equal(t, int64(101), got.DatabaseID.ValueInt64())
+}
+
+func TestSelectDomainAccess(t *testing.T) {
+	permissions := []legocharmclient.DomainUserPermissionData{
+		{ID: 1, AccessLevel: "domain"},
+		{ID: 2, AccessLevel: "subdomain"},
+	}
+
+	data := importedDomainAccessModel()
+	data.AccessLevel = types.StringValue("subdomain")
+	require.Equal(t, 2, selectDomainAccess(permissions, data).ID)
+
+	data.DatabaseID = types.Int64Value(1)
+	require.Equal(t, 1, selectDomainAccess(permissions, data).ID)
+
+	data.DatabaseID = types.Int64Value(3)
+	require.Equal(t, 2, selectDomainAccess(permissions, data).ID)
+
+	// A grant at another access level is never adopted.
+	require.Nil(t, selectDomainAccess(permissions[:1], data))
+
+	require.Nil(t, selectDomainAccess(nil, data))
+}
+
+func TestUserDomainAccessResource_Read_RecreatedPermission(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[43] = legocharmclient.DomainUserPermissionData{ID: 43, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// State still refers to permission 42, which an admin replaced with 43.
+	data := importedDomainAccessModel()
+	data.UserId = types.StringValue("1004")
+	data.Username = types.StringValue("alice")
+	data.Domain = types.StringValue("staging.example.com")
+	data.AccessLevel = types.StringValue("domain")
+	data.DatabaseID = types.Int64Value(42)
+	data.Id = types.StringValue("1004:staging.example.com:domain")
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	require.Equal(t, "Domain Access Recreated Outside Terraform", resp.Diagnostics.Warnings()[0].Summary())
+
+	var refreshed UserDomainAccessModel
+	require.False(t, resp.State.Get(ctx, &refreshed).HasError())
+	require.Equal(t, int64(43), refreshed.DatabaseID.ValueInt64())
+}
+
+func TestUserDomainAccessResource_Read_OtherAccessLevelRemains(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	// Another resource manages the subdomain grant; this one's domain grant
+	// was deleted outside Terraform.
+	api.permissions[43] = legocharmclient.DomainUserPermissionData{ID: 43, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := importedDomainAccessModel()
+	data.UserId = types.StringValue("1004")
+	data.Username = types.StringValue("alice")
+	data.Domain = types.StringValue("staging.example.com")
+	data.AccessLevel = types.StringValue("domain")
+	data.DatabaseID = types.Int64Value(42)
+	data.Id = types.StringValue("1004:staging.example.com:domain")
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.True(t, resp.State.Raw.IsNull(), "the subdomain grant must not be adopted")
+	require.Equal(t, "subdomain", api.permissions[43].AccessLevel)
+}
+
+func TestUserDomainAccessResource_Update_StoredAccessLevel(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[43] = legocharmclient.DomainUserPermissionData{ID: 43, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	// The API normalizes the access level it stores.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			var payload legocharmclient.DomainUserPermissionUpdateData
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			payload.AccessLevel = strings.ToLower(payload.AccessLevel)
+			b, err := json.Marshal(payload)
+			require.NoError(t, err)
+			r.Body = io.NopCloser(bytes.NewReader(b))
+			r.ContentLength = int64(len(b))
+		}
+		api.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := importedDomainAccessModel()
+	data.UserId = types.StringValue("1004")
+	data.Username = types.StringValue("alice")
+	data.Domain = types.StringValue("staging.example.com")
+	data.AccessLevel = types.StringValue("subdomain")
+	data.DatabaseID = types.Int64Value(43)
+	data.Id = types.StringValue("1004:staging.example.com:subdomain")
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+	data.AccessLevel = types.StringValue("DOMAIN")
+	data.Id = types.StringUnknown()
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var updated UserDomainAccessModel
+	require.False(t, resp.State.Get(ctx, &updated).HasError())
+	require.Equal(t, "domain", updated.AccessLevel.ValueString())
+	require.Equal(t, "1004:staging.example.com:domain", updated.Id.ValueString())
+	require.Equal(t, int64(43), updated.DatabaseID.ValueInt64())
+}
+
+func TestUserDomainAccessResource_Duplicates(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.permissions[42] = legocharmclient.DomainUserPermissionData{ID: 42, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	api.permissions[43] = legocharmclient.DomainUserPermissionData{ID: 43, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	api.permissions[44] = legocharmclient.DomainUserPermissionData{ID: 44, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	// A grant at another access level is not a duplicate.
+	api.permissions[45] = legocharmclient.DomainUserPermissionData{ID: 45, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := importedDomainAccessModel()
+	data.UserId = types.StringValue("1004")
+	data.Username = types.StringValue("alice")
+	data.Domain = types.StringValue("staging.example.com")
+	data.AccessLevel = types.StringValue("domain")
+	data.DatabaseID = types.Int64Value(43)
+	data.DomainId = types.Int64Value(2)
+	data.Fqdn = types.StringValue("staging.example.com")
+	data.Id = types.StringValue("1004:staging.example.com:domain")
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	// Refresh only reports the duplicates, with or without dedupe.
+	for _, dedupe := range []bool{false, true} {
+		data.Dedupe = types.BoolValue(dedupe)
+		require.False(t, state.Set(ctx, &data).HasError())
+		resp := &resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		require.Len(t, resp.Diagnostics.Warnings(), 1)
+		require.Equal(t, "Duplicate Domain Access", resp.Diagnostics.Warnings()[0].Summary())
+		require.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), ": 42, 44.")
+		require.Len(t, api.permissions, 4)
+	}
+
+	// Without dedupe, planning leaves the permission as it is.
+	data.Dedupe = types.BoolValue(false)
+	require.False(t, state.Set(ctx, &data).HasError())
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw}
+	planResp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan}, planResp)
+	require.False(t, planResp.Diagnostics.HasError(), planResp.Diagnostics)
+	require.Empty(t, planResp.Diagnostics.Warnings())
+	require.True(t, planResp.Plan.Raw.Equal(state.Raw))
+
+	// With dedupe, planning forces an update, which deletes the duplicates.
+	data.Dedupe = types.BoolValue(true)
+	require.False(t, state.Set(ctx, &data).HasError())
+	plan = tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw}
+	planResp = &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan}, planResp)
+	require.False(t, planResp.Diagnostics.HasError(), planResp.Diagnostics)
+	require.Equal(t, "Duplicate Domain Access Will Be Deleted", planResp.Diagnostics.Warnings()[0].Summary())
+	var planned UserDomainAccessModel
+	require.False(t, planResp.Plan.Get(ctx, &planned).HasError())
+	require.True(t, planned.Id.IsUnknown())
+
+	updateResp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: planResp.Plan, State: state}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Equal(t, "Duplicate Domain Access Removed", updateResp.Diagnostics.Warnings()[0].Summary())
+	require.Len(t, api.permissions, 2)
+	require.Contains(t, api.permissions, 43)
+	require.Contains(t, api.permissions, 45)
+
+	var updated UserDomainAccessModel
+	require.False(t, updateResp.State.Get(ctx, &updated).HasError())
+	require.Equal(t, int64(43), updated.DatabaseID.ValueInt64())
+	require.Equal(t, "1004:staging.example.com:domain", updated.Id.ValueString())
+}
+
+func TestUserDomainAccessResource_List(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "bob"
+	api.domains[3] = "example.com"
+	api.permissions[7] = legocharmclient.DomainUserPermissionData{ID: 7, UserID: 1005, Domain: 2, AccessLevel: "subdomain"}
+	api.permissions[8] = legocharmclient.DomainUserPermissionData{ID: 8, UserID: 1004, Domain: 3, AccessLevel: "domain"}
+	api.permissions[9] = legocharmclient.DomainUserPermissionData{ID: 9, UserID: 1004, Domain: 2, AccessLevel: "domain"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserDomainAccessResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+	listSchemaResp := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, listSchemaResp)
+
+	run := func(username, domain types.String) []list.ListResult {
+		config := tfsdk.State{Schema: listSchemaResp.Schema}
+		require.False(t, config.Set(ctx, UserDomainAccessListModel{Username: username, Domain: domain}).HasError())
+
+		stream := &list.ListResultsStream{}
+		r.List(ctx, list.ListRequest{
+			Config:                 tfsdk.Config{Schema: listSchemaResp.Schema, Raw: config.Raw},
+			IncludeResource:        true,
+			ResourceSchema:         schemaResp.Schema,
+			ResourceIdentitySchema: identityResp.IdentitySchema,
+		}, stream)
+
+		var results []list.ListResult
+		for result := range stream.Results {
+			require.False(t, result.Diagnostics.HasError(), result.Diagnostics)
+			results = append(results, result)
+		}
+		return results
+	}
+	databaseIDs := func(results []list.ListResult) []int64 {
+		var ids []int64
+		for _, result := range results {
+			var identity UserDomainAccessIdentityModel
+			require.False(t, result.Identity.Get(ctx, &identity).HasError())
+			ids = append(ids, identity.DatabaseID.ValueInt64())
+		}
+		return ids
+	}
+
+	results := run(types.StringNull(), types.StringNull())
+	require.Equal(t, []int64{8, 9, 7}, databaseIDs(results))
+	require.Equal(t, "alice on example.com (domain)", results[0].DisplayName)
+
+	var data UserDomainAccessModel
+	require.False(t, results[2].Resource.Get(ctx, &data).HasError())
+	require.Equal(t, "1005:staging.example.com:subdomain", data.Id.ValueString())
+	require.Equal(t, "bob", data.Username.ValueString())
+	require.Equal(t, int64(2), data.DomainId.ValueInt64())
+	require.True(t, data.ManageDomain.ValueBool())
+
+	require.Equal(t, []int64{8, 9}, databaseIDs(run(types.StringValue("alice"), types.StringNull())))
+	require.Equal(t, []int64{9, 7}, databaseIDs(run(types.StringNull(), types.StringValue("Staging.example.com"))))
+	require.Equal(t, []int64{9}, databaseIDs(run(types.StringValue("alice"), types.StringValue("staging.example.com"))))
+	require.Empty(t, run(types.StringNull(), types.StringValue("missing.example.com")))
+}
+
+func TestAccUserDomainAccessResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	_, username, _ := testAccUser(t, env)
+	fqdn := testAccName(t) + ".example.com"
+	ctx := context.Background()
+
+	var databaseID string
+	level := func(want string) resourcetest.TestCheckFunc {
+		return resourcetest.TestCheckResourceAttrWith("legocharm_user_domain_access.test", "database_id", func(value string) error {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				return err
+			}
+			access, err := env.API.GetDomainAccessById(ctx, id)
+			if err != nil {
+				return err
+			}
+			if access.AccessLevel != want {
+				return fmt.Errorf("permission %d has access level %s, want %s", id, access.AccessLevel, want)
+			}
+			databaseID = value
+			return nil
+		})
+	}
+	config := func(accessLevel string) string {
+		return fmt.Sprintf(`
+resource "legocharm_user_domain_access" "test" {
+  username       = %q
+  domain         = %q
+  access_level   = %q
+  cleanup_domain = true
+}
+`, username, fqdn, accessLevel)
+	}
+	// Import cannot tell whether the domain was created for the permission,
+	// and does not know the optional flags.
+	importIgnore := []string{"domain_created", "cleanup_domain", "manage_domain", "dedupe"}
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config("domain"),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					level("domain"),
+					resourcetest.TestCheckResourceAttr("legocharm_user_domain_access.test", "domain_created", "true"),
+					resourcetest.TestCheckResourceAttr("legocharm_user_domain_access.test", "fqdn", fqdn),
+				),
+			},
+			{
+				Config: config("subdomain"),
+				Check:  level("subdomain"),
+			},
+			{
+				ResourceName: "legocharm_user_domain_access.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return databaseID, nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: importIgnore,
+			},
+			{
+				ResourceName:            "legocharm_user_domain_access.test",
+				ImportState:             true,
+				ImportStateId:           username + ":" + fqdn + ":subdomain",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: importIgnore,
+			},
+			// An access level changed outside Terraform is detected and
+			// reverted.
+			{
+				PreConfig: func() {
+					id, _ := strconv.Atoi(databaseID)
+					_, err := env.API.UpdateDomainAccess(ctx, id, "domain")
+					require.NoError(t, err)
+				},
+				Config:             config("subdomain"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("subdomain"),
+				Check:  level("subdomain"),
+			},
+			// A permission revoked outside Terraform is granted again.
+			{
+				PreConfig: func() {
+					id, _ := strconv.Atoi(databaseID)
+					_, err := env.API.DeleteDomainAccess(ctx, id)
+					require.NoError(t, err)
+				},
+				Config: config("subdomain"),
+				Check:  level("subdomain"),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			accesses, err := env.API.ListDomainAccessByUsername(ctx, username)
+			if err != nil {
+				return err
+			}
+			if len(accesses) > 0 {
+				return fmt.Errorf("user %s still holds %d permissions", username, len(accesses))
+			}
+			// The grant recreated by the last step found the domain already
+			// there, so destroying it leaves the domain in place.
+			domain, err := env.API.GetDomain(ctx, fqdn)
+			if err != nil {
+				return err
+			}
+			return env.API.DeleteDomain(ctx, domain.ID)
+		},
+	})
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ datasource.DataSource = &UserEffectiveDomainsDataSource{}
+
+// NewUserEffectiveDomainsDataSource creates a new user effective domains data
+// source.
+func NewUserEffectiveDomainsDataSource() datasource.DataSource {
+	return &UserEffectiveDomainsDataSource{}
+}
+
+// UserEffectiveDomainsDataSource is the data source implementation for the
+// names a user's permissions cover.
+type UserEffectiveDomainsDataSource struct {
+	client legocharmclient.API
+}
+
+// UserEffectiveDomainsDataSourceModel maps Terraform schema to Go types for
+// the user effective domains data source.
+type UserEffectiveDomainsDataSourceModel struct {
+	Username         types.String `tfsdk:"username"`
+	Domains          types.List   `tfsdk:"domains"`
+	Wildcards        types.List   `tfsdk:"wildcards"`
+	EffectiveDomains types.List   `tfsdk:"effective_domains"`
+}
+
+func (d *UserEffectiveDomainsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_effective_domains"
+}
+
+func (d *UserEffectiveDomainsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Expands the permissions of a user into the names they can solve challenges for, for reviewing over-broad ACME identities. A `subdomain` permission covers its domain and every name below it at any depth, which is shown as a wildcard.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the user. Reading fails if the user does not exist.",
+				Required:            true,
+			},
+			"domains": schema.ListAttribute{
+				MarkdownDescription: "Normalized FQDNs of the domains the user holds a permission on, at any access level, sorted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"wildcards": schema.ListAttribute{
+				MarkdownDescription: "Wildcards such as `*.example.com` for the namespaces the user's `subdomain` permissions cover, sorted. Each matches every name below its domain at any depth. Wildcards within a broader one are omitted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"effective_domains": schema.ListAttribute{
+				MarkdownDescription: "The minimal set of names and wildcards covering everything the user has access to, sorted: `wildcards` plus the `domains` not covered by a wildcard.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserEffectiveDomainsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UserEffectiveDomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserEffectiveDomainsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this data source")
+		return
+	}
+
+	username := data.Username.ValueString()
+	if _, err := d.client.GetUserByUsername(ctx, username); err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("username"), "User Not Found", fmt.Sprintf("No user with username %q exists.", username))
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to look up user", err, nil)
+		return
+	}
+
+	grants, err := userGrants(ctx, d.client, username)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read domain access", err, nil)
+		return
+	}
+
+	domains, wildcards, effective := effectiveDomains(grants)
+	domainList, diags := types.ListValueFrom(ctx, types.StringType, domains)
+	resp.Diagnostics.Append(diags...)
+	wildcardList, diags := types.ListValueFrom(ctx, types.StringType, wildcards)
+	resp.Diagnostics.Append(diags...)
+	effectiveList, diags := types.ListValueFrom(ctx, types.StringType, effective)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Domains = domainList
+	data.Wildcards = wildcardList
+	data.EffectiveDomains = effectiveList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// effectiveDomains expands grants, sorted by domain, into the granted
+// domains, the minimal wildcards covering the subdomain-level grants, and the
+// minimal set of names and wildcards covering everything granted.
+func effectiveDomains(grants []userGrant) (domains, wildcards, effective []string) {
+	domains = []string{}
+	bases := map[string]bool{}
+	for _, grant := range grants {
+		if len(domains) == 0 || domains[len(domains)-1] != grant.domain {
+			domains = append(domains, grant.domain)
+		}
+		if grant.accessLevel == "subdomain" {
+			bases[grantBase(grant.domain)] = true
+		}
+	}
+
+	// under reports whether name is strictly below one of the bases.
+	under := func(name string) bool {
+		for base := range bases {
+			if strings.HasSuffix(name, "."+base) {
+				return true
+			}
+		}
+		return false
+	}
+
+	wildcards = []string{}
+	names := map[string]bool{}
+	for base := range bases {
+		if under(base) {
+			continue
+		}
+		wildcards = append(wildcards, "*."+base)
+		names["*."+base] = true
+	}
+	for _, domain := range domains {
+		if !under(domain) {
+			names[domain] = true
+		}
+	}
+	sort.Strings(wildcards)
+
+	effective = []string{}
+	for name := range names {
+		effective = append(effective, name)
+	}
+	sort.Strings(effective)
+	return domains, wildcards, effective
+}
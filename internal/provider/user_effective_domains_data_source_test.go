@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestUserEffectiveDomainsDataSource_Read(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.domains[3] = "*.example.com"
+	api.domains[4] = "db.prod.example.com"
+	api.domains[5] = "example.org"
+	api.permissions[7] = legocharmclient.DomainUserPermissionData{ID: 7, UserID: 1004, Domain: 2, AccessLevel: "subdomain"}
+	api.permissions[8] = legocharmclient.DomainUserPermissionData{ID: 8, UserID: 1004, Domain: 3, AccessLevel: "subdomain"}
+	api.permissions[9] = legocharmclient.DomainUserPermissionData{ID: 9, UserID: 1004, Domain: 4, AccessLevel: "domain"}
+	api.permissions[10] = legocharmclient.DomainUserPermissionData{ID: 10, UserID: 1004, Domain: 5, AccessLevel: "domain"}
+	api.users[1005] = "bob"
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	d := &UserEffectiveDomainsDataSource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	read := func(username string) *datasource.ReadResponse {
+		data := UserEffectiveDomainsDataSourceModel{
+			Username:         types.StringValue(username),
+			Domains:          types.ListNull(types.StringType),
+			Wildcards:        types.ListNull(types.StringType),
+			EffectiveDomains: types.ListNull(types.StringType),
+		}
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &data).HasError())
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+		return resp
+	}
+	strings := func(list types.List) []string {
+		var values []string
+		require.False(t, list.ElementsAs(ctx, &values, false).HasError())
+		return values
+	}
+
+	resp := read("alice")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var result UserEffectiveDomainsDataSourceModel
+	require.False(t, resp.State.Get(ctx, &result).HasError())
+	require.Equal(t, []string{"*.example.com", "db.prod.example.com", "example.org", "staging.example.com"}, strings(result.Domains))
+	// The wildcard permission covers staging.example.com and its subdomains.
+	require.Equal(t, []string{"*.example.com"}, strings(result.Wildcards))
+	require.Equal(t, []string{"*.example.com", "example.org"}, strings(result.EffectiveDomains))
+
+	resp = read("bob")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.False(t, resp.State.Get(ctx, &result).HasError())
+	require.Empty(t, strings(result.Domains))
+	require.Empty(t, strings(result.EffectiveDomains))
+
+	resp = read("missing")
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", resp.Diagnostics[0].Summary())
+}
+
+func TestAccUserEffectiveDomainsDataSource(t *testing.T) {
+	env := testAccPreCheck(t)
+	userID, username, _ := testAccUser(t, env)
+	domain := testAccDomain(t, env)
+	testAccGrant(t, env, userID, domain.Fqdn, "subdomain")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "legocharm_user_effective_domains" "test" {
+  username = %q
+}
+`, username),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.legocharm_user_effective_domains.test", "domains.#", "1"),
+					resource.TestCheckResourceAttr("data.legocharm_user_effective_domains.test", "domains.0", domain.Fqdn),
+					resource.TestCheckResourceAttr("data.legocharm_user_effective_domains.test", "wildcards.#", "1"),
+					resource.TestCheckResourceAttr("data.legocharm_user_effective_domains.test", "wildcards.0", "*."+domain.Fqdn),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ resource.Resource = &UserGroupMembershipResource{}
+var _ resource.ResourceWithImportState = &UserGroupMembershipResource{}
+
+// NewUserGroupMembershipResource creates a new user group membership resource.
+func NewUserGroupMembershipResource() resource.Resource { return &UserGroupMembershipResource{} }
+
+// UserGroupMembershipResource is the resource implementation for a single
+// user's membership of a LegoCharm group. Other members of the group are left
+// alone, so several configurations can contribute members to the same group.
+type UserGroupMembershipResource struct {
+	client legocharmclient.API
+}
+
+// UserGroupMembershipModel maps Terraform schema to Go types for user group
+// membership resources.
+type UserGroupMembershipModel struct {
+	Username types.String `tfsdk:"username"`
+	Group    types.String `tfsdk:"group"`
+	Id       types.String `tfsdk:"id"`
+}
+
+func (r *UserGroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_group_membership"
+}
+
+func (r *UserGroupMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Membership of one user in one group. Other members of the group are not affected. Do not combine with `legocharm_group_membership` for the same group.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the member.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					username(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Group to add the user to, as reported in the `groups` attribute of `legocharm_user`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringLengthBetween(1, 0),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user group membership resource, in format 'username:group'",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserGroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserGroupMembershipModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	if err := r.client.AddUserToGroup(ctx, data.Username.ValueString(), data.Group.ValueString()); err != nil {
+		if err == legocharmclient.ErrNotFound {
+			resp.Diagnostics.AddAttributeError(path.Root("username"), "User Not Found", fmt.Sprintf("No user with username %q exists.", data.Username.ValueString()))
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to add user to group", err, groupMembershipAPIFields)
+		return
+	}
+	data.Id = types.StringValue(data.Username.ValueString() + ":" + data.Group.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserGroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserGroupMembershipModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	user, err := r.client.GetUserByUsername(ctx, data.Username.ValueString())
+	if err != nil {
+		if err == legocharmclient.ErrNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to read user", err, nil)
+		return
+	}
+
+	member := false
+	for _, g := range user.Groups {
+		if g == data.Group.ValueString() {
+			member = true
+			break
+		}
+	}
+	if !member {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Id = types.StringValue(data.Username.ValueString() + ":" + data.Group.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called with changes, since every attribute requires
+// replacement, but is required by resource.Resource.
+func (r *UserGroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserGroupMembershipModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserGroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserGroupMembershipModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	err := r.client.RemoveUserFromGroup(ctx, data.Username.ValueString(), data.Group.ValueString())
+	if err != nil && err != legocharmclient.ErrNotFound {
+		addClientError(&resp.Diagnostics, "Unable to remove user from group", err, nil)
+	}
+}
+
+// ImportState implements resource import for UserGroupMembershipResource.
+func (r *UserGroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// id is of format "username:group"; usernames cannot contain colons
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", "Import ID must be in the format 'username:group'")
+		return
+	}
+
+	data := UserGroupMembershipModel{
+		Username: types.StringValue(parts[0]),
+		Group:    types.StringValue(parts[1]),
+		Id:       types.StringValue(req.ID),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserGroupMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
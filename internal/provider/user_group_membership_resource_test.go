@@ -0,0 +1,133 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestUserGroupMembershipResource_Lifecycle(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.users[1005] = "bob"
+	api.groups[1005] = []string{"acme"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserGroupMembershipResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := UserGroupMembershipModel{Username: types.StringValue("alice"), Group: types.StringValue("acme"), Id: types.StringUnknown()}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	require.Equal(t, []string{"acme"}, api.groups[1004])
+
+	var created UserGroupMembershipModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Equal(t, "alice:acme", created.Id.ValueString())
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.False(t, readResp.State.Raw.IsNull())
+
+	// Deleting leaves other members of the group alone.
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Empty(t, api.groups[1004])
+	require.Equal(t, []string{"acme"}, api.groups[1005])
+
+	// A membership removed outside Terraform is removed from state.
+	goneResp := &resource.ReadResponse{State: readResp.State}
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, goneResp)
+	require.False(t, goneResp.Diagnostics.HasError(), goneResp.Diagnostics)
+	require.True(t, goneResp.State.Raw.IsNull())
+
+	// Unknown users are reported against username.
+	data.Username = types.StringValue("mallory")
+	require.False(t, plan.Set(ctx, &data).HasError())
+	badResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, badResp)
+	require.True(t, badResp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", badResp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestUserGroupMembershipResource_ImportState(t *testing.T) {
+	r := &UserGroupMembershipResource{}
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "alice:team:acme"}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	var data UserGroupMembershipModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	require.Equal(t, "alice", data.Username.ValueString())
+	require.Equal(t, "team:acme", data.Group.ValueString())
+
+	for _, id := range []string{"alice", ":acme", "alice:"} {
+		bad := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, bad)
+		require.True(t, bad.Diagnostics.HasError(), id)
+	}
+}
+
+func TestAccUserGroupMembershipResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	group := testAccGroup(t)
+	_, username, _ := testAccUser(t, env)
+	ctx := context.Background()
+
+	config := fmt.Sprintf(`
+resource "legocharm_user_group_membership" "test" {
+  username = %q
+  group    = %q
+}
+`, username, group)
+
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckGroupMember(env, username, group, true),
+			},
+			{
+				ResourceName:      "legocharm_user_group_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// A membership removed outside Terraform is restored.
+			{
+				PreConfig: func() {
+					require.NoError(t, env.API.RemoveUserFromGroup(ctx, username, group))
+				},
+				Config: config,
+				Check:  testAccCheckGroupMember(env, username, group, true),
+			},
+		},
+		CheckDestroy: testAccCheckGroupMember(env, username, group, false),
+	})
+}
@@ -5,23 +5,37 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	"terraform-provider-legocharm/internal/legocharmclient"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
 )
 
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithValidateConfig = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
+var _ resource.ResourceWithIdentity = &UserResource{}
 
 // NewUserResource creates a new user resource.
 func NewUserResource() resource.Resource { return &UserResource{} }
@@ -29,40 +43,142 @@ func NewUserResource() resource.Resource { return &UserResource{} }
 // UserResource is the resource implementation for LegoCharm users.
 // It manages the lifecycle of user resources in the LegoCharm API.
 type UserResource struct {
-	client *legocharmclient.Client
+	client legocharmclient.API
 }
 
 // UserModel maps Terraform schema to Go types for user resources.
 type UserModel struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-	Email    types.String `tfsdk:"email"`
-	Id       types.String `tfsdk:"id"`
+	Username           types.String   `tfsdk:"username"`
+	Password           types.String   `tfsdk:"password"`
+	PasswordWO         types.String   `tfsdk:"password_wo"`
+	PasswordVersion    types.String   `tfsdk:"password_version"`
+	TrackPasswordHash  types.Bool     `tfsdk:"track_password_hash"`
+	PasswordHash       types.String   `tfsdk:"password_hash"`
+	ValidatePassword   types.Bool     `tfsdk:"validate_password"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+	CascadeDelete      types.Bool     `tfsdk:"cascade_delete"`
+	PasswordLength     types.Int64    `tfsdk:"password_length"`
+	PasswordSpecial    types.Bool     `tfsdk:"password_special"`
+	Email              types.String   `tfsdk:"email"`
+	IsStaff            types.Bool     `tfsdk:"is_staff"`
+	IsSuperuser        types.Bool     `tfsdk:"is_superuser"`
+	IsActive           types.Bool     `tfsdk:"is_active"`
+	Groups             types.List     `tfsdk:"groups"`
+	DateJoined         types.String   `tfsdk:"date_joined"`
+	LastLogin          types.String   `tfsdk:"last_login"`
+	Id                 types.String   `tfsdk:"id"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
+// UserIdentityModel maps the identity schema of user resources.
+type UserIdentityModel struct {
+	Id types.String `tfsdk:"id"`
+}
+
+// userTimeouts are the operations configurable in the timeouts block of
+// legocharm_user.
+var userTimeouts = timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}
+
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_user"
 }
 
+// IdentitySchema implements resource.ResourceWithIdentity.
+func (r *UserResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the user.",
+			},
+		},
+	}
+}
+
 func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             userSchemaVersion,
 		MarkdownDescription: "User resource for LegoCharm",
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
-				MarkdownDescription: "Username",
+				MarkdownDescription: "Username. 150 characters or fewer; letters, digits and `@`, `.`, `+`, `-` and `_` only.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					username(),
+				},
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "Password",
-				Required:            true,
+				MarkdownDescription: "Password. If neither this nor `password_wo` is set, a random password is generated according to `password_length` and `password_special`.",
+				Optional:            true,
+				Computed:            true,
 				Sensitive:           true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					useStateForUnconfigured(),
+					// A password missing from state (password-less import or
+					// invalidated on refresh) is rotated in place instead.
+					stringplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"If the value of this attribute changes, Terraform will destroy and recreate the resource, unless no password is known in state.",
+						"If the value of this attribute changes, Terraform will destroy and recreate the resource, unless no password is known in state.",
+					),
+				},
+				Validators: []validator.String{
+					stringLengthAtLeast(minPasswordLength),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only password. The value is sent to the API but never stored in the Terraform plan or state. Conflicts with `password`. Requires Terraform 1.11 or later.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringLengthAtLeast(minPasswordLength),
+				},
+			},
+			"password_version": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value whose change rotates the password in place. A generated password is regenerated, and the current `password_wo` value is sent to the API again. A `password` value is only sent when it changes. Use this to roll `password_wo` or to schedule credential rotation.",
+				Optional:            true,
+			},
+			"track_password_hash": schema.BoolAttribute{
+				MarkdownDescription: "Store only a salted PBKDF2 hash of `password_wo` in state and use it to detect when the configured password changes, rotating it on the next apply. Requires `password_wo`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"password_hash": schema.StringAttribute{
+				MarkdownDescription: "Salted hash of the write-only password, set when `track_password_hash` is enabled.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"validate_password": schema.BoolAttribute{
+				MarkdownDescription: "Whether to verify on every refresh that the stored password still authenticates against the API. Disable to avoid an authentication attempt per user per plan, for example under account lockout policies. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"password_length": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Length of the generated password. Only used when `password` is omitted. Defaults to `%d`.", defaultPasswordLength),
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultPasswordLength),
+				Validators: []validator.Int64{
+					int64AtLeast(minPasswordLength),
 				},
 			},
+			"password_special": schema.BoolAttribute{
+				MarkdownDescription: "Whether the generated password includes special characters. Only used when `password` is omitted. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
 			"email": schema.StringAttribute{
 				MarkdownDescription: "Email address",
 				Optional:            true,
@@ -71,6 +187,64 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					emailAddress(),
+				},
+			},
+			"is_staff": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user can log into the admin site. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"is_superuser": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user has all permissions without explicitly assigning them. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"is_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user account is active. Set to `false` to deactivate the account instead of deleting it. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"groups": schema.ListAttribute{
+				MarkdownDescription: "Groups the user belongs to, as reported by the API.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"date_joined": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 timestamp of when the user account was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_login": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 timestamp of the user's last login, or null if the user has never logged in.",
+				Computed:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over an existing user with the same username on create instead of failing. The adopted user's password is rotated and its email and flags are updated to match the configuration. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"cascade_delete": schema.BoolAttribute{
+				MarkdownDescription: "Whether to remove all of the user's domain access permissions before deleting the user, including grants not managed by Terraform. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform is prevented from deleting the user. When `true`, destroying or replacing the resource fails until the flag is set to `false` and applied. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -79,6 +253,9 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, userTimeouts),
+		},
 	}
 }
 
@@ -87,11 +264,11 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*legocharmclient.Client)
+	client, ok := req.ProviderData.(legocharmclient.API)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *legocharmclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -99,6 +276,76 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+// ValidateConfig ensures at most one way of supplying the password is used.
+func (r *UserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data UserModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Password.IsNull() && !data.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo"),
+			"Conflicting Password Arguments",
+			"Only one of `password` and `password_wo` may be set.",
+		)
+	}
+
+	if data.TrackPasswordHash.ValueBool() && data.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("track_password_hash"),
+			"Missing Write-Only Password",
+			"`track_password_hash` requires the password to be supplied through `password_wo`, otherwise the plaintext is stored in state regardless.",
+		)
+	}
+}
+
+// ModifyPlan marks the password as unknown when it is going to be rotated so
+// that the plan shows the change. A generated password is rotated when
+// password_version changes or when state holds no password (for example after
+// a password-less import); a tracked write-only password is rotated when its
+// configured value no longer matches the hash in state. A warning is emitted
+// whenever the password is about to be reset because state holds none.
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state, config UserModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	versionChanged := !plan.PasswordVersion.Equal(state.PasswordVersion)
+	if (versionChanged || state.Password.IsNull()) && config.Password.IsNull() && config.PasswordWO.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringUnknown())...)
+	}
+
+	// A missing password in state means Read found it invalid or the user was
+	// imported without one; make the upcoming reset explicit in the plan.
+	if state.Password.IsNull() && config.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("password"),
+			"User Password Will Be Reset",
+			fmt.Sprintf("No valid password for user '%s' is recorded in state: it was changed outside of Terraform, or the user was imported without one. "+
+				"Applying this plan will set a new password on the existing account in place.", state.Username.ValueString()),
+		)
+	}
+
+	switch {
+	case !plan.TrackPasswordHash.ValueBool():
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password_hash"), types.StringNull())...)
+	case versionChanged || config.PasswordWO.IsUnknown() || state.PasswordHash.IsNull() ||
+		!passwordMatchesHash(config.PasswordWO.ValueString(), state.PasswordHash.ValueString()):
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password_hash"), types.StringUnknown())...)
+	}
+}
+
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data UserModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -112,47 +359,121 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Check for conflict: ensure username does not already exist
-	if existingUser, err := r.client.GetUserByUsername(data.Username.ValueString()); err == nil {
+	timeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := clientClock(r.client).WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Check for conflict: ensure username does not already exist, unless the
+	// existing account should be adopted
+	existingUser, err := r.client.GetUserByUsername(ctx, data.Username.ValueString())
+	if err == nil && !data.AdoptExisting.ValueBool() {
 		existingUserId := legocharmclient.LastPathSegment(existingUser.Url)
-		resp.Diagnostics.AddError("User Exists", fmt.Sprintf("A user with username '%s' already exists (id=%s).", data.Username.ValueString(), existingUserId))
+		resp.Diagnostics.AddError("User Exists", fmt.Sprintf("A user with username '%s' already exists (id=%s). Set adopt_existing = true to manage it, or import it.", data.Username.ValueString(), existingUserId))
 		return
-	} else if err != legocharmclient.ErrNotFound {
+	} else if err != nil && err != legocharmclient.ErrNotFound {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check for existing user: %s", err))
 		return
 	}
 
+	password, diags := resolvePassword(ctx, req.Config, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if existingUser != nil {
+		r.adopt(ctx, existingUser, password, &data, resp)
+		return
+	}
+
 	create := legocharmclient.UserCreateData{
-		Username: data.Username.ValueString(),
-		Password: data.Password.ValueString(),
-		Email:    data.Email.ValueString(),
-		Groups:   []string{},
+		Username:    data.Username.ValueString(),
+		Password:    password,
+		Email:       data.Email.ValueString(),
+		Groups:      []string{},
+		IsStaff:     data.IsStaff.ValueBool(),
+		IsSuperuser: data.IsSuperuser.ValueBool(),
+		IsActive:    data.IsActive.ValueBool(),
 	}
 
-	_, err := r.client.CreateUser(create)
+	created, err := r.client.CreateUser(ctx, create)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user, got error: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to create user", err, userAPIFields)
 		return
 	}
 
-	time.Sleep(500 * time.Millisecond)
-
-	// Fetch created user to populate state
-	user, err := r.client.GetUserByUsername(data.Username.ValueString())
+	user, err := createdUser(ctx, r.client, created, create.Username)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("User created but failed to read back: %s", err))
 		return
 	}
 
 	data.Id = types.StringValue(legocharmclient.LastPathSegment(user.Url))
-	data.Email = types.StringValue(user.Email)
-	data.Password = types.StringValue(data.Password.ValueString())
+	setUserAttributes(&data, user)
 
 	// Write logs
 	tflog.Trace(ctx, "created user")
 
 	// Save state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setUserIdentity(ctx, resp.Identity, data.Id, &resp.Diagnostics)
+}
+
+// createdUser returns the user with the given username, as answered by the
+// API to its creation. An answer only pointing at the user with a Location
+// header is completed by reading the user it points at. An answer without
+// either is completed by polling for the username until the API reports it
+// or ctx is done. The polls bypass the read cache, which would keep answering
+// that the user does not exist yet.
+func createdUser(ctx context.Context, client legocharmclient.API, created *legocharmclient.UserData, username string) (*legocharmclient.UserData, error) {
+	if created.Username == username && created.Url != "" {
+		return created, nil
+	}
+	if created.Username == "" && created.Url != "" {
+		return client.GetUserById(ctx, legocharmclient.LastPathSegment(created.Url))
+	}
+
+	var user *legocharmclient.UserData
+	err := poll(ctx, clientClock(client), func() (bool, error) {
+		found, err := client.GetUserByUsername(legocharmclient.WithoutReadCache(ctx), username)
+		if err == legocharmclient.ErrNotFound {
+			return false, nil
+		}
+		user = found
+		return err == nil, err
+	})
+	return user, err
+}
+
+// adopt takes over an existing user during Create: its password is rotated to
+// the planned one and its email and flags are updated to match the plan.
+func (r *UserResource) adopt(ctx context.Context, existing *legocharmclient.UserData, password string, data *UserModel, resp *resource.CreateResponse) {
+	id := legocharmclient.LastPathSegment(existing.Url)
+	email := data.Email.ValueString()
+
+	user, err := r.client.UpdateUser(ctx, id, legocharmclient.UserUpdateData{
+		Password:    password,
+		Email:       &email,
+		IsStaff:     data.IsStaff.ValueBoolPointer(),
+		IsSuperuser: data.IsSuperuser.ValueBoolPointer(),
+		IsActive:    data.IsActive.ValueBoolPointer(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to adopt existing user", err, userAPIFields)
+		return
+	}
+
+	data.Id = types.StringValue(id)
+	setUserAttributes(data, user)
+
+	tflog.Trace(ctx, "adopted existing user", map[string]interface{}{"id": id})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	setUserIdentity(ctx, resp.Identity, data.Id, &resp.Diagnostics)
 }
 
 func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -167,48 +488,70 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Attempt to look up by id (URL) first, then by username
+	timeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := clientClock(r.client).WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Look up by the stable ID when known so that an out-of-band username
+	// change is reported as drift rather than deletion; fall back to the
+	// username otherwise.
 	var user *legocharmclient.UserData
 	var err error
 	if !data.Id.IsNull() && data.Id.ValueString() != "" {
-		// Try delete or fetch by URL: the API may not support fetch by URL, so
-		// fall back to username lookup.
-		user, err = r.client.GetUserByUsername(data.Username.ValueString())
+		user, err = r.client.GetUserById(ctx, data.Id.ValueString())
 	} else {
-		user, err = r.client.GetUserByUsername(data.Username.ValueString())
+		user, err = r.client.GetUserByUsername(ctx, data.Username.ValueString())
 	}
 	if err != nil {
 		if err == legocharmclient.ErrNotFound {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to read user", err, nil)
 		return
 	}
 
-	data.Email = types.StringValue(user.Email)
+	data.Username = types.StringValue(user.Username)
 	data.Id = types.StringValue(legocharmclient.LastPathSegment(user.Url))
+	setUserAttributes(&data, user)
+
+	// Without a stored password (write-only or already invalidated) there is
+	// nothing to validate. Validation is also skipped when explicitly disabled;
+	// a null value (e.g. right after import) keeps the default behaviour.
+	// Inactive users cannot authenticate at all, so their password cannot be
+	// checked either.
+	if data.Password.IsNull() || data.ValidatePassword.Equal(types.BoolValue(false)) || !user.IsActive {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		setUserIdentity(ctx, resp.Identity, data.Id, &resp.Diagnostics)
+		return
+	}
 
 	// ensure the password is valid
-	valid, err := r.client.HasValidUserPassword(data.Username.ValueString(), data.Password.ValueString())
+	valid, err := r.client.HasValidUserPassword(ctx, data.Username.ValueString(), data.Password.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to validate user password: %s", err))
 		return
 	}
 	if !valid {
 		resp.Diagnostics.AddWarning("Invalid Password", "The stored password is no longer valid")
-		// require replacement on next apply
+		// rotate the password on next apply
 		data.Password = types.StringNull()
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setUserIdentity(ctx, resp.Identity, data.Id, &resp.Diagnostics)
 }
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Refresh state from the API. Password is generated at creation and retained
-	// in state; it is not user-supplied and will be preserved across refreshes.
-	var plan UserModel
+	// Password is retained in state and preserved across updates unless
+	// password_version changes, in which case it is rotated in place.
+	var plan, state UserModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -218,27 +561,81 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	user, err := r.client.GetUserByUsername(plan.Username.ValueString())
+	timeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := clientClock(r.client).WithTimeout(ctx, timeout)
+	defer cancel()
+
+	update := legocharmclient.UserUpdateData{}
+	if !plan.IsStaff.Equal(state.IsStaff) {
+		update.IsStaff = plan.IsStaff.ValueBoolPointer()
+	}
+	if !plan.IsSuperuser.Equal(state.IsSuperuser) {
+		update.IsSuperuser = plan.IsSuperuser.ValueBoolPointer()
+	}
+	if !plan.IsActive.Equal(state.IsActive) {
+		update.IsActive = plan.IsActive.ValueBoolPointer()
+	}
+
+	// A changed password_version rotates a generated password, which the
+	// plan then shows as unknown, and a write-only one, which Terraform
+	// cannot compare. A configured password is only sent when it changed.
+	var passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	rotate := !plan.Password.Equal(state.Password) || plan.PasswordHash.IsUnknown() ||
+		(!plan.PasswordVersion.Equal(state.PasswordVersion) && !passwordWO.IsNull())
+	if rotate {
+		password, diags := resolvePassword(ctx, req.Config, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		update.Password = password
+	}
+
+	// Only settings of the provider changed, such as deletion_protection or
+	// timeouts: there is nothing to send, and the user is as in state.
+	if update == (legocharmclient.UserUpdateData{}) {
+		plan.Id = state.Id
+		plan.Groups = state.Groups
+		plan.DateJoined = state.DateJoined
+		plan.LastLogin = state.LastLogin
+
+		tflog.Trace(ctx, "updated user settings only")
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		setUserIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+		return
+	}
+
+	user, err := r.client.UpdateUser(ctx, state.Id.ValueString(), update)
 	if err != nil {
 		if err == legocharmclient.ErrNotFound {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to update user", err, userAPIFields)
 		return
 	}
 
-	plan.Email = types.StringValue(user.Email)
-	plan.Id = types.StringValue(legocharmclient.LastPathSegment(user.Url))
+	plan.Id = state.Id
+	setUserAttributes(&plan, user)
 
-	// Preserve generated password from prior state (if present)
-	var state UserModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	if !state.Password.IsNull() && !state.Password.IsUnknown() {
+	// Preserve password from prior state (if present)
+	if !rotate && !state.Password.IsNull() && !state.Password.IsUnknown() {
 		plan.Password = state.Password
 	}
 
+	tflog.Trace(ctx, "updated user", map[string]interface{}{"password_rotated": rotate})
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setUserIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
 }
 
 func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -248,52 +645,286 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Deletion Protection Enabled",
+			fmt.Sprintf("User '%s' has deletion_protection enabled. Set deletion_protection = false and apply before destroying or replacing it.", data.Username.ValueString()),
+		)
+		return
+	}
+
 	if r.client == nil {
 		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
 		return
 	}
 
+	timeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := clientClock(r.client).WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Use ID (URL) if set, otherwise fetch user to get a URL and delete by that.
-	if !data.Id.IsNull() && data.Id.ValueString() != "" {
-		_, err := r.client.DeleteUserById(data.Id.ValueString())
+	id := data.Id.ValueString()
+	if data.Id.IsNull() || id == "" {
+		user, err := r.client.GetUserByUsername(ctx, data.Username.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user: %s", err))
+			if err == legocharmclient.ErrNotFound {
+				return
+			}
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to locate user for deletion: %s", err))
 			return
 		}
-		return
+		id = legocharmclient.LastPathSegment(user.Url)
 	}
 
-	user, err := r.client.GetUserByUsername(data.Username.ValueString())
-	if err != nil {
-		if err == legocharmclient.ErrNotFound {
+	if data.CascadeDelete.ValueBool() {
+		r.deleteDomainAccess(ctx, data.Username.ValueString(), resp)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to locate user for deletion: %s", err))
-		return
 	}
 
-	_, err = r.client.DeleteUserById(legocharmclient.LastPathSegment(user.Url))
+	_, err := r.client.DeleteUserById(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user: %s", err))
 		return
 	}
 }
 
+// deleteDomainAccess removes every domain access permission granted to
+// username, so that the user can be deleted without orphaning grants.
+func (r *UserResource) deleteDomainAccess(ctx context.Context, username string, resp *resource.DeleteResponse) {
+	permissions, err := r.client.ListDomainAccessByUsername(ctx, username)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list domain access for user: %s", err))
+		return
+	}
+
+	for _, permission := range permissions {
+		res, err := r.client.DeleteDomainAccess(ctx, permission.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete domain access %d: %s", permission.ID, err))
+			return
+		}
+		res.Body.Close()
+		if res.StatusCode >= 400 && res.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete domain access %d: status %d", permission.ID, res.StatusCode))
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "deleted domain access for user", map[string]interface{}{"count": len(permissions)})
+}
+
 func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// id is of format "username:password"
-	parts := strings.Split(req.ID, ":")
+	// Import blocks using identity carry the user ID instead of an ID string.
+	// It is always an ID, never the name of a user.
+	importID := req.ID
+	if importID == "" && req.Identity != nil {
+		var identity UserIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !userIDRegexp.MatchString(identity.Id.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("id"), "Invalid Import Identity", "id must be a numeric user ID")
+			return
+		}
+		r.importByID(ctx, identity.Id.ValueString(), false, resp)
+		return
+	}
 
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("Invalid Import ID", "Import ID must be in the format 'username:password'")
+	// id is "id:" followed by a user ID, a numeric user ID, a username, or of
+	// format "username:password". A number is the name of a user when no
+	// user has it as ID, and so is one that is no user ID at all, such as
+	// "0123" or "-1", since usernames may be made of digits and signs.
+	if id, ok := strings.CutPrefix(importID, "id:"); ok {
+		if !userIDRegexp.MatchString(id) {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("User ID %q must be a positive integer.", id))
+			return
+		}
+		r.importByID(ctx, id, false, resp)
+		return
+	}
+	if userIDRegexp.MatchString(importID) {
+		r.importByID(ctx, importID, true, resp)
 		return
 	}
 
-	username := parts[0]
-	password := parts[1]
+	// Otherwise id is a username, optionally followed by ":password". Without
+	// a password nothing is stored and the password is rotated on next apply.
+	parts := strings.SplitN(req.ID, ":", 2)
 
-	var data UserModel
+	if parts[0] == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", "Import ID must be a numeric user ID, a username, or in the format 'username:password'")
+		return
+	}
+
+	importByUsername(ctx, parts[0], parts[1:], resp)
+}
+
+// importByUsername imports the user named username, with the password in
+// password if any. Nothing is looked up: Read finds the user by name.
+func importByUsername(ctx context.Context, username string, password []string, resp *resource.ImportStateResponse) {
+	data := importedUserModel()
 	data.Username = types.StringValue(username)
-	data.Password = types.StringValue(password)
+	if len(password) == 1 && password[0] != "" {
+		data.Password = types.StringValue(password[0])
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// importByID imports the user with the ID id. If orName is set, id may also
+// be the name of a user: it is imported as one when no user has the ID, and
+// is rejected as ambiguous when a user has the ID and another the name.
+func (r *UserResource) importByID(ctx context.Context, id string, orName bool, resp *resource.ImportStateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this resource")
+		return
+	}
+
+	user, err := r.client.GetUserById(ctx, id)
+	if errors.Is(err, legocharmclient.ErrNotFound) && orName {
+		importByUsername(ctx, id, nil, resp)
+		return
+	}
+	if err != nil {
+		if errors.Is(err, legocharmclient.ErrNotFound) {
+			resp.Diagnostics.AddError("User Not Found", fmt.Sprintf("No user with ID %s exists.", id))
+			return
+		}
+		addClientError(&resp.Diagnostics, "Unable to read user", err, nil)
+		return
+	}
+	if orName && user.Username != id {
+		named, err := r.client.GetUserByUsername(ctx, id)
+		if err != nil && !errors.Is(err, legocharmclient.ErrNotFound) {
+			addClientError(&resp.Diagnostics, "Unable to look up user", err, nil)
+			return
+		}
+		if named != nil {
+			resp.Diagnostics.AddError(
+				"Ambiguous Import ID",
+				fmt.Sprintf("%s is both the ID of user %q and the name of another user. Import id:%s to import the user by ID, or %s: to import the user by name.", id, user.Username, id, id),
+			)
+			return
+		}
+	}
+
+	data := importedUserModel()
+	data.Id = types.StringValue(id)
+	data.Username = types.StringValue(user.Username)
+	setUserAttributes(&data, user)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setUserIdentity(ctx, resp.Identity, data.Id, &resp.Diagnostics)
+}
+
+// setUserIdentity records the user ID as the resource identity. identity is
+// nil when Terraform does not support resource identity.
+func setUserIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String, diags *diag.Diagnostics) {
+	if identity == nil || diags.HasError() {
+		return
+	}
+	diags.Append(identity.Set(ctx, UserIdentityModel{Id: id})...)
+}
+
+// importedUserModel returns a model with the provider-only settings set to
+// their schema defaults, for state built without a plan (imports and state
+// upgrades), so that the next plan does not show a spurious update.
+// Attributes that come from the API are left null for Read to fill in.
+func importedUserModel() UserModel {
+	return UserModel{
+		Username:           types.StringNull(),
+		Password:           types.StringNull(),
+		PasswordWO:         types.StringNull(),
+		PasswordVersion:    types.StringNull(),
+		TrackPasswordHash:  types.BoolValue(false),
+		PasswordHash:       types.StringNull(),
+		ValidatePassword:   types.BoolValue(true),
+		DeletionProtection: types.BoolValue(false),
+		AdoptExisting:      types.BoolValue(false),
+		CascadeDelete:      types.BoolValue(false),
+		PasswordLength:     types.Int64Value(defaultPasswordLength),
+		PasswordSpecial:    types.BoolValue(true),
+		Email:              types.StringNull(),
+		IsStaff:            types.BoolNull(),
+		IsSuperuser:        types.BoolNull(),
+		IsActive:           types.BoolNull(),
+		Groups:             types.ListNull(types.StringType),
+		DateJoined:         types.StringNull(),
+		LastLogin:          types.StringNull(),
+		Id:                 types.StringNull(),
+		Timeouts:           nullTimeouts(userTimeouts),
+	}
+}
+
+// setUserAttributes copies the attributes reported by the API into the model.
+func setUserAttributes(data *UserModel, user *legocharmclient.UserData) {
+	data.Email = types.StringValue(user.Email)
+	data.IsStaff = types.BoolValue(user.IsStaff)
+	data.IsSuperuser = types.BoolValue(user.IsSuperuser)
+	data.IsActive = types.BoolValue(user.IsActive)
+	data.Groups = types.ListValueMust(types.StringType, groupValues(user.Groups))
+	data.DateJoined = types.StringValue(user.DateJoined)
+	data.LastLogin = types.StringPointerValue(user.LastLogin)
+}
+
+// groupValues converts the API's group list into Terraform string values.
+func groupValues(groups []string) []attr.Value {
+	values := make([]attr.Value, 0, len(groups))
+	for _, g := range groups {
+		values = append(values, types.StringValue(g))
+	}
+	return values
+}
+
+// resolvePassword determines the password to send to the API. A write-only
+// password from configuration takes precedence and is never stored in state;
+// otherwise the planned password is used, or a new one is generated when the
+// plan does not contain one. The password hash is refreshed alongside it.
+func resolvePassword(ctx context.Context, config tfsdk.Config, data *UserModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Write-only values are only available from configuration
+	var passwordWO types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	var password string
+	switch {
+	case !passwordWO.IsNull():
+		password = passwordWO.ValueString()
+		data.Password = types.StringNull()
+	case !data.Password.IsNull() && !data.Password.IsUnknown():
+		password = data.Password.ValueString()
+	default:
+		// Generate a password when none was configured
+		generated, err := generatePassword(int(data.PasswordLength.ValueInt64()), data.PasswordSpecial.ValueBool())
+		if err != nil {
+			diags.AddError("Password Generation Error", fmt.Sprintf("Unable to generate password: %s", err))
+			return "", diags
+		}
+		password = generated
+		data.Password = types.StringValue(password)
+	}
+
+	data.PasswordHash = types.StringNull()
+	if data.TrackPasswordHash.ValueBool() {
+		hash, err := hashPassword(password)
+		if err != nil {
+			diags.AddError("Password Hash Error", fmt.Sprintf("Unable to hash password: %s", err))
+			return "", diags
+		}
+		data.PasswordHash = types.StringValue(hash)
+	}
+
+	return password, diags
+}
@@ -13,13 +13,25 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	resourcetest "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/require"
 
-	"terraform-provider-legocharm/internal/legocharmclient"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient/legocharmclienttest"
 )
 
 func TestUserResource_Schema(t *testing.T) {
@@ -41,8 +53,9 @@ func TestUserResource_Schema(t *testing.T) {
 	require.False(t, attrs["username"].IsOptional())
 	require.False(t, attrs["username"].IsComputed())
 
-	// Verify password is required and sensitive
-	require.True(t, attrs["password"].IsRequired())
+	// Verify password is optional, computed and sensitive
+	require.True(t, attrs["password"].IsOptional())
+	require.True(t, attrs["password"].IsComputed())
 	require.True(t, attrs["password"].IsSensitive())
 
 	// Verify email is optional
@@ -52,6 +65,9 @@ func TestUserResource_Schema(t *testing.T) {
 	// Verify id is computed
 	require.True(t, attrs["id"].IsComputed())
 	require.False(t, attrs["id"].IsRequired())
+
+	// Verify timeouts block is present
+	require.Contains(t, resp.Schema.Blocks, "timeouts")
 }
 
 func TestUserResource_Metadata(t *testing.T) {
@@ -138,6 +154,12 @@ func TestUserResource_Implements_Interfaces(t *testing.T) {
 
 	_, ok = r.(resource.ResourceWithImportState)
 	require.True(t, ok, "UserResource should implement resource.ResourceWithImportState")
+
+	_, ok = r.(resource.ResourceWithValidateConfig)
+	require.True(t, ok, "UserResource should implement resource.ResourceWithValidateConfig")
+
+	_, ok = r.(resource.ResourceWithModifyPlan)
+	require.True(t, ok, "UserResource should implement resource.ResourceWithModifyPlan")
 }
 
 func TestUserModel_TypesHandling(t *testing.T) {
@@ -207,13 +229,775 @@ func TestUserResource_SchemaAttributes_Characteristics(t *testing.T) {
 	require.False(t, attrs["username"].IsComputed(), "username should not be computed")
 
 	// Verify password characteristics
-	require.True(t, attrs["password"].IsRequired(), "password should be required")
+	require.True(t, attrs["password"].IsOptional(), "password should be optional")
+	require.True(t, attrs["password"].IsComputed(), "password should be computed when generated")
 	require.True(t, attrs["password"].IsSensitive(), "password should be sensitive")
+	require.True(t, attrs["password_wo"].IsWriteOnly(), "password_wo should be write-only")
+	require.True(t, attrs["password_wo"].IsSensitive(), "password_wo should be sensitive")
+	require.True(t, attrs["password_version"].IsOptional(), "password_version should be optional")
+	require.False(t, attrs["password_version"].IsComputed(), "password_version should not be computed")
+	require.True(t, attrs["track_password_hash"].IsOptional(), "track_password_hash should be optional")
+	require.True(t, attrs["password_hash"].IsComputed(), "password_hash should be computed")
+	require.False(t, attrs["password_hash"].IsOptional(), "password_hash should not be optional")
+	require.True(t, attrs["validate_password"].IsOptional(), "validate_password should be optional")
+	require.True(t, attrs["password_length"].IsOptional(), "password_length should be optional")
+	require.True(t, attrs["password_special"].IsOptional(), "password_special should be optional")
 
 	// Verify email characteristics
 	require.True(t, attrs["email"].IsOptional(), "email should be optional")
 
+	// Verify user flag characteristics
+	for _, name := range []string{"is_staff", "is_superuser", "is_active"} {
+		require.True(t, attrs[name].IsOptional(), "%s should be optional", name)
+		require.True(t, attrs[name].IsComputed(), "%s should be computed", name)
+	}
+
+	// Verify timestamp characteristics
+	for _, name := range []string{"date_joined", "last_login"} {
+		require.True(t, attrs[name].IsComputed(), "%s should be computed", name)
+		require.False(t, attrs[name].IsOptional(), "%s should not be optional", name)
+	}
+
 	// Verify id characteristics
 	require.True(t, attrs["id"].IsComputed(), "id should be computed")
 	require.False(t, attrs["id"].IsRequired(), "id should not be required")
 }
+
+func TestUserResource_ImportState_NumericID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/users/1004/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"username":"alice","url":"http://example.com/api/v1/users/1004/","email":"alice@example.com","groups":["admins"],"is_active":true}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "1004"}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var data UserModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	require.Equal(t, "1004", data.Id.ValueString())
+	require.Equal(t, "alice", data.Username.ValueString())
+	require.Equal(t, "alice@example.com", data.Email.ValueString())
+	require.True(t, data.Password.IsNull())
+	require.Len(t, data.Groups.Elements(), 1)
+
+	resp.Diagnostics = nil
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "id:9999"}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", resp.Diagnostics.Errors()[0].Summary())
+
+	for _, id := range []string{"id:0", "id:-5", "id:alice"} {
+		resp.Diagnostics = nil
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		require.True(t, resp.Diagnostics.HasError(), id)
+		require.Equal(t, "Invalid Import ID", resp.Diagnostics.Errors()[0].Summary(), id)
+	}
+}
+
+func TestUserResource_ImportState_NumericUsername(t *testing.T) {
+	// User 1004 is alice, and user 1005 is named "1004"; no user has ID 12345
+	// but one is named so.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/users/1004/":
+			w.Write([]byte(`{"username":"alice","url":"http://example.com/api/v1/users/1004/","is_active":true}`)) // nolint:errcheck
+		case r.URL.Path == "/api/v1/users/" && r.URL.Query().Get("username") == "1004":
+			w.Write([]byte(`[{"username":"1004","url":"http://example.com/api/v1/users/1005/","is_active":true}]`)) // nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	importState := func(id string) (*resource.ImportStateResponse, UserModel) {
+		resp := &resource.ImportStateResponse{State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		var data UserModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.State.Get(ctx, &data).HasError())
+		}
+		return resp, data
+	}
+
+	// No user has the ID 12345, so it is a username.
+	resp, data := importState("12345")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.True(t, data.Id.IsNull())
+	require.Equal(t, "12345", data.Username.ValueString())
+
+	// Numbers that are no user ID at all are usernames too.
+	for _, id := range []string{"0123", "-1", "0", "+5"} {
+		resp, data := importState(id)
+		require.False(t, resp.Diagnostics.HasError(), id, resp.Diagnostics)
+		require.True(t, data.Id.IsNull(), id)
+		require.Equal(t, id, data.Username.ValueString(), id)
+	}
+
+	// 1004 is both an ID and a username.
+	resp, _ = importState("1004")
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Ambiguous Import ID", resp.Diagnostics.Errors()[0].Summary())
+
+	resp, data = importState("id:1004")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, "1004", data.Id.ValueString())
+	require.Equal(t, "alice", data.Username.ValueString())
+
+	resp, data = importState("1004:")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.True(t, data.Id.IsNull())
+	require.Equal(t, "1004", data.Username.ValueString())
+	require.True(t, data.Password.IsNull())
+}
+
+func TestUserResource_ImportState_Username(t *testing.T) {
+	r := &UserResource{}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for id, want := range map[string]types.String{
+		"alice":          types.StringNull(),
+		"alice:s3cr3t":   types.StringValue("s3cr3t"),
+		"alice:pa:ss:wd": types.StringValue("pa:ss:wd"),
+	} {
+		resp := &resource.ImportStateResponse{State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		require.False(t, resp.Diagnostics.HasError(), "%s: %v", id, resp.Diagnostics)
+
+		var data UserModel
+		require.False(t, resp.State.Get(ctx, &data).HasError())
+		require.Equal(t, "alice", data.Username.ValueString())
+		require.Equal(t, want, data.Password, id)
+		require.True(t, data.Id.IsNull())
+	}
+}
+
+func TestUserResource_ImportState_Identity(t *testing.T) {
+	api := newFakeDomainAccessAPI()
+	api.groups[1004] = []string{"admins"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	newIdentity := func(id types.String) *tfsdk.ResourceIdentity {
+		identity := &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema}
+		require.False(t, identity.Set(ctx, UserIdentityModel{Id: id}).HasError())
+		return identity
+	}
+
+	identity := newIdentity(types.StringValue("1004"))
+	resp := &resource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}, Identity: identity}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	// Every computed attribute is known straight after import.
+	var data UserModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	require.Equal(t, "alice", data.Username.ValueString())
+	require.Equal(t, "1004", data.Id.ValueString())
+	require.True(t, data.IsActive.ValueBool())
+	require.False(t, data.IsStaff.IsNull())
+	require.False(t, data.IsSuperuser.IsNull())
+	require.False(t, data.DateJoined.IsNull())
+	require.Equal(t, []attr.Value{types.StringValue("admins")}, data.Groups.Elements())
+	var got UserIdentityModel
+	require.False(t, resp.Identity.Get(ctx, &got).HasError())
+	require.Equal(t, "1004", got.Id.ValueString())
+
+	invalid := newIdentity(types.StringValue("alice"))
+	bad := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}, Identity: invalid}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: invalid}, bad)
+	require.True(t, bad.Diagnostics.HasError())
+	require.Equal(t, "Invalid Import Identity", bad.Diagnostics.Errors()[0].Summary())
+
+	// An identity always names an ID, never a user called "42".
+	missing := newIdentity(types.StringValue("42"))
+	notFound := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}, Identity: missing}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: missing}, notFound)
+	require.True(t, notFound.Diagnostics.HasError())
+	require.Equal(t, "User Not Found", notFound.Diagnostics.Errors()[0].Summary())
+}
+
+func TestUserResource_Delete_DeletionProtection(t *testing.T) {
+	// The client is never reached, so an unroutable address is fine.
+	address, username, password := "https://127.0.0.1:1", "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := importedUserModel()
+	data.Username = types.StringValue("alice")
+	data.Id = types.StringValue("1004")
+	data.DeletionProtection = types.BoolValue(true)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Deletion Protection Enabled", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestUserResource_Delete_CascadeDelete(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/domain-user-permissions/":
+			w.Write([]byte(`[{"id":3,"user":1004,"domain":2,"access_level":"domain"},{"id":4,"user":1004,"domain":5,"access_level":"subdomain"}]`)) // nolint:errcheck
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/domain-user-permissions/4/":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := importedUserModel()
+	data.Username = types.StringValue("alice")
+	data.Id = types.StringValue("1004")
+	data.CascadeDelete = types.BoolValue(true)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Equal(t, []string{
+		"GET /api/v1/domain-user-permissions/",
+		"DELETE /api/v1/domain-user-permissions/3/",
+		"DELETE /api/v1/domain-user-permissions/4/",
+		"DELETE /api/v1/users/1004/",
+	}, requests)
+}
+
+func TestUserResource_Create_AdoptExisting(t *testing.T) {
+	var patched map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/users/":
+			w.Write([]byte(`[{"username":"alice","url":"http://example.com/api/v1/users/1004/","is_active":true}]`)) // nolint:errcheck
+		case r.Method == "PATCH" && r.URL.Path == "/api/v1/users/1004/":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patched))
+			w.Write([]byte(`{"username":"alice","url":"http://example.com/api/v1/users/1004/","email":"alice@example.com","is_staff":true,"is_active":true}`)) // nolint:errcheck
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	r := &UserResource{client: client}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := importedUserModel()
+	data.Username = types.StringValue("alice")
+	data.Password = types.StringValue("n3w-passw0rd")
+	data.Email = types.StringValue("alice@example.com")
+	data.IsStaff = types.BoolValue(true)
+	data.IsSuperuser = types.BoolValue(false)
+	data.IsActive = types.BoolValue(true)
+	data.AdoptExisting = types.BoolValue(true)
+
+	plan := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	resp := &resource.CreateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.Create(ctx, resource.CreateRequest{
+		Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+	}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	require.Equal(t, "n3w-passw0rd", patched["password"])
+	require.Equal(t, true, patched["is_staff"])
+
+	var got UserModel
+	require.False(t, resp.State.Get(ctx, &got).HasError())
+	require.Equal(t, "1004", got.Id.ValueString())
+	require.Equal(t, "n3w-passw0rd", got.Password.ValueString())
+}
+
+func TestUserResource_Create(t *testing.T) {
+	const alice = `{"username":"alice","url":"http://example.com/api/v1/users/1004/","email":"alice@example.com","is_active":true,"date_joined":"2026-01-02T03:04:05Z"}`
+	tests := []struct {
+		name     string
+		answer   func(w http.ResponseWriter)
+		requests []string
+	}{{
+		name: "created user",
+		answer: func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(alice)) // nolint:errcheck
+		},
+		requests: []string{"GET /api/v1/users/", "POST /api/v1/users/"},
+	}, {
+		name: "location only",
+		answer: func(w http.ResponseWriter) {
+			w.Header().Set("Location", "/api/v1/users/1004/")
+			w.WriteHeader(http.StatusCreated)
+		},
+		requests: []string{"GET /api/v1/users/", "POST /api/v1/users/", "GET /api/v1/users/1004/"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			created := false
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/api/v1/users/" && created:
+					w.Write([]byte("[" + alice + "]")) // nolint:errcheck
+				case r.Method == "GET" && r.URL.Path == "/api/v1/users/":
+					w.Write([]byte(`[]`)) // nolint:errcheck
+				case r.Method == "GET" && r.URL.Path == "/api/v1/users/1004/":
+					w.Write([]byte(alice)) // nolint:errcheck
+				case r.Method == "POST" && r.URL.Path == "/api/v1/users/":
+					created = true
+					tt.answer(w)
+				default:
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+				}
+			}))
+			defer srv.Close()
+
+			address, username, password := srv.URL, "admin", "admin"
+			client, err := legocharmclient.NewClient(&address, &username, &password)
+			require.NoError(t, err)
+			r := &UserResource{client: client}
+
+			ctx := context.Background()
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			data := importedUserModel()
+			data.Username = types.StringValue("alice")
+			data.Password = types.StringValue("n3w-passw0rd")
+			data.Email = types.StringValue("alice@example.com")
+			data.IsStaff = types.BoolValue(false)
+			data.IsSuperuser = types.BoolValue(false)
+			data.IsActive = types.BoolValue(true)
+
+			plan := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(ctx, &data).HasError())
+
+			resp := &resource.CreateResponse{State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}}
+			r.Create(ctx, resource.CreateRequest{
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+			}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			require.Equal(t, tt.requests, requests)
+
+			var got UserModel
+			require.False(t, resp.State.Get(ctx, &got).HasError())
+			require.Equal(t, "1004", got.Id.ValueString())
+			require.Equal(t, "2026-01-02T03:04:05Z", got.DateJoined.ValueString())
+		})
+	}
+}
+
+func TestUserResource_CRUD(t *testing.T) {
+	ctx := context.Background()
+	r := &UserResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	alice := legocharmclient.UserData{Username: "alice", Url: "http://example.com/api/v1/users/1004/", Email: "alice@example.com", IsActive: true}
+	api := &legocharmclienttest.APIMock{
+		GetUserByUsernameFunc: func(ctx context.Context, username string) (*legocharmclient.UserData, error) {
+			return nil, legocharmclient.ErrNotFound
+		},
+		CreateUserFunc: func(ctx context.Context, user legocharmclient.UserCreateData) (*legocharmclient.UserData, error) {
+			return &alice, nil
+		},
+		GetUserByIdFunc: func(ctx context.Context, userId string) (*legocharmclient.UserData, error) {
+			return &alice, nil
+		},
+		HasValidUserPasswordFunc: func(ctx context.Context, username, password string) (bool, error) {
+			return true, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, id string, update legocharmclient.UserUpdateData) (*legocharmclient.UserData, error) {
+			staff := alice
+			staff.IsStaff = *update.IsStaff
+			return &staff, nil
+		},
+		DeleteUserByIdFunc: func(ctx context.Context, id string) (*http.Response, error) {
+			return nil, nil
+		},
+	}
+	r.client = api
+
+	data := importedUserModel()
+	data.Username = types.StringValue("alice")
+	data.Password = types.StringValue("n3w-passw0rd")
+	data.Email = types.StringValue("alice@example.com")
+	data.IsStaff = types.BoolValue(false)
+	data.IsSuperuser = types.BoolValue(false)
+	data.IsActive = types.BoolValue(true)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+	require.Len(t, api.CreateUserCalls(), 1)
+	require.Equal(t, "alice", api.CreateUserCalls()[0].User.Username)
+	require.Equal(t, "n3w-passw0rd", api.CreateUserCalls()[0].User.Password)
+	var created UserModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Equal(t, "1004", created.Id.ValueString())
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	require.Equal(t, "1004", api.GetUserByIdCalls()[0].UserId)
+	require.Equal(t, "n3w-passw0rd", api.HasValidUserPasswordCalls()[0].Password)
+
+	// Only the changed flag is sent, and the password is kept.
+	created.IsStaff = types.BoolValue(true)
+	require.False(t, plan.Set(ctx, &created).HasError())
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+	require.Len(t, api.UpdateUserCalls(), 1)
+	update := api.UpdateUserCalls()[0]
+	require.Equal(t, "1004", update.Id)
+	require.Equal(t, legocharmclient.UserUpdateData{IsStaff: update.Update.IsStaff}, update.Update)
+	require.True(t, *update.Update.IsStaff)
+	var updated UserModel
+	require.False(t, updateResp.State.Get(ctx, &updated).HasError())
+	require.True(t, updated.IsStaff.ValueBool())
+	require.Equal(t, "n3w-passw0rd", updated.Password.ValueString())
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	require.Equal(t, "1004", api.DeleteUserByIdCalls()[0].Id)
+}
+
+func TestUserResource_APIErrors(t *testing.T) {
+	ctx := context.Background()
+	r := &UserResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	unavailable := errors.New("connection refused")
+	api := &legocharmclienttest.APIMock{
+		GetUserByUsernameFunc: func(ctx context.Context, username string) (*legocharmclient.UserData, error) {
+			return nil, legocharmclient.ErrNotFound
+		},
+		CreateUserFunc: func(ctx context.Context, user legocharmclient.UserCreateData) (*legocharmclient.UserData, error) {
+			return nil, unavailable
+		},
+		GetUserByIdFunc: func(ctx context.Context, userId string) (*legocharmclient.UserData, error) {
+			return nil, unavailable
+		},
+		UpdateUserFunc: func(ctx context.Context, id string, update legocharmclient.UserUpdateData) (*legocharmclient.UserData, error) {
+			return nil, unavailable
+		},
+		DeleteUserByIdFunc: func(ctx context.Context, id string) (*http.Response, error) {
+			return nil, unavailable
+		},
+	}
+	r.client = api
+
+	data := importedUserModel()
+	data.Username = types.StringValue("alice")
+	data.Password = types.StringValue("n3w-passw0rd")
+	data.Email = types.StringValue("alice@example.com")
+	data.IsStaff = types.BoolValue(false)
+	data.IsSuperuser = types.BoolValue(false)
+	data.IsActive = types.BoolValue(true)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, createResp)
+	require.True(t, createResp.Diagnostics.HasError())
+	require.Contains(t, createResp.Diagnostics.Errors()[0].Detail(), "Unable to create user")
+
+	data.Id = types.StringValue("1004")
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	// Transient errors on read keep the resource in state.
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+	require.True(t, readResp.Diagnostics.HasError())
+	require.Contains(t, readResp.Diagnostics.Errors()[0].Detail(), "Unable to read user")
+	require.False(t, readResp.State.Raw.IsNull())
+
+	data.IsStaff = types.BoolValue(true)
+	require.False(t, plan.Set(ctx, &data).HasError())
+	updateResp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, updateResp)
+	require.True(t, updateResp.Diagnostics.HasError())
+	require.Contains(t, updateResp.Diagnostics.Errors()[0].Detail(), "Unable to update user")
+	require.False(t, updateResp.State.Raw.IsNull())
+
+	deleteResp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, deleteResp)
+	require.True(t, deleteResp.Diagnostics.HasError())
+	require.Contains(t, deleteResp.Diagnostics.Errors()[0].Detail(), "Unable to delete user")
+
+	// A user deleted outside Terraform is removed from state, on read as on
+	// update.
+	api.GetUserByIdFunc = func(ctx context.Context, userId string) (*legocharmclient.UserData, error) {
+		return nil, legocharmclient.ErrNotFound
+	}
+	api.UpdateUserFunc = func(ctx context.Context, id string, update legocharmclient.UserUpdateData) (*legocharmclient.UserData, error) {
+		return nil, legocharmclient.ErrNotFound
+	}
+	goneResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, goneResp)
+	require.False(t, goneResp.Diagnostics.HasError(), goneResp.Diagnostics)
+	require.True(t, goneResp.State.Raw.IsNull())
+
+	goneUpdateResp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, goneUpdateResp)
+	require.False(t, goneUpdateResp.Diagnostics.HasError(), goneUpdateResp.Diagnostics)
+	require.True(t, goneUpdateResp.State.Raw.IsNull())
+}
+
+func TestUserResource_Update_SettingsOnly(t *testing.T) {
+	ctx := context.Background()
+	api := &legocharmclienttest.APIMock{}
+	r := &UserResource{client: api}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := importedUserModel()
+	data.Username = types.StringValue("alice")
+	data.Password = types.StringValue("n3w-passw0rd")
+	data.PasswordVersion = types.StringValue("1")
+	data.Email = types.StringValue("alice@example.com")
+	data.IsStaff = types.BoolValue(false)
+	data.IsSuperuser = types.BoolValue(false)
+	data.IsActive = types.BoolValue(true)
+	data.Groups = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("dns-admins")})
+	data.DateJoined = types.StringValue("2026-01-02T03:04:05Z")
+	data.LastLogin = types.StringValue("2026-02-03T04:05:06Z")
+	data.Id = types.StringValue("1004")
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	// The configured password is kept across a password_version change.
+	data.DeletionProtection = types.BoolValue(true)
+	data.CascadeDelete = types.BoolValue(true)
+	data.PasswordVersion = types.StringValue("2")
+	data.LastLogin = types.StringUnknown()
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &data).HasError())
+
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Empty(t, api.UpdateUserCalls())
+
+	var got UserModel
+	require.False(t, resp.State.Get(ctx, &got).HasError())
+	require.True(t, got.DeletionProtection.ValueBool())
+	require.Equal(t, "2", got.PasswordVersion.ValueString())
+	require.Equal(t, "n3w-passw0rd", got.Password.ValueString())
+	require.Equal(t, "2026-02-03T04:05:06Z", got.LastLogin.ValueString())
+	require.Equal(t, "1004", got.Id.ValueString())
+}
+
+func TestUserResource_ModifyPlan_InvalidatedPassword(t *testing.T) {
+	r := &UserResource{}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// State as left by Read after the stored password stopped working.
+	data := importedUserModel()
+	data.Username = types.StringValue("alice")
+	data.Id = types.StringValue("1004")
+	data.Email = types.StringValue("")
+	data.IsStaff = types.BoolValue(false)
+	data.IsSuperuser = types.BoolValue(false)
+	data.IsActive = types.BoolValue(true)
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &data).HasError())
+
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		State:  state,
+		Plan:   tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+		Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+	}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	require.Equal(t, "User Password Will Be Reset", resp.Diagnostics.Warnings()[0].Summary())
+
+	var planned UserModel
+	require.False(t, resp.Plan.Get(ctx, &planned).HasError())
+	require.True(t, planned.Password.IsUnknown(), "generated password should be regenerated")
+}
+
+func TestAccUserResource(t *testing.T) {
+	env := testAccPreCheck(t)
+	username := testAccName(t)
+	ctx := context.Background()
+
+	user := func(email string, active bool) resourcetest.TestCheckFunc {
+		return resourcetest.TestCheckResourceAttrWith("legocharm_user.test", "id", func(id string) error {
+			user, err := env.API.GetUserByUsername(ctx, username)
+			switch {
+			case err != nil:
+				return err
+			case legocharmclient.LastPathSegment(user.Url) != id:
+				return fmt.Errorf("id is %s, but user %s has ID %s", id, username, legocharmclient.LastPathSegment(user.Url))
+			case user.Email != email || user.IsActive != active:
+				return fmt.Errorf("user %s has email %q and is_active %v, want %q and %v", username, user.Email, user.IsActive, email, active)
+			}
+			return nil
+		})
+	}
+	config := func(email string, active bool, passwordVersion string) string {
+		return fmt.Sprintf(`
+resource "legocharm_user" "test" {
+  username         = %q
+  email            = %q
+  is_active        = %t
+  password_version = %q
+}
+`, username, email, active, passwordVersion)
+	}
+	email := "new-" + username + "@example.com"
+
+	var id string
+	resourcetest.Test(t, resourcetest.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resourcetest.TestStep{
+			{
+				Config: config(username+"@example.com", true, "1"),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					resourcetest.TestCheckResourceAttrWith("legocharm_user.test", "password", func(password string) error {
+						if len(password) != defaultPasswordLength {
+							return fmt.Errorf("password has %d characters, want %d", len(password), defaultPasswordLength)
+						}
+						return nil
+					}),
+					user(username+"@example.com", true),
+					testAccCheckPassword(env, "legocharm_user.test", username),
+				),
+			},
+			{
+				Config: config(email, false, "1"),
+				Check: resourcetest.ComposeAggregateTestCheckFunc(
+					user(email, false),
+					testAccStoreAttr("legocharm_user.test", "id", &id),
+				),
+			},
+			{
+				ResourceName: "legocharm_user.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return username + ":" + s.RootModule().Resources["legocharm_user.test"].Primary.Attributes["password"], nil
+				},
+				ImportStateVerify: true,
+				// password_version only exists in the configuration.
+				ImportStateVerifyIgnore: []string{"password_version"},
+			},
+			// An email change outside Terraform is detected, and since email
+			// requires replacement, the user is recreated with the configured
+			// one.
+			{
+				PreConfig: func() {
+					drift := "drift@example.com"
+					_, err := env.API.UpdateUser(ctx, id, legocharmclient.UserUpdateData{Email: &drift})
+					require.NoError(t, err)
+				},
+				Config: config(email, false, "1"),
+				ConfigPlanChecks: resourcetest.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("legocharm_user.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: user(email, false),
+			},
+			// Changing password_version rotates the generated password.
+			{
+				Config: config(email, true, "2"),
+				Check:  testAccCheckPassword(env, "legocharm_user.test", username),
+			},
+		},
+		CheckDestroy: testAccCheckUserDestroyed(env, username),
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &UserResource{}
+
+// userSchemaVersion is the current schema version of legocharm_user. Bump it
+// and add an entry to UpgradeState whenever existing state needs migrating.
+const userSchemaVersion = 1
+
+// userModelV0 maps the original legocharm_user schema, before the account
+// flags and password management attributes were added.
+type userModelV0 struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Email    types.String `tfsdk:"email"`
+	Id       types.String `tfsdk:"id"`
+}
+
+// UpgradeState migrates prior legocharm_user state to the current schema.
+func (r *UserResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"username": schema.StringAttribute{Required: true},
+					"password": schema.StringAttribute{Required: true, Sensitive: true},
+					"email":    schema.StringAttribute{Optional: true, Computed: true},
+					"id":       schema.StringAttribute{Computed: true},
+				},
+			},
+			StateUpgrader: upgradeUserStateV0,
+		},
+	}
+}
+
+// upgradeUserStateV0 carries the original attributes over and sets the
+// provider-only settings introduced since to their defaults, so that the first
+// plan after upgrading does not show spurious changes. Attributes read from
+// the API are left null for the next refresh to fill in.
+func upgradeUserStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior userModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := importedUserModel()
+	data.Username = prior.Username
+	data.Password = prior.Password
+	data.Email = prior.Email
+	data.Id = prior.Id
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserResource_SchemaVersion(t *testing.T) {
+	r := &UserResource{}
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	require.Equal(t, int64(userSchemaVersion), resp.Schema.Version)
+
+	// Every prior version must have an upgrader.
+	upgraders := r.UpgradeState(context.Background())
+	for v := int64(0); v < userSchemaVersion; v++ {
+		require.Contains(t, upgraders, v)
+	}
+}
+
+func TestUserResource_UpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &UserResource{}
+
+	upgrader := r.UpgradeState(ctx)[0]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	prior := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw: tftypes.NewValue(priorType, map[string]tftypes.Value{
+			"username": tftypes.NewValue(tftypes.String, "alice"),
+			"password": tftypes.NewValue(tftypes.String, "s3cr3t-pass"),
+			"email":    tftypes.NewValue(tftypes.String, "alice@example.com"),
+			"id":       tftypes.NewValue(tftypes.String, "1004"),
+		}),
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var data UserModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	require.Equal(t, "alice", data.Username.ValueString())
+	require.Equal(t, "s3cr3t-pass", data.Password.ValueString())
+	require.Equal(t, "alice@example.com", data.Email.ValueString())
+	require.Equal(t, "1004", data.Id.ValueString())
+	require.True(t, data.ValidatePassword.ValueBool())
+	require.Equal(t, int64(defaultPasswordLength), data.PasswordLength.ValueInt64())
+	require.True(t, data.IsActive.IsNull())
+}
@@ -0,0 +1,399 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+var _ validator.Int64 = int64AtLeastValidator{}
+
+// int64AtLeastValidator validates that an integer attribute is at least min.
+type int64AtLeastValidator struct {
+	min int64
+}
+
+// int64AtLeast returns a validator which ensures the configured value is
+// greater than or equal to min. Null and unknown values are skipped.
+func int64AtLeast(min int64) validator.Int64 {
+	return int64AtLeastValidator{min: min}
+}
+
+func (v int64AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64AtLeastValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt64() < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), req.ConfigValue.ValueInt64()),
+		)
+	}
+}
+
+var _ validator.String = stringLengthBetweenValidator{}
+
+// stringLengthBetweenValidator validates that a string attribute's length, in
+// characters, is within [min, max]. A max of zero means no upper bound.
+type stringLengthBetweenValidator struct {
+	min, max int
+}
+
+// stringLengthBetween returns a validator which ensures the configured string
+// is between min and max characters long. Null and unknown values are skipped.
+func stringLengthBetween(min, max int) validator.String {
+	return stringLengthBetweenValidator{min: min, max: max}
+}
+
+// stringLengthAtLeast returns a validator which ensures the configured string
+// is at least min characters long. Null and unknown values are skipped.
+func stringLengthAtLeast(min int) validator.String {
+	return stringLengthBetweenValidator{min: min}
+}
+
+func (v stringLengthBetweenValidator) Description(_ context.Context) string {
+	if v.max == 0 {
+		return fmt.Sprintf("string length must be at least %d", v.min)
+	}
+	return fmt.Sprintf("string length must be between %d and %d", v.min, v.max)
+}
+
+func (v stringLengthBetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringLengthBetweenValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	length := utf8.RuneCountInString(req.ConfigValue.ValueString())
+	if length < v.min || (v.max > 0 && length > v.max) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value Length",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), length),
+		)
+	}
+}
+
+var _ validator.String = stringRegexValidator{}
+
+// stringRegexValidator validates that a string attribute matches a regular
+// expression.
+type stringRegexValidator struct {
+	regexp  *regexp.Regexp
+	message string
+}
+
+// stringMatches returns a validator which ensures the configured string
+// matches re. message describes the expected format to the user. Null and
+// unknown values are skipped.
+func stringMatches(re *regexp.Regexp, message string) validator.String {
+	return stringRegexValidator{regexp: re, message: message}
+}
+
+func (v stringRegexValidator) Description(_ context.Context) string {
+	return v.message
+}
+
+func (v stringRegexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringRegexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !v.regexp.MatchString(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value Match",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+var _ validator.String = emailAddressValidator{}
+
+// emailAddressValidator validates that a string attribute is a bare RFC 5322
+// email address. Empty strings are accepted so the attribute can be cleared.
+type emailAddressValidator struct{}
+
+// emailAddress returns a validator which ensures the configured string is an
+// email address without a display name. Null, unknown and empty values are
+// skipped.
+func emailAddress() validator.String {
+	return emailAddressValidator{}
+}
+
+func (v emailAddressValidator) Description(_ context.Context) string {
+	return "value must be a valid email address"
+}
+
+func (v emailAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v emailAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() == "" {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Name != "" || addr.Address != value {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Email Address",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that a string attribute is a positive Go
+// duration such as "30s" or "2h45m", or zero if allowZero is set.
+type durationValidator struct {
+	allowZero bool
+}
+
+// duration returns a validator which ensures the configured string parses
+// with time.ParseDuration and is positive. Null and unknown values are
+// skipped.
+func duration() validator.String {
+	return durationValidator{}
+}
+
+// durationOrZero returns a validator like duration which also accepts a zero
+// duration such as "0s".
+func durationOrZero() validator.String {
+	return durationValidator{allowZero: true}
+}
+
+func (v durationValidator) Description(_ context.Context) string {
+	if v.allowZero {
+		return "value must be a duration such as \"30s\" or \"2h45m\", or \"0s\""
+	}
+	return "value must be a positive duration such as \"30s\" or \"2h45m\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d < 0 || (d == 0 && !v.allowZero) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+var _ validator.String = fqdnValidator{}
+
+// fqdnLabelRegexp matches a single DNS label: letters, digits and hyphens,
+// not starting or ending with a hyphen.
+var fqdnLabelRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// fqdnValidator validates that a string attribute is a fully qualified
+// domain name, optionally with a wildcard as its leftmost label.
+type fqdnValidator struct{}
+
+// fqdn returns a validator which ensures the configured string is a domain
+// name such as "example.com" or "*.example.com". The wildcard may only be
+// the entire leftmost label and must be followed by at least two labels.
+// Case and a trailing dot are ignored. Null and unknown values are skipped.
+func fqdn() validator.String {
+	return fqdnValidator{}
+}
+
+func (v fqdnValidator) Description(_ context.Context) string {
+	return "value must be a domain name such as \"example.com\", optionally with a leading \"*.\" wildcard label"
+}
+
+func (v fqdnValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v fqdnValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if problem := fqdnProblem(req.ConfigValue.ValueString()); problem != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Domain Name",
+			fmt.Sprintf("Attribute %s %s, got: %s (%s)", req.Path, v.Description(ctx), req.ConfigValue.ValueString(), problem),
+		)
+	}
+}
+
+// fqdnProblem describes why value is not an acceptable domain name, or
+// returns an empty string if it is.
+func fqdnProblem(value string) string {
+	name := legocharmclient.NormalizeFQDN(value)
+	if name == "" {
+		return "domain name is empty"
+	}
+	if len(name) > 253 {
+		return "domain name is longer than 253 characters"
+	}
+
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if label == "*" {
+			if i != 0 {
+				return "a wildcard may only be the leftmost label"
+			}
+			if len(labels) < 3 {
+				return "a wildcard must be followed by at least two labels"
+			}
+			continue
+		}
+		if strings.Contains(label, "*") {
+			return "a wildcard must be an entire label"
+		}
+		if label == "" {
+			return "domain name contains an empty label"
+		}
+		if !fqdnLabelRegexp.MatchString(label) {
+			return fmt.Sprintf("label %q is not a valid DNS label", label)
+		}
+	}
+	if len(labels) < 2 {
+		return "domain name must have at least two labels"
+	}
+	return ""
+}
+
+// maxUsernameLength and usernameRegexp mirror the constraints of Django's
+// default User.username field and UnicodeUsernameValidator.
+const maxUsernameLength = 150
+
+var usernameRegexp = regexp.MustCompile(`^[\p{L}\p{N}_.@+-]+$`)
+
+var _ validator.String = usernameValidator{}
+
+// usernameValidator validates that a string attribute is a username the API
+// accepts.
+type usernameValidator struct{}
+
+// username returns a validator which ensures the configured string is 1 to
+// 150 characters long and made of letters, digits and @/./+/-/_ characters
+// only, as the API requires of usernames. Null and unknown values are
+// skipped.
+func username() validator.String {
+	return usernameValidator{}
+}
+
+func (v usernameValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be 1 to %d characters long and contain only letters, digits and @/./+/-/_ characters", maxUsernameLength)
+}
+
+func (v usernameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v usernameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if length := utf8.RuneCountInString(value); length > maxUsernameLength || !usernameRegexp.MatchString(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Username",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+var _ resource.ConfigValidator = domainAccessLevelValidator{}
+
+// domainAccessLevelValidator warns about domain and access level
+// combinations which the API rejects or grants more narrowly or broadly than
+// it looks.
+type domainAccessLevelValidator struct {
+	domain, accessLevel path.Path
+}
+
+// domainAccessLevel returns a resource validator which checks the domain at
+// the domain path against the access level at the accessLevel path. Null and
+// unknown values are skipped.
+func domainAccessLevel(domain, accessLevel path.Path) resource.ConfigValidator {
+	return domainAccessLevelValidator{domain: domain, accessLevel: accessLevel}
+}
+
+func (v domainAccessLevelValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("%s and %s must describe a sensible grant", v.domain, v.accessLevel)
+}
+
+func (v domainAccessLevelValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v domainAccessLevelValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var domain, accessLevel types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.domain, &domain)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.accessLevel, &accessLevel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if domain.IsNull() || domain.IsUnknown() || accessLevel.IsNull() || accessLevel.IsUnknown() {
+		return
+	}
+
+	if problem := accessLevelProblem(domain.ValueString(), accessLevel.ValueString()); problem != "" {
+		resp.Diagnostics.AddAttributeWarning(v.accessLevel, "Suspicious Access Level", problem)
+	}
+}
+
+// accessLevelProblem describes why granting level on domain is unlikely to do
+// what was intended, or returns an empty string if the combination is fine.
+func accessLevelProblem(domain, level string) string {
+	name := legocharmclient.NormalizeFQDN(domain)
+	labels := strings.Split(name, ".")
+	switch {
+	case labels[0] == "*" && level == "domain":
+		return fmt.Sprintf("%q is a wildcard, but access level \"domain\" only covers the name itself. Use \"subdomain\" to cover the names the wildcard matches.", name)
+	case labels[0] != "*" && len(labels) == 2 && level == "subdomain":
+		return fmt.Sprintf("%q is an apex domain, so access level \"subdomain\" grants every name in the zone. Grant a narrower domain or use \"domain\" if that is not intended.", name)
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func validateString(v validator.String, value types.String) bool {
+	resp := &validator.StringResponse{}
+	v.ValidateString(context.Background(), validator.StringRequest{
+		Path:        path.Root("test"),
+		ConfigValue: value,
+	}, resp)
+	return !resp.Diagnostics.HasError()
+}
+
+func TestUsernameValidator(t *testing.T) {
+	valid := []string{"alice", "alice.smith", "alice+acme@example.com", "svc-account_01", "jürgen"}
+	invalid := []string{"", "alice smith", "alice/smith", "alice:smith", strings.Repeat("a", maxUsernameLength+1)}
+
+	check := func(value string) bool {
+		return validateString(username(), types.StringValue(value))
+	}
+
+	for _, username := range valid {
+		require.True(t, check(username), "username %q should be valid", username)
+	}
+	for _, username := range invalid {
+		require.False(t, check(username), "username %q should be invalid", username)
+	}
+}
+
+func TestEmailAddressValidator(t *testing.T) {
+	for _, email := range []string{"", "test@example.com", "first.last+tag@sub.example.org"} {
+		require.True(t, validateString(emailAddress(), types.StringValue(email)), "email %q should be valid", email)
+	}
+	for _, email := range []string{"test", "test@", "Test <test@example.com>", " test@example.com"} {
+		require.False(t, validateString(emailAddress(), types.StringValue(email)), "email %q should be invalid", email)
+	}
+}
+
+func TestStringLengthAtLeast(t *testing.T) {
+	v := stringLengthAtLeast(minPasswordLength)
+	require.False(t, validateString(v, types.StringValue("short")))
+	require.True(t, validateString(v, types.StringValue("long-enough")))
+	require.True(t, validateString(v, types.StringNull()))
+	require.True(t, validateString(v, types.StringUnknown()))
+}
+
+func TestFQDNValidator(t *testing.T) {
+	valid := []string{"example.com", "Example.COM.", "staging.example.com", "*.example.com", "*.staging.example.com", "xn--bcher-kva.example", "a-b.example.com"}
+	invalid := []string{"", "com", "*.com", "*", "foo.*.example.com", "*foo.example.com", "f*.example.com", "example..com", "-foo.example.com", "foo-.example.com", "foo_bar.example.com", "exa mple.com"}
+
+	for _, name := range valid {
+		require.True(t, validateString(fqdn(), types.StringValue(name)), "fqdn %q should be valid", name)
+	}
+	for _, name := range invalid {
+		require.False(t, validateString(fqdn(), types.StringValue(name)), "fqdn %q should be invalid", name)
+	}
+	require.True(t, validateString(fqdn(), types.StringUnknown()))
+}
+
+func TestUserIDValidator(t *testing.T) {
+	v := stringMatches(userIDRegexp, "value must be a positive integer")
+	for _, id := range []string{"1", "1004", "9000000000"} {
+		require.True(t, validateString(v, types.StringValue(id)), "user_id %q should be valid", id)
+	}
+	for _, id := range []string{"", "0", "-1", "01", "1.5", "alice", " 1004", "1004 "} {
+		require.False(t, validateString(v, types.StringValue(id)), "user_id %q should be invalid", id)
+	}
+	require.True(t, validateString(v, types.StringUnknown()))
+}
+
+func TestDurationValidators(t *testing.T) {
+	for value, want := range map[string]bool{"30s": true, "2h45m": true, "0s": false, "-1m": false, "soon": false} {
+		require.Equal(t, want, validateString(duration(), types.StringValue(value)), "duration() with %q", value)
+	}
+	for value, want := range map[string]bool{"30s": true, "0s": true, "0": true, "-1m": false, "soon": false} {
+		require.Equal(t, want, validateString(durationOrZero(), types.StringValue(value)), "durationOrZero() with %q", value)
+	}
+}
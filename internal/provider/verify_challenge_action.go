@@ -0,0 +1,140 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// defaultVerifyPropagationTimeout is how long legocharm_verify_challenge
+// waits for the record to be visible in DNS when no timeout is configured.
+const defaultVerifyPropagationTimeout = 2 * time.Minute
+
+var _ action.ActionWithConfigure = &VerifyChallengeAction{}
+
+// NewVerifyChallengeAction creates a new verify challenge action.
+func NewVerifyChallengeAction() action.Action { return &VerifyChallengeAction{} }
+
+// VerifyChallengeAction is the action implementation for an end-to-end
+// DNS-01 challenge round-trip through the charm that fails on any error.
+type VerifyChallengeAction struct {
+	client legocharmclient.API
+}
+
+// VerifyChallengeActionModel maps Terraform schema to Go types for the
+// verify challenge action.
+type VerifyChallengeActionModel struct {
+	Domain             types.String `tfsdk:"domain"`
+	Username           types.String `tfsdk:"username"`
+	PasswordWO         types.String `tfsdk:"password_wo"`
+	PropagationTimeout types.String `tfsdk:"propagation_timeout"`
+	Nameserver         types.String `tfsdk:"nameserver"`
+}
+
+func (a *VerifyChallengeAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_verify_challenge"
+}
+
+func (a *VerifyChallengeAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Performs a DNS-01 challenge round-trip with the given credentials, like the `legocharm_challenge_test` data source, and fails if any step fails: a random TXT record is presented through the charm, waited for in DNS and cleaned up again. Use it to check the ACME pipeline after upgrading the charm, with `terraform apply -invoke` or from a lifecycle `action_trigger`. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Domain to test, such as `example.com`. A wildcard such as `*.example.com` uses the record of the domain it covers.",
+				Required:            true,
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the ACME identity to test.",
+				Required:            true,
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "Password of the ACME identity to test.",
+				Required:            true,
+				WriteOnly:           true,
+			},
+			"propagation_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How long to wait for the record to be visible in DNS, as a duration such as `5m`. `0s` skips the DNS check, so only the charm is tested. Defaults to `%s`.", defaultVerifyPropagationTimeout),
+				Optional:            true,
+				Validators: []validator.String{
+					duration(),
+				},
+			},
+			"nameserver": schema.StringAttribute{
+				MarkdownDescription: "Nameserver to query for propagation, as `host:port`, such as an authoritative server of the zone. Defaults to the system resolver.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *VerifyChallengeAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(legocharmclient.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected legocharmclient.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+func (a *VerifyChallengeAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data VerifyChallengeActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if a.client == nil {
+		resp.Diagnostics.AddError("Client Not Configured", "The LegoCharm API client is not configured for this action")
+		return
+	}
+
+	// The round-trip is made as the identity under test, not as the
+	// provider's account.
+	address := a.client.Endpoint()
+	username, password := data.Username.ValueString(), data.PasswordWO.ValueString()
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Credentials", fmt.Sprintf("Unable to create client for %s: %s", username, err))
+		return
+	}
+
+	value, err := generatePassword(32, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Token Generation Failed", err.Error())
+		return
+	}
+
+	timeout := defaultVerifyPropagationTimeout
+	if !data.PropagationTimeout.IsNull() {
+		timeout, _ = time.ParseDuration(data.PropagationTimeout.ValueString())
+	}
+
+	record := legocharmclient.TXTRecordData{Fqdn: challengeFQDN(data.Domain.ValueString()), Value: value}
+	_, err = challengeRoundTrip(ctx, client, record, timeout, data.Nameserver.ValueString(), func(message string) {
+		resp.SendProgress(action.InvokeProgressEvent{Message: message})
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Challenge Verification Failed", fmt.Sprintf("The DNS-01 challenge for %s as %s failed: %s", data.Domain.ValueString(), username, err))
+	}
+}
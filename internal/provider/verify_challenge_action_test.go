@@ -0,0 +1,107 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+func TestVerifyChallengeAction_Invoke(t *testing.T) {
+	records := map[string]string{}
+	failCleanup := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, _ := r.BasicAuth(); username != "ci-bot" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var record legocharmclient.TXTRecordData
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+		switch r.URL.Path {
+		case "/present":
+			records[record.Fqdn] = record.Value
+		case "/cleanup":
+			if failCleanup {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			delete(records, record.Fqdn)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	defer func(orig func(context.Context, string, string) ([]string, error)) { lookupTXT = orig }(lookupTXT)
+	lookupTXT = func(ctx context.Context, nameserver, name string) ([]string, error) {
+		if value, ok := records[name]; ok {
+			return []string{value}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	address, username, password := srv.URL, "admin", "admin"
+	client, err := legocharmclient.NewClient(&address, &username, &password)
+	require.NoError(t, err)
+	a := &VerifyChallengeAction{client: client}
+
+	ctx := context.Background()
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, schemaResp)
+
+	invoke := func(data VerifyChallengeActionModel) (*action.InvokeResponse, []string) {
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, config.Set(ctx, &data).HasError())
+
+		var messages []string
+		resp := &action.InvokeResponse{SendProgress: func(event action.InvokeProgressEvent) {
+			messages = append(messages, event.Message)
+		}}
+		a.Invoke(ctx, action.InvokeRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+		return resp, messages
+	}
+	config := VerifyChallengeActionModel{
+		Domain:             types.StringValue("example.com"),
+		Username:           types.StringValue("ci-bot"),
+		PasswordWO:         types.StringValue("secret"),
+		PropagationTimeout: types.StringNull(),
+		Nameserver:         types.StringNull(),
+	}
+
+	resp, messages := invoke(config)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Len(t, messages, 3)
+	require.Equal(t, `Presented TXT record "_acme-challenge.example.com.".`, messages[0])
+	require.Contains(t, messages[1], "visible in DNS")
+	require.Equal(t, `Cleaned up TXT record "_acme-challenge.example.com.".`, messages[2])
+	require.Empty(t, records)
+
+	// Skipping the DNS check only exercises the charm.
+	config.PropagationTimeout = types.StringValue("0s")
+	resp, messages = invoke(config)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.Len(t, messages, 2)
+
+	failCleanup = true
+	resp, _ = invoke(config)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Challenge Verification Failed", resp.Diagnostics.Errors()[0].Summary())
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "cleanup failed")
+
+	config.PasswordWO = types.StringValue("wrong")
+	resp, messages = invoke(config)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "present failed")
+	require.Empty(t, messages)
+}
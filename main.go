@@ -8,11 +8,18 @@ import (
 	"flag"
 	"log"
 
-	"terraform-provider-legocharm/internal/provider"
+	"github.com/alexdlukens/terraform-provider-legocharm/internal/provider"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
 )
 
+// address is the provider's registry address. In debug mode Terraform only
+// uses the running provider for this address, so it must match the source
+// of the provider in the configuration being debugged.
+const address = "registry.terraform.io/alexdlukens/legocharm"
+
 var (
 	// these will be set by the goreleaser configuration
 	// to appropriate values for the compiled binary.
@@ -28,17 +35,24 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		// NOTE: This is not a typical Terraform Registry provider address,
-		// such as registry.terraform.io/hashicorp/hashicups. This specific
-		// provider address is used in these tutorials in conjunction with a
-		// specific Terraform CLI configuration for manual development testing
-		// of this provider.
-		Address: "hashicorp.com/edu/legocharm",
-		Debug:   debug,
+	var opts []tf6server.ServeOpt
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
+	}
+
+	// The provider is served through tf6server rather than providerserver
+	// so that API deprecation warnings can be added to the responses.
+	server, err := provider.NewProtocol6Server(version)()
+	if err != nil {
+		log.Fatal(err.Error())
 	}
+	err = tf6server.Serve(address, func() tfprotov6.ProviderServer { return server }, opts...)
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	// The summary of API calls is logged when Terraform stops the provider,
+	// while it still collects the provider's logs. Serve returns once
+	// Terraform is done with the provider, when it may no longer collect
+	// them; log the summary then only if it was not stopped.
+	provider.LogAPICallSummary(tfsdklog.NewRootProviderLogger(context.Background(), tfsdklog.WithLevelFromEnv("TF_LOG_PROVIDER")), server)
 
 	if err != nil {
 		log.Fatal(err.Error())
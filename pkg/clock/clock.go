@@ -0,0 +1,141 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+// Package clock abstracts the passage of time, so that polling and timeout
+// logic can be tested without waiting for it.
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the time, sleeps and expires contexts.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+	// WithTimeout is context.WithTimeout measured on this clock.
+	WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc)
+}
+
+// Real is the wall clock.
+type Real struct{}
+
+func (Real) Now() time.Time                         { return time.Now() }
+func (Real) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (Real) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
+
+// Fake is a Clock that only moves when told to. Sleeping on it with After
+// advances it by the duration slept and returns at once, so code that polls
+// with backoff runs through its whole schedule instantly and
+// deterministically. Contexts from WithTimeout expire, with
+// context.DeadlineExceeded, as soon as the clock reaches their deadline.
+type Fake struct {
+	mu        sync.Mutex
+	now       time.Time
+	deadlines []*fakeDeadline
+	slept     []time.Duration
+}
+
+// NewFake returns a Fake clock set to now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	f.slept = append(f.slept, d)
+	f.mu.Unlock()
+	now := f.Advance(d)
+	ch := make(chan time.Time, 1)
+	ch <- now
+	return ch
+}
+
+// Slept returns the durations passed to After so far.
+func (f *Fake) Slept() []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Duration(nil), f.slept...)
+}
+
+// Advance moves the clock forward by d, expiring the contexts whose deadline
+// it reaches, and returns the new time.
+func (f *Fake) Advance(d time.Duration) time.Time {
+	f.mu.Lock()
+	f.now = f.now.Add(d)
+	now := f.now
+	var expired []*fakeDeadline
+	pending := f.deadlines[:0]
+	for _, dl := range f.deadlines {
+		if !now.Before(dl.deadline) {
+			expired = append(expired, dl)
+		} else {
+			pending = append(pending, dl)
+		}
+	}
+	f.deadlines = pending
+	f.mu.Unlock()
+
+	for _, dl := range expired {
+		dl.cancel(context.DeadlineExceeded)
+	}
+	return now
+}
+
+func (f *Fake) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	f.mu.Lock()
+	dl := &fakeDeadline{Context: ctx, deadline: f.now.Add(d), done: make(chan struct{})}
+	if d > 0 {
+		f.deadlines = append(f.deadlines, dl)
+	}
+	f.mu.Unlock()
+
+	if d <= 0 {
+		dl.cancel(context.DeadlineExceeded)
+	}
+	stop := context.AfterFunc(ctx, func() { dl.cancel(ctx.Err()) })
+	return dl, func() {
+		stop()
+		dl.cancel(context.Canceled)
+	}
+}
+
+// fakeDeadline is a context that expires on a Fake clock.
+type fakeDeadline struct {
+	context.Context
+	deadline time.Time
+
+	once sync.Once
+	mu   sync.Mutex
+	done chan struct{}
+	err  error
+}
+
+func (c *fakeDeadline) cancel(err error) {
+	c.once.Do(func() {
+		c.mu.Lock()
+		c.err = err
+		c.mu.Unlock()
+		close(c.done)
+	})
+}
+
+func (c *fakeDeadline) Deadline() (time.Time, bool) { return c.deadline, true }
+func (c *fakeDeadline) Done() <-chan struct{}       { return c.done }
+
+func (c *fakeDeadline) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
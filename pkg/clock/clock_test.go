@@ -0,0 +1,73 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFake_After(t *testing.T) {
+	f := NewFake(epoch)
+	got := <-f.After(time.Second)
+	if want := epoch.Add(time.Second); !got.Equal(want) || !f.Now().Equal(want) {
+		t.Fatalf("After(1s) fired at %s, clock at %s; want %s", got, f.Now(), want)
+	}
+	f.After(2 * time.Second)
+	if slept := f.Slept(); len(slept) != 2 || slept[0] != time.Second || slept[1] != 2*time.Second {
+		t.Fatalf("unexpected Slept() %v", slept)
+	}
+}
+
+func TestFake_WithTimeout(t *testing.T) {
+	f := NewFake(epoch)
+	ctx, cancel := f.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if deadline, ok := ctx.Deadline(); !ok || !deadline.Equal(epoch.Add(10*time.Second)) {
+		t.Fatalf("unexpected deadline %s, %t", deadline, ok)
+	}
+	f.Advance(9 * time.Second)
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("context expired early: %v", err)
+	}
+	f.Advance(time.Second)
+	select {
+	case <-ctx.Done():
+	default:
+		t.Fatal("context not done at its deadline")
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", ctx.Err())
+	}
+}
+
+func TestFake_WithTimeoutCanceled(t *testing.T) {
+	f := NewFake(epoch)
+
+	ctx, cancel := f.WithTimeout(context.Background(), time.Minute)
+	cancel()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Fatalf("expected Canceled after cancel, got %v", ctx.Err())
+	}
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel = f.WithTimeout(parent, time.Minute)
+	defer cancel()
+	cancelParent()
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Fatalf("expected Canceled after parent cancel, got %v", ctx.Err())
+	}
+
+	ctx, cancel = f.WithTimeout(context.Background(), 0)
+	defer cancel()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded for a zero timeout, got %v", ctx.Err())
+	}
+}
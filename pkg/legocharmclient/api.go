@@ -0,0 +1,65 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"net/http"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -rm -pkg legocharmclienttest -out legocharmclienttest/api_mock.go . API
+
+// API is the set of LegoCharm API operations the provider uses. *Client
+// implements it against a real server; legocharmclienttest.APIMock lets tests
+// stub individual calls.
+type API interface {
+	// Endpoint returns the base URL of the API.
+	Endpoint() string
+	// AuthenticatedUsername returns the username the client authenticates as.
+	AuthenticatedUsername() string
+	ChangePassword(ctx context.Context, password string) error
+
+	GetUserById(ctx context.Context, userId string) (*UserData, error)
+	GetUserByUsername(ctx context.Context, username string) (*UserData, error)
+	CreateUser(ctx context.Context, user UserCreateData) (*UserData, error)
+	UpdateUser(ctx context.Context, id string, update UserUpdateData) (*UserData, error)
+	ListUsers(ctx context.Context) ([]UserData, error)
+	AddUserToGroup(ctx context.Context, username, group string) error
+	RemoveUserFromGroup(ctx context.Context, username, group string) error
+	DeleteUserById(ctx context.Context, id string) (*http.Response, error)
+	HasValidUserPassword(ctx context.Context, username, password string) (bool, error)
+
+	GetDomainAccess(ctx context.Context, userId, domain string) (*DomainUserPermissionData, error)
+	ListDomainAccess(ctx context.Context, userId, domain string) ([]DomainUserPermissionData, error)
+	GetDomainAccessById(ctx context.Context, id int) (*DomainUserPermissionData, error)
+	ListDomainAccessByUsername(ctx context.Context, username string) ([]DomainUserPermissionData, error)
+	ListDomainAccessByFqdn(ctx context.Context, fqdn string) ([]DomainUserPermissionData, error)
+	ListAllDomainAccess(ctx context.Context) ([]DomainUserPermissionData, error)
+	CreateDomainAccess(ctx context.Context, access DomainUserPermissionCreateData) (*DomainUserPermissionData, error)
+	UpdateDomainAccess(ctx context.Context, id int, accessLevel string) (*DomainUserPermissionData, error)
+	DeleteDomainAccess(ctx context.Context, id int) (*http.Response, error)
+
+	GetDomain(ctx context.Context, fqdn string) (DomainData, error)
+	ListDomains(ctx context.Context) ([]DomainData, error)
+	GetDomainById(ctx context.Context, id int) (*DomainData, error)
+	CreateDomain(ctx context.Context, domain DomainData) (*DomainData, error)
+	UpdateDomain(ctx context.Context, id int, fqdn string) (*DomainData, error)
+	DeleteDomain(ctx context.Context, id int) error
+	DeleteDomainIfUnused(ctx context.Context, fqdn string) (bool, error)
+
+	PresentTXTRecord(ctx context.Context, record TXTRecordData) error
+	CleanupTXTRecord(ctx context.Context, record TXTRecordData) error
+}
+
+var _ API = (*Client)(nil)
+
+// Endpoint returns the base URL of the API.
+func (c *Client) Endpoint() string {
+	return c.BaseURL
+}
+
+// AuthenticatedUsername returns the username the client authenticates as.
+func (c *Client) AuthenticatedUsername() string {
+	return c.Username
+}
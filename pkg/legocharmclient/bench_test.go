@@ -0,0 +1,191 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// The benchmarks cover the calls made on every refresh. They answer from
+// memory through routeTransport, so the numbers reflect the client's own
+// request building and decoding rather than the network; BenchmarkHTTP
+// gives the cost of a round trip over loopback for comparison.
+//
+//	go test ./pkg/legocharmclient -run '^$' -bench . -benchmem
+
+// benchSizes are the numbers of objects in benchmarked list responses.
+var benchSizes = []int{1, 100, 10000}
+
+// routeTransport answers requests from memory by URL path.
+type routeTransport map[string][]byte
+
+func (rt routeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := rt[req.URL.Path]
+	status := http.StatusOK
+	if !ok {
+		status = http.StatusNotFound
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func benchClient(b *testing.B, routes routeTransport) *Client {
+	b.Helper()
+	client, err := NewClient(ptr("https://lego.example.com"), ptr("admin"), ptr("pass"))
+	if err != nil {
+		b.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.HTTPClient = &http.Client{Transport: routes}
+	return client
+}
+
+func benchJSON(b *testing.B, v any) []byte {
+	b.Helper()
+	body, err := json.Marshal(v)
+	if err != nil {
+		b.Fatalf("encoding response: %v", err)
+	}
+	return body
+}
+
+func benchUsers(n int) []UserData {
+	users := make([]UserData, n)
+	for i := range users {
+		users[i] = UserData{
+			Username:   fmt.Sprintf("user-%d", i),
+			Url:        fmt.Sprintf("https://lego.example.com/api/v1/users/%d/", i+1),
+			Email:      fmt.Sprintf("user-%d@example.com", i),
+			Groups:     []string{"certbots"},
+			IsActive:   true,
+			DateJoined: "2026-01-12T09:41:07.123456Z",
+		}
+	}
+	return users
+}
+
+func benchDomains(n int) []DomainData {
+	domains := make([]DomainData, n)
+	for i := range domains {
+		domains[i] = DomainData{ID: i + 1, Fqdn: fmt.Sprintf("host-%d.example.com", i)}
+	}
+	return domains
+}
+
+func benchAccesses(n int) []DomainUserPermissionData {
+	accesses := make([]DomainUserPermissionData, n)
+	for i := range accesses {
+		accesses[i] = DomainUserPermissionData{ID: i + 1, UserID: 1, Domain: i + 1, AccessLevel: "domain"}
+	}
+	return accesses
+}
+
+// BenchmarkGetUserByUsername looks up the last user of a list, as when the
+// server's filter is lax and returns every user.
+func BenchmarkGetUserByUsername(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("users=%d", n), func(b *testing.B) {
+			users := benchUsers(n)
+			client := benchClient(b, routeTransport{"/api/v1/users/": benchJSON(b, users)})
+			ctx := context.Background()
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := client.GetUserByUsername(ctx, users[n-1].Username); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkGetDomainAccess measures the three requests of a permission
+// refresh: the user, the domain and the matching permissions.
+func BenchmarkGetDomainAccess(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("permissions=%d", n), func(b *testing.B) {
+			user := benchUsers(1)[0]
+			domain := DomainData{ID: n, Fqdn: fmt.Sprintf("host-%d.example.com", n-1)}
+			client := benchClient(b, routeTransport{
+				"/api/v1/users/1/":                 benchJSON(b, user),
+				"/api/v1/domains/":                 benchJSON(b, []DomainData{domain}),
+				"/api/v1/domain-user-permissions/": benchJSON(b, benchAccesses(n)),
+			})
+			ctx := context.Background()
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := client.GetDomainAccess(ctx, "1", domain.Fqdn); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkListDecoding measures decoding of the list endpoints.
+func BenchmarkListDecoding(b *testing.B) {
+	for _, n := range benchSizes {
+		client := benchClient(b, routeTransport{
+			"/api/v1/users/":                   benchJSON(b, benchUsers(n)),
+			"/api/v1/domains/":                 benchJSON(b, benchDomains(n)),
+			"/api/v1/domain-user-permissions/": benchJSON(b, benchAccesses(n)),
+		})
+		ctx := context.Background()
+
+		b.Run(fmt.Sprintf("ListUsers/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := client.ListUsers(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("ListDomains/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := client.ListDomains(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("ListAllDomainAccess/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := client.ListAllDomainAccess(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkHTTP measures GetDomainById over a loopback HTTP server, the
+// floor that the in-memory benchmarks leave out.
+func BenchmarkHTTP(b *testing.B) {
+	body := benchJSON(b, DomainData{ID: 1, Fqdn: "example.com"})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body) // nolint:errcheck
+	}))
+	defer srv.Close()
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("pass"))
+	if err != nil {
+		b.Fatalf("unexpected error creating client: %v", err)
+	}
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.GetDomainById(ctx, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ReadCache keeps the answers of the API to GET requests for a while, so that
+// reading the same object again, such as the user of many grants refreshed by
+// Terraform, does not call the API each time. Concurrent reads of the same
+// object share one call, unless it was sent before a write. Any other request
+// empties the cache, since it may change what was read. It is safe for
+// concurrent use and may be shared by several clients.
+type ReadCache struct {
+	ttl time.Duration
+
+	mu sync.Mutex
+	// generation counts the writes, so that reads answered before a write
+	// completed are not kept after it.
+	generation int
+	entries    map[string]cachedResponse
+	calls      map[string]*cacheCall
+}
+
+// cachedResponse is a successful answer of the API to a GET request.
+type cachedResponse struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// cacheCall is a GET request in flight, which concurrent reads wait for.
+type cacheCall struct {
+	done chan struct{}
+	// generation is that of the cache when the request was sent. Reads after
+	// a later write do not wait for it, since it may answer with what was
+	// there before the write.
+	generation int
+	// ok is set if the request was answered successfully, with resp.
+	ok   bool
+	resp cachedResponse
+}
+
+// uncachedKey is the context key of WithoutReadCache.
+type uncachedKey struct{}
+
+// WithoutReadCache returns a context whose GET requests are sent to the API
+// even if a ReadCache holds their answer, for example to poll for an object
+// the API does not list yet. Their answers are still kept for other reads.
+func WithoutReadCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, uncachedKey{}, true)
+}
+
+// NewReadCache returns a cache keeping the answers for ttl.
+func NewReadCache(ttl time.Duration) *ReadCache {
+	return &ReadCache{
+		ttl:     ttl,
+		entries: make(map[string]cachedResponse),
+		calls:   make(map[string]*cacheCall),
+	}
+}
+
+// TTL returns how long the answers are kept.
+func (c *ReadCache) TTL() time.Duration {
+	return c.ttl
+}
+
+// response returns a response to req with the cached answer.
+func (r cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(r.status),
+		StatusCode:    r.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
+
+// invalidate empties the cache.
+func (c *ReadCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	clear(c.entries)
+}
+
+// do sends req with send, unless it is a GET request answered recently or
+// in flight, and empties the cache for other requests. Answers expire on the
+// clock of now.
+func (c *ReadCache) do(req *http.Request, now func() time.Time, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		defer c.invalidate()
+		return send(req)
+	}
+
+	// The answers depend on the permissions of the user.
+	username, _, _ := req.BasicAuth()
+	key := username + " " + req.URL.String()
+
+	c.mu.Lock()
+	if req.Context().Value(uncachedKey{}) != nil {
+		generation := c.generation
+		c.mu.Unlock()
+		resp, entry, err := c.fetch(req, now, send)
+		if entry != nil {
+			c.store(key, *entry, generation)
+		}
+		return resp, err
+	}
+	if entry, ok := c.entries[key]; ok {
+		if now().Before(entry.expires) {
+			c.mu.Unlock()
+			return entry.response(req), nil
+		}
+		delete(c.entries, key)
+	}
+	if call, ok := c.calls[key]; ok && call.generation == c.generation {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if call.ok {
+			return call.resp.response(req), nil
+		}
+		// Failed answers are not shared, each read gets its own.
+		return send(req)
+	}
+	generation := c.generation
+	call := &cacheCall{done: make(chan struct{}), generation: generation}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	resp, entry, err := c.fetch(req, now, send)
+	if entry != nil {
+		call.ok, call.resp = true, *entry
+		c.store(key, *entry, generation)
+	}
+	c.mu.Lock()
+	// A read after a write may have sent its own request meanwhile.
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return resp, err
+}
+
+// fetch sends req with send and returns, for a successful answer, the entry
+// keeping it from now on.
+func (c *ReadCache) fetch(req *http.Request, now func() time.Time, send func(*http.Request) (*http.Response, error)) (*http.Response, *cachedResponse, error) {
+	resp, err := send(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, &cachedResponse{status: resp.StatusCode, header: resp.Header.Clone(), body: body, expires: now().Add(c.ttl)}, nil
+}
+
+// store keeps entry for key, unless the cache was emptied since generation.
+func (c *ReadCache) store(key string, entry cachedResponse, generation int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation == c.generation {
+		c.entries[key] = entry
+	}
+}
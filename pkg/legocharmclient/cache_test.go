@@ -0,0 +1,199 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/clock"
+)
+
+// countingServer serves the domain 7, counting the requests by method, after
+// waiting for release if it is set.
+type countingServer struct {
+	mu      sync.Mutex
+	counts  map[string]int
+	release chan struct{}
+}
+
+func (s *countingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.release != nil {
+		<-s.release
+	}
+	s.mu.Lock()
+	s.counts[r.Method]++
+	s.mu.Unlock()
+	w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+}
+
+func (s *countingServer) count(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counts[method]
+}
+
+func cachingClient(t *testing.T, cache *ReadCache) (*Client, *countingServer) {
+	t.Helper()
+	server := &countingServer{counts: map[string]int{}}
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.ReadCache = cache
+	return client, server
+}
+
+func TestReadCache(t *testing.T) {
+	ctx := context.Background()
+	client, server := cachingClient(t, NewReadCache(30*time.Second))
+	fake := clock.NewFake(testStart)
+	client.Clock = fake
+
+	read := func(ctx context.Context) {
+		t.Helper()
+		domain, err := client.GetDomainById(ctx, 7)
+		if err != nil || domain.Fqdn != "example.com" {
+			t.Fatalf("GetDomainById() = %+v, %v", domain, err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		read(ctx)
+	}
+	if got := server.count("GET"); got != 1 {
+		t.Fatalf("the API was read %d times, want once", got)
+	}
+
+	read(WithoutReadCache(ctx))
+	if got := server.count("GET"); got != 2 {
+		t.Fatalf("the API was read %d times, want the uncached read to be sent", got)
+	}
+
+	if _, err := client.UpdateDomain(ctx, 7, "example.com"); err != nil {
+		t.Fatalf("UpdateDomain: %v", err)
+	}
+	read(ctx)
+	if got := server.count("GET"); got != 3 {
+		t.Fatalf("the API was read %d times, want the write to empty the cache", got)
+	}
+
+	fake.Advance(31 * time.Second)
+	read(ctx)
+	if got := server.count("GET"); got != 4 {
+		t.Fatalf("the API was read %d times, want the answer to expire", got)
+	}
+}
+
+func TestReadCache_Concurrent(t *testing.T) {
+	cache := NewReadCache(time.Minute)
+	client, server := cachingClient(t, cache)
+	server.release = make(chan struct{})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetDomainById(context.Background(), 7)
+			errs <- err
+		}()
+	}
+	// Let the reads queue up behind the first.
+	time.Sleep(20 * time.Millisecond)
+	close(server.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("GetDomainById: %v", err)
+		}
+	}
+	if got := server.count("GET"); got != 1 {
+		t.Fatalf("the API was read %d times, want the concurrent reads to share one call", got)
+	}
+}
+
+func TestReadCache_PerUser(t *testing.T) {
+	cache := NewReadCache(time.Minute)
+	client, server := cachingClient(t, cache)
+	other, err := NewClient(ptr(client.BaseURL), ptr("alice"), ptr("alice-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	other.ReadCache = cache
+
+	for _, c := range []*Client{client, other, client, other} {
+		if _, err := c.GetDomainById(context.Background(), 7); err != nil {
+			t.Fatalf("GetDomainById: %v", err)
+		}
+	}
+	if got := server.count("GET"); got != 2 {
+		t.Fatalf("the API was read %d times, want once per user", got)
+	}
+}
+
+func TestReadCache_WriteDuringRead(t *testing.T) {
+	cache := NewReadCache(time.Minute)
+	answer := func(body string) func(*http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+	}
+	read := func(send func(*http.Request) (*http.Response, error)) string {
+		resp, err := cache.do(httptest.NewRequest(http.MethodGet, "/api/v1/domains/7/", nil), time.Now, send)
+		if err != nil {
+			t.Errorf("GET: %v", err)
+			return ""
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	// A read is in flight when the domain is renamed.
+	sent, release := make(chan struct{}), make(chan struct{})
+	before := make(chan string)
+	go func() {
+		before <- read(func(req *http.Request) (*http.Response, error) {
+			close(sent)
+			<-release
+			return answer("old")(req)
+		})
+	}()
+	<-sent
+	if _, err := cache.do(httptest.NewRequest(http.MethodPatch, "/api/v1/domains/7/", nil), time.Now, answer("new")); err != nil {
+		t.Fatalf("PATCH: %v", err)
+	}
+
+	// A read after the write does not wait for the one sent before it.
+	after := make(chan string)
+	go func() { after <- read(answer("new")) }()
+	select {
+	case got := <-after:
+		if got != "new" {
+			t.Fatalf("read after the write = %q, want %q", got, "new")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the read after the write waits for the read sent before it")
+	}
+
+	close(release)
+	if got := <-before; got != "old" {
+		t.Fatalf("read before the write = %q, want %q", got, "old")
+	}
+	if got := read(answer("unexpected")); got != "new" {
+		t.Fatalf("cached read = %q, want the answer read after the write", got)
+	}
+	if len(cache.calls) != 0 {
+		t.Fatalf("%d calls still in flight", len(cache.calls))
+	}
+}
@@ -0,0 +1,1335 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/clock"
+)
+
+// NormalizeFQDN returns the canonical form of a domain name: lower case,
+// without surrounding whitespace or a trailing dot. The API compares FQDNs
+// literally, so every lookup goes through this.
+func NormalizeFQDN(fqdn string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(fqdn), "."))
+}
+
+// LastPathSegment returns the last non-empty segment of a URL path.
+func LastPathSegment(u string) string {
+	u = strings.TrimSuffix(u, "/")
+	parts := strings.Split(u, "/")
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[len(parts)-1]
+}
+
+// DefaultUserAgent is the User-Agent sent by clients that do not set one.
+const DefaultUserAgent = "terraform-provider-legocharm"
+
+// Client is a lightweight HTTP client for the LegoCharm API. It stores the
+// base URL and credentials and exposes helpers to build and dispatch requests.
+// All methods preserve the original API interactions while following Go conventions.
+type Client struct {
+	BaseURL string
+	// FailoverURLs are the base URLs of other endpoints of the API, such
+	// as the other ingress units of a highly available deployment, tried in
+	// order when the client cannot connect to BaseURL.
+	FailoverURLs []string
+	Username     string
+	Password     string
+	HTTPClient   *http.Client
+	// UserAgent is sent with every request, DefaultUserAgent if empty.
+	// Tools other than the provider should set their own.
+	UserAgent string
+	// Headers are added to every request, for example to identify the
+	// pipeline making it in the API's access logs.
+	Headers http.Header
+	// HTTPLogging is the detail of the API calls logged to LogSubsystem.
+	HTTPLogging HTTPLogging
+	// Transcript logs every request and response in full to LogSubsystem
+	// at DEBUG, with credentials and secret fields replaced, to troubleshoot
+	// incompatibilities with the API. It is HTTPLoggingBodies, whatever
+	// HTTPLogging is set to.
+	Transcript bool
+	// Stats, if set, counts the calls made by the client.
+	Stats *CallStats
+	// Deprecations, if set, collects the deprecations announced by the API
+	// for the endpoints the client calls.
+	Deprecations *DeprecationNotices
+	// MaintenanceWait is how long requests wait, in all, for the API to come
+	// back when it answers that it is in maintenance. With zero, they fail
+	// at once with ErrMaintenance.
+	MaintenanceWait time.Duration
+	// RequestLimit, if set, caps the number of requests in flight to the
+	// API, across the clients sharing it.
+	RequestLimit *RequestLimit
+	// ReadCache, if set, answers GET requests repeated while it keeps their
+	// answers, across the clients sharing it.
+	ReadCache *ReadCache
+	// Clock measures the waits for the API to come out of maintenance and
+	// how long ReadCache keeps answers. NewClient sets it to clock.Real.
+	Clock clock.Clock
+	// APIVersion is the version of the API requests are sent to,
+	// APIVersion1 if empty. NegotiateAPIVersion sets it to the newest
+	// version the API serves.
+	APIVersion APIVersion
+
+	// endpointMu guards endpoint, the index of the endpoint requests are
+	// sent to, BaseURL followed by FailoverURLs.
+	endpointMu sync.Mutex
+	endpoint   int
+
+	// maintenanceMu guards maintenanceUntil, the end of the current wait
+	// for the API to come out of maintenance, if any.
+	maintenanceMu    sync.Mutex
+	maintenanceUntil time.Time
+
+	// credentialsMu guards Password. Do holds it for reading
+	// for the duration of each request, so ChangePassword can wait for
+	// requests sent with the old password to finish before switching.
+	credentialsMu sync.RWMutex
+
+	// domainLocks serializes per FQDN the creation of domains with their
+	// first permission and the deletion of unused domains, so that parallel
+	// grants for the same new domain do not race to create it, and a domain
+	// is not deleted while a grant is being added to it.
+	domainLocks keyedMutex
+
+	// userLocks serializes read-modify-write updates of a user's groups per
+	// username, so that parallel membership changes do not overwrite each
+	// other.
+	userLocks keyedMutex
+}
+
+// NewClient constructs a new LegoCharm API client.
+// The provider code passes pointers to strings, so this function accepts
+// pointer arguments and validates them.
+func NewClient(address, username, password *string) (*Client, error) {
+	if address == nil || *address == "" {
+		return nil, errors.New("address is required")
+	}
+	if username == nil || *username == "" {
+		return nil, errors.New("username is required")
+	}
+	if password == nil || *password == "" {
+		return nil, errors.New("password is required")
+	}
+
+	baseURL, err := NormalizeAddress(*address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine HTTP client timeout from environment variable LEGOCHARM_API_TIMEOUT.
+	// Accepts either a duration string (e.g. "30s") or an integer number of seconds (e.g. "30").
+	// Defaults to 120 seconds when unset.
+	timeout := 120 * time.Second
+	if v := os.Getenv("LEGOCHARM_API_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			timeout = d
+		} else if s, err2 := strconv.Atoi(v); err2 == nil {
+			timeout = time.Duration(s) * time.Second
+		} else {
+			return nil, fmt.Errorf("invalid LEGOCHARM_API_TIMEOUT %q: %w", v, err)
+		}
+	}
+
+	return &Client{
+		BaseURL:    baseURL,
+		Username:   *username,
+		Password:   *password,
+		HTTPClient: &http.Client{Timeout: timeout},
+		Clock:      clock.Real{},
+	}, nil
+}
+
+func (c *Client) userAgent() string {
+	if c.UserAgent != "" {
+		return c.UserAgent
+	}
+	return DefaultUserAgent
+}
+
+// headersKey is the context key of the headers set by WithHeaders.
+type headersKey struct{}
+
+// WithHeaders returns a context whose requests carry headers, in addition to
+// the Headers of the client, for example to attribute the requests of one
+// operation to its caller.
+func WithHeaders(ctx context.Context, headers http.Header) context.Context {
+	return context.WithValue(ctx, headersKey{}, headers)
+}
+
+// setHeaders sets the User-Agent and Headers of the client, and the headers
+// of the request context, on req.
+func (c *Client) setHeaders(req *http.Request) {
+	ctxHeaders, _ := req.Context().Value(headersKey{}).(http.Header)
+	for _, headers := range []http.Header{c.Headers, ctxHeaders} {
+		for name, values := range headers {
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+	}
+	req.Header.Set("User-Agent", c.userAgent())
+}
+
+// NewRequest creates an HTTP request for the LegoCharm API, setting basic
+// authentication and reasonable default headers.
+func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	if c == nil {
+		return nil, errors.New("client is nil")
+	}
+
+	return c.adaptRequest(ctx, method, path, body)
+}
+
+// newRequest is NewRequest for a path already in the API version in use.
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	rel := strings.TrimLeft(path, "/")
+	full := c.BaseURL + "/" + rel
+	req, err := http.NewRequestWithContext(ctx, method, full, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use basic auth for now.
+	c.credentialsMu.RLock()
+	req.SetBasicAuth(c.Username, c.Password)
+	c.credentialsMu.RUnlock()
+	c.setHeaders(req)
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// Do sends the HTTP request using the client's underlying HTTP client.
+// Requests built by NewRequest before the password was changed are sent with
+// the current password.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c == nil {
+		return nil, errors.New("client is nil")
+	}
+
+	c.credentialsMu.RLock()
+	defer c.credentialsMu.RUnlock()
+	if username, _, ok := req.BasicAuth(); ok && username == c.Username {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	send := c.sendWaitingForMaintenance
+	if c.ReadCache != nil {
+		send = func(req *http.Request) (*http.Response, error) {
+			return c.ReadCache.do(req, c.now, c.sendWaitingForMaintenance)
+		}
+	}
+	resp, err := send(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.adaptResponse(req, resp)
+}
+
+// ChangePassword changes the password of the user the client authenticates
+// as and switches the client to the new password. Requests in flight finish
+// with the old password first, and requests issued meanwhile wait and are
+// sent with the new one.
+func (c *Client) ChangePassword(ctx context.Context, password string) error {
+	user, err := c.GetUserByUsername(ctx, c.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get user data: %w", err)
+	}
+
+	b, err := json.Marshal(UserUpdateData{Password: password})
+	if err != nil {
+		return fmt.Errorf("failed to marshal user data: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, "PATCH", "/api/v1/users/"+url.PathEscape(LastPathSegment(user.Url))+"/", bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.credentialsMu.Lock()
+	defer c.credentialsMu.Unlock()
+
+	// Do would wait for the lock held here.
+	req.SetBasicAuth(c.Username, c.Password)
+	resp, err := c.sendWaitingForMaintenance(req)
+	if c.ReadCache != nil {
+		c.ReadCache.invalidate()
+	}
+	if err == nil {
+		resp, err = c.adaptResponse(req, resp)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return c.newAPIError("change password", resp, body)
+	}
+
+	c.Password = password
+	return nil
+}
+
+// ErrNotFound is returned when an API lookup yields no results.
+var ErrNotFound = errors.New("not found")
+
+// ErrUnauthorized and ErrForbidden are matched (via errors.Is) by an APIError
+// for a 401 or 403 response respectively, i.e. when the configured credentials
+// are rejected or lack the permissions for the request.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// APIError is returned when the API responds with an unexpected status code.
+// Validation failures reported by Django REST framework as a JSON object of
+// field names to messages are decoded into FieldErrors.
+type APIError struct {
+	Action      string
+	StatusCode  int
+	Body        string
+	FieldErrors map[string][]string
+	// RequestID is the RequestIDHeader of the response, if the API or an
+	// ingress in front of it set one, to find the request in their logs.
+	RequestID string
+	// RetryAfter is the delay a maintenance response asked for.
+	RetryAfter time.Duration
+
+	maintenance bool
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("failed to %s: status %d, body: %s", e.Action, e.StatusCode, e.Body)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Unwrap allows errors.Is to match ErrUnauthorized, ErrForbidden and
+// ErrMaintenance.
+func (e *APIError) Unwrap() error {
+	if e.maintenance {
+		return ErrMaintenance
+	}
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	}
+	return nil
+}
+
+// newAPIError builds an APIError for a failed action from its response,
+// decoding any per-field validation messages from the body. The body and
+// messages are scrubbed of the secrets of the request.
+func (c *Client) newAPIError(action string, resp *http.Response, body []byte) *APIError {
+	secrets := requestSecrets(resp.Request)
+	apiErr := &APIError{Action: action, StatusCode: resp.StatusCode, Body: redactText(string(body), secrets), RequestID: resp.Header.Get(RequestIDHeader)}
+	apiErr.RetryAfter, apiErr.maintenance = retryAfter(resp, c.now())
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return apiErr
+	}
+	for field, raw := range fields {
+		var messages []string
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			var message string
+			if err := json.Unmarshal(raw, &message); err != nil {
+				continue
+			}
+			messages = []string{message}
+		}
+		for i, message := range messages {
+			messages[i] = replaceSecrets(message, secrets)
+		}
+		if apiErr.FieldErrors == nil {
+			apiErr.FieldErrors = map[string][]string{}
+		}
+		apiErr.FieldErrors[field] = messages
+	}
+	return apiErr
+}
+
+// GetUserById queries the API for a user by user ID and returns the user data.
+// Returns ErrNotFound if the user does not exist.
+func (c *Client) GetUserById(ctx context.Context, userId string) (*UserData, error) {
+
+	req, err := c.NewRequest(ctx, "GET", "/api/v1/users/"+url.PathEscape(userId)+"/", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("get user", resp, body)
+	}
+
+	return decodeUser(resp, body)
+}
+
+// decodeUser parses the user answered by the API in body.
+func decodeUser(resp *http.Response, body []byte) (*UserData, error) {
+	var userData UserData
+	if err := json.Unmarshal(body, &userData); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w (body: %s)", err, redactBody(resp, body))
+	}
+	return &userData, nil
+}
+
+// GetUserByUsername queries the API for a user by username and returns the
+// first matching user record or ErrNotFound if none exist.
+func (c *Client) GetUserByUsername(ctx context.Context, username string) (*UserData, error) {
+	req, err := c.NewRequest(ctx, "GET", "/api/v1/users/?username="+url.QueryEscape(username), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("get user", resp, body)
+	}
+
+	// Try to decode an array response first, and only accept an exact match
+	// in case the server's filter is lax.
+	var list []UserData
+	if err := json.Unmarshal(body, &list); err == nil {
+		for i := range list {
+			if list[i].Username == username {
+				return &list[i], nil
+			}
+		}
+		return nil, ErrNotFound
+	}
+
+	// Fallback to single-object decode. An object without a username, such
+	// as a pagination envelope, is not a user.
+	var single UserData
+	if err := json.Unmarshal(body, &single); err == nil && single.Username != "" {
+		if single.Username != username {
+			return nil, ErrNotFound
+		}
+		return &single, nil
+	}
+
+	return nil, fmt.Errorf("failed to parse user response: %s", redactBody(resp, body))
+}
+
+// CreateUser creates a new user by POSTing the provided user object
+// as JSON and returns the created user as answered by the API. If the answer
+// only points at the user with a Location header, the returned user has its
+// Url and nothing else.
+func (c *Client) CreateUser(ctx context.Context, user UserCreateData) (*UserData, error) {
+	b, err := json.Marshal(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user data: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, "POST", "/api/v1/users/", bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("create user", resp, body)
+	}
+
+	// The API may only point at the created user with a Location header.
+	if len(bytes.TrimSpace(body)) == 0 {
+		location, err := resp.Location()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse user response: empty body without a location")
+		}
+		return &UserData{Url: location.String()}, nil
+	}
+	return decodeUser(resp, body)
+}
+
+// UpdateUser applies a partial update to the user with the given ID by
+// PATCHing the provided fields as JSON and returns the updated user.
+func (c *Client) UpdateUser(ctx context.Context, id string, update UserUpdateData) (*UserData, error) {
+	b, err := json.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user data: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, "PATCH", "/api/v1/users/"+url.PathEscape(id)+"/", bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("update user", resp, body)
+	}
+
+	return decodeUser(resp, body)
+}
+
+// ListUsers returns all users.
+func (c *Client) ListUsers(ctx context.Context) ([]UserData, error) {
+	req, err := c.NewRequest(ctx, "GET", "/api/v1/users/", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("list users", resp, body)
+	}
+
+	var list []UserData
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse user list response: %w (body: %s)", err, redactBody(resp, body))
+	}
+	return list, nil
+}
+
+// AddUserToGroup adds the user with the given username to group. It is a
+// no-op if the user is already a member and returns ErrNotFound if the user
+// does not exist.
+func (c *Client) AddUserToGroup(ctx context.Context, username, group string) error {
+	return c.changeUserGroups(ctx, username, group, true)
+}
+
+// RemoveUserFromGroup removes the user with the given username from group.
+// It is a no-op if the user is not a member and returns ErrNotFound if the
+// user does not exist.
+func (c *Client) RemoveUserFromGroup(ctx context.Context, username, group string) error {
+	return c.changeUserGroups(ctx, username, group, false)
+}
+
+// changeUserGroups adds or removes group from the user's groups. The API
+// only supports replacing the whole list, so the read and write are done
+// under a per-user lock.
+func (c *Client) changeUserGroups(ctx context.Context, username, group string, add bool) error {
+	unlock := c.userLocks.lock(username)
+	defer unlock()
+
+	user, err := c.GetUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	groups := []string{}
+	member := false
+	for _, g := range user.Groups {
+		if g == group {
+			member = true
+			if !add {
+				continue
+			}
+		}
+		groups = append(groups, g)
+	}
+	if member == add {
+		return nil
+	}
+	if add {
+		groups = append(groups, group)
+	}
+
+	_, err = c.UpdateUser(ctx, LastPathSegment(user.Url), UserUpdateData{Groups: &groups})
+	return err
+}
+
+// DeleteUserById deletes a user by their ID.
+// Returns the HTTP response from the API.
+func (c *Client) DeleteUserById(ctx context.Context, id string) (*http.Response, error) {
+	req, err := c.NewRequest(ctx, "DELETE", "/api/v1/users/"+url.PathEscape(id)+"/", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	return resp, nil
+}
+
+// HasValidUserPassword verifies if a username and password combination is valid
+// by attempting to authenticate with the API using those credentials.
+func (c *Client) HasValidUserPassword(ctx context.Context, username, password string) (bool, error) {
+	// create a new client with the user credentials
+	userClient, err := NewClient(&c.BaseURL, &username, &password)
+	if err != nil {
+		return false, fmt.Errorf("failed to create client: %w", err)
+	}
+	// reuse the transport and settings of this client
+	userClient.FailoverURLs = c.FailoverURLs
+	userClient.HTTPClient = c.HTTPClient
+	userClient.UserAgent = c.UserAgent
+	userClient.Headers = c.Headers
+	userClient.Transcript = c.Transcript
+	userClient.HTTPLogging = c.HTTPLogging
+	userClient.Stats = c.Stats
+	userClient.Deprecations = c.Deprecations
+	userClient.MaintenanceWait = c.MaintenanceWait
+	userClient.Clock = c.Clock
+	userClient.APIVersion = c.APIVersion
+	userClient.RequestLimit = c.RequestLimit
+	req, err := userClient.NewRequest(ctx, "GET", "/api/v1/users/?username="+url.QueryEscape(username), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := userClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// if result is 401 Unauthorized, the password is incorrect (return false)
+	if resp.StatusCode == http.StatusUnauthorized {
+		return false, nil
+	}
+	// if result is 403 Forbidden, the password is correct (return true)
+	if resp.StatusCode == http.StatusForbidden {
+		return true, nil
+	}
+	// superusers may list users, so success also means the password is correct
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return true, nil
+	}
+
+	// For other status codes, return an error
+	if _, ok := retryAfter(resp, c.now()); ok {
+		return false, fmt.Errorf("unexpected status code: %d: %w", resp.StatusCode, ErrMaintenance)
+	}
+	return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+
+}
+
+// GetDomainAccess retrieves domain access permissions for a user and domain.
+// Returns ErrNotFound if no matching permission exists.
+func (c *Client) GetDomainAccess(ctx context.Context, userId, domain string) (*DomainUserPermissionData, error) {
+	list, err := c.ListDomainAccess(ctx, userId, domain)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// ListDomainAccess retrieves the domain access permissions granted to the
+// user with the given ID on exactly the given domain. The API filters by
+// username and FQDN, so records for other users or domains that slip through
+// the filter are discarded.
+func (c *Client) ListDomainAccess(ctx context.Context, userId, domain string) ([]DomainUserPermissionData, error) {
+	// get user to fetch username
+	user, err := c.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user data: %w", err)
+	}
+
+	domainData, err := c.GetDomain(ctx, domain)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain data: %w", err)
+	}
+
+	list, err := c.listDomainAccess(ctx, url.Values{"username": {user.Username}, "fqdn": {domainData.Fqdn}})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []DomainUserPermissionData
+	for _, access := range list {
+		if strconv.Itoa(access.UserID) == LastPathSegment(user.Url) && access.Domain == domainData.ID {
+			matches = append(matches, access)
+		}
+	}
+	return matches, nil
+}
+
+// GetDomainAccessById retrieves the domain access permission with the given
+// database ID. Returns ErrNotFound if it does not exist.
+func (c *Client) GetDomainAccessById(ctx context.Context, id int) (*DomainUserPermissionData, error) {
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("/api/v1/domain-user-permissions/%d/", id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("get domain access", resp, body)
+	}
+
+	var accessData DomainUserPermissionData
+	if err := json.Unmarshal(body, &accessData); err != nil {
+		return nil, fmt.Errorf("failed to parse domain access response: %w (body: %s)", err, redactBody(resp, body))
+	}
+
+	return &accessData, nil
+}
+
+// ListDomainAccessByUsername retrieves all domain access permissions granted
+// to the user with the given username.
+func (c *Client) ListDomainAccessByUsername(ctx context.Context, username string) ([]DomainUserPermissionData, error) {
+	return c.listDomainAccess(ctx, url.Values{"username": {username}})
+}
+
+// ListDomainAccessByFqdn retrieves all domain access permissions granted on
+// the domain with the given FQDN, for any user.
+func (c *Client) ListDomainAccessByFqdn(ctx context.Context, fqdn string) ([]DomainUserPermissionData, error) {
+	return c.listDomainAccess(ctx, url.Values{"fqdn": {NormalizeFQDN(fqdn)}})
+}
+
+// ListAllDomainAccess retrieves all domain access permissions, for any user
+// and domain.
+func (c *Client) ListAllDomainAccess(ctx context.Context) ([]DomainUserPermissionData, error) {
+	return c.listDomainAccess(ctx, nil)
+}
+
+func (c *Client) listDomainAccess(ctx context.Context, query url.Values) ([]DomainUserPermissionData, error) {
+	path := "/api/v1/domain-user-permissions/"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	req, err := c.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("list domain access", resp, body)
+	}
+
+	var list []DomainUserPermissionData
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse domain access response: %w (body: %s)", err, redactBody(resp, body))
+	}
+
+	return list, nil
+}
+
+// GetDomain retrieves domain information by FQDN.
+// Returns ErrNotFound if the domain does not exist.
+func (c *Client) GetDomain(ctx context.Context, fqdn string) (DomainData, error) {
+	req, err := c.NewRequest(ctx, "GET", "/api/v1/domains/?fqdn="+url.QueryEscape(NormalizeFQDN(fqdn)), nil)
+	if err != nil {
+		return DomainData{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return DomainData{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return DomainData{}, ErrNotFound
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return DomainData{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return DomainData{}, c.newAPIError("get domain", resp, body)
+	}
+
+	// Try to decode an array response first, and only accept an exact match
+	// in case the server's filter is lax.
+	var list []DomainData
+	if err := json.Unmarshal(body, &list); err == nil {
+		for _, d := range list {
+			if NormalizeFQDN(d.Fqdn) == NormalizeFQDN(fqdn) {
+				return d, nil
+			}
+		}
+		return DomainData{}, ErrNotFound
+	}
+
+	// Fallback to single-object decode.
+	// An object without an FQDN, such as a pagination envelope, is not a
+	// domain.
+	var single DomainData
+	if err := json.Unmarshal(body, &single); err == nil && single.Fqdn != "" {
+		if NormalizeFQDN(single.Fqdn) != NormalizeFQDN(fqdn) {
+			return DomainData{}, ErrNotFound
+		}
+		return single, nil
+	}
+
+	return DomainData{}, fmt.Errorf("failed to parse domain response: %s", redactBody(resp, body))
+}
+
+// ListDomains returns all domains.
+func (c *Client) ListDomains(ctx context.Context) ([]DomainData, error) {
+	req, err := c.NewRequest(ctx, "GET", "/api/v1/domains/", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("list domains", resp, body)
+	}
+
+	var list []DomainData
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse domain list response: %w (body: %s)", err, redactBody(resp, body))
+	}
+	return list, nil
+}
+
+// GetDomainById retrieves domain information by database ID.
+// Returns ErrNotFound if the domain does not exist.
+func (c *Client) GetDomainById(ctx context.Context, id int) (*DomainData, error) {
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("/api/v1/domains/%d/", id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("get domain", resp, body)
+	}
+
+	var domainData DomainData
+	if err := json.Unmarshal(body, &domainData); err != nil {
+		return nil, fmt.Errorf("failed to parse domain response: %w (body: %s)", err, redactBody(resp, body))
+	}
+
+	return &domainData, nil
+}
+
+// CreateDomain creates a new domain in the LegoCharm API.
+func (c *Client) CreateDomain(ctx context.Context, domain DomainData) (*DomainData, error) {
+	// The ID is assigned by the API, so only the FQDN is sent.
+	b, err := json.Marshal(DomainUpdateData{Fqdn: NormalizeFQDN(domain.Fqdn)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal domain data: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, "POST", "/api/v1/domains/", bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("create domain", resp, body)
+	}
+
+	var domainData DomainData
+	if err := json.Unmarshal(body, &domainData); err != nil {
+		return nil, fmt.Errorf("failed to parse domain response: %w (body: %s)", err, redactBody(resp, body))
+	}
+	return &domainData, nil
+}
+
+// CreateDomainAccess creates a new domain access permission.
+// If the domain does not exist, it will be created automatically unless
+// access.RequireExistingDomain is set.
+func (c *Client) CreateDomainAccess(ctx context.Context, access DomainUserPermissionCreateData) (*DomainUserPermissionData, error) {
+	unlock := c.domainLocks.lock(NormalizeFQDN(access.Domain))
+	defer unlock()
+
+	domainData, domainCreated, err := c.getOrCreateDomain(ctx, access.Domain, !access.RequireExistingDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadData := DomainUserPermissionCreatePayloadData{
+		UserID:      access.UserID,
+		Domain:      domainData.ID,
+		AccessLevel: access.AccessLevel,
+	}
+
+	b, err := json.Marshal(payloadData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload data: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, "POST", "/api/v1/domain-user-permissions/", bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("create domain access", resp, body)
+	}
+
+	var accessData DomainUserPermissionData
+	if err := json.Unmarshal(body, &accessData); err != nil {
+		return nil, fmt.Errorf("failed to parse domain access response: %w (body: %s)", err, redactBody(resp, body))
+	}
+	accessData.DomainCreated = domainCreated
+
+	return &accessData, nil
+}
+
+// UpdateDomainAccess changes the access level of the domain access permission
+// with the given ID in place and returns the updated permission.
+func (c *Client) UpdateDomainAccess(ctx context.Context, id int, accessLevel string) (*DomainUserPermissionData, error) {
+	b, err := json.Marshal(DomainUserPermissionUpdateData{AccessLevel: accessLevel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload data: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("/api/v1/domain-user-permissions/%d/", id), bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("update domain access", resp, body)
+	}
+
+	var accessData DomainUserPermissionData
+	if err := json.Unmarshal(body, &accessData); err != nil {
+		return nil, fmt.Errorf("failed to parse domain access response: %w (body: %s)", err, redactBody(resp, body))
+	}
+
+	return &accessData, nil
+}
+
+// getOrCreateDomain returns the domain with the given FQDN, creating it if
+// it does not exist and create is true. The caller holds the domain lock of
+// the FQDN, and if creation still fails because another client created the
+// domain concurrently, the existing domain is returned.
+func (c *Client) getOrCreateDomain(ctx context.Context, fqdn string, create bool) (DomainData, bool, error) {
+	domainData, err := c.GetDomain(ctx, fqdn)
+	if err == nil {
+		return domainData, false, nil
+	}
+	if err != ErrNotFound {
+		return DomainData{}, false, fmt.Errorf("failed to get domain data: %w", err)
+	}
+	if !create {
+		return DomainData{}, false, fmt.Errorf("domain %q does not exist: %w", fqdn, ErrNotFound)
+	}
+
+	newDomainData, createErr := c.CreateDomain(ctx, DomainData{Fqdn: fqdn})
+	if createErr == nil {
+		return *newDomainData, true, nil
+	}
+
+	// Another process may have won the race; use its domain if so.
+	if domainData, err := c.GetDomain(ctx, fqdn); err == nil {
+		return domainData, false, nil
+	}
+	return DomainData{}, false, fmt.Errorf("failed to create domain: %w", createErr)
+}
+
+// UpdateDomain renames the domain with the given ID to fqdn. It returns
+// ErrNotFound if the domain does not exist.
+func (c *Client) UpdateDomain(ctx context.Context, id int, fqdn string) (*DomainData, error) {
+	b, err := json.Marshal(DomainUpdateData{Fqdn: NormalizeFQDN(fqdn)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal domain data: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("/api/v1/domains/%d/", id), bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// if we got a non-2xx response, return an error
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, c.newAPIError("update domain", resp, body)
+	}
+
+	var domainData DomainData
+	if err := json.Unmarshal(body, &domainData); err != nil {
+		return nil, fmt.Errorf("failed to parse domain response: %w (body: %s)", err, redactBody(resp, body))
+	}
+	return &domainData, nil
+}
+
+// DeleteDomain deletes the domain with the given ID. A domain that does not
+// exist is treated as already deleted.
+func (c *Client) DeleteDomain(ctx context.Context, id int) error {
+	req, err := c.NewRequest(ctx, "DELETE", fmt.Sprintf("/api/v1/domains/%d/", id), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return c.newAPIError("delete domain", resp, body)
+	}
+	return nil
+}
+
+// DeleteDomainIfUnused deletes the domain with the given FQDN unless
+// permissions still reference it, and reports whether it was deleted. A
+// domain that does not exist is left alone. Grants added through this client
+// to the same FQDN wait for the deletion, and the deletion waits for them.
+func (c *Client) DeleteDomainIfUnused(ctx context.Context, fqdn string) (bool, error) {
+	unlock := c.domainLocks.lock(NormalizeFQDN(fqdn))
+	defer unlock()
+
+	// Decide on what the API holds now, not on answers read before the lock.
+	ctx = WithoutReadCache(ctx)
+	remaining, err := c.ListDomainAccessByFqdn(ctx, fqdn)
+	if err != nil {
+		return false, err
+	}
+	if len(remaining) > 0 {
+		return false, nil
+	}
+
+	domain, err := c.GetDomain(ctx, fqdn)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if err := c.DeleteDomain(ctx, domain.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PresentTXTRecord asks the charm to publish a TXT record with the given
+// value, as lego's httpreq DNS provider does for an ACME DNS-01 challenge.
+func (c *Client) PresentTXTRecord(ctx context.Context, record TXTRecordData) error {
+	return c.challengeRequest(ctx, "present", record)
+}
+
+// CleanupTXTRecord asks the charm to remove a TXT record published by
+// PresentTXTRecord.
+func (c *Client) CleanupTXTRecord(ctx context.Context, record TXTRecordData) error {
+	return c.challengeRequest(ctx, "cleanup", record)
+}
+
+func (c *Client) challengeRequest(ctx context.Context, action string, record TXTRecordData) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record data: %w", err)
+	}
+
+	req, err := c.NewRequest(ctx, "POST", "/"+action, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return c.newAPIError(action+" TXT record", resp, body)
+	}
+	return nil
+}
+
+// DeleteDomainAccess deletes a domain access permission using the provided ID.
+func (c *Client) DeleteDomainAccess(ctx context.Context, id int) (*http.Response, error) {
+	path := fmt.Sprintf("/api/v1/domain-user-permissions/%d/", id)
+	req, err := c.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	return resp, nil
+}
+
+// UserData represents a user returned from the LegoCharm API.
+type UserData struct {
+	Username    string   `json:"username"`
+	Url         string   `json:"url"`
+	Email       string   `json:"email"`
+	Groups      []string `json:"groups"`
+	IsStaff     bool     `json:"is_staff"`
+	IsSuperuser bool     `json:"is_superuser"`
+	IsActive    bool     `json:"is_active"`
+	DateJoined  string   `json:"date_joined"`
+	LastLogin   *string  `json:"last_login"`
+}
+
+// UserCreateData represents the data needed to create a new user.
+type UserCreateData struct {
+	Username    string   `json:"username"`
+	Password    string   `json:"password"`
+	Email       string   `json:"email"`
+	Groups      []string `json:"groups"`
+	IsStaff     bool     `json:"is_staff"`
+	IsSuperuser bool     `json:"is_superuser"`
+	IsActive    bool     `json:"is_active"`
+}
+
+// UserUpdateData represents a partial update to an existing user. Only
+// non-nil fields are sent to the API.
+type UserUpdateData struct {
+	Password    string  `json:"password,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	IsStaff     *bool   `json:"is_staff,omitempty"`
+	IsSuperuser *bool   `json:"is_superuser,omitempty"`
+	IsActive    *bool   `json:"is_active,omitempty"`
+	// Groups replaces the user's groups when non-nil.
+	Groups *[]string `json:"groups,omitempty"`
+}
+
+// DomainUserPermissionCreateData represents the input data for creating a user's access permission to a domain.
+type DomainUserPermissionCreateData struct {
+	UserID      string `json:"user"`
+	Domain      string `json:"domain"`
+	AccessLevel string `json:"access_level"`
+	// RequireExistingDomain makes CreateDomainAccess fail with ErrNotFound
+	// instead of creating the domain when it does not exist.
+	RequireExistingDomain bool `json:"-"`
+}
+
+// DomainUserPermissionCreatePayloadData represents the API payload for creating a domain access permission.
+type DomainUserPermissionCreatePayloadData struct {
+	UserID      string `json:"user"`
+	Domain      int    `json:"domain"`
+	AccessLevel string `json:"access_level"`
+}
+
+// DomainUserPermissionUpdateData represents the API payload for changing a domain access permission.
+type DomainUserPermissionUpdateData struct {
+	AccessLevel string `json:"access_level"`
+}
+
+// DomainUserPermissionData represents a user's access permission to a domain as returned from the API.
+type DomainUserPermissionData struct {
+	UserID      int    `json:"user"`
+	Domain      int    `json:"domain"`
+	AccessLevel string `json:"access_level"`
+	ID          int    `json:"id"`
+	// DomainCreated is set by CreateDomainAccess when it had to create the
+	// domain for the permission.
+	DomainCreated bool `json:"-"`
+}
+
+// DomainUpdateData represents the fields of a domain which can be updated.
+type DomainUpdateData struct {
+	Fqdn string `json:"fqdn"`
+}
+
+// DomainData represents domain information from the LegoCharm API.
+type DomainData struct {
+	Fqdn string `json:"fqdn"`
+	ID   int    `json:"id"`
+}
+
+// TXTRecordData represents a DNS-01 challenge record in the format of lego's
+// httpreq DNS provider.
+type TXTRecordData struct {
+	Fqdn  string `json:"fqdn"`
+	Value string `json:"value"`
+}
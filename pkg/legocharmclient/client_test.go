@@ -0,0 +1,1026 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(nil, ptr("u"), ptr("p"))
+	if err == nil {
+		t.Fatal("expected error for nil address")
+	}
+
+	_, err = NewClient(ptr(""), ptr("u"), ptr("p"))
+	if err == nil {
+		t.Fatal("expected error for empty address")
+	}
+
+	_, err = NewClient(ptr("https://example.com"), ptr(""), ptr("p"))
+	if err == nil {
+		t.Fatal("expected error for empty username")
+	}
+
+	_, err = NewClient(ptr("https://example.com"), ptr("u"), ptr(""))
+	if err == nil {
+		t.Fatal("expected error for empty password")
+	}
+}
+
+func TestNewRequestSetsBasicAuth(t *testing.T) {
+	client, err := NewClient(ptr("https://example.com"), ptr("user"), ptr("pass"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), "GET", "/api/v1/thing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %v", err)
+	}
+
+	auth := req.Header.Get("Authorization")
+	if auth == "" {
+		t.Fatalf("expected Authorization header to be set; got empty")
+	}
+}
+
+func TestNewRequestUserAgent(t *testing.T) {
+	client, err := NewClient(ptr("https://example.com"), ptr("user"), ptr("pass"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), "GET", "/api/v1/thing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %v", err)
+	}
+	if got := req.Header.Get("User-Agent"); got != DefaultUserAgent {
+		t.Fatalf("expected User-Agent %q, got %q", DefaultUserAgent, got)
+	}
+
+	client.UserAgent = "my-controller/1.0"
+	req, err = client.NewRequest(context.Background(), "GET", "/api/v1/thing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %v", err)
+	}
+	if got := req.Header.Get("User-Agent"); got != "my-controller/1.0" {
+		t.Fatalf("expected User-Agent %q, got %q", "my-controller/1.0", got)
+	}
+}
+
+func TestNewRequestHeaders(t *testing.T) {
+	client, err := NewClient(ptr("https://example.com"), ptr("user"), ptr("pass"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.Headers = http.Header{"X-Terraform-Workspace": {"prod"}, "User-Agent": {"ignored"}}
+
+	req, err := client.NewRequest(context.Background(), "GET", "/api/v1/thing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %v", err)
+	}
+	if got := req.Header.Get("X-Terraform-Workspace"); got != "prod" {
+		t.Fatalf("expected X-Terraform-Workspace %q, got %q", "prod", got)
+	}
+	if got := req.Header.Values("User-Agent"); len(got) != 1 || got[0] != DefaultUserAgent {
+		t.Fatalf("expected Headers not to override the User-Agent, got %q", got)
+	}
+	if _, _, ok := req.BasicAuth(); !ok {
+		t.Fatalf("expected basic auth to be kept")
+	}
+
+	ctx := WithHeaders(context.Background(), http.Header{"X-Terraform-Module-Name": {"certs"}})
+	req, err = client.NewRequest(ctx, "GET", "/api/v1/thing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %v", err)
+	}
+	if req.Header.Get("X-Terraform-Module-Name") != "certs" || req.Header.Get("X-Terraform-Workspace") != "prod" {
+		t.Fatalf("expected the headers of the context and of the client, got %v", req.Header)
+	}
+}
+
+func TestDo_Succeeds(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok")) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	req, err := client.NewRequest(context.Background(), "GET", "/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error doing request: %v", err)
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK; got %d", resp.StatusCode)
+	}
+}
+
+func TestDeleteUser_AbsoluteURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/v1/users/1004/" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	resp, err := client.DeleteUserById(context.Background(), "1004")
+	if err != nil {
+		t.Fatalf("unexpected error deleting user: %v", err)
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204 No Content; got %d", resp.StatusCode)
+	}
+}
+
+func TestDeleteUser_RelativePath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/v1/users/1004/" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	resp, err := client.DeleteUserById(context.Background(), "1004")
+	if err != nil {
+		t.Fatalf("unexpected error deleting user: %v", err)
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204 No Content; got %d", resp.StatusCode)
+	}
+}
+
+func TestGetUserById(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/users/1004/":
+			w.Write([]byte(`{"username":"renamed","url":"http://example.com/api/v1/users/1004/"}`)) // nolint:errcheck
+		case "/api/v1/users/1005/":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"detail":"forbidden"}`)) // nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	user, err := client.GetUserById(context.Background(), "1004")
+	if err != nil {
+		t.Fatalf("unexpected error getting user: %v", err)
+	}
+	if user.Username != "renamed" || LastPathSegment(user.Url) != "1004" {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+
+	if _, err := client.GetUserById(context.Background(), "1005"); !errors.Is(err, ErrForbidden) || errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrForbidden for 403; got %v", err)
+	}
+
+	if _, err := client.GetUserById(context.Background(), "9999"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound; got %v", err)
+	}
+}
+
+func TestGetUserById_EmptyBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/api/v1/users/1004/")
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	if user, err := client.GetUserById(context.Background(), "1004"); err == nil {
+		t.Fatalf("expected a parse error; got %+v", user)
+	}
+}
+
+func TestUpdateUser_SendsOnlySetFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/api/v1/users/1004/" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if len(body) != 1 || body["is_active"] != false {
+			t.Fatalf("unexpected request body: %v", body)
+		}
+		w.Write([]byte(`{"username":"bob","url":"/api/v1/users/1004/","is_active":false}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	inactive := false
+	user, err := client.UpdateUser(context.Background(), "1004", UserUpdateData{IsActive: &inactive})
+	if err != nil {
+		t.Fatalf("unexpected error updating user: %v", err)
+	}
+	if user.Username != "bob" || user.IsActive {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+}
+
+func TestCreateUser_Location(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/api/v1/users/1004/")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	user, err := client.CreateUser(context.Background(), UserCreateData{Username: "bob"})
+	if err != nil {
+		t.Fatalf("unexpected error creating user: %v", err)
+	}
+	if want := srv.URL + "/api/v1/users/1004/"; user.Url != want {
+		t.Fatalf("expected url %q; got %q", want, user.Url)
+	}
+}
+
+func TestCreateUser_ValidationError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"email":["Enter a valid email address."],"non_field_errors":"Bad request."}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	_, err = client.CreateUser(context.Background(), UserCreateData{Username: "bob", Email: "bob"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError; got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400; got %d", apiErr.StatusCode)
+	}
+	if got := apiErr.FieldErrors["email"]; len(got) != 1 || got[0] != "Enter a valid email address." {
+		t.Fatalf("unexpected email errors: %v", got)
+	}
+	if got := apiErr.FieldErrors["non_field_errors"]; len(got) != 1 || got[0] != "Bad request." {
+		t.Fatalf("unexpected non-field errors: %v", got)
+	}
+}
+
+func TestGetUserByUsername_Unauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"detail":"Invalid username/password."}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	user, err := client.GetUserByUsername(context.Background(), "bob")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized; got user %+v, err %v", user, err)
+	}
+}
+
+func TestAPIError_RequestID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// An ingress echoing the ID the client sent.
+		w.Header().Set(RequestIDHeader, r.Header.Get(RequestIDHeader))
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	_, err = client.ListDomains(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError; got %v", err)
+	}
+	if apiErr.RequestID == "" {
+		t.Fatal("expected the response's request ID to be recorded")
+	}
+	if !strings.HasSuffix(err.Error(), "(request ID "+apiErr.RequestID+")") {
+		t.Fatalf("expected the request ID in %q", err.Error())
+	}
+}
+
+func TestListDomainAccessByUsername(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/domain-user-permissions/" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("username"); got != "bob+ci" {
+			t.Fatalf("expected username query bob+ci; got %q", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":3,"user":7,"domain":2,"access_level":"subdomain"},{"id":4,"user":7,"domain":5,"access_level":"domain"}]`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	list, err := client.ListDomainAccessByUsername(context.Background(), "bob+ci")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != 3 || list[1].Domain != 5 {
+		t.Fatalf("unexpected permissions: %+v", list)
+	}
+}
+
+func TestListAllDomainAccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/domain-user-permissions/" || r.URL.RawQuery != "" {
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`[{"id":3,"user":7,"domain":2,"access_level":"subdomain"},{"id":4,"user":8,"domain":5,"access_level":"domain"}]`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	list, err := client.ListAllDomainAccess(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != 3 || list[1].UserID != 8 {
+		t.Fatalf("unexpected permissions: %+v", list)
+	}
+}
+
+func TestUpdateDomainAccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/api/v1/domain-user-permissions/3/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if len(body) != 1 || body["access_level"] != "subdomain" {
+			t.Fatalf("unexpected request body: %v", body)
+		}
+		w.Write([]byte(`{"id":3,"user":7,"domain":2,"access_level":"subdomain"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	access, err := client.UpdateDomainAccess(context.Background(), 3, "subdomain")
+	if err != nil {
+		t.Fatalf("unexpected error updating domain access: %v", err)
+	}
+	if access.ID != 3 || access.AccessLevel != "subdomain" {
+		t.Fatalf("unexpected domain access: %+v", access)
+	}
+
+	if _, err := client.UpdateDomainAccess(context.Background(), 4, "domain"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound; got %v", err)
+	}
+}
+
+func TestCreateDomainAccess_RequireExistingDomain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/domains/" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[]`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	_, err = client.CreateDomainAccess(context.Background(), DomainUserPermissionCreateData{
+		UserID:                "7",
+		Domain:                "example.com",
+		AccessLevel:           "domain",
+		RequireExistingDomain: true,
+	})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound; got %v", err)
+	}
+}
+
+func TestDeleteDomain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/domains/2/":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/domains/3/":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	if err := client.DeleteDomain(context.Background(), 2); err != nil {
+		t.Fatalf("unexpected error deleting domain: %v", err)
+	}
+	if err := client.DeleteDomain(context.Background(), 3); err != nil {
+		t.Fatalf("expected missing domain to be treated as deleted; got %v", err)
+	}
+	if err := client.DeleteDomain(context.Background(), 4); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden; got %v", err)
+	}
+}
+
+func TestNormalizeFQDN(t *testing.T) {
+	tests := map[string]string{
+		"example.com":        "example.com",
+		"Example.COM.":       "example.com",
+		" staging.example. ": "staging.example",
+		"*.Example.com":      "*.example.com",
+		"":                   "",
+	}
+	for in, want := range tests {
+		if got := NormalizeFQDN(in); got != want {
+			t.Errorf("NormalizeFQDN(%q) = %q; want %q", in, got, want)
+		}
+	}
+}
+
+func TestGetDomain_NormalizesFQDN(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fqdn"); got != "example.com" {
+			t.Fatalf("expected normalized fqdn query; got %q", got)
+		}
+		w.Write([]byte(`[{"id":2,"fqdn":"example.com"}]`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	domain, err := client.GetDomain(context.Background(), "Example.COM.")
+	if err != nil {
+		t.Fatalf("unexpected error getting domain: %v", err)
+	}
+	if domain.ID != 2 {
+		t.Fatalf("unexpected domain: %+v", domain)
+	}
+}
+
+func TestListDomainAccess_ExactMatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/users/7/":
+			w.Write([]byte(`{"username":"bob","url":"http://example.com/api/v1/users/7/"}`)) // nolint:errcheck
+		case "/api/v1/domains/":
+			// A lax filter returns a similar domain first.
+			w.Write([]byte(`[{"id":3,"fqdn":"www.example.com"},{"id":2,"fqdn":"example.com"}]`)) // nolint:errcheck
+		case "/api/v1/domain-user-permissions/":
+			w.Write([]byte(`[{"id":10,"user":8,"domain":2,"access_level":"domain"},{"id":11,"user":7,"domain":3,"access_level":"domain"},{"id":12,"user":7,"domain":2,"access_level":"subdomain"}]`)) // nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	list, err := client.ListDomainAccess(context.Background(), "7", "Example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != 12 {
+		t.Fatalf("expected only permission 12; got %+v", list)
+	}
+
+	access, err := client.GetDomainAccess(context.Background(), "7", "example.com")
+	if err != nil || access.ID != 12 {
+		t.Fatalf("expected permission 12; got %+v, err %v", access, err)
+	}
+
+	if _, err := client.GetDomainAccess(context.Background(), "7", "other.example.com"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown domain; got %v", err)
+	}
+}
+
+func TestCreateDomainAccess_ConcurrentNewDomain(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		domains []string
+		creates int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/domains/":
+			if len(domains) == 0 {
+				w.Write([]byte(`[]`)) // nolint:errcheck
+				return
+			}
+			w.Write([]byte(`[{"id":2,"fqdn":"example.com"}]`)) // nolint:errcheck
+		case r.Method == "POST" && r.URL.Path == "/api/v1/domains/":
+			creates++
+			if len(domains) > 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"fqdn":["domain with this fqdn already exists."]}`)) // nolint:errcheck
+				return
+			}
+			domains = append(domains, "example.com")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":2,"fqdn":"example.com"}`)) // nolint:errcheck
+		case r.Method == "POST" && r.URL.Path == "/api/v1/domain-user-permissions/":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":1,"user":7,"domain":2,"access_level":"domain"}`)) // nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.CreateDomainAccess(context.Background(), DomainUserPermissionCreateData{UserID: "7", Domain: "Example.com", AccessLevel: "domain"})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error creating domain access: %v", err)
+		}
+	}
+	if creates != 1 {
+		t.Fatalf("expected the domain to be created once; got %d attempts", creates)
+	}
+}
+
+func TestDeleteDomainIfUnused(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		permissions string
+		deleted     bool
+	)
+	posting, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/domains/":
+			w.Write([]byte(`[{"id":2,"fqdn":"example.com"}]`)) // nolint:errcheck
+		case r.Method == "GET" && r.URL.Path == "/api/v1/domain-user-permissions/":
+			mu.Lock()
+			defer mu.Unlock()
+			if permissions == "" {
+				w.Write([]byte(`[]`)) // nolint:errcheck
+				return
+			}
+			w.Write([]byte("[" + permissions + "]")) // nolint:errcheck
+		case r.Method == "POST" && r.URL.Path == "/api/v1/domain-user-permissions/":
+			close(posting)
+			<-release
+			mu.Lock()
+			defer mu.Unlock()
+			permissions = `{"id":1,"user":7,"domain":2,"access_level":"domain"}`
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(permissions)) // nolint:errcheck
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/domains/2/":
+			mu.Lock()
+			defer mu.Unlock()
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	granted := make(chan error, 1)
+	go func() {
+		_, err := client.CreateDomainAccess(context.Background(), DomainUserPermissionCreateData{UserID: "7", Domain: "example.com", AccessLevel: "domain"})
+		granted <- err
+	}()
+	<-posting
+
+	// The cleanup waits for the grant being added, and then keeps the domain
+	// it uses.
+	cleaned := make(chan bool, 1)
+	go func() {
+		deleted, err := client.DeleteDomainIfUnused(context.Background(), "example.com")
+		if err != nil {
+			t.Errorf("unexpected error cleaning up domain: %v", err)
+		}
+		cleaned <- deleted
+	}()
+	select {
+	case <-cleaned:
+		t.Fatalf("expected the cleanup to wait for the grant")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	if err := <-granted; err != nil {
+		t.Fatalf("unexpected error creating domain access: %v", err)
+	}
+	if <-cleaned || deleted {
+		t.Fatalf("expected the domain in use to be kept")
+	}
+
+	mu.Lock()
+	permissions = ""
+	mu.Unlock()
+	ok, err := client.DeleteDomainIfUnused(context.Background(), "example.com")
+	if err != nil || !ok || !deleted {
+		t.Fatalf("expected the unused domain to be deleted; got %v, err %v", ok, err)
+	}
+}
+
+func TestCreateDomainAccess_DomainCreatedElsewhere(t *testing.T) {
+	gets := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/domains/":
+			// Another client creates the domain between our lookup and create.
+			gets++
+			if gets == 1 {
+				w.Write([]byte(`[]`)) // nolint:errcheck
+				return
+			}
+			w.Write([]byte(`[{"id":2,"fqdn":"example.com"}]`)) // nolint:errcheck
+		case r.Method == "POST" && r.URL.Path == "/api/v1/domains/":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"fqdn":["domain with this fqdn already exists."]}`)) // nolint:errcheck
+		case r.Method == "POST" && r.URL.Path == "/api/v1/domain-user-permissions/":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":1,"user":7,"domain":2,"access_level":"domain"}`)) // nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	access, err := client.CreateDomainAccess(context.Background(), DomainUserPermissionCreateData{UserID: "7", Domain: "example.com", AccessLevel: "domain"})
+	if err != nil {
+		t.Fatalf("unexpected error creating domain access: %v", err)
+	}
+	if access.DomainCreated {
+		t.Fatalf("expected domain created elsewhere not to be reported as created by us")
+	}
+}
+
+func TestUpdateDomain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/api/v1/domains/2/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if len(body) != 1 || body["fqdn"] != "new.example.com" {
+			t.Fatalf("unexpected request body: %v", body)
+		}
+		w.Write([]byte(`{"id":2,"fqdn":"new.example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	domain, err := client.UpdateDomain(context.Background(), 2, "New.Example.com.")
+	if err != nil {
+		t.Fatalf("unexpected error updating domain: %v", err)
+	}
+	if domain.ID != 2 || domain.Fqdn != "new.example.com" {
+		t.Fatalf("unexpected domain: %+v", domain)
+	}
+
+	if _, err := client.UpdateDomain(context.Background(), 3, "other.example.com"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound; got %v", err)
+	}
+}
+
+func TestUserGroups(t *testing.T) {
+	groups := []string{"ops"}
+	patches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/users/":
+			if r.URL.Query().Get("username") == "missing" {
+				w.Write([]byte(`[]`)) // nolint:errcheck
+				return
+			}
+			b, _ := json.Marshal([]UserData{{Username: "alice", Url: "http://example.com/api/v1/users/7/", Groups: groups}})
+			w.Write(b) // nolint:errcheck
+		case r.Method == "PATCH" && r.URL.Path == "/api/v1/users/7/":
+			patches++
+			var update UserUpdateData
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil || update.Groups == nil {
+				t.Fatalf("unexpected request body: %v", err)
+			}
+			groups = *update.Groups
+			w.Write([]byte(`{"username":"alice","url":"http://example.com/api/v1/users/7/"}`)) // nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	ctx := context.Background()
+
+	users, err := client.ListUsers(ctx)
+	if err != nil || len(users) != 1 || users[0].Username != "alice" {
+		t.Fatalf("unexpected users %+v: %v", users, err)
+	}
+
+	if err := client.AddUserToGroup(ctx, "alice", "acme"); err != nil {
+		t.Fatalf("unexpected error adding user to group: %v", err)
+	}
+	if len(groups) != 2 || groups[0] != "ops" || groups[1] != "acme" {
+		t.Fatalf("unexpected groups after add: %v", groups)
+	}
+	if err := client.AddUserToGroup(ctx, "alice", "acme"); err != nil || patches != 1 {
+		t.Fatalf("expected adding an existing member to be a no-op; got %d patches: %v", patches, err)
+	}
+
+	if err := client.RemoveUserFromGroup(ctx, "alice", "ops"); err != nil {
+		t.Fatalf("unexpected error removing user from group: %v", err)
+	}
+	if len(groups) != 1 || groups[0] != "acme" {
+		t.Fatalf("unexpected groups after remove: %v", groups)
+	}
+	if err := client.RemoveUserFromGroup(ctx, "alice", "ops"); err != nil || patches != 2 {
+		t.Fatalf("expected removing a non-member to be a no-op; got %d patches: %v", patches, err)
+	}
+
+	if err := client.AddUserToGroup(ctx, "missing", "acme"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound; got %v", err)
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestChangePassword(t *testing.T) {
+	password := "old"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "admin" || p != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/users/":
+			w.Write([]byte(`[{"username":"admin","url":"http://example.com/api/v1/users/1/"}]`)) // nolint:errcheck
+		case r.Method == "PATCH" && r.URL.Path == "/api/v1/users/1/":
+			var update UserUpdateData
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil || update.Password == "" {
+				t.Fatalf("unexpected request body: %v", err)
+			}
+			password = update.Password
+			w.Write([]byte(`{"username":"admin","url":"http://example.com/api/v1/users/1/"}`)) // nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("old"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	ctx := context.Background()
+
+	// A request built before the change is sent with the new password.
+	req, err := client.NewRequest(ctx, "GET", "/api/v1/users/?username=admin", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %v", err)
+	}
+
+	if err := client.ChangePassword(ctx, "new"); err != nil {
+		t.Fatalf("unexpected error changing password: %v", err)
+	}
+	if password != "new" || client.Password != "new" {
+		t.Fatalf("expected password to change; API has %q, client has %q", password, client.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error sending request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected request built before the change to succeed; got status %d", resp.StatusCode)
+	}
+	if _, err := client.GetUserByUsername(ctx, "admin"); err != nil {
+		t.Fatalf("unexpected error after password change: %v", err)
+	}
+}
+
+func TestTXTRecord(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var record TXTRecordData
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			t.Fatalf("unexpected request body: %v", err)
+		}
+		if record.Fqdn == "_acme-challenge.forbidden.example.com." {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+record.Fqdn+" "+record.Value)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	ctx := context.Background()
+
+	record := TXTRecordData{Fqdn: "_acme-challenge.example.com.", Value: "token"}
+	if err := client.PresentTXTRecord(ctx, record); err != nil {
+		t.Fatalf("unexpected error presenting record: %v", err)
+	}
+	if err := client.CleanupTXTRecord(ctx, record); err != nil {
+		t.Fatalf("unexpected error cleaning up record: %v", err)
+	}
+	want := []string{
+		"POST /present _acme-challenge.example.com. token",
+		"POST /cleanup _acme-challenge.example.com. token",
+	}
+	if len(calls) != 2 || calls[0] != want[0] || calls[1] != want[1] {
+		t.Fatalf("unexpected calls %q", calls)
+	}
+
+	err = client.PresentTXTRecord(ctx, TXTRecordData{Fqdn: "_acme-challenge.forbidden.example.com.", Value: "token"})
+	if !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden; got %v", err)
+	}
+}
+
+func TestListDomains(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/domains/" || r.URL.RawQuery != "" {
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`[{"id":1,"fqdn":"example.com"},{"id":2,"fqdn":"*.example.org"}]`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	domains, err := client.ListDomains(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error listing domains: %v", err)
+	}
+	if len(domains) != 2 || domains[0].ID != 1 || domains[1].Fqdn != "*.example.org" {
+		t.Fatalf("unexpected domains %+v", domains)
+	}
+}
+
+func TestHasValidUserPassword(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		switch {
+		case username == "admin" && password == "secret":
+			w.Write([]byte(`[]`)) // nolint:errcheck
+		case username == "alice" && password == "secret":
+			w.WriteHeader(http.StatusForbidden)
+		case username == "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("u"), ptr("p"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	for _, tc := range []struct {
+		username, password string
+		valid              bool
+	}{
+		{"admin", "secret", true},
+		{"alice", "secret", true},
+		{"alice", "wrong", false},
+		{"missing", "secret", false},
+	} {
+		valid, err := client.HasValidUserPassword(context.Background(), tc.username, tc.password)
+		if err != nil || valid != tc.valid {
+			t.Fatalf("%s/%s: expected %v; got %v (err %v)", tc.username, tc.password, tc.valid, valid, err)
+		}
+	}
+
+	if _, err := client.HasValidUserPassword(context.Background(), "broken", "secret"); err == nil {
+		t.Fatalf("expected an error for an unexpected status")
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/internal/fakeserver"
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/legocharmclient"
+)
+
+// TestContract drives every API method against the fake server and checks
+// each request the client sends (path, method, query parameters and JSON
+// body fields) against an OpenAPI schema of the charm. By default it uses
+// testdata/openapi.json; to catch drift after a charm upgrade, point
+// LEGOCHARM_OPENAPI_SCHEMA at a JSON schema generated from the deployment,
+// for example with
+//
+//	python3 manage.py generateschema --format openapi-json > openapi.json
+func TestContract(t *testing.T) {
+	schemaPath := os.Getenv("LEGOCHARM_OPENAPI_SCHEMA")
+	if schemaPath == "" {
+		schemaPath = "testdata/openapi.json"
+	}
+	schema := loadOpenAPISchema(t, schemaPath)
+	t.Logf("validating requests against %s", schemaPath)
+
+	requests := exerciseAPI(t)
+	for _, req := range requests {
+		for _, problem := range schema.validate(req) {
+			t.Errorf("%s %s: %s", req.Method, req.URL, problem)
+		}
+	}
+}
+
+// exerciseAPI calls every method of legocharmclient.API against the fake
+// server and returns the requests sent.
+func exerciseAPI(t *testing.T) []*recordedRequest {
+	t.Helper()
+	ctx := context.Background()
+
+	s := fakeserver.New("admin", "secret")
+	srv := s.Start()
+	defer srv.Close()
+
+	recorder := &recordingTransport{next: http.DefaultTransport}
+	newClient := func(username, password string) *legocharmclient.Client {
+		client, err := legocharmclient.NewClient(&srv.URL, &username, &password)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		client.HTTPClient = &http.Client{Transport: recorder}
+		return client
+	}
+	client := newClient("admin", "secret")
+
+	exercised := map[string]bool{"Endpoint": true, "AuthenticatedUsername": true}
+	check := func(method string, err error) {
+		t.Helper()
+		exercised[method] = true
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+	}
+
+	_, err := client.ListUsers(ctx)
+	check("ListUsers", err)
+	user, err := client.CreateUser(ctx, legocharmclient.UserCreateData{Username: "alice", Password: "alice-pw", Groups: []string{}, IsActive: true})
+	check("CreateUser", err)
+	userID := legocharmclient.LastPathSegment(user.Url)
+	_, err = client.GetUserById(ctx, userID)
+	check("GetUserById", err)
+	_, err = client.GetUserByUsername(ctx, "alice")
+	check("GetUserByUsername", err)
+	email := "alice@example.com"
+	_, err = client.UpdateUser(ctx, userID, legocharmclient.UserUpdateData{Email: &email})
+	check("UpdateUser", err)
+	check("AddUserToGroup", client.AddUserToGroup(ctx, "alice", "admins"))
+	check("RemoveUserFromGroup", client.RemoveUserFromGroup(ctx, "alice", "admins"))
+	_, err = client.HasValidUserPassword(ctx, "alice", "alice-pw")
+	check("HasValidUserPassword", err)
+
+	domain, err := client.CreateDomain(ctx, legocharmclient.DomainData{Fqdn: "example.com"})
+	check("CreateDomain", err)
+	_, err = client.GetDomain(ctx, "example.com")
+	check("GetDomain", err)
+	_, err = client.GetDomainById(ctx, domain.ID)
+	check("GetDomainById", err)
+	_, err = client.ListDomains(ctx)
+	check("ListDomains", err)
+	_, err = client.UpdateDomain(ctx, domain.ID, "example.org")
+	check("UpdateDomain", err)
+
+	// Granting access to an unknown domain creates it first.
+	access, err := client.CreateDomainAccess(ctx, legocharmclient.DomainUserPermissionCreateData{UserID: userID, Domain: "example.net", AccessLevel: "subdomain"})
+	check("CreateDomainAccess", err)
+	_, err = client.GetDomainAccess(ctx, userID, "example.net")
+	check("GetDomainAccess", err)
+	_, err = client.ListDomainAccess(ctx, userID, "example.net")
+	check("ListDomainAccess", err)
+	_, err = client.GetDomainAccessById(ctx, access.ID)
+	check("GetDomainAccessById", err)
+	_, err = client.ListDomainAccessByUsername(ctx, "alice")
+	check("ListDomainAccessByUsername", err)
+	_, err = client.ListDomainAccessByFqdn(ctx, "example.net")
+	check("ListDomainAccessByFqdn", err)
+	_, err = client.ListAllDomainAccess(ctx)
+	check("ListAllDomainAccess", err)
+	_, err = client.UpdateDomainAccess(ctx, access.ID, "domain")
+	check("UpdateDomainAccess", err)
+
+	alice := newClient("alice", "alice-pw")
+	record := legocharmclient.TXTRecordData{Fqdn: "_acme-challenge.example.net.", Value: "token"}
+	check("PresentTXTRecord", alice.PresentTXTRecord(ctx, record))
+	check("CleanupTXTRecord", alice.CleanupTXTRecord(ctx, record))
+
+	resp, err := client.DeleteDomainAccess(ctx, access.ID)
+	if err == nil {
+		resp.Body.Close()
+	}
+	check("DeleteDomainAccess", err)
+	_, err = client.DeleteDomainIfUnused(ctx, "example.net")
+	check("DeleteDomainIfUnused", err)
+	check("DeleteDomain", client.DeleteDomain(ctx, domain.ID))
+	resp, err = client.DeleteUserById(ctx, userID)
+	if err == nil {
+		resp.Body.Close()
+	}
+	check("DeleteUserById", err)
+	check("ChangePassword", client.ChangePassword(ctx, "new-secret"))
+
+	// Methods added to the API must be added here too, so that their
+	// requests are validated.
+	api := reflect.TypeFor[legocharmclient.API]()
+	for i := range api.NumMethod() {
+		if name := api.Method(i).Name; !exercised[name] {
+			t.Errorf("legocharmclient.API.%s is not exercised by the contract test", name)
+		}
+	}
+
+	return recorder.requests
+}
+
+type recordedRequest struct {
+	Method string
+	URL    *url.URL
+	Body   []byte
+}
+
+// recordingTransport records the requests sent through it.
+type recordingTransport struct {
+	next     http.RoundTripper
+	mu       sync.Mutex
+	requests []*recordedRequest
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	rt.mu.Lock()
+	rt.requests = append(rt.requests, &recordedRequest{Method: req.Method, URL: req.URL, Body: body})
+	rt.mu.Unlock()
+	return rt.next.RoundTrip(req)
+}
+
+// openAPISchema is the subset of an OpenAPI 3 document the contract test
+// checks requests against.
+type openAPISchema struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*openAPIObject `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPIParameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+type openAPIOperation struct {
+	Parameters  []openAPIParameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *openAPIObject `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+}
+
+type openAPIObject struct {
+	Ref        string                    `json:"$ref"`
+	Type       string                    `json:"type"`
+	Properties map[string]*openAPIObject `json:"properties"`
+	Required   []string                  `json:"required"`
+	ReadOnly   bool                      `json:"readOnly"`
+	Enum       []any                     `json:"enum"`
+}
+
+func loadOpenAPISchema(t *testing.T, path string) *openAPISchema {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading OpenAPI schema: %v", err)
+	}
+	var schema openAPISchema
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("parsing OpenAPI schema %s (only JSON is supported): %v", path, err)
+	}
+	if len(schema.Paths) == 0 {
+		t.Fatalf("OpenAPI schema %s has no paths", path)
+	}
+	return &schema
+}
+
+// resolve follows a local "#/components/schemas/..." reference.
+func (s *openAPISchema) resolve(obj *openAPIObject) (*openAPIObject, error) {
+	for obj != nil && obj.Ref != "" {
+		name, ok := strings.CutPrefix(obj.Ref, "#/components/schemas/")
+		if !ok {
+			return nil, fmt.Errorf("unsupported reference %q", obj.Ref)
+		}
+		if obj = s.Components.Schemas[name]; obj == nil {
+			return nil, fmt.Errorf("unknown schema %q", name)
+		}
+	}
+	return obj, nil
+}
+
+// findPath returns the path item whose template matches path, such as
+// "/api/v1/users/{id}/" for "/api/v1/users/3/".
+func (s *openAPISchema) findPath(path string) (map[string]json.RawMessage, bool) {
+	if item, ok := s.Paths[path]; ok {
+		return item, true
+	}
+	segments := strings.Split(path, "/")
+	for template, item := range s.Paths {
+		parts := strings.Split(template, "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+		match := true
+		for i, part := range parts {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				match = match && segments[i] != ""
+			} else {
+				match = match && part == segments[i]
+			}
+		}
+		if match {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// validate returns the ways req does not conform to the schema.
+func (s *openAPISchema) validate(req *recordedRequest) []string {
+	item, ok := s.findPath(req.URL.Path)
+	if !ok {
+		return []string{"path is not in the schema"}
+	}
+	rawOp, ok := item[strings.ToLower(req.Method)]
+	if !ok {
+		return []string{"method is not allowed by the schema"}
+	}
+	var op openAPIOperation
+	if err := json.Unmarshal(rawOp, &op); err != nil {
+		return []string{fmt.Sprintf("parsing operation: %v", err)}
+	}
+	if rawParams, ok := item["parameters"]; ok {
+		var pathParams []openAPIParameter
+		if err := json.Unmarshal(rawParams, &pathParams); err != nil {
+			return []string{fmt.Sprintf("parsing parameters: %v", err)}
+		}
+		op.Parameters = append(op.Parameters, pathParams...)
+	}
+
+	var problems []string
+	query := req.URL.Query()
+	for name := range query {
+		if !slices.ContainsFunc(op.Parameters, func(p openAPIParameter) bool { return p.In == "query" && p.Name == name }) {
+			problems = append(problems, fmt.Sprintf("query parameter %q is not in the schema", name))
+		}
+	}
+	for _, p := range op.Parameters {
+		if p.In == "query" && p.Required && !query.Has(p.Name) {
+			problems = append(problems, fmt.Sprintf("required query parameter %q is missing", p.Name))
+		}
+	}
+
+	if op.RequestBody == nil {
+		if len(req.Body) > 0 {
+			problems = append(problems, "the schema does not allow a request body")
+		}
+		return problems
+	}
+	content, ok := op.RequestBody.Content["application/json"]
+	if !ok {
+		return append(problems, "the schema does not accept a JSON request body")
+	}
+	body, err := s.resolve(content.Schema)
+	if err != nil {
+		return append(problems, err.Error())
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(req.Body, &fields); err != nil {
+		return append(problems, fmt.Sprintf("request body is not a JSON object: %v", err))
+	}
+	for name, value := range fields {
+		prop, err := s.resolve(body.Properties[name])
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("field %q: %v", name, err))
+		case prop == nil:
+			problems = append(problems, fmt.Sprintf("field %q is not in the schema", name))
+		case prop.ReadOnly:
+			problems = append(problems, fmt.Sprintf("field %q is read-only", name))
+		case len(prop.Enum) > 0 && !slices.Contains(prop.Enum, value):
+			problems = append(problems, fmt.Sprintf("field %q: %v is not one of %v", name, value, prop.Enum))
+		case !matchesType(prop.Type, value):
+			problems = append(problems, fmt.Sprintf("field %q: %v is not of type %s", name, value, prop.Type))
+		}
+	}
+	// Partial updates may leave out required fields.
+	if req.Method != http.MethodPatch {
+		for _, name := range body.Required {
+			if _, ok := fields[name]; !ok {
+				problems = append(problems, fmt.Sprintf("required field %q is missing", name))
+			}
+		}
+	}
+	return problems
+}
+
+// matchesType reports whether a decoded JSON value has the given OpenAPI
+// type. Django REST framework parses primary keys sent as strings, so an
+// integer may also be a string of digits.
+func matchesType(typ string, value any) bool {
+	switch typ {
+	case "":
+		return true
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "integer":
+		switch v := value.(type) {
+		case float64:
+			return v == float64(int64(v))
+		case string:
+			_, err := strconv.Atoi(v)
+			return err == nil
+		}
+		return false
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Deprecation is an API endpoint that the charm announced as deprecated, with
+// the Deprecation header (RFC 9745), or as going away, with the Sunset header
+// (RFC 8594).
+type Deprecation struct {
+	Method string
+	// Endpoint is the request path with object IDs replaced by {id}.
+	Endpoint string
+	// Deprecated is set if the endpoint is, or will be, deprecated.
+	Deprecated bool
+	// Since is when the endpoint was or will be deprecated, if announced.
+	Since time.Time
+	// Sunset is when the endpoint will stop working, if announced. It is
+	// zero if the endpoint is only deprecated, or if the date is malformed.
+	Sunset time.Time
+	// Link is the documentation of the deprecation or sunset, if announced.
+	Link string
+}
+
+// DeprecationNotices collects the deprecations announced in responses. Each
+// endpoint is collected once. It is safe for concurrent use and may be shared
+// by several clients; its zero value is ready to use.
+type DeprecationNotices struct {
+	mu      sync.Mutex
+	seen    map[CallKey]bool
+	pending []Deprecation
+}
+
+// Pending returns the deprecations collected since the previous call.
+func (n *DeprecationNotices) Pending() []Deprecation {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	pending := n.pending
+	n.pending = nil
+	return pending
+}
+
+// record collects the deprecation announced in resp to req, if any, and
+// reports whether it is new.
+func (n *DeprecationNotices) record(req *http.Request, resp *http.Response) (Deprecation, bool) {
+	d, ok := parseDeprecation(resp.Header)
+	if !ok {
+		return Deprecation{}, false
+	}
+	d.Method = req.Method
+	d.Endpoint = endpoint(req.URL.Path)
+	key := CallKey{Method: d.Method, Endpoint: d.Endpoint}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.seen[key] {
+		return Deprecation{}, false
+	}
+	if n.seen == nil {
+		n.seen = make(map[CallKey]bool)
+	}
+	n.seen[key] = true
+	n.pending = append(n.pending, d)
+	return d, true
+}
+
+// linkRe matches an entry of a Link header (RFC 8288) and its rel parameter.
+var linkRe = regexp.MustCompile(`<([^>]*)>[^,]*?;\s*rel="?([^";,]*)"?`)
+
+// parseDeprecation reads the Deprecation, Sunset and Link headers of a
+// response. Malformed dates are ignored, but the announcement is kept.
+func parseDeprecation(h http.Header) (Deprecation, bool) {
+	deprecation, sunset := h.Get("Deprecation"), h.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return Deprecation{}, false
+	}
+
+	var d Deprecation
+	switch {
+	case deprecation == "":
+	case strings.HasPrefix(deprecation, "@"):
+		// RFC 9745: a structured field date, in seconds since the epoch.
+		d.Deprecated = true
+		if secs, err := strconv.ParseInt(deprecation[1:], 10, 64); err == nil {
+			d.Since = time.Unix(secs, 0).UTC()
+		}
+	case strings.EqualFold(deprecation, "false"):
+	default:
+		// Earlier drafts: "true" or an HTTP date.
+		d.Deprecated = true
+		if t, err := http.ParseTime(deprecation); err == nil {
+			d.Since = t.UTC()
+		}
+	}
+	if sunset != "" {
+		if t, err := http.ParseTime(sunset); err == nil {
+			d.Sunset = t.UTC()
+		}
+	}
+	if !d.Deprecated && sunset == "" {
+		return Deprecation{}, false
+	}
+
+	for _, link := range h.Values("Link") {
+		for _, m := range linkRe.FindAllStringSubmatch(link, -1) {
+			for _, rel := range strings.Fields(m[2]) {
+				if (rel == "deprecation" || rel == "sunset") && d.Link == "" {
+					d.Link = m[1]
+				}
+			}
+		}
+	}
+	return d, true
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   Deprecation
+		ok     bool
+	}{
+		{
+			name:   "none",
+			header: http.Header{},
+		},
+		{
+			name:   "structured date",
+			header: http.Header{"Deprecation": {"@1767225600"}},
+			want:   Deprecation{Deprecated: true, Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+			ok:     true,
+		},
+		{
+			name:   "true",
+			header: http.Header{"Deprecation": {"true"}},
+			want:   Deprecation{Deprecated: true},
+			ok:     true,
+		},
+		{
+			name:   "false",
+			header: http.Header{"Deprecation": {"false"}},
+		},
+		{
+			name: "sunset and link",
+			header: http.Header{
+				"Deprecation": {"Thu, 01 Jan 2026 00:00:00 GMT"},
+				"Sunset":      {"Thu, 31 Dec 2026 23:59:59 GMT"},
+				"Link": {
+					`<https://lego.example.com/>; rel="alternate"`,
+					`<https://example.com/api-v2>; rel="successor-version", <https://example.com/deprecations>; rel="deprecation"; type="text/html"`,
+				},
+			},
+			want: Deprecation{
+				Deprecated: true,
+				Since:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				Sunset:     time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC),
+				Link:       "https://example.com/deprecations",
+			},
+			ok: true,
+		},
+		{
+			name:   "malformed sunset",
+			header: http.Header{"Sunset": {"soon"}},
+			ok:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseDeprecation(tt.header)
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseDeprecation() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDeprecationNotices(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/domains/" {
+			w.Header().Set("Deprecation", "@1767225600")
+		}
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("pass"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.Deprecations = &DeprecationNotices{}
+
+	ctx := context.Background()
+	for _, id := range []int{7, 8} {
+		if _, err := client.GetDomainById(ctx, id); err != nil {
+			t.Fatalf("GetDomainById: %v", err)
+		}
+	}
+	if _, err := client.GetDomain(ctx, "example.com"); err != nil {
+		t.Fatalf("GetDomain: %v", err)
+	}
+
+	want := []Deprecation{{
+		Method:     "GET",
+		Endpoint:   "/api/v1/domains/{id}/",
+		Deprecated: true,
+		Since:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	if got := client.Deprecations.Pending(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Pending() = %+v, want %+v", got, want)
+	}
+	if _, err := client.GetDomainById(ctx, 9); err != nil {
+		t.Fatalf("GetDomainById: %v", err)
+	}
+	if got := client.Deprecations.Pending(); len(got) != 0 {
+		t.Fatalf("Pending() = %+v, want each endpoint reported once", got)
+	}
+}
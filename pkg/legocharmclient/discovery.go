@@ -0,0 +1,52 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// lookupSRV resolves SRV records, replaced in tests.
+var lookupSRV = net.DefaultResolver.LookupSRV
+
+// LookupSRV returns the base URLs of the API published by the DNS SRV record
+// name, such as "_legocharm._tcp.example.com", in the order they are to be
+// tried: by priority, and at random by weight within a priority, which suits
+// BaseURL followed by FailoverURLs. The URLs are https, unless name is
+// prefixed with "http://".
+func LookupSRV(ctx context.Context, name string) ([]string, error) {
+	scheme := "https"
+	if rest, ok := strings.CutPrefix(name, "http://"); ok {
+		scheme, name = "http", rest
+	} else {
+		name = strings.TrimPrefix(name, "https://")
+	}
+	name = strings.TrimSuffix(name, "/")
+	if name == "" {
+		return nil, errors.New("SRV record name is required")
+	}
+
+	_, records, err := lookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up SRV record %s: %w", name, err)
+	}
+	var urls []string
+	for _, record := range records {
+		target := strings.TrimSuffix(record.Target, ".")
+		// A target of "." means the service is not available at the name.
+		if target == "" {
+			continue
+		}
+		urls = append(urls, scheme+"://"+net.JoinHostPort(target, strconv.Itoa(int(record.Port))))
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("SRV record %s has no targets", name)
+	}
+	return urls, nil
+}
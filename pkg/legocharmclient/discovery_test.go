@@ -0,0 +1,62 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func useSRVRecords(t *testing.T, records map[string][]*net.SRV) {
+	t.Helper()
+	old := lookupSRV
+	lookupSRV = func(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		if service != "" || proto != "" {
+			t.Fatalf("unexpected lookup of service %q, proto %q", service, proto)
+		}
+		srvs, ok := records[name]
+		if !ok {
+			return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+		}
+		return name, srvs, nil
+	}
+	t.Cleanup(func() { lookupSRV = old })
+}
+
+func TestLookupSRV(t *testing.T) {
+	useSRVRecords(t, map[string][]*net.SRV{
+		"_legocharm._tcp.example.com": {
+			{Target: "lego-1.example.com.", Port: 8443, Priority: 10},
+			{Target: "lego-0.example.com.", Port: 443, Priority: 20},
+		},
+		"_legocharm._tcp.down.example.com": {{Target: ".", Port: 0}},
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "_legocharm._tcp.example.com", want: []string{"https://lego-1.example.com:8443", "https://lego-0.example.com:443"}},
+		{name: "https://_legocharm._tcp.example.com/", want: []string{"https://lego-1.example.com:8443", "https://lego-0.example.com:443"}},
+		{name: "http://_legocharm._tcp.example.com", want: []string{"http://lego-1.example.com:8443", "http://lego-0.example.com:443"}},
+	}
+	for _, tt := range tests {
+		got, err := LookupSRV(ctx, tt.name)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LookupSRV(%q) = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := LookupSRV(ctx, "_legocharm._tcp.down.example.com"); err == nil {
+		t.Errorf("expected an error for a record without targets")
+	}
+	var dnsErr *net.DNSError
+	if _, err := LookupSRV(ctx, "_legocharm._tcp.missing.example.com"); !errors.As(err, &dnsErr) {
+		t.Errorf("expected the DNS error, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+// Package legocharmclient is a Go client for the API of the
+// httprequest-lego-provider charm, which manages the users, domains and
+// per-domain permissions behind the charm's ACME DNS-01 proxy.
+//
+// It is the client the Terraform provider uses, published so that other
+// tooling such as controllers and CLIs does not have to reimplement it:
+//
+//	address, username, password := "https://lego.example.com", "admin", os.Getenv("LEGO_PASSWORD")
+//	client, err := legocharmclient.NewClient(&address, &username, &password)
+//	if err != nil {
+//		return err
+//	}
+//	client.UserAgent = "my-controller"
+//	domains, err := client.ListDomains(ctx)
+//
+// Code that only needs the operations should depend on the API interface,
+// which legocharmclienttest.APIMock implements for tests. Lookups return
+// ErrNotFound for missing objects, and errors for other non-2xx responses
+// can be inspected as an *APIError with errors.As.
+//
+// The package follows the module's semantic version: exported identifiers
+// are not removed or changed incompatibly within a major version.
+package legocharmclient
@@ -0,0 +1,113 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// NormalizeAddress returns the base URL of the API at address, which
+// defaults to https if it has no scheme.
+func NormalizeAddress(address string) (string, error) {
+	u := address
+	parsed, err := url.Parse(u)
+	if err != nil || !parsed.IsAbs() {
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			u = "https://" + u
+			parsed, err = url.Parse(u)
+		}
+		if err != nil || !parsed.IsAbs() {
+			return "", redactError(fmt.Errorf("invalid address %q: %w", address, err), nil)
+		}
+	}
+	return strings.TrimRight(u, "/"), nil
+}
+
+// endpoints returns the base URLs of the API, in the order they are tried.
+func (c *Client) endpoints() []string {
+	return append([]string{c.BaseURL}, c.FailoverURLs...)
+}
+
+// isConnectionError reports whether err is a failure to connect to the API,
+// which happens before any of the request is sent, so that it is safe to
+// send it to another endpoint whatever its method.
+func isConnectionError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// sendFailingOver sends req, built against BaseURL, to the endpoint in use,
+// and, with FailoverURLs set, to the next endpoints in order while it cannot
+// connect. The endpoint that answers is used by the next requests of the
+// client, until it fails in turn.
+func (c *Client) sendFailingOver(req *http.Request) (*http.Response, error) {
+	endpoints := c.endpoints()
+	rel, ok := strings.CutPrefix(req.URL.String(), c.BaseURL)
+	if len(endpoints) == 1 || !ok || (req.Body != nil && req.GetBody == nil) {
+		return c.send(req)
+	}
+
+	c.endpointMu.Lock()
+	first := c.endpoint % len(endpoints)
+	c.endpointMu.Unlock()
+
+	var err error
+	for i := range endpoints {
+		current := (first + i) % len(endpoints)
+		attempt := req
+		if current != 0 {
+			if attempt, err = rebase(req, endpoints[current]+rel); err != nil {
+				return nil, err
+			}
+		}
+		if i > 0 {
+			attempt = attempt.WithContext(retryContext(attempt.Context()))
+		}
+		var resp *http.Response
+		resp, err = c.send(attempt)
+		if err == nil || !isConnectionError(err) {
+			c.endpointMu.Lock()
+			c.endpoint = current
+			c.endpointMu.Unlock()
+			return resp, err
+		}
+		if i < len(endpoints)-1 {
+			tflog.Warn(req.Context(), "LegoCharm API endpoint unreachable, failing over", map[string]interface{}{
+				"endpoint":      endpoints[current],
+				"next_endpoint": endpoints[(current+1)%len(endpoints)],
+				"error":         err.Error(),
+			})
+		}
+	}
+	return nil, err
+}
+
+// rebase returns a copy of req sent to rawURL, with its body rebuilt.
+func rebase(req *http.Request, rawURL string) (*http.Request, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	out := req.Clone(req.Context())
+	out.URL = u
+	out.Host = ""
+	out.Header.Del(RequestIDHeader)
+	if req.GetBody != nil {
+		if out.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
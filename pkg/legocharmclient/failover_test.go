@@ -0,0 +1,104 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// hostRecorder records the host of every request it sends.
+type hostRecorder struct {
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (r *hostRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	r.hosts = append(r.hosts, req.URL.Host)
+	r.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// unreachable returns the address of a server that is no longer listening.
+func unreachable() string {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+func failoverClient(t *testing.T, addresses ...string) (*Client, *hostRecorder) {
+	t.Helper()
+	client, err := NewClient(ptr(addresses[0]), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.FailoverURLs = addresses[1:]
+	recorder := &hostRecorder{}
+	client.HTTPClient = &http.Client{Transport: recorder}
+	return client, recorder
+}
+
+func TestFailover_ConnectionError(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+	down := unreachable()
+	client, recorder := failoverClient(t, down, srv.URL)
+	ctx := context.Background()
+
+	if _, err := client.CreateDomain(ctx, DomainData{Fqdn: "example.com"}); err != nil {
+		t.Fatalf("CreateDomain: %v", err)
+	}
+	if want := []string{`{"fqdn":"example.com"}`}; !reflect.DeepEqual(bodies, want) {
+		t.Fatalf("received bodies %q, want %q", bodies, want)
+	}
+
+	// The endpoint that answered is used from then on.
+	if _, err := client.GetDomainById(ctx, 7); err != nil {
+		t.Fatalf("GetDomainById: %v", err)
+	}
+	up := srv.Listener.Addr().String()
+	if want := []string{down[len("http://"):], up, up}; !reflect.DeepEqual(recorder.hosts, want) {
+		t.Fatalf("requests sent to %q, want %q", recorder.hosts, want)
+	}
+}
+
+func TestFailover_NotOnAPIError(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	other := httptest.NewServer(http.NotFoundHandler())
+	defer other.Close()
+	client, recorder := failoverClient(t, failing.URL, other.URL)
+
+	if _, err := client.GetDomainById(context.Background(), 7); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if want := []string{failing.Listener.Addr().String()}; !reflect.DeepEqual(recorder.hosts, want) {
+		t.Fatalf("requests sent to %q, want %q", recorder.hosts, want)
+	}
+}
+
+func TestFailover_AllUnreachable(t *testing.T) {
+	client, recorder := failoverClient(t, unreachable(), unreachable())
+
+	_, err := client.GetDomainById(context.Background(), 7)
+	if err == nil || !isConnectionError(err) {
+		t.Fatalf("expected a connection error, got %v", err)
+	}
+	if len(recorder.hosts) != 2 {
+		t.Fatalf("expected both endpoints to be tried, got %q", recorder.hosts)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// cannedTransport answers every request with the same status and body, so
+// fuzz targets exercise the decoding paths without a server.
+type cannedTransport struct {
+	status int
+	body   []byte
+}
+
+func (t cannedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: t.status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(string(t.body))),
+		Request:    req,
+	}, nil
+}
+
+func fuzzClient(t *testing.T, status int, body []byte) *Client {
+	client, err := NewClient(ptr("https://example.com"), ptr("admin"), ptr("pass"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.HTTPClient = &http.Client{Transport: cannedTransport{status: status, body: body}}
+	return client
+}
+
+// fuzzResponseSeeds are bodies the charm returns or could plausibly return:
+// plain lists, single objects, DRF pagination envelopes and error bodies.
+var fuzzResponseSeeds = []string{
+	`[]`,
+	`null`,
+	`{}`,
+	`[{"url":"https://example.com/api/v1/users/1/","username":"alice","groups":[],"is_active":true}]`,
+	`{"url":"https://example.com/api/v1/users/1/","username":"alice"}`,
+	`[{"id":1,"fqdn":"example.com"},{"id":2,"fqdn":"sub.example.com"}]`,
+	`{"id":1,"fqdn":"Example.com."}`,
+	`[{"id":3,"user":1,"domain":1,"access_level":"domain","text_record_access":false}]`,
+	`{"count":1,"next":null,"previous":null,"results":[{"username":"alice"}]}`,
+	`{"count":3,"next":"https://example.com/api/v1/domains/?page=2","previous":null,"results":[{"id":1,"fqdn":"example.com"}]}`,
+	`{"detail":"Authentication credentials were not provided."}`,
+	`{"username":["A user with that username already exists."],"password":"This field is required."}`,
+	`<html><body>502 Bad Gateway</body></html>`,
+	`[{"username":1}]`,
+	`{"id":"1","fqdn":["example.com"]}`,
+	``,
+}
+
+var fuzzStatusSeeds = []int{http.StatusOK, http.StatusCreated, http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadGateway}
+
+func addResponseSeeds(f *testing.F) {
+	for i, body := range fuzzResponseSeeds {
+		f.Add(fuzzStatusSeeds[i%len(fuzzStatusSeeds)], []byte(body))
+		f.Add(http.StatusOK, []byte(body))
+	}
+}
+
+// FuzzGetUserByUsername covers the array-vs-object fallback: whatever the
+// response, a user is only returned if it is the one asked for.
+func FuzzGetUserByUsername(f *testing.F) {
+	addResponseSeeds(f)
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		if status < 100 || status > 999 {
+			t.Skip()
+		}
+		user, err := fuzzClient(t, status, body).GetUserByUsername(context.Background(), "alice")
+		if err != nil {
+			if user != nil {
+				t.Fatalf("got user %+v along with error %v", user, err)
+			}
+			return
+		}
+		if status < 200 || status >= 400 {
+			t.Fatalf("status %d decoded without error", status)
+		}
+		if user == nil || user.Username != "alice" {
+			t.Fatalf("GetUserByUsername(alice) returned %+v for body %q", user, body)
+		}
+	})
+}
+
+// FuzzGetDomain covers the array-vs-object fallback of domain lookups: a
+// domain is only returned if its FQDN matches the one asked for.
+func FuzzGetDomain(f *testing.F) {
+	addResponseSeeds(f)
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		if status < 100 || status > 999 {
+			t.Skip()
+		}
+		domain, err := fuzzClient(t, status, body).GetDomain(context.Background(), "Example.COM.")
+		if err != nil {
+			if domain != (DomainData{}) {
+				t.Fatalf("got domain %+v along with error %v", domain, err)
+			}
+			return
+		}
+		if status < 200 || status >= 400 {
+			t.Fatalf("status %d decoded without error", status)
+		}
+		if NormalizeFQDN(domain.Fqdn) != "example.com" {
+			t.Fatalf("GetDomain(example.com) returned %+v for body %q", domain, body)
+		}
+	})
+}
+
+// FuzzListResponses covers the list endpoints, which accept only plain JSON
+// arrays: a pagination envelope or any other object must be an error rather
+// than a silently empty or truncated list.
+func FuzzListResponses(f *testing.F) {
+	addResponseSeeds(f)
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		if status < 100 || status > 999 {
+			t.Skip()
+		}
+		ctx := context.Background()
+		client := fuzzClient(t, status, body)
+
+		var isArray bool
+		var raw any
+		if json.Unmarshal(body, &raw) == nil {
+			_, isArray = raw.([]any)
+			isArray = isArray || raw == nil
+		}
+		check := func(name string, n int, err error) {
+			if err == nil && !isArray {
+				t.Fatalf("%s decoded %d items from non-array body %q", name, n, body)
+			}
+			if err == nil && (status < 200 || status >= 400) {
+				t.Fatalf("%s: status %d decoded without error", name, status)
+			}
+		}
+
+		users, err := client.ListUsers(ctx)
+		check("ListUsers", len(users), err)
+		domains, err := client.ListDomains(ctx)
+		check("ListDomains", len(domains), err)
+		// The permissions endpoint answers 404 for filters matching nothing.
+		accesses, err := client.ListAllDomainAccess(ctx)
+		if status == http.StatusNotFound {
+			if err != nil || accesses != nil {
+				t.Fatalf("ListAllDomainAccess: got %v, %v for status 404", accesses, err)
+			}
+			return
+		}
+		check("ListAllDomainAccess", len(accesses), err)
+	})
+}
+
+// FuzzAPIError covers decoding of error bodies, which may be DRF field
+// errors, a detail message, or not JSON at all.
+func FuzzAPIError(f *testing.F) {
+	addResponseSeeds(f)
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		apiErr := (&Client{}).newAPIError("do thing", &http.Response{StatusCode: status}, body)
+		if apiErr.Body != redactText(string(body), nil) || apiErr.StatusCode != status {
+			t.Fatalf("APIError does not preserve the response: %+v", apiErr)
+		}
+		if !strings.Contains(apiErr.Error(), "failed to do thing") {
+			t.Fatalf("unexpected message %q", apiErr.Error())
+		}
+		if errors.Is(apiErr, ErrUnauthorized) != (status == http.StatusUnauthorized) {
+			t.Fatalf("errors.Is(ErrUnauthorized) wrong for status %d", status)
+		}
+		if errors.Is(apiErr, ErrForbidden) != (status == http.StatusForbidden) {
+			t.Fatalf("errors.Is(ErrForbidden) wrong for status %d", status)
+		}
+
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(body, &fields) != nil && apiErr.FieldErrors != nil {
+			t.Fatalf("field errors %v decoded from a body that is not an object", apiErr.FieldErrors)
+		}
+		for field := range apiErr.FieldErrors {
+			if _, ok := fields[field]; !ok {
+				t.Fatalf("field error for %q, which is not in the body", field)
+			}
+		}
+	})
+}
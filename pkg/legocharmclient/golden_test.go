@@ -0,0 +1,265 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite testdata/golden from the current decoding of testdata/responses")
+
+// goldenResult is what a golden file records: the decoded value, or the
+// error and, for API errors, its decoded fields.
+type goldenResult struct {
+	Result      any                 `json:"result,omitempty"`
+	Error       string              `json:"error,omitempty"`
+	StatusCode  int                 `json:"status_code,omitempty"`
+	FieldErrors map[string][]string `json:"field_errors,omitempty"`
+	NotFound    bool                `json:"not_found,omitempty"`
+	Forbidden   bool                `json:"forbidden,omitempty"`
+}
+
+// TestDecodeGolden serves each response in testdata/responses to the client
+// method that receives it from the charm and compares the outcome with
+// testdata/golden/<name>.json. Run with -update after an intended change to
+// the decoding and review the diff.
+func TestDecodeGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		status   int
+		call     func(ctx context.Context, c *Client) (any, error)
+	}{
+		{
+			name:     "get_user_by_id",
+			response: "user.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetUserById(ctx, "3")
+			},
+		},
+		{
+			name:     "get_user_by_username_list",
+			response: "users.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetUserByUsername(ctx, "alice")
+			},
+		},
+		{
+			name:     "get_user_by_username_object",
+			response: "user.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetUserByUsername(ctx, "alice")
+			},
+		},
+		{
+			name:     "get_user_by_username_empty",
+			response: "empty_list.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetUserByUsername(ctx, "alice")
+			},
+		},
+		{
+			name:     "list_users",
+			response: "users.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.ListUsers(ctx)
+			},
+		},
+		{
+			name:     "list_users_empty",
+			response: "empty_list.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.ListUsers(ctx)
+			},
+		},
+		{
+			name:     "create_user_validation_error",
+			response: "error_validation.json",
+			status:   http.StatusBadRequest,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.CreateUser(ctx, UserCreateData{Username: "alice"})
+			},
+		},
+		{
+			name:     "get_user_forbidden",
+			response: "error_detail.json",
+			status:   http.StatusForbidden,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetUserById(ctx, "3")
+			},
+		},
+		{
+			name:     "get_domain_by_id",
+			response: "domain.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetDomainById(ctx, 7)
+			},
+		},
+		{
+			name:     "get_domain_list",
+			response: "domains.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetDomain(ctx, "Sub.Example.com.")
+			},
+		},
+		{
+			name:     "get_domain_object",
+			response: "domain.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetDomain(ctx, "example.com")
+			},
+		},
+		{
+			name:     "get_domain_no_match",
+			response: "domains.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetDomain(ctx, "example.org")
+			},
+		},
+		{
+			name:     "list_domains",
+			response: "domains.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.ListDomains(ctx)
+			},
+		},
+		{
+			name:     "list_domains_paginated",
+			response: "domains_paginated.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.ListDomains(ctx)
+			},
+		},
+		{
+			name:     "get_domain_paginated",
+			response: "domains_paginated.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetDomain(ctx, "example.com")
+			},
+		},
+		{
+			name:     "list_domains_bad_gateway",
+			response: "error_gateway.html",
+			status:   http.StatusBadGateway,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.ListDomains(ctx)
+			},
+		},
+		{
+			name:     "get_domain_access_by_id",
+			response: "permission.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetDomainAccessById(ctx, 12)
+			},
+		},
+		{
+			name:     "get_domain_access_by_id_not_found",
+			response: "error_not_found.json",
+			status:   http.StatusNotFound,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.GetDomainAccessById(ctx, 12)
+			},
+		},
+		{
+			name:     "list_all_domain_access",
+			response: "permissions.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.ListAllDomainAccess(ctx)
+			},
+		},
+		{
+			name:     "list_all_domain_access_empty",
+			response: "empty_list.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.ListAllDomainAccess(ctx)
+			},
+		},
+		{
+			name:     "update_domain_access",
+			response: "permission.json",
+			status:   http.StatusOK,
+			call: func(ctx context.Context, c *Client) (any, error) {
+				return c.UpdateDomainAccess(ctx, 12, "subdomain")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", "responses", tt.response))
+			if err != nil {
+				t.Fatalf("reading response: %v", err)
+			}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write(body) // nolint:errcheck
+			}))
+			defer srv.Close()
+			client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("pass"))
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+
+			var got goldenResult
+			result, err := tt.call(context.Background(), client)
+			if err != nil {
+				got.Error = err.Error()
+				got.NotFound = errors.Is(err, ErrNotFound)
+				got.Forbidden = errors.Is(err, ErrForbidden)
+				var apiErr *APIError
+				if errors.As(err, &apiErr) {
+					got.StatusCode = apiErr.StatusCode
+					got.FieldErrors = apiErr.FieldErrors
+				}
+			} else {
+				got.Result = result
+			}
+			gotJSON, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatalf("encoding result: %v", err)
+			}
+			gotJSON = append(gotJSON, '\n')
+
+			goldenPath := filepath.Join("testdata", "golden", tt.name+".json")
+			if *updateGolden {
+				if err := os.WriteFile(goldenPath, gotJSON, 0o644); err != nil {
+					t.Fatalf("writing golden file: %v", err)
+				}
+				return
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("reading golden file (run with -update to create it): %v", err)
+			}
+			if !bytes.Equal(gotJSON, want) {
+				t.Errorf("decoding %s differs from %s:\n got: %s\nwant: %s", tt.response, goldenPath, gotJSON, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import "context"
+
+// RequestLimit caps the number of requests in flight to the API, for small
+// deployments that time out under many parallel requests. A request is in
+// flight from when it is sent until the API answers it. It is safe for
+// concurrent use and may be shared by several clients, which are then
+// limited together.
+type RequestLimit struct {
+	slots chan struct{}
+}
+
+// NewRequestLimit returns a limit of n requests in flight, at least one.
+func NewRequestLimit(n int) *RequestLimit {
+	return &RequestLimit{slots: make(chan struct{}, max(n, 1))}
+}
+
+// Max returns the number of requests allowed in flight.
+func (l *RequestLimit) Max() int {
+	return cap(l.slots)
+}
+
+// acquire waits for a request to be allowed in flight, or for ctx to be done.
+func (l *RequestLimit) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release ends a request in flight.
+func (l *RequestLimit) release() {
+	<-l.slots
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRequestLimit(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, most := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		most = max(most, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	limit := NewRequestLimit(2)
+	clients := make([]*Client, 2)
+	for i := range clients {
+		client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+		if err != nil {
+			t.Fatalf("unexpected error creating client: %v", err)
+		}
+		client.RequestLimit = limit
+		clients[i] = client
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := clients[i%2].GetDomainById(context.Background(), 7)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("GetDomainById: %v", err)
+		}
+	}
+	if most != 2 {
+		t.Fatalf("%d requests were in flight at most, want 2", most)
+	}
+}
+
+func TestRequestLimit_Canceled(t *testing.T) {
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.RequestLimit = NewRequestLimit(1)
+	go client.GetDomainById(context.Background(), 7) // nolint:errcheck
+	<-started
+
+	// The first request holds the only slot, so the second waits until its
+	// context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetDomainById(ctx, 7); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the waiting request to time out, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem API calls are logged to. Its level
+// can be set apart from the provider's with TF_LOG_PROVIDER_LEGOCHARM_API.
+const LogSubsystem = "legocharm_api"
+
+// RequestIDHeader carries the correlation ID sent with every request, so
+// that the provider's logs can be matched with those of the charm and any
+// ingress in front of it.
+const RequestIDHeader = "X-Request-ID"
+
+// maxTranscriptBody bounds the size of a body included in a transcript.
+const maxTranscriptBody = 64 << 10
+
+// HTTPLogging is the detail of the API calls logged to LogSubsystem.
+type HTTPLogging string
+
+const (
+	// HTTPLoggingDefault logs a summary of every call at DEBUG and the
+	// request body at TRACE.
+	HTTPLoggingDefault HTTPLogging = ""
+	// HTTPLoggingNone logs no API calls. Deprecated endpoints are still
+	// logged at WARN.
+	HTTPLoggingNone HTTPLogging = "none"
+	// HTTPLoggingSummary logs a summary of every call at DEBUG.
+	HTTPLoggingSummary HTTPLogging = "summary"
+	// HTTPLoggingHeaders adds the request and response headers, at DEBUG.
+	HTTPLoggingHeaders HTTPLogging = "headers"
+	// HTTPLoggingBodies adds the full request and response, at DEBUG.
+	HTTPLoggingBodies HTTPLogging = "bodies"
+)
+
+// httpLogging returns the detail of the calls to log.
+func (c *Client) httpLogging() HTTPLogging {
+	if c.Transcript {
+		return HTTPLoggingBodies
+	}
+	return c.HTTPLogging
+}
+
+// send dispatches req with the client's HTTP client and logs it to
+// LogSubsystem, as set by HTTPLogging: by default, a DEBUG line with the
+// method, path, status, duration and correlation ID of every call, and the
+// request body at TRACE. Secrets are redacted and credentials never logged.
+// With Stats set, the call is counted too, and with Deprecations set,
+// deprecations announced in the response are collected and logged at WARN.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	requestID := req.Header.Get(RequestIDHeader)
+	if requestID == "" {
+		requestID = newRequestID()
+		req.Header.Set(RequestIDHeader, requestID)
+	}
+
+	detail := c.httpLogging()
+	ctx := tflog.NewSubsystem(req.Context(), LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", strings.ToUpper(LogSubsystem)))
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+		"request_id":  requestID,
+	}
+	if req.URL.RawQuery != "" {
+		fields["http_query"] = req.URL.RawQuery
+	}
+	var body string
+	switch detail {
+	case HTTPLoggingDefault:
+		if body = requestBodyForLog(req); body != "" {
+			tflog.SubsystemTrace(ctx, LogSubsystem, "Sending API request", fields, map[string]interface{}{"http_request_body": body})
+		}
+	case HTTPLoggingHeaders:
+		tflog.SubsystemDebug(ctx, LogSubsystem, "API request transcript", fields, map[string]interface{}{"http_transcript": requestTranscript(req, "")})
+	case HTTPLoggingBodies:
+		body = requestBodyForLog(req)
+		tflog.SubsystemDebug(ctx, LogSubsystem, "API request transcript", fields, map[string]interface{}{"http_transcript": requestTranscript(req, body)})
+	}
+
+	if c.RequestLimit != nil {
+		if err := c.RequestLimit.acquire(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	start := time.Now()
+	resp, err := c.HTTPClient.Do(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	if c.RequestLimit != nil {
+		c.RequestLimit.release()
+	}
+	if c.Stats != nil {
+		c.Stats.record(req, resp)
+	}
+	if err != nil {
+		err = redactError(err, requestSecrets(req))
+		if detail != HTTPLoggingNone {
+			tflog.SubsystemDebug(ctx, LogSubsystem, "API request failed", fields, map[string]interface{}{"error": err.Error()})
+		}
+		return nil, err
+	}
+	fields["http_status"] = resp.StatusCode
+	if detail != HTTPLoggingNone {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "API request", fields)
+	}
+	if c.Deprecations != nil {
+		if d, ok := c.Deprecations.record(req, resp); ok {
+			tflog.SubsystemWarn(ctx, LogSubsystem, "API endpoint deprecated", fields, map[string]interface{}{
+				"deprecation": resp.Header.Get("Deprecation"),
+				"sunset":      resp.Header.Get("Sunset"),
+				"link":        d.Link,
+			})
+		}
+	}
+	if detail == HTTPLoggingHeaders || detail == HTTPLoggingBodies {
+		transcript, err := responseTranscript(resp, requestSecrets(req), detail == HTTPLoggingBodies)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		tflog.SubsystemDebug(ctx, LogSubsystem, "API response transcript", fields, map[string]interface{}{"http_transcript": transcript})
+	}
+	return resp, nil
+}
+
+// newRequestID returns a random correlation ID.
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b) // nolint:errcheck // never fails
+	return hex.EncodeToString(b)
+}
+
+// requestBodyForLog returns the body of req for logging, with secretFields
+// redacted, without consuming it. A body that is not JSON is not logged at
+// all, since it cannot be checked for secrets.
+func requestBodyForLog(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	r, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer r.Close()
+	body, err := io.ReadAll(r)
+	if err != nil || len(body) == 0 {
+		return ""
+	}
+	if redactedBody, ok := redactJSON(body); ok {
+		return redactedBody
+	}
+	return redacted
+}
+
+// requestTranscript renders req in HTTP/1.1 wire format, with secret headers
+// replaced and body as returned by requestBodyForLog.
+func requestTranscript(req *http.Request, body string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s HTTP/1.1\r\nHost: %s\r\n", req.Method, req.URL.RequestURI(), req.URL.Host)
+	writeHeaders(&b, req.Header)
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return b.String()
+}
+
+// responseTranscript renders resp in HTTP/1.1 wire format, with secret
+// headers and fields, and any of secrets, replaced. With withBody, it reads
+// the body and replaces it with a copy, so the caller can still read it.
+func responseTranscript(resp *http.Response, secrets []string, withBody bool) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "HTTP/1.1 %s\r\n", resp.Status)
+	writeHeaders(&b, resp.Header)
+	b.WriteString("\r\n")
+	if !withBody {
+		return replaceSecrets(b.String(), secrets), nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if redactedBody, ok := redactJSON(body); ok {
+		b.WriteString(redactedBody)
+	} else if len(body) > maxTranscriptBody {
+		fmt.Fprintf(&b, "%s\n[%d more bytes]", redactText(string(body[:maxTranscriptBody]), nil), len(body)-maxTranscriptBody)
+	} else {
+		b.WriteString(redactText(string(body), nil))
+	}
+	return replaceSecrets(b.String(), secrets), nil
+}
+
+// writeHeaders writes h sorted by name, with secretHeaders replaced.
+func writeHeaders(b *strings.Builder, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range h[name] {
+			for _, secret := range secretHeaders {
+				if http.CanonicalHeaderKey(name) == secret {
+					value = redacted
+				}
+			}
+			fmt.Fprintf(b, "%s: %s\r\n", name, value)
+		}
+	}
+}
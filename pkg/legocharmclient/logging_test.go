@@ -0,0 +1,201 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestSend_LogsRequests(t *testing.T) {
+	var requestID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get(RequestIDHeader)
+		w.Write([]byte(`{"url":"/api/v1/users/3/","username":"alice"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	email := "alice@example.com"
+	if _, err := client.UpdateUser(ctx, "3", UserUpdateData{Password: "user-secret", Email: &email}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+
+	if requestID == "" {
+		t.Fatalf("expected the %s header to be sent", RequestIDHeader)
+	}
+	for _, secret := range []string{"user-secret", "admin-secret", "Basic "} {
+		if strings.Contains(out.String(), secret) {
+			t.Fatalf("log contains %q:\n%s", secret, out.String())
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	if err != nil {
+		t.Fatalf("decoding log: %v", err)
+	}
+	var sent, done map[string]interface{}
+	for _, entry := range entries {
+		switch entry["@message"] {
+		case "Sending API request":
+			sent = entry
+		case "API request":
+			done = entry
+		}
+	}
+	if sent == nil || done == nil {
+		t.Fatalf("expected request and response log entries, got %v", entries)
+	}
+	if got := sent["http_request_body"]; got != `{"email":"alice@example.com","password":"***"}` {
+		t.Fatalf("unexpected logged body %v", got)
+	}
+	if done["@module"] != "provider."+LogSubsystem || done["@level"] != "debug" {
+		t.Fatalf("unexpected module or level in %v", done)
+	}
+	if done["http_method"] != "PATCH" || done["http_path"] != "/api/v1/users/3/" || done["http_status"] != float64(200) || done["request_id"] != requestID {
+		t.Fatalf("unexpected fields in %v", done)
+	}
+	if _, ok := done["duration_ms"]; !ok {
+		t.Fatalf("expected duration_ms in %v", done)
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := map[string]string{
+		`{"username":"alice","password":"pw"}`:          `{"password":"***","username":"alice"}`,
+		`{"api_token":"t","Client_Secret":1}`:           `{"Client_Secret":"***","api_token":"***"}`,
+		`{"fqdn":"<example>.com","id":12345678901234}`:  `{"fqdn":"<example>.com","id":12345678901234}`,
+		`[{"user":{"password":"pw"}},"password"]`:       `[{"user":{"password":"***"}},"password"]`,
+		`{"results":[{"secret":{"nested":"x"}}],"n":1}`: `{"n":1,"results":[{"secret":"***"}]}`,
+	}
+	for body, want := range tests {
+		if got, ok := redactJSON([]byte(body)); !ok || got != want {
+			t.Errorf("redactJSON(%s) = %s, %t; want %s", body, got, ok, want)
+		}
+	}
+	for _, body := range []string{`password=pw`, `{"a":1} {"b":2}`, ``} {
+		if got, ok := redactJSON([]byte(body)); ok {
+			t.Errorf("redactJSON(%s) = %s, want not JSON", body, got)
+		}
+	}
+}
+
+func TestSend_Transcript(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "sessionid=s3cr3t")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"url":"/api/v1/users/3/","username":"alice","password":"hash"}]`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	// Without Transcript, no transcript is logged.
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	if _, err := client.GetUserByUsername(ctx, "alice"); err != nil {
+		t.Fatalf("GetUserByUsername: %v", err)
+	}
+	if strings.Contains(out.String(), "transcript") {
+		t.Fatalf("transcript logged without Transcript set:\n%s", out.String())
+	}
+
+	client.Transcript = true
+	out.Reset()
+	user, err := client.GetUserByUsername(ctx, "alice")
+	if err != nil || user.Username != "alice" {
+		t.Fatalf("GetUserByUsername with transcript: %+v, %v", user, err)
+	}
+	for _, secret := range []string{"admin-secret", "YWRtaW46YWRtaW4tc2VjcmV0", "s3cr3t", "hash"} {
+		if strings.Contains(out.String(), secret) {
+			t.Fatalf("log contains %q:\n%s", secret, out.String())
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	if err != nil {
+		t.Fatalf("decoding log: %v", err)
+	}
+	transcripts := map[string]string{}
+	for _, entry := range entries {
+		if transcript, ok := entry["http_transcript"].(string); ok {
+			transcripts[entry["@message"].(string)] = transcript
+		}
+	}
+	request := transcripts["API request transcript"]
+	if !strings.HasPrefix(request, "GET /api/v1/users/?username=alice HTTP/1.1\r\n") || !strings.Contains(request, "Authorization: ***\r\n") {
+		t.Fatalf("unexpected request transcript %q", request)
+	}
+	response := transcripts["API response transcript"]
+	if !strings.HasPrefix(response, "HTTP/1.1 200 OK\r\n") || !strings.Contains(response, "Set-Cookie: ***\r\n") ||
+		!strings.HasSuffix(response, `[{"password":"***","url":"/api/v1/users/3/","username":"alice"}]`) {
+		t.Fatalf("unexpected response transcript %q", response)
+	}
+}
+
+func TestSend_HTTPLogging(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":3,"username":"alice","email":"alice@example.com"}`)) // nolint:errcheck
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	tests := []struct {
+		logging  HTTPLogging
+		messages []string
+		body     bool
+	}{
+		{logging: HTTPLoggingDefault, messages: []string{"Sending API request", "API request"}, body: true},
+		{logging: HTTPLoggingNone},
+		{logging: HTTPLoggingSummary, messages: []string{"API request"}},
+		{logging: HTTPLoggingHeaders, messages: []string{"API request transcript", "API request", "API response transcript"}},
+		{logging: HTTPLoggingBodies, messages: []string{"API request transcript", "API request", "API response transcript"}, body: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%q", tt.logging), func(t *testing.T) {
+			client.HTTPLogging = tt.logging
+			var out bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &out)
+			if _, err := client.UpdateUser(ctx, "3", UserUpdateData{Email: ptr("alice@example.com")}); err != nil {
+				t.Fatalf("UpdateUser: %v", err)
+			}
+			logged := out.String()
+			entries, err := tflogtest.MultilineJSONDecode(&out)
+			if err != nil {
+				t.Fatalf("decoding log: %v", err)
+			}
+			var messages []string
+			for _, entry := range entries {
+				messages = append(messages, entry["@message"].(string))
+			}
+			if !reflect.DeepEqual(messages, tt.messages) {
+				t.Fatalf("logged %q, want %q", messages, tt.messages)
+			}
+			if got := strings.Contains(logged, "alice@example.com"); got != tt.body {
+				t.Fatalf("bodies logged: %t, want %t:\n%s", got, tt.body, logged)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ErrMaintenance is matched (via errors.Is) by an APIError for a 503 Service
+// Unavailable response with a Retry-After header, which the charm returns
+// while it is in maintenance, for example during an upgrade.
+var ErrMaintenance = errors.New("API in maintenance")
+
+// now returns the time on the clock of the client.
+func (c *Client) now() time.Time {
+	if c.Clock == nil {
+		return time.Now()
+	}
+	return c.Clock.Now()
+}
+
+// sleep waits for d on the clock of the client, or for ctx to be done.
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	var after <-chan time.Time
+	if c.Clock == nil {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		after = t.C
+	} else {
+		after = c.Clock.After(d)
+	}
+	select {
+	case <-after:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryAfter returns the delay a maintenance response asks for at now: the
+// Retry-After header of a 503 response, in seconds or as an HTTP date. It
+// reports false for any other response.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sendWaitingForMaintenance sends req and, with MaintenanceWait set, resends
+// it while the API answers that it is in maintenance, after the delay it
+// asks for. The wait is shared by the requests of the client: it starts with
+// the first maintenance response and ends after MaintenanceWait, or with the
+// first other response. Once it is over, maintenance responses are returned
+// to the caller at once, for it to report ErrMaintenance.
+func (c *Client) sendWaitingForMaintenance(req *http.Request) (*http.Response, error) {
+	for {
+		resp, err := c.sendFailingOver(req)
+		if err != nil || c.MaintenanceWait <= 0 {
+			return resp, err
+		}
+		delay, ok := retryAfter(resp, c.now())
+		if !ok {
+			c.maintenanceMu.Lock()
+			c.maintenanceUntil = time.Time{}
+			c.maintenanceMu.Unlock()
+			return resp, nil
+		}
+
+		c.maintenanceMu.Lock()
+		if c.maintenanceUntil.IsZero() {
+			c.maintenanceUntil = c.now().Add(c.MaintenanceWait)
+		}
+		remaining := c.maintenanceUntil.Sub(c.now())
+		c.maintenanceMu.Unlock()
+		if remaining <= 0 || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		io.Copy(io.Discard, resp.Body) // nolint:errcheck // the body is not used
+		resp.Body.Close()
+		delay = min(max(delay, time.Second), remaining)
+		tflog.Info(req.Context(), "LegoCharm API in maintenance, waiting before retrying", map[string]interface{}{
+			"http_method": req.Method,
+			"http_path":   req.URL.Path,
+			"retry_after": delay.String(),
+			"wait_left":   remaining.Round(time.Second).String(),
+		})
+		if err := c.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		retry := req.Clone(retryContext(req.Context()))
+		retry.Header.Del(RequestIDHeader)
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		req = retry
+	}
+}
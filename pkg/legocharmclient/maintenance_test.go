@@ -0,0 +1,179 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/clock"
+)
+
+// testStart is the time the fake clocks of the tests start at.
+var testStart = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// maintenanceServer answers with 503 and the Retry-After header while it has
+// maintenance responses left, then with body. It records the request bodies.
+type maintenanceServer struct {
+	mu         sync.Mutex
+	left       int
+	retryAfter string
+	body       string
+	received   []string
+}
+
+func (s *maintenanceServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.received = append(s.received, string(body))
+	if s.left != 0 {
+		s.left--
+		if s.retryAfter != "" {
+			w.Header().Set("Retry-After", s.retryAfter)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("<h1>Upgrading</h1>")) // nolint:errcheck
+		return
+	}
+	w.Write([]byte(s.body)) // nolint:errcheck
+}
+
+// maintenanceClient returns a client of s waiting for wait on the returned
+// fake clock, which only moves when slept on.
+func maintenanceClient(t *testing.T, s *maintenanceServer, wait time.Duration) (*Client, *clock.Fake) {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("pass"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.MaintenanceWait = wait
+	fake := clock.NewFake(testStart)
+	client.Clock = fake
+	return client, fake
+}
+
+func TestMaintenance_FailsWithoutWait(t *testing.T) {
+	s := &maintenanceServer{left: -1, retryAfter: "30"}
+	client, fake := maintenanceClient(t, s, 0)
+
+	_, err := client.GetDomainById(context.Background(), 7)
+	if !errors.Is(err, ErrMaintenance) {
+		t.Fatalf("expected ErrMaintenance, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 30*time.Second {
+		t.Fatalf("expected an APIError asking to retry after 30s, got %#v", err)
+	}
+	if len(s.received) != 1 || len(fake.Slept()) != 0 {
+		t.Fatalf("expected a single request and no wait, got %d requests and waits %v", len(s.received), fake.Slept())
+	}
+}
+
+func TestMaintenance_RetryAfterDateOnClientClock(t *testing.T) {
+	s := &maintenanceServer{left: -1, retryAfter: testStart.Add(45 * time.Second).Format(http.TimeFormat)}
+	client, _ := maintenanceClient(t, s, 0)
+
+	_, err := client.GetDomainById(context.Background(), 7)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 45*time.Second {
+		t.Fatalf("expected an APIError asking to retry after 45s on the client clock, got %#v", err)
+	}
+}
+
+func TestMaintenance_WaitsAndResends(t *testing.T) {
+	s := &maintenanceServer{left: 2, retryAfter: "30", body: `{"id":7,"fqdn":"example.com"}`}
+	client, fake := maintenanceClient(t, s, 10*time.Minute)
+
+	domain, err := client.CreateDomain(context.Background(), DomainData{Fqdn: "example.com"})
+	if err != nil {
+		t.Fatalf("CreateDomain: %v", err)
+	}
+	if domain.ID != 7 {
+		t.Fatalf("unexpected domain %+v", domain)
+	}
+	want := []string{`{"fqdn":"example.com"}`, `{"fqdn":"example.com"}`, `{"fqdn":"example.com"}`}
+	if !reflect.DeepEqual(s.received, want) {
+		t.Fatalf("received bodies %q, want the body resent each time: %q", s.received, want)
+	}
+	if want := []time.Duration{30 * time.Second, 30 * time.Second}; !reflect.DeepEqual(fake.Slept(), want) {
+		t.Fatalf("waited %v, want %v", fake.Slept(), want)
+	}
+}
+
+func TestMaintenance_SharedWait(t *testing.T) {
+	s := &maintenanceServer{left: -1, retryAfter: "120"}
+	client, fake := maintenanceClient(t, s, 5*time.Minute)
+	ctx := context.Background()
+
+	if _, err := client.GetDomainById(ctx, 7); !errors.Is(err, ErrMaintenance) {
+		t.Fatalf("expected ErrMaintenance, got %v", err)
+	}
+	if want := []time.Duration{2 * time.Minute, 2 * time.Minute, time.Minute}; !reflect.DeepEqual(fake.Slept(), want) {
+		t.Fatalf("waited %v, want %v", fake.Slept(), want)
+	}
+
+	// The wait is over for every request of the client.
+	if _, err := client.GetDomainById(ctx, 8); !errors.Is(err, ErrMaintenance) {
+		t.Fatalf("expected ErrMaintenance, got %v", err)
+	}
+	if len(fake.Slept()) != 3 {
+		t.Fatalf("expected no further wait, waited %v", fake.Slept())
+	}
+
+	// Until the API answers again.
+	s.mu.Lock()
+	s.left, s.body = 0, `{"id":7,"fqdn":"example.com"}`
+	s.mu.Unlock()
+	if _, err := client.GetDomainById(ctx, 7); err != nil {
+		t.Fatalf("GetDomainById: %v", err)
+	}
+	s.mu.Lock()
+	s.left = 1
+	s.mu.Unlock()
+	if _, err := client.GetDomainById(ctx, 7); err != nil {
+		t.Fatalf("GetDomainById: %v", err)
+	}
+	if len(fake.Slept()) != 4 {
+		t.Fatalf("expected a new wait, waited %v", fake.Slept())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		value  string
+		want   time.Duration
+		ok     bool
+	}{
+		{name: "seconds", status: http.StatusServiceUnavailable, value: "45", want: 45 * time.Second, ok: true},
+		{name: "date", status: http.StatusServiceUnavailable, value: "Sun, 01 Mar 2026 12:05:00 GMT", want: 5 * time.Minute, ok: true},
+		{name: "past date", status: http.StatusServiceUnavailable, value: "Sun, 01 Mar 2026 11:00:00 GMT", ok: true},
+		{name: "no header", status: http.StatusServiceUnavailable},
+		{name: "malformed", status: http.StatusServiceUnavailable, value: "soon"},
+		{name: "rate limited", status: http.StatusTooManyRequests, value: "45"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			if tt.value != "" {
+				resp.Header.Set("Retry-After", tt.value)
+			}
+			got, ok := retryAfter(resp, testStart)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("retryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// The client never puts a credential in an error or log line. Text from the
+// API, which may echo what it was sent, goes through redactText with the
+// secrets of the request it answers, and errors from the transport through
+// redactError.
+
+// redacted replaces secrets in errors and logs.
+const redacted = "***"
+
+// secretFields are the JSON fields never logged or reported in clear: any
+// field whose name contains one of them.
+var secretFields = []string{"password", "secret", "token"}
+
+// secretHeaders are the headers whose values are never logged.
+var secretHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// minSecretLen is the length below which a known secret is not searched for
+// in text, where it would match parts of words. It is the minimum length of
+// Django's default password validation.
+const minSecretLen = 8
+
+// secretPatterns match credentials in text that is not JSON, such as an
+// error page of a proxy echoing the request, and replace them.
+var secretPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// Header lines.
+	{regexp.MustCompile(`(?i)\b((?:proxy-)?authorization|(?:set-)?cookie)(\s*[:=]\s*)[^\r\n]*`), "${1}${2}" + redacted},
+	// Authorization values.
+	{regexp.MustCompile(`(?i)\b(basic|bearer|token)(\s+)[A-Za-z0-9+/=._~-]{8,}`), "${1}${2}" + redacted},
+	// Fields in forms, query strings and other key-value text.
+	{regexp.MustCompile(`(?i)\b(\w*(?:password|secret|token)\w*)(["']?\s*[:=]\s*["']?)[^\s"'&,;<>]+`), "${1}${2}" + redacted},
+	// Credentials in URLs.
+	{regexp.MustCompile(`(//)[^/@\s:]+:[^/@\s]*@`), "${1}" + redacted + "@"},
+}
+
+// redactText scrubs secrets from text received from the API. JSON has the
+// values of secretFields replaced and is otherwise kept as it is; other text
+// has the credentials matched by secretPatterns replaced. In both, any of the
+// known secrets is replaced wherever it appears.
+func redactText(s string, secrets []string) string {
+	if redactedJSON, changed, ok := redactJSONIfNeeded([]byte(s)); ok {
+		if changed {
+			s = redactedJSON
+		}
+	} else {
+		for _, p := range secretPatterns {
+			s = p.re.ReplaceAllString(s, p.repl)
+		}
+	}
+	return replaceSecrets(s, secrets)
+}
+
+// replaceSecrets replaces each of secrets in s.
+func replaceSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		if len(secret) >= minSecretLen {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
+
+// redactBody returns the body of resp for an error, with secrets scrubbed.
+func redactBody(resp *http.Response, body []byte) string {
+	return redactText(string(body), requestSecrets(resp.Request))
+}
+
+// requestSecrets returns the secrets that req carries, which the response
+// to it may echo: its credentials and the values of its secret body fields.
+func requestSecrets(req *http.Request) []string {
+	if req == nil {
+		return nil
+	}
+	var secrets []string
+	if _, password, ok := req.BasicAuth(); ok {
+		secrets = append(secrets, password)
+	}
+	for _, name := range secretHeaders {
+		if value := req.Header.Get(name); value != "" {
+			secrets = append(secrets, value)
+			if _, credentials, ok := strings.Cut(value, " "); ok {
+				secrets = append(secrets, credentials)
+			}
+		}
+	}
+	if req.URL != nil && req.URL.User != nil {
+		if password, ok := req.URL.User.Password(); ok {
+			secrets = append(secrets, password)
+		}
+	}
+	if req.GetBody == nil {
+		return secrets
+	}
+	r, err := req.GetBody()
+	if err != nil {
+		return secrets
+	}
+	defer r.Close()
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return secrets
+	}
+	var v any
+	if json.Unmarshal(body, &v) == nil {
+		secrets = append(secrets, secretValues(v, false)...)
+	}
+	return secrets
+}
+
+// secretValues returns the strings of v held by secretFields, or all of
+// them if secret is set.
+func secretValues(v any, secret bool) []string {
+	var values []string
+	switch v := v.(type) {
+	case string:
+		if secret {
+			values = append(values, v)
+		}
+	case map[string]any:
+		for name, value := range v {
+			values = append(values, secretValues(value, secret || isSecretField(name))...)
+		}
+	case []any:
+		for _, value := range v {
+			values = append(values, secretValues(value, secret)...)
+		}
+	}
+	return values
+}
+
+// redactError returns err with secrets scrubbed from its message. The error
+// it wraps is kept for errors.Is and errors.As.
+func redactError(err error, secrets []string) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	scrubbed := redactText(msg, secrets)
+	if scrubbed == msg {
+		return err
+	}
+	return &redactedError{msg: scrubbed, err: err}
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+func isSecretField(name string) bool {
+	for _, secret := range secretFields {
+		if strings.Contains(strings.ToLower(name), secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSON replaces the values of secretFields, at any depth, in a JSON
+// body. It reports false if body is not JSON.
+func redactJSON(body []byte) (string, bool) {
+	v, _, ok := decodeRedacted(body)
+	if !ok {
+		return "", false
+	}
+	return encodeJSON(v)
+}
+
+// redactJSONIfNeeded is redactJSON, but also reports whether body had secrets
+// to replace. If it had none, the returned string is empty and body is to be
+// used as it is.
+func redactJSONIfNeeded(body []byte) (string, bool, bool) {
+	v, changed, ok := decodeRedacted(body)
+	if !ok || !changed {
+		return "", false, ok
+	}
+	redactedBody, ok := encodeJSON(v)
+	return redactedBody, ok, ok
+}
+
+// decodeRedacted decodes a JSON body with the values of secretFields
+// replaced, and reports whether there were any.
+func decodeRedacted(body []byte) (any, bool, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return nil, false, false
+	}
+	changed := false
+	return redactValue(v, &changed), changed, true
+}
+
+func encodeJSON(v any) (string, bool) {
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(out.String()), true
+}
+
+// redactValue replaces the values of secretFields in v, except for lists,
+// which in responses are the validation messages of the field, such as
+// {"password": ["This password is too common."]}. Those are scrubbed of
+// the known secrets instead, like the rest of the text.
+func redactValue(v any, changed *bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for name, value := range v {
+			if _, isList := value.([]any); isSecretField(name) && !isList {
+				v[name] = redacted
+				*changed = true
+				continue
+			}
+			v[name] = redactValue(value, changed)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value, changed)
+		}
+	}
+	return v
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CallStats counts API calls by method, endpoint and outcome. It is safe for
+// concurrent use and may be shared by several clients; its zero value is
+// ready to use.
+type CallStats struct {
+	mu     sync.Mutex
+	counts map[CallKey]int
+}
+
+// CallKey identifies a group of counted calls.
+type CallKey struct {
+	Method string
+	// Endpoint is the request path with object IDs replaced by {id}, such
+	// as /api/v1/users/{id}/.
+	Endpoint string
+	// Outcome is the status class of the response, such as 2xx or 4xx, or
+	// "error" when no response was received.
+	Outcome string
+	// Retry is set for the attempts of a call after the first, sent again
+	// once the API came out of maintenance or to another endpoint.
+	Retry bool
+}
+
+// retryKey is the context key marking the requests of retries.
+type retryKey struct{}
+
+// retryContext returns ctx for the request of a retry of a previous attempt.
+func retryContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, true)
+}
+
+// CallCount is the number of calls counted for a CallKey.
+type CallCount struct {
+	CallKey
+	Count int
+}
+
+// record counts a call to req which received resp, or failed if resp is nil.
+func (s *CallStats) record(req *http.Request, resp *http.Response) {
+	key := CallKey{Method: req.Method, Endpoint: endpoint(req.URL.Path), Outcome: "error", Retry: req.Context().Value(retryKey{}) != nil}
+	if resp != nil {
+		key.Outcome = fmt.Sprintf("%dxx", resp.StatusCode/100)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts == nil {
+		s.counts = make(map[CallKey]int)
+	}
+	s.counts[key]++
+}
+
+// Counts returns the calls counted so far, sorted by endpoint, method,
+// outcome and first attempts before retries.
+func (s *CallStats) Counts() []CallCount {
+	s.mu.Lock()
+	counts := make([]CallCount, 0, len(s.counts))
+	for key, n := range s.counts {
+		counts = append(counts, CallCount{CallKey: key, Count: n})
+	}
+	s.mu.Unlock()
+	sort.Slice(counts, func(i, j int) bool {
+		a, b := counts[i], counts[j]
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.Outcome != b.Outcome {
+			return a.Outcome < b.Outcome
+		}
+		return !a.Retry && b.Retry
+	})
+	return counts
+}
+
+// Summary describes the calls counted so far in one line, such as
+// "312 GETs, 14 POSTs, 1 DELETE; 3 retries; 2 failed". Calls are counted
+// once however many attempts they took, and the attempts that failed are
+// counted as failed, whether they were retried or not.
+func (s *CallStats) Summary() string {
+	byMethod := make(map[string]int)
+	retries, failed := 0, 0
+	for _, c := range s.Counts() {
+		if c.Retry {
+			retries += c.Count
+		} else {
+			byMethod[c.Method] += c.Count
+		}
+		if c.Outcome != "2xx" {
+			failed += c.Count
+		}
+	}
+	if len(byMethod) == 0 {
+		return "no API calls"
+	}
+	methods := make([]string, 0, len(byMethod))
+	for method := range byMethod {
+		methods = append(methods, method)
+	}
+	// Most frequent first, so that the traffic that matters leads.
+	sort.Slice(methods, func(i, j int) bool {
+		if byMethod[methods[i]] != byMethod[methods[j]] {
+			return byMethod[methods[i]] > byMethod[methods[j]]
+		}
+		return methods[i] < methods[j]
+	})
+	parts := make([]string, len(methods))
+	for i, method := range methods {
+		parts[i] = fmt.Sprintf("%d %s", byMethod[method], method)
+		if byMethod[method] != 1 {
+			parts[i] += "s"
+		}
+	}
+	summary := strings.Join(parts, ", ")
+	if retries == 1 {
+		summary += "; 1 retry"
+	} else if retries > 1 {
+		summary += fmt.Sprintf("; %d retries", retries)
+	}
+	if failed > 0 {
+		summary += fmt.Sprintf("; %d failed", failed)
+	}
+	return summary
+}
+
+// endpoint returns path with its numeric segments, the object IDs, replaced
+// by {id}.
+func endpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
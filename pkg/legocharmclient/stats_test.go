@@ -0,0 +1,88 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestCallStats(t *testing.T) {
+	client, err := NewClient(ptr("https://lego.example.com"), ptr("admin"), ptr("pass"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.Stats = &CallStats{}
+	client.HTTPClient = &http.Client{Transport: routeTransport{
+		"/api/v1/users/3/": []byte(`{"url":"/api/v1/users/3/","username":"alice"}`),
+		"/api/v1/users/4/": []byte(`{"url":"/api/v1/users/4/","username":"bob"}`),
+	}}
+	ctx := context.Background()
+	for _, id := range []string{"3", "4", "5"} {
+		client.GetUserById(ctx, id) // nolint:errcheck // 5 is not found
+	}
+	if _, err := client.DeleteUserById(ctx, "3"); err != nil {
+		t.Fatalf("DeleteUserById: %v", err)
+	}
+	client.HTTPClient = &http.Client{Transport: failingTransport{}}
+	if _, err := client.GetDomainById(ctx, 7); err == nil {
+		t.Fatalf("expected GetDomainById to fail")
+	}
+
+	want := []CallCount{
+		{CallKey{Method: "GET", Endpoint: "/api/v1/domains/{id}/", Outcome: "error"}, 1},
+		{CallKey{Method: "DELETE", Endpoint: "/api/v1/users/{id}/", Outcome: "2xx"}, 1},
+		{CallKey{Method: "GET", Endpoint: "/api/v1/users/{id}/", Outcome: "2xx"}, 2},
+		{CallKey{Method: "GET", Endpoint: "/api/v1/users/{id}/", Outcome: "4xx"}, 1},
+	}
+	if got := client.Stats.Counts(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Counts() = %v, want %v", got, want)
+	}
+	if got, want := client.Stats.Summary(), "4 GETs, 1 DELETE; 2 failed"; got != want {
+		t.Fatalf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestCallStats_Retries(t *testing.T) {
+	s := &maintenanceServer{left: 1, retryAfter: "30", body: `{"id":7,"fqdn":"example.com"}`}
+	client, _ := maintenanceClient(t, s, 10*time.Minute)
+	client.FailoverURLs = []string{client.BaseURL}
+	client.BaseURL = unreachable()
+	client.Stats = &CallStats{}
+
+	if _, err := client.GetDomainById(context.Background(), 7); err != nil {
+		t.Fatalf("GetDomainById: %v", err)
+	}
+	want := []CallCount{
+		{CallKey{Method: "GET", Endpoint: "/api/v1/domains/{id}/", Outcome: "2xx", Retry: true}, 1},
+		{CallKey{Method: "GET", Endpoint: "/api/v1/domains/{id}/", Outcome: "5xx", Retry: true}, 1},
+		{CallKey{Method: "GET", Endpoint: "/api/v1/domains/{id}/", Outcome: "error"}, 1},
+	}
+	if got := client.Stats.Counts(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Counts() = %v, want %v", got, want)
+	}
+	if got, want := client.Stats.Summary(), "1 GET; 2 retries; 2 failed"; got != want {
+		t.Fatalf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestCallStats_Empty(t *testing.T) {
+	var stats CallStats
+	if got := stats.Counts(); len(got) != 0 {
+		t.Fatalf("Counts() = %v, want none", got)
+	}
+	if got, want := stats.Summary(), "no API calls"; got != want {
+		t.Fatalf("Summary() = %q, want %q", got, want)
+	}
+}
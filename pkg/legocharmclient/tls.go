@@ -0,0 +1,207 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/alexdlukens/terraform-provider-legocharm/pkg/pkcs11"
+)
+
+// TLSOptions restrict the TLS connections of the client to the API, for
+// environments that must enforce approved protocol versions and ciphers, or
+// that authenticate clients with certificates.
+type TLSOptions struct {
+	// MinVersion is the minimum TLS version, "1.2" or "1.3". Go's default,
+	// TLS 1.2, if empty.
+	MinVersion string
+	// CipherSuites are the names of the allowed cipher suites, as in
+	// crypto/tls, such as "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384". Go
+	// cannot restrict the TLS 1.3 suites, so either all of them are listed,
+	// or none and TLS 1.3 is not used. Go's defaults if empty.
+	CipherSuites []string
+	// ClientCertificate is presented to the API, which authenticates the
+	// client with it on top of its credentials. Its PrivateKey may be any
+	// crypto.Signer, such as one backed by a hardware security module.
+	ClientCertificate *tls.Certificate
+}
+
+// IsZero reports whether the options leave Go's TLS defaults as they are.
+func (o TLSOptions) IsZero() bool {
+	return o.MinVersion == "" && len(o.CipherSuites) == 0 && o.ClientCertificate == nil
+}
+
+// tlsVersions are the TLS versions of TLSOptions.MinVersion.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Config returns the TLS configuration of the options.
+func (o TLSOptions) Config() (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if o.MinVersion != "" {
+		version, ok := tlsVersions[o.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported minimum TLS version %q: must be 1.2 or 1.3", o.MinVersion)
+		}
+		cfg.MinVersion = version
+	}
+	if o.ClientCertificate != nil {
+		cfg.Certificates = []tls.Certificate{*o.ClientCertificate}
+	}
+	if len(o.CipherSuites) == 0 {
+		return cfg, nil
+	}
+
+	var tls13, allTLS13 []uint16
+	for _, suite := range tls.CipherSuites() {
+		if slices.Equal(suite.SupportedVersions, []uint16{tls.VersionTLS13}) {
+			allTLS13 = append(allTLS13, suite.ID)
+		}
+	}
+	for _, name := range o.CipherSuites {
+		i := slices.IndexFunc(tls.CipherSuites(), func(s *tls.CipherSuite) bool { return s.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("unsupported or insecure cipher suite %q: must be one of %s", name, strings.Join(CipherSuiteNames(), ", "))
+		}
+		suite := tls.CipherSuites()[i]
+		if slices.Contains(allTLS13, suite.ID) {
+			tls13 = append(tls13, suite.ID)
+		} else {
+			cfg.CipherSuites = append(cfg.CipherSuites, suite.ID)
+		}
+	}
+
+	switch {
+	case len(tls13) == 0:
+		if cfg.MinVersion == tls.VersionTLS13 {
+			return nil, fmt.Errorf("no TLS 1.3 cipher suites are allowed, but the minimum TLS version is 1.3")
+		}
+		cfg.MaxVersion = tls.VersionTLS12
+	case len(tls13) < len(allTLS13):
+		return nil, fmt.Errorf("the TLS 1.3 cipher suites cannot be restricted: allow all of them (%s) or none", strings.Join(suiteNames(allTLS13), ", "))
+	case len(cfg.CipherSuites) == 0:
+		cfg.MinVersion = tls.VersionTLS13
+	}
+	return cfg, nil
+}
+
+// CipherSuiteNames returns the names of the cipher suites TLSOptions can
+// allow: those of crypto/tls without known security issues.
+func CipherSuiteNames() []string {
+	var names []string
+	for _, suite := range tls.CipherSuites() {
+		names = append(names, suite.Name)
+	}
+	return names
+}
+
+// LoadClientCertificate returns the client certificate and private key of
+// the PEM data, or files if they do not start with "-----BEGIN".
+func LoadClientCertificate(cert, key string) (*tls.Certificate, error) {
+	certPEM, err := readPEM(cert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client certificate: %w", err)
+	}
+	keyPEM, err := readPEM(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client key: %w", err)
+	}
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate: %w", err)
+	}
+	return &pair, nil
+}
+
+// PKCS11Key locates a client key held by a PKCS#11 token, such as a hardware
+// security module, which signs with it without ever revealing it.
+type PKCS11Key struct {
+	// Module is the path of the PKCS#11 module of the token.
+	Module string
+	// Slot is the ID of the slot of the token, if TokenLabel is empty.
+	// Without either, the token must be the only one present.
+	Slot *uint
+	// TokenLabel is the label of the token.
+	TokenLabel string
+	// KeyLabel is the label of the private key, and of the certificate read
+	// from the token when none is given.
+	KeyLabel string
+	// PIN logs in to the token, unless it is empty.
+	PIN string
+}
+
+// LoadPKCS11ClientCertificate returns the client certificate of the PEM data,
+// or file if it does not start with "-----BEGIN", with its private key held
+// by a PKCS#11 token. If cert is empty, the certificate labelled as the key is
+// read from the token. PKCS#11 modules can only be loaded on Linux and macOS.
+func LoadPKCS11ClientCertificate(cert string, key PKCS11Key) (*tls.Certificate, error) {
+	token, err := pkcs11.Open(pkcs11.Config{Module: key.Module, Slot: key.Slot, TokenLabel: key.TokenLabel, PIN: key.PIN})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client key: %w", err)
+	}
+	var chain [][]byte
+	if cert == "" {
+		der, err := token.Certificate(key.KeyLabel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		chain = append(chain, der)
+	} else {
+		certPEM, err := readPEM(cert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		for block, rest := pem.Decode(certPEM); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type == "CERTIFICATE" {
+				chain = append(chain, block.Bytes)
+			}
+		}
+		if len(chain) == 0 {
+			return nil, fmt.Errorf("failed to read client certificate: no CERTIFICATE PEM block found")
+		}
+	}
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate: %w", err)
+	}
+	signer, err := token.Signer(key.KeyLabel, leaf.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client key: %w", err)
+	}
+	return &tls.Certificate{Certificate: chain, PrivateKey: signer, Leaf: leaf}, nil
+}
+
+// readPEM returns s if it is PEM data, or else the content of the file s.
+func readPEM(s string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(s), "-----BEGIN") {
+		return []byte(s), nil
+	}
+	return os.ReadFile(s)
+}
+
+func suiteNames(ids []uint16) []string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = tls.CipherSuiteName(id)
+	}
+	return names
+}
+
+// UseTLSConfig makes the client connect to the API with cfg.
+func (c *Client) UseTLSConfig(cfg *tls.Config) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+	httpClient := *c.HTTPClient
+	httpClient.Transport = transport
+	c.HTTPClient = &httpClient
+}
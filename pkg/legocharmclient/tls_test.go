@@ -0,0 +1,177 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTLSOptions_Config(t *testing.T) {
+	tls13 := []string{"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384", "TLS_CHACHA20_POLY1305_SHA256"}
+	tests := []struct {
+		name    string
+		opts    TLSOptions
+		want    *tls.Config
+		wantErr string
+	}{
+		{name: "defaults", want: &tls.Config{}},
+		{name: "minimum version", opts: TLSOptions{MinVersion: "1.3"}, want: &tls.Config{MinVersion: tls.VersionTLS13}},
+		{name: "old version", opts: TLSOptions{MinVersion: "1.0"}, wantErr: "must be 1.2 or 1.3"},
+		{
+			name: "TLS 1.2 suites",
+			opts: TLSOptions{MinVersion: "1.2", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+			want: &tls.Config{MinVersion: tls.VersionTLS12, MaxVersion: tls.VersionTLS12, CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}},
+		},
+		{
+			name: "TLS 1.2 and 1.3 suites",
+			opts: TLSOptions{CipherSuites: append([]string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}, tls13...)},
+			want: &tls.Config{CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}},
+		},
+		{name: "TLS 1.3 suites", opts: TLSOptions{CipherSuites: tls13}, want: &tls.Config{MinVersion: tls.VersionTLS13}},
+		{name: "some TLS 1.3 suites", opts: TLSOptions{CipherSuites: tls13[:2]}, wantErr: "cannot be restricted"},
+		{name: "no TLS 1.3 suites with TLS 1.3", opts: TLSOptions{MinVersion: "1.3", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}}, wantErr: "minimum TLS version is 1.3"},
+		{name: "insecure suite", opts: TLSOptions{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}, wantErr: "unsupported or insecure cipher suite"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.Config()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Config() = %+v, %v; want error containing %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Config() = %+v, %v; want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseTLSConfig(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	srv.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	trusted := srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	client.UseTLSConfig(&tls.Config{RootCAs: trusted, MinVersion: tls.VersionTLS13})
+	if _, err := client.GetDomainById(context.Background(), 7); err == nil || !strings.Contains(err.Error(), "protocol version") {
+		t.Fatalf("expected the TLS 1.2 server to be refused, got %v", err)
+	}
+
+	client.UseTLSConfig(&tls.Config{RootCAs: trusted, MinVersion: tls.VersionTLS12})
+	if _, err := client.GetDomainById(context.Background(), 7); err != nil {
+		t.Fatalf("GetDomainById: %v", err)
+	}
+}
+
+// opaqueSigner hides the type of its key, like a signer backed by a hardware
+// security module, whose private key cannot be read.
+type opaqueSigner struct{ crypto.Signer }
+
+func TestTLSOptions_ClientCertificate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	caPool := x509.NewCertPool()
+	caPool.AppendCertsFromPEM([]byte(certPEM))
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "terraform" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: caPool}
+	srv.StartTLS()
+	defer srv.Close()
+	trusted := srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, []byte(certPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, []byte(keyPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fromFiles, err := LoadClientCertificate(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("LoadClientCertificate from files: %v", err)
+	}
+	fromPEM, err := LoadClientCertificate(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("LoadClientCertificate from PEM: %v", err)
+	}
+	fromSigner := &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: opaqueSigner{key}}
+
+	for name, cert := range map[string]*tls.Certificate{"files": fromFiles, "pem": fromPEM, "signer": fromSigner} {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+			cfg, err := TLSOptions{ClientCertificate: cert}.Config()
+			if err != nil {
+				t.Fatalf("Config: %v", err)
+			}
+			cfg.RootCAs = trusted
+			client.UseTLSConfig(cfg)
+			if _, err := client.GetDomainById(context.Background(), 7); err != nil {
+				t.Fatalf("GetDomainById: %v", err)
+			}
+		})
+	}
+
+	if _, err := LoadClientCertificate(certPEM, filepath.Join(dir, "missing.key")); err == nil || !strings.Contains(err.Error(), "failed to read client key") {
+		t.Fatalf("expected a missing key to be reported, got %v", err)
+	}
+	if _, err := LoadPKCS11ClientCertificate(certPEM, PKCS11Key{Module: filepath.Join(dir, "missing.so"), KeyLabel: "client"}); err == nil || !strings.Contains(err.Error(), "failed to load client key") {
+		t.Fatalf("expected a missing PKCS#11 module to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// APIVersion is a version of the API, named as in the prefix of its paths.
+type APIVersion string
+
+// APIVersion1 is the version served by the charm revisions released so far,
+// under /api/v1/.
+const APIVersion1 APIVersion = "v1"
+
+// ErrUnsupportedAPIVersion is returned by NegotiateAPIVersion when the API
+// serves none of the versions the client supports, for example a charm
+// revision too old or too new for it.
+var ErrUnsupportedAPIVersion = errors.New("unsupported API version")
+
+// v1Prefix is the prefix of the paths the methods of the client request,
+// which the adapter of the version in use rewrites.
+const v1Prefix = "/api/v1/"
+
+// apiAdapter translates the requests of the client, written against v1, for
+// a version of the API, and its responses back.
+type apiAdapter struct {
+	version APIVersion
+	// path returns the path, in the version, of the v1 endpoint path.
+	path func(path string) string
+	// request, if not nil, returns the body, in the version, of a request
+	// to the v1 endpoint path with the v1 body.
+	request func(path string, body []byte) ([]byte, error)
+	// response, if not nil, returns the v1 body of a response of the
+	// version from the v1 endpoint path.
+	response func(path string, body []byte) ([]byte, error)
+}
+
+// apiAdapters are the versions the client supports, newest first. A charm
+// revision that moves endpoints gets an adapter here, so that one client
+// works with both older and newer revisions.
+var apiAdapters = []apiAdapter{
+	{version: APIVersion1, path: func(path string) string { return path }},
+}
+
+// SupportedAPIVersions returns the versions of the API the client supports,
+// newest first.
+func SupportedAPIVersions() []APIVersion {
+	versions := make([]APIVersion, len(apiAdapters))
+	for i, a := range apiAdapters {
+		versions[i] = a.version
+	}
+	return versions
+}
+
+// adapter returns the adapter of APIVersion.
+func (c *Client) adapter() (apiAdapter, error) {
+	version := c.APIVersion
+	if version == "" {
+		version = APIVersion1
+	}
+	for _, a := range apiAdapters {
+		if a.version == version {
+			return a, nil
+		}
+	}
+	return apiAdapter{}, fmt.Errorf("%w %q: must be one of %s", ErrUnsupportedAPIVersion, version, versionList())
+}
+
+// v1PathKey is the context key of the v1 endpoint path of a request, which
+// Do passes to the response adapter.
+type v1PathKey struct{}
+
+// adaptRequest returns the request, in APIVersion, to the v1 endpoint path
+// with the v1 body. Other paths, such as those of the challenge endpoints,
+// are not versioned.
+func (c *Client) adaptRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	if !strings.HasPrefix(path, v1Prefix) {
+		return c.newRequest(ctx, method, path, body)
+	}
+	a, err := c.adapter()
+	if err != nil {
+		return nil, err
+	}
+	if a.request != nil && body != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		if b, err = a.request(path, b); err != nil {
+			return nil, fmt.Errorf("failed to adapt request to API %s: %w", a.version, err)
+		}
+		body = bytes.NewReader(b)
+	}
+	return c.newRequest(context.WithValue(ctx, v1PathKey{}, path), method, a.path(path), body)
+}
+
+// adaptResponse rewrites the body of resp, answering req, to v1 if req was
+// created by adaptRequest.
+func (c *Client) adaptResponse(req *http.Request, resp *http.Response) (*http.Response, error) {
+	path, ok := req.Context().Value(v1PathKey{}).(string)
+	if !ok {
+		return resp, nil
+	}
+	a, err := c.adapter()
+	if err != nil || a.response == nil {
+		return resp, err
+	}
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if b, err = a.response(path, b); err != nil {
+		return nil, fmt.Errorf("failed to adapt response of API %s: %w", a.version, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	resp.ContentLength = int64(len(b))
+	return resp, nil
+}
+
+// NegotiateAPIVersion sets APIVersion to the newest version supported by the
+// client that the API serves, and returns it. A version is served unless the
+// API answers 404 Not Found for its users endpoint, so that credentials
+// lacking permissions do not prevent the detection. It returns an error
+// matching ErrUnsupportedAPIVersion if the API serves none, and leaves
+// APIVersion unchanged on other errors. While the client supports a single
+// version there is nothing to choose, and it is set without a request.
+func (c *Client) NegotiateAPIVersion(ctx context.Context) (APIVersion, error) {
+	if len(apiAdapters) == 1 {
+		c.APIVersion = apiAdapters[0].version
+		return c.APIVersion, nil
+	}
+	for _, a := range apiAdapters {
+		req, err := c.newRequest(ctx, "GET", a.path(v1Prefix+"users/?username="+url.QueryEscape(c.Username)), nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to create request: %w", err)
+		}
+		resp, err := c.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to execute request: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to read response body: %w", err)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if resp.StatusCode >= 500 {
+			return "", c.newAPIError("negotiate API version", resp, body)
+		}
+		c.APIVersion = a.version
+		return a.version, nil
+	}
+	return "", fmt.Errorf("%w: the API serves none of %s", ErrUnsupportedAPIVersion, versionList())
+}
+
+func versionList() string {
+	var names []string
+	for _, v := range SupportedAPIVersions() {
+		names = append(names, string(v))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package legocharmclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// withV2 makes the client also support a version v2, which moved the
+// endpoints under /api/v2/, for the duration of the test.
+func withV2(t *testing.T) {
+	old := apiAdapters
+	apiAdapters = append([]apiAdapter{{
+		version: "v2",
+		path:    func(path string) string { return "/api/v2/" + strings.TrimPrefix(path, v1Prefix) },
+	}}, old...)
+	t.Cleanup(func() { apiAdapters = old })
+}
+
+// versionServer serves the domain 7 and the users endpoint under the prefix
+// of each of versions, answering with status to the users endpoint.
+func versionServer(t *testing.T, status int, versions ...string) (*httptest.Server, *[]string) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		for _, v := range versions {
+			switch r.URL.Path {
+			case "/api/" + v + "/users/":
+				w.WriteHeader(status)
+				w.Write([]byte(`[]`)) // nolint:errcheck
+				return
+			case "/api/" + v + "/domains/7/":
+				w.Write([]byte(`{"id":7,"fqdn":"example.com"}`)) // nolint:errcheck
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &paths
+}
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	withV2(t)
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		status   int
+		versions []string
+		want     APIVersion
+		wantErr  error
+	}{
+		{name: "older revision", status: http.StatusOK, versions: []string{"v1"}, want: APIVersion1},
+		{name: "newer revision", status: http.StatusOK, versions: []string{"v1", "v2"}, want: "v2"},
+		{name: "without permissions", status: http.StatusForbidden, versions: []string{"v1"}, want: APIVersion1},
+		{name: "unsupported revision", status: http.StatusOK, versions: []string{"v0"}, wantErr: ErrUnsupportedAPIVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, paths := versionServer(t, tt.status, tt.versions...)
+			client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+			got, err := client.NegotiateAPIVersion(ctx)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || client.APIVersion != "" {
+					t.Fatalf("NegotiateAPIVersion() = %q, %v with APIVersion %q; want error %v", got, err, client.APIVersion, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want || client.APIVersion != tt.want {
+				t.Fatalf("NegotiateAPIVersion() = %q, %v with APIVersion %q; want %q", got, err, client.APIVersion, tt.want)
+			}
+
+			*paths = nil
+			if _, err := client.GetDomainById(ctx, 7); err != nil {
+				t.Fatalf("GetDomainById: %v", err)
+			}
+			if want := "/api/" + string(tt.want) + "/domains/7/"; len(*paths) != 1 || (*paths)[0] != want {
+				t.Fatalf("requested %v, want %s", *paths, want)
+			}
+		})
+	}
+}
+
+func TestNegotiateAPIVersion_SingleVersion(t *testing.T) {
+	srv, paths := versionServer(t, http.StatusOK, "v1")
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	got, err := client.NegotiateAPIVersion(context.Background())
+	if err != nil || got != APIVersion1 || client.APIVersion != APIVersion1 {
+		t.Fatalf("NegotiateAPIVersion() = %q, %v with APIVersion %q; want %q", got, err, client.APIVersion, APIVersion1)
+	}
+	if len(*paths) != 0 {
+		t.Fatalf("requested %v, want no request while there is a single version", *paths)
+	}
+}
+
+func TestNegotiateAPIVersion_ServerError(t *testing.T) {
+	withV2(t)
+	srv, _ := versionServer(t, http.StatusInternalServerError, "v1")
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	_, err = client.NegotiateAPIVersion(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected the server error to be returned, got %v", err)
+	}
+	if client.APIVersion != "" {
+		t.Fatalf("APIVersion = %q, want it unchanged", client.APIVersion)
+	}
+}
+
+func TestAPIVersion_Payloads(t *testing.T) {
+	// v2 names the FQDN of domains "name".
+	rename := func(from, to string) func(string, []byte) ([]byte, error) {
+		return func(path string, body []byte) ([]byte, error) {
+			if !strings.HasPrefix(path, v1Prefix+"domains/") {
+				return body, nil
+			}
+			return bytes.ReplaceAll(body, []byte(`"`+from+`":`), []byte(`"`+to+`":`)), nil
+		}
+	}
+	old := apiAdapters
+	apiAdapters = append([]apiAdapter{{
+		version:  "v2",
+		path:     func(path string) string { return "/api/v2/" + strings.TrimPrefix(path, v1Prefix) },
+		request:  rename("fqdn", "name"),
+		response: rename("name", "fqdn"),
+	}}, old...)
+	t.Cleanup(func() { apiAdapters = old })
+
+	var sent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/domains/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		sent = string(b)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":7,"name":"example.com"}`)) // nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient(ptr(srv.URL), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.APIVersion = "v2"
+	domain, err := client.CreateDomain(context.Background(), DomainData{Fqdn: "example.com"})
+	if err != nil {
+		t.Fatalf("CreateDomain: %v", err)
+	}
+	if sent != `{"name":"example.com"}` {
+		t.Fatalf("sent %s, want the v2 payload", sent)
+	}
+	if domain.ID != 7 || domain.Fqdn != "example.com" {
+		t.Fatalf("CreateDomain() = %+v, want the v2 response read as v1", domain)
+	}
+}
+
+func TestAPIVersion_Unsupported(t *testing.T) {
+	client, err := NewClient(ptr("https://lego.example.com"), ptr("admin"), ptr("admin-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.APIVersion = "v9"
+	if _, err := client.GetDomainById(context.Background(), 7); !errors.Is(err, ErrUnsupportedAPIVersion) {
+		t.Fatalf("expected an unsupported version error, got %v", err)
+	}
+	// The challenge endpoints are not versioned.
+	if _, err := client.NewRequest(context.Background(), "POST", "/present", nil); err != nil {
+		t.Fatalf("NewRequest(/present): %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+//go:build linux || darwin
+
+package pkcs11
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+// module is a PKCS#11 module loaded in the process.
+type module struct {
+	// functions is its CK_FUNCTION_LIST.
+	functions unsafe.Pointer
+}
+
+// loadModule loads the module at path.
+func loadModule(path string) (*module, error) {
+	lib, err := purego.Dlopen(path, purego.RTLD_NOW|purego.RTLD_LOCAL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %s: %w", path, err)
+	}
+	getFunctionList, err := purego.Dlsym(lib, "C_GetFunctionList")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a PKCS#11 module: %w", path, err)
+	}
+	m := &module{}
+	if rv, _, _ := purego.SyscallN(getFunctionList, uintptr(unsafe.Pointer(&m.functions))); rv != ckrOK {
+		return nil, fmt.Errorf("failed to get the functions of PKCS#11 module %s: %w", path, Error(rv))
+	}
+	return m, nil
+}
+
+// call calls the function of the module at index fn of CK_FUNCTION_LIST,
+// and returns its return value if it is not CKR_OK. The objects args point
+// to are kept in place until it returns.
+//
+//go:uintptrescapes
+func (m *module) call(fn int, args ...uintptr) error {
+	// The functions follow the CK_VERSION the list starts with, aligned as
+	// pointers.
+	ptr := *(*uintptr)(unsafe.Add(m.functions, (fn+1)*int(unsafe.Sizeof(uintptr(0)))))
+	if rv, _, _ := purego.SyscallN(ptr, args...); rv != ckrOK {
+		return Error(rv)
+	}
+	return nil
+}
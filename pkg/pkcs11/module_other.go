@@ -0,0 +1,17 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+//go:build !linux && !darwin
+
+package pkcs11
+
+// module is a PKCS#11 module, which cannot be loaded on this platform.
+type module struct{}
+
+func loadModule(path string) (*module, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func (m *module) call(fn int, args ...uintptr) error {
+	return ErrUnsupportedPlatform
+}
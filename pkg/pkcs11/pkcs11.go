@@ -0,0 +1,433 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+// Package pkcs11 signs with private keys held by PKCS#11 tokens, such as
+// hardware security modules, for TLS client authentication.
+//
+// It loads the PKCS#11 module of the token without cgo, so that the provider
+// is still built with CGO_ENABLED=0, and implements the little of PKCS#11
+// that needs: finding a token, logging in to it, reading a certificate and
+// signing with RSA (PKCS#1 v1.5 and PSS) and ECDSA keys. Modules are only
+// loaded on Linux and macOS.
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"runtime"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// ErrUnsupportedPlatform is returned by Open where modules cannot be loaded.
+var ErrUnsupportedPlatform = errors.New("PKCS#11 modules cannot be loaded on " + runtime.GOOS)
+
+// ulong is CK_ULONG, C's unsigned long, as wide as a pointer on the
+// platforms modules are loaded on.
+type ulong = uint
+
+// Indexes of the functions of CK_FUNCTION_LIST, in the order of the
+// specification.
+const (
+	fnInitialize        = 0
+	fnGetSlotList       = 4
+	fnGetTokenInfo      = 6
+	fnOpenSession       = 12
+	fnLogin             = 18
+	fnGetAttributeValue = 24
+	fnFindObjectsInit   = 26
+	fnFindObjects       = 27
+	fnFindObjectsFinal  = 28
+	fnSignInit          = 42
+	fnSign              = 43
+)
+
+const (
+	ckrOK                         = 0x000
+	ckrUserAlreadyLoggedIn        = 0x100
+	ckrCryptokiAlreadyInitialized = 0x191
+
+	ckfRWSession     = 0x2
+	ckfSerialSession = 0x4
+	ckfOSLockingOK   = 0x2
+
+	ckuUser = 1
+
+	ckoCertificate = 1
+	ckoPrivateKey  = 3
+
+	ckaClass = 0x000
+	ckaLabel = 0x003
+	ckaValue = 0x011
+
+	ckmRSAPKCS    = 0x0001
+	ckmRSAPKCSPSS = 0x000d
+	ckmECDSA      = 0x1041
+)
+
+// Error is a PKCS#11 return value other than CKR_OK.
+type Error ulong
+
+// errorNames are the names of the return values commonly met.
+var errorNames = map[Error]string{
+	0x003: "CKR_SLOT_ID_INVALID",
+	0x005: "CKR_GENERAL_ERROR",
+	0x007: "CKR_ARGUMENTS_BAD",
+	0x030: "CKR_DEVICE_ERROR",
+	0x054: "CKR_FUNCTION_NOT_SUPPORTED",
+	0x063: "CKR_KEY_TYPE_INCONSISTENT",
+	0x070: "CKR_MECHANISM_INVALID",
+	0x071: "CKR_MECHANISM_PARAM_INVALID",
+	0x0a0: "CKR_PIN_INCORRECT",
+	0x0a4: "CKR_PIN_LOCKED",
+	0x0b3: "CKR_SESSION_HANDLE_INVALID",
+	0x0e0: "CKR_TOKEN_NOT_PRESENT",
+	0x101: "CKR_USER_NOT_LOGGED_IN",
+	0x150: "CKR_BUFFER_TOO_SMALL",
+	0x190: "CKR_CRYPTOKI_NOT_INITIALIZED",
+}
+
+func (e Error) Error() string {
+	if name, ok := errorNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("CKR_0x%X", ulong(e))
+}
+
+// attribute is CK_ATTRIBUTE.
+type attribute struct {
+	typ   ulong
+	value unsafe.Pointer
+	len   ulong
+}
+
+// mechanism is CK_MECHANISM.
+type mechanism struct {
+	typ   ulong
+	param unsafe.Pointer
+	len   ulong
+}
+
+// pssParams is CK_RSA_PKCS_PSS_PARAMS.
+type pssParams struct {
+	hashAlg ulong
+	mgf     ulong
+	sLen    ulong
+}
+
+// initializeArgs is CK_C_INITIALIZE_ARGS.
+type initializeArgs struct {
+	createMutex, destroyMutex, lockMutex, unlockMutex unsafe.Pointer
+	flags                                             ulong
+	reserved                                          unsafe.Pointer
+}
+
+// modules are the modules loaded, by path. A module is initialized once per
+// process, and stays loaded until it exits.
+var (
+	modulesMu sync.Mutex
+	modules   = map[string]*module{}
+)
+
+// loadedModule returns the module at path, loading and initializing it if
+// it is not loaded yet.
+func loadedModule(path string) (*module, error) {
+	modulesMu.Lock()
+	defer modulesMu.Unlock()
+	if m, ok := modules[path]; ok {
+		return m, nil
+	}
+	m, err := loadModule(path)
+	if err != nil {
+		return nil, err
+	}
+	// The module locks its state with the locks of the operating system, so
+	// that it can be called from the threads Go schedules goroutines on.
+	args := &initializeArgs{flags: ckfOSLockingOK}
+	if err := m.call(fnInitialize, uintptr(unsafe.Pointer(args))); err != nil && err != Error(ckrCryptokiAlreadyInitialized) {
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module %s: %w", path, err)
+	}
+	modules[path] = m
+	return m, nil
+}
+
+// Config locates a token and logs in to it.
+type Config struct {
+	// Module is the path of the PKCS#11 module, a shared library.
+	Module string
+	// Slot is the ID of the slot holding the token, if TokenLabel is empty.
+	// Without either, the token must be the only one present.
+	Slot *uint
+	// TokenLabel is the label of the token.
+	TokenLabel string
+	// PIN logs in to the token as its user, unless it is empty.
+	PIN string
+}
+
+// Token is a session with a PKCS#11 token. It is safe for concurrent use.
+type Token struct {
+	module *module
+	// mu serializes the operations of the session, which PKCS#11 runs one
+	// at a time.
+	mu      sync.Mutex
+	session ulong
+}
+
+// Open opens a session with the token of cfg and logs in to it. The session
+// stays open until the process exits.
+func Open(cfg Config) (*Token, error) {
+	m, err := loadedModule(cfg.Module)
+	if err != nil {
+		return nil, err
+	}
+	slot, err := findSlot(m, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Token{module: m}
+	if err := m.call(fnOpenSession, uintptr(slot), ckfSerialSession|ckfRWSession, 0, 0, uintptr(unsafe.Pointer(&t.session))); err != nil {
+		return nil, fmt.Errorf("failed to open a PKCS#11 session: %w", err)
+	}
+	if cfg.PIN != "" {
+		pin := []byte(cfg.PIN)
+		// The login is shared by the sessions of the process with the token.
+		if err := m.call(fnLogin, uintptr(t.session), ckuUser, uintptr(unsafe.Pointer(&pin[0])), uintptr(len(pin))); err != nil && err != Error(ckrUserAlreadyLoggedIn) {
+			return nil, fmt.Errorf("failed to log in to the PKCS#11 token: %w", err)
+		}
+	}
+	return t, nil
+}
+
+// findSlot returns the ID of the slot of the token of cfg.
+func findSlot(m *module, cfg Config) (ulong, error) {
+	var count ulong
+	if err := m.call(fnGetSlotList, 1, 0, uintptr(unsafe.Pointer(&count))); err != nil {
+		return 0, fmt.Errorf("failed to list the PKCS#11 slots: %w", err)
+	}
+	slots := make([]ulong, count+1)
+	if err := m.call(fnGetSlotList, 1, uintptr(unsafe.Pointer(&slots[0])), uintptr(unsafe.Pointer(&count))); err != nil {
+		return 0, fmt.Errorf("failed to list the PKCS#11 slots: %w", err)
+	}
+	slots = slots[:count]
+
+	switch {
+	case cfg.TokenLabel != "":
+		var labels []string
+		for _, slot := range slots {
+			label, err := tokenLabel(m, slot)
+			if err != nil {
+				return 0, err
+			}
+			if label == cfg.TokenLabel {
+				return slot, nil
+			}
+			labels = append(labels, fmt.Sprintf("%q", label))
+		}
+		return 0, fmt.Errorf("no PKCS#11 token is labelled %q, found %s", cfg.TokenLabel, orNone(labels))
+	case cfg.Slot != nil:
+		for _, slot := range slots {
+			if slot == ulong(*cfg.Slot) {
+				return slot, nil
+			}
+		}
+		return 0, fmt.Errorf("no PKCS#11 token is present in slot %d", *cfg.Slot)
+	case len(slots) == 1:
+		return slots[0], nil
+	}
+	return 0, fmt.Errorf("%d PKCS#11 tokens are present: select one by slot or label", len(slots))
+}
+
+// tokenLabel returns the label of the token in slot.
+func tokenLabel(m *module, slot ulong) (string, error) {
+	// CK_TOKEN_INFO starts with the label, padded with spaces; the rest of
+	// the buffer is larger than the fields after it.
+	info := make([]byte, 512)
+	if err := m.call(fnGetTokenInfo, uintptr(slot), uintptr(unsafe.Pointer(&info[0]))); err != nil {
+		return "", fmt.Errorf("failed to read the PKCS#11 token in slot %d: %w", slot, err)
+	}
+	return strings.TrimRight(string(info[:32]), " \x00"), nil
+}
+
+func orNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
+
+// findObject returns the handle of the object of class labelled label.
+// Callers hold t.mu.
+func (t *Token) findObject(class ulong, label string) (ulong, error) {
+	labelBytes := []byte(label)
+	template := []attribute{
+		{typ: ckaClass, value: unsafe.Pointer(&class), len: ulong(unsafe.Sizeof(class))},
+		{typ: ckaLabel, value: unsafe.Pointer(unsafe.SliceData(labelBytes)), len: ulong(len(labelBytes))},
+	}
+	if err := t.module.call(fnFindObjectsInit, uintptr(t.session), uintptr(unsafe.Pointer(&template[0])), uintptr(len(template))); err != nil {
+		return 0, err
+	}
+	handles := make([]ulong, 2)
+	var count ulong
+	err := t.module.call(fnFindObjects, uintptr(t.session), uintptr(unsafe.Pointer(&handles[0])), uintptr(len(handles)), uintptr(unsafe.Pointer(&count)))
+	if finalErr := t.module.call(fnFindObjectsFinal, uintptr(t.session)); err == nil {
+		err = finalErr
+	}
+	runtime.KeepAlive(labelBytes)
+	switch {
+	case err != nil:
+		return 0, err
+	case count == 0:
+		return 0, fmt.Errorf("no object is labelled %q", label)
+	case count > 1:
+		return 0, fmt.Errorf("several objects are labelled %q", label)
+	}
+	return handles[0], nil
+}
+
+// Certificate returns the DER encoding of the certificate labelled label.
+func (t *Token) Certificate(label string) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	handle, err := t.findObject(ckoCertificate, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the PKCS#11 certificate: %w", err)
+	}
+	value := attribute{typ: ckaValue}
+	if err := t.module.call(fnGetAttributeValue, uintptr(t.session), uintptr(handle), uintptr(unsafe.Pointer(&value)), 1); err != nil {
+		return nil, fmt.Errorf("failed to read the PKCS#11 certificate: %w", err)
+	}
+	der := make([]byte, value.len)
+	value.value = unsafe.Pointer(unsafe.SliceData(der))
+	if err := t.module.call(fnGetAttributeValue, uintptr(t.session), uintptr(handle), uintptr(unsafe.Pointer(&value)), 1); err != nil {
+		return nil, fmt.Errorf("failed to read the PKCS#11 certificate: %w", err)
+	}
+	runtime.KeepAlive(der)
+	return der[:value.len], nil
+}
+
+// Signer returns the private key labelled label, whose public key is public,
+// an *rsa.PublicKey or *ecdsa.PublicKey.
+func (t *Token) Signer(label string, public crypto.PublicKey) (crypto.Signer, error) {
+	switch public.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported PKCS#11 key type %T: must be RSA or ECDSA", public)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	handle, err := t.findObject(ckoPrivateKey, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the PKCS#11 private key: %w", err)
+	}
+	return &signer{token: t, handle: handle, public: public}, nil
+}
+
+// signer is a private key of a token.
+type signer struct {
+	token  *Token
+	handle ulong
+	public crypto.PublicKey
+}
+
+func (s *signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// digestInfoPrefixes are the DER prefixes of the DigestInfo PKCS#1 v1.5
+// signs, by hash.
+var digestInfoPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// pssHashes are the CKM_ hash mechanisms and CKG_MGF1_ functions of PSS, by
+// hash.
+var pssHashes = map[crypto.Hash][2]ulong{
+	crypto.SHA1:   {0x220, 0x1},
+	crypto.SHA256: {0x250, 0x2},
+	crypto.SHA384: {0x260, 0x3},
+	crypto.SHA512: {0x270, 0x4},
+}
+
+// Sign signs digest with the key on the token. The token draws its own
+// randomness.
+func (s *signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hash := opts.HashFunc()
+	if hash != 0 && len(digest) != hash.Size() {
+		return nil, fmt.Errorf("digest is %d bytes long, %s digests are %d", len(digest), hash, hash.Size())
+	}
+	switch public := s.public.(type) {
+	case *rsa.PublicKey:
+		if pss, ok := opts.(*rsa.PSSOptions); ok {
+			mgf, ok := pssHashes[hash]
+			if !ok {
+				return nil, fmt.Errorf("unsupported PSS hash %s", hash)
+			}
+			saltLength := pss.SaltLength
+			if saltLength == rsa.PSSSaltLengthAuto || saltLength == rsa.PSSSaltLengthEqualsHash {
+				saltLength = hash.Size()
+			}
+			params := &pssParams{hashAlg: mgf[0], mgf: mgf[1], sLen: ulong(saltLength)}
+			return s.sign(mechanism{typ: ckmRSAPKCSPSS, param: unsafe.Pointer(params), len: ulong(unsafe.Sizeof(*params))}, digest, public.Size())
+		}
+		data := digest
+		if hash != 0 {
+			prefix, ok := digestInfoPrefixes[hash]
+			if !ok {
+				return nil, fmt.Errorf("unsupported PKCS#1 v1.5 hash %s", hash)
+			}
+			data = append(append([]byte{}, prefix...), digest...)
+		}
+		return s.sign(mechanism{typ: ckmRSAPKCS}, data, public.Size())
+	case *ecdsa.PublicKey:
+		size := (public.Curve.Params().BitSize + 7) / 8
+		raw, err := s.sign(mechanism{typ: ckmECDSA}, digest, 2*size)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsaSignature(raw)
+	}
+	return nil, fmt.Errorf("unsupported PKCS#11 key type %T", s.public)
+}
+
+// sign signs data with the key and mech, into a signature of at most size
+// bytes.
+func (s *signer) sign(mech mechanism, data []byte, size int) ([]byte, error) {
+	s.token.mu.Lock()
+	defer s.token.mu.Unlock()
+	m, session := s.token.module, s.token.session
+	if err := m.call(fnSignInit, uintptr(session), uintptr(unsafe.Pointer(&mech)), uintptr(s.handle)); err != nil {
+		return nil, fmt.Errorf("failed to sign with the PKCS#11 key: %w", err)
+	}
+	signature := make([]byte, size)
+	length := ulong(size)
+	if err := m.call(fnSign, uintptr(session), uintptr(unsafe.Pointer(unsafe.SliceData(data))), uintptr(len(data)), uintptr(unsafe.Pointer(&signature[0])), uintptr(unsafe.Pointer(&length))); err != nil {
+		return nil, fmt.Errorf("failed to sign with the PKCS#11 key: %w", err)
+	}
+	runtime.KeepAlive(mech.param)
+	runtime.KeepAlive(data)
+	return signature[:length], nil
+}
+
+// ecdsaSignature returns the ASN.1 encoding crypto.Signer returns of the
+// PKCS#11 ECDSA signature raw, r followed by s.
+func ecdsaSignature(raw []byte) ([]byte, error) {
+	if len(raw) == 0 || len(raw)%2 != 0 {
+		return nil, fmt.Errorf("invalid PKCS#11 ECDSA signature of %d bytes", len(raw))
+	}
+	half := len(raw) / 2
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		R: new(big.Int).SetBytes(raw[:half]),
+		S: new(big.Int).SetBytes(raw[half:]),
+	})
+}
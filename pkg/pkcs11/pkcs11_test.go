@@ -0,0 +1,263 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package pkcs11
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// fakeModule builds testdata/fakepkcs11.c, holding the certificate cert, and
+// returns its path.
+func fakeModule(t *testing.T, cert []byte) string {
+	t.Helper()
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skipf("PKCS#11 modules cannot be loaded on %s", runtime.GOOS)
+	}
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("cc is needed to build the fake PKCS#11 module")
+	}
+	dir := t.TempDir()
+	var header strings.Builder
+	header.WriteString("static const unsigned char cert[] = {")
+	for _, b := range cert {
+		fmt.Fprintf(&header, "%d,", b)
+	}
+	header.WriteString("};\n")
+	if err := os.WriteFile(filepath.Join(dir, "cert.h"), []byte(header.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	module := filepath.Join(dir, "fakepkcs11.so")
+	out, err := exec.Command(cc, "-shared", "-fPIC", "-I", dir, "-o", module, filepath.Join("testdata", "fakepkcs11.c")).CombinedOutput()
+	if err != nil {
+		t.Fatalf("failed to build the fake PKCS#11 module: %v\n%s", err, out)
+	}
+	return module
+}
+
+func slot(id uint) *uint {
+	return &id
+}
+
+func TestOpen(t *testing.T) {
+	module := fakeModule(t, []byte("cert"))
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "token label", cfg: Config{TokenLabel: "legocharm", PIN: "1234"}},
+		{name: "slot", cfg: Config{Slot: slot(7), PIN: "1234"}},
+		{name: "unknown token label", cfg: Config{TokenLabel: "missing"}, wantErr: `no PKCS#11 token is labelled "missing", found "other", "legocharm"`},
+		{name: "unknown slot", cfg: Config{Slot: slot(1)}, wantErr: "no PKCS#11 token is present in slot 1"},
+		{name: "several tokens", cfg: Config{}, wantErr: "2 PKCS#11 tokens are present: select one by slot or label"},
+		{name: "wrong PIN", cfg: Config{Slot: slot(7), PIN: "0000"}, wantErr: "failed to log in to the PKCS#11 token: CKR_PIN_INCORRECT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Module = module
+			_, err := Open(tt.cfg)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("Open error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOpen_NotAModule(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skipf("PKCS#11 modules cannot be loaded on %s", runtime.GOOS)
+	}
+	_, err := Open(Config{Module: filepath.Join(t.TempDir(), "missing.so")})
+	if err == nil || !strings.HasPrefix(err.Error(), "failed to load PKCS#11 module ") {
+		t.Fatalf("Open error = %v", err)
+	}
+}
+
+func TestToken_Certificate(t *testing.T) {
+	token, err := Open(Config{Module: fakeModule(t, []byte("certificate DER")), Slot: slot(7)})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	cert, err := token.Certificate("client")
+	if err != nil {
+		t.Fatalf("Certificate: %v", err)
+	}
+	if string(cert) != "certificate DER" {
+		t.Errorf("Certificate = %q", cert)
+	}
+	if _, err := token.Certificate("server"); err == nil || err.Error() != `failed to find the PKCS#11 certificate: no object is labelled "server"` {
+		t.Errorf("Certificate error = %v", err)
+	}
+}
+
+func TestToken_Signer(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	module := fakeModule(t, []byte("cert"))
+
+	// Private keys are only found once logged in.
+	token, err := Open(Config{Module: module, TokenLabel: "legocharm"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := token.Signer("client", &rsaKey.PublicKey); err == nil || err.Error() != `failed to find the PKCS#11 private key: no object is labelled "client"` {
+		t.Errorf("Signer error = %v", err)
+	}
+	token, err = Open(Config{Module: module, TokenLabel: "legocharm", PIN: "1234"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := token.Signer("client", []byte("ed25519")); err == nil || err.Error() != "unsupported PKCS#11 key type []uint8: must be RSA or ECDSA" {
+		t.Errorf("Signer error = %v", err)
+	}
+	signer, err := token.Signer("client", &rsaKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Signer: %v", err)
+	}
+	if signer.Public() != &rsaKey.PublicKey {
+		t.Errorf("Public = %v", signer.Public())
+	}
+}
+
+func TestSigner_Sign(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := Open(Config{Module: fakeModule(t, []byte("cert")), Slot: slot(7), PIN: "1234"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	digest := sha256.Sum256([]byte("handshake"))
+
+	t.Run("PKCS#1 v1.5", func(t *testing.T) {
+		signer, err := token.Signer("client", &rsaKey.PublicKey)
+		if err != nil {
+			t.Fatalf("Signer: %v", err)
+		}
+		got, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		// The fake signs the DigestInfo it is given as is.
+		want := append(append([]byte{}, digestInfoPrefixes[crypto.SHA256]...), digest[:]...)
+		if !bytes.Equal(got, want) {
+			t.Errorf("signed %x, want %x", got, want)
+		}
+	})
+
+	t.Run("PSS", func(t *testing.T) {
+		signer, err := token.Signer("client", &rsaKey.PublicKey)
+		if err != nil {
+			t.Fatalf("Signer: %v", err)
+		}
+		got, err := signer.Sign(rand.Reader, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256})
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		// The fake appends CK_RSA_PKCS_PSS_PARAMS to the digest.
+		size := int(unsafe.Sizeof(ulong(0)))
+		if len(got) != len(digest)+3*size || !bytes.Equal(got[:len(digest)], digest[:]) {
+			t.Fatalf("signed %x", got)
+		}
+		var params [3]uint64
+		for i := range params {
+			field := got[len(digest)+i*size:][:size]
+			if size == 8 {
+				params[i] = binary.NativeEndian.Uint64(field)
+			} else {
+				params[i] = uint64(binary.NativeEndian.Uint32(field))
+			}
+		}
+		if want := [3]uint64{0x250, 0x2, 32}; params != want {
+			t.Errorf("PSS params = %#x, want %#x", params, want)
+		}
+	})
+
+	t.Run("ECDSA", func(t *testing.T) {
+		signer, err := token.Signer("client", &ecKey.PublicKey)
+		if err != nil {
+			t.Fatalf("Signer: %v", err)
+		}
+		got, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		// The fake returns the digest as both r and s.
+		var sig struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(got, &sig); err != nil {
+			t.Fatalf("signature is not ASN.1: %v", err)
+		}
+		want := new(big.Int).SetBytes(digest[:])
+		if sig.R.Cmp(want) != 0 || sig.S.Cmp(want) != 0 {
+			t.Errorf("signature = %+v, want r = s = %x", sig, digest)
+		}
+	})
+
+	t.Run("wrong digest length", func(t *testing.T) {
+		signer, err := token.Signer("client", &rsaKey.PublicKey)
+		if err != nil {
+			t.Fatalf("Signer: %v", err)
+		}
+		if _, err := signer.Sign(rand.Reader, digest[:20], crypto.SHA256); err == nil || err.Error() != "digest is 20 bytes long, SHA-256 digests are 32" {
+			t.Errorf("Sign error = %v", err)
+		}
+	})
+}
+
+func TestEcdsaSignature(t *testing.T) {
+	got, err := ecdsaSignature([]byte{0x00, 0x01, 0x80, 0x02})
+	if err != nil {
+		t.Fatalf("ecdsaSignature: %v", err)
+	}
+	// r = 1 and s = 0x8002, which gets a leading zero to stay positive.
+	want := []byte{0x30, 0x08, 0x02, 0x01, 0x01, 0x02, 0x03, 0x00, 0x80, 0x02}
+	if !bytes.Equal(got, want) {
+		t.Errorf("ecdsaSignature = %x, want %x", got, want)
+	}
+	if _, err := ecdsaSignature([]byte{1, 2, 3}); err == nil {
+		t.Error("ecdsaSignature accepted an odd length")
+	}
+}
+
+func TestError(t *testing.T) {
+	if got := Error(0xa0).Error(); got != "CKR_PIN_INCORRECT" {
+		t.Errorf("Error = %q", got)
+	}
+	if got := Error(0x1234).Error(); got != "CKR_0x1234" {
+		t.Errorf("Error = %q", got)
+	}
+	var err error = fmt.Errorf("login: %w", Error(0xa0))
+	if !errors.Is(err, Error(0xa0)) {
+		t.Error("errors.Is does not match the return value")
+	}
+}
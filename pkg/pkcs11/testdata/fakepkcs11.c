@@ -0,0 +1,194 @@
+// Copyright 2026 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+// A fake PKCS#11 module for the tests, with tokens labelled "other" in slot 3
+// and "legocharm" in slot 7, whose user PIN is "1234". Each holds a private
+// key and a certificate labelled "client", the certificate being the DER
+// bytes of cert.h. Instead of signing, C_Sign returns what it was given: the
+// data, followed by the parameters of CKM_RSA_PKCS_PSS, or twice for
+// CKM_ECDSA.
+
+#include <string.h>
+
+#include "cert.h"
+
+typedef unsigned long CK_ULONG;
+typedef CK_ULONG CK_RV;
+
+typedef struct {
+	CK_ULONG type;
+	void *value;
+	CK_ULONG len;
+} CK_ATTRIBUTE;
+
+typedef struct {
+	CK_ULONG type;
+	void *param;
+	CK_ULONG len;
+} CK_MECHANISM;
+
+typedef struct {
+	void *create_mutex, *destroy_mutex, *lock_mutex, *unlock_mutex;
+	CK_ULONG flags;
+	void *reserved;
+} CK_C_INITIALIZE_ARGS;
+
+#define CKR_OK 0x0
+#define CKR_SLOT_ID_INVALID 0x3
+#define CKR_ARGUMENTS_BAD 0x7
+#define CKR_MECHANISM_INVALID 0x70
+#define CKR_PIN_INCORRECT 0xa0
+#define CKR_SESSION_PARALLEL_NOT_SUPPORTED 0xb4
+#define CKR_BUFFER_TOO_SMALL 0x150
+#define CKR_CRYPTOKI_NOT_INITIALIZED 0x190
+
+#define CKF_SERIAL_SESSION 0x4
+#define CKF_OS_LOCKING_OK 0x2
+
+#define CKO_CERTIFICATE 1
+#define CKO_PRIVATE_KEY 3
+#define CKA_CLASS 0x0
+#define CKA_LABEL 0x3
+#define CKA_VALUE 0x11
+
+#define CKM_RSA_PKCS 0x1
+#define CKM_RSA_PKCS_PSS 0xd
+#define CKM_ECDSA 0x1041
+
+// Objects are numbered slot * 10 + class.
+static int initialized, logged_in;
+static CK_ULONG found[2], found_count;
+static CK_MECHANISM mechanism;
+static unsigned char mechanism_param[64];
+
+static CK_RV initialize(CK_C_INITIALIZE_ARGS *args) {
+	if (args == NULL || !(args->flags & CKF_OS_LOCKING_OK))
+		return CKR_ARGUMENTS_BAD;
+	initialized = 1;
+	return CKR_OK;
+}
+
+static CK_RV get_slot_list(unsigned char present, CK_ULONG *slots, CK_ULONG *count) {
+	if (!initialized)
+		return CKR_CRYPTOKI_NOT_INITIALIZED;
+	if (slots != NULL) {
+		if (*count < 2)
+			return CKR_BUFFER_TOO_SMALL;
+		slots[0] = 3;
+		slots[1] = 7;
+	}
+	*count = 2;
+	return CKR_OK;
+}
+
+static CK_RV get_token_info(CK_ULONG slot, unsigned char *info) {
+	const char *label = slot == 3 ? "other" : slot == 7 ? "legocharm" : NULL;
+	if (label == NULL)
+		return CKR_SLOT_ID_INVALID;
+	memset(info, ' ', 32);
+	memcpy(info, label, strlen(label));
+	return CKR_OK;
+}
+
+static CK_RV open_session(CK_ULONG slot, CK_ULONG flags, void *app, void *notify, CK_ULONG *session) {
+	if (slot != 3 && slot != 7)
+		return CKR_SLOT_ID_INVALID;
+	if (!(flags & CKF_SERIAL_SESSION))
+		return CKR_SESSION_PARALLEL_NOT_SUPPORTED;
+	*session = slot;
+	return CKR_OK;
+}
+
+static CK_RV login(CK_ULONG session, CK_ULONG user, unsigned char *pin, CK_ULONG len) {
+	if (len != 4 || memcmp(pin, "1234", 4) != 0)
+		return CKR_PIN_INCORRECT;
+	logged_in = 1;
+	return CKR_OK;
+}
+
+static CK_RV find_objects_init(CK_ULONG session, CK_ATTRIBUTE *template, CK_ULONG count) {
+	CK_ULONG class = 0;
+	int client = 0;
+	for (CK_ULONG i = 0; i < count; i++) {
+		if (template[i].type == CKA_CLASS)
+			class = *(CK_ULONG *)template[i].value;
+		else if (template[i].type == CKA_LABEL)
+			client = template[i].len == 6 && memcmp(template[i].value, "client", 6) == 0;
+	}
+	found_count = 0;
+	// Private keys are only found once logged in.
+	if (client && (class == CKO_CERTIFICATE || (class == CKO_PRIVATE_KEY && logged_in)))
+		found[found_count++] = session * 10 + class;
+	return CKR_OK;
+}
+
+static CK_RV find_objects(CK_ULONG session, CK_ULONG *objects, CK_ULONG max, CK_ULONG *count) {
+	*count = found_count < max ? found_count : max;
+	memcpy(objects, found, *count * sizeof(CK_ULONG));
+	return CKR_OK;
+}
+
+static CK_RV find_objects_final(CK_ULONG session) {
+	found_count = 0;
+	return CKR_OK;
+}
+
+static CK_RV get_attribute_value(CK_ULONG session, CK_ULONG object, CK_ATTRIBUTE *template, CK_ULONG count) {
+	if (object % 10 != CKO_CERTIFICATE || count != 1 || template[0].type != CKA_VALUE)
+		return CKR_ARGUMENTS_BAD;
+	if (template[0].value != NULL) {
+		if (template[0].len < sizeof(cert))
+			return CKR_BUFFER_TOO_SMALL;
+		memcpy(template[0].value, cert, sizeof(cert));
+	}
+	template[0].len = sizeof(cert);
+	return CKR_OK;
+}
+
+static CK_RV sign_init(CK_ULONG session, CK_MECHANISM *mech, CK_ULONG key) {
+	if (key % 10 != CKO_PRIVATE_KEY || mech->len > sizeof(mechanism_param))
+		return CKR_ARGUMENTS_BAD;
+	if (mech->type != CKM_RSA_PKCS && mech->type != CKM_RSA_PKCS_PSS && mech->type != CKM_ECDSA)
+		return CKR_MECHANISM_INVALID;
+	mechanism = *mech;
+	memcpy(mechanism_param, mech->param, mech->len);
+	return CKR_OK;
+}
+
+static CK_RV sign(CK_ULONG session, unsigned char *data, CK_ULONG len, unsigned char *signature, CK_ULONG *signature_len) {
+	CK_ULONG n = mechanism.type == CKM_ECDSA ? 2 * len : len + mechanism.len;
+	if (*signature_len < n)
+		return CKR_BUFFER_TOO_SMALL;
+	memcpy(signature, data, len);
+	if (mechanism.type == CKM_ECDSA)
+		memcpy(signature + len, data, len);
+	else
+		memcpy(signature + len, mechanism_param, mechanism.len);
+	*signature_len = n;
+	return CKR_OK;
+}
+
+static struct {
+	unsigned char major, minor;
+	void *functions[68];
+} function_list = {
+	2, 40,
+	{
+		[0] = initialize,
+		[4] = get_slot_list,
+		[6] = get_token_info,
+		[12] = open_session,
+		[18] = login,
+		[24] = get_attribute_value,
+		[26] = find_objects_init,
+		[27] = find_objects,
+		[28] = find_objects_final,
+		[42] = sign_init,
+		[43] = sign,
+	},
+};
+
+CK_RV C_GetFunctionList(void **list) {
+	*list = &function_list;
+	return CKR_OK;
+}